@@ -133,6 +133,10 @@ func (cmd *containerOverwriteACLCmd) Execute(args []string) error {
 			cmd.ContainerID())
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputACL()
+	}
+
 	return nil
 }
 
@@ -176,6 +180,10 @@ func (cmd *containerUpdateACLCmd) Execute(args []string) error {
 			cmd.ContainerID())
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputACL()
+	}
+
 	return nil
 }
 
@@ -208,6 +216,10 @@ func (cmd *containerDeleteACLCmd) Execute(args []string) error {
 			cmd.ContainerID())
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputACL()
+	}
+
 	return nil
 }
 
@@ -228,6 +240,19 @@ func convertACLProps(props []*property) (acl *control.AccessControlList) {
 	return
 }
 
+// outputACL outputs the ACL of the container in JSON format, the handlers
+// modifying it only print it in text format.
+func (cmd *existingContainerCmd) outputACL() error {
+	aclProps, cleanupAcl, err := getContAcl(cmd.cContHandle)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to query ACL for container %s", cmd.ContainerID())
+	}
+	defer cleanupAcl()
+
+	return cmd.outputJSON(convertACLProps(aclProps), nil)
+}
+
 type containerGetACLCmd struct {
 	existingContainerCmd
 
@@ -316,5 +341,9 @@ func (cmd *containerSetOwnerCmd) Execute(args []string) error {
 			cmd.ContainerID())
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputACL()
+	}
+
 	return nil
 }
@@ -0,0 +1,777 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/contarchive"
+)
+
+/*
+#include "util.h"
+
+static int
+obj_update_value(daos_handle_t oh, void *dkey_buf, size_t dkey_len,
+		 void *akey_buf, size_t akey_len, daos_iod_type_t type,
+		 daos_size_t size, uint64_t idx, uint64_t nr, void *buf,
+		 size_t buf_len)
+{
+	daos_key_t	dkey;
+	daos_iod_t	iod = {0};
+	daos_recx_t	recx = {.rx_idx = idx, .rx_nr = nr};
+	d_sg_list_t	sgl;
+	d_iov_t		iov;
+
+	d_iov_set(&dkey, dkey_buf, dkey_len);
+	d_iov_set(&iod.iod_name, akey_buf, akey_len);
+	iod.iod_type = type;
+	iod.iod_size = size;
+	iod.iod_nr = 1;
+	if (type == DAOS_IOD_ARRAY)
+		iod.iod_recxs = &recx;
+
+	d_iov_set(&iov, buf, buf_len);
+	sgl.sg_nr = 1;
+	sgl.sg_nr_out = 0;
+	sgl.sg_iovs = &iov;
+
+	return daos_obj_update(oh, DAOS_TX_NONE, 0, &dkey, 1, &iod, &sgl, NULL);
+}
+*/
+import "C"
+
+const (
+	// archiveChunkSize is the target size of the array records written
+	// to an archive.
+	archiveChunkSize = 1 << 20
+	archiveBufSize   = 1 << 20
+)
+
+// importSkipProps lists the properties that are recorded in an archive but
+// not applied to the imported container, either because they are managed
+// by DAOS or because they are specific to the source system.
+var importSkipProps = map[string]struct{}{
+	"label":          {}, // applied separately so that it can be overridden
+	"status":         {},
+	"layout_version": {},
+	"owner":          {},
+	"group":          {},
+}
+
+type archiveStats struct {
+	Objects uint64 `json:"objects"`
+	DKeys   uint64 `json:"dkeys"`
+	AKeys   uint64 `json:"akeys"`
+	Bytes   uint64 `json:"bytes"`
+}
+
+type containerExportResult struct {
+	ContainerUUID string `json:"container_uuid"`
+	Output        string `json:"output"`
+	*archiveStats
+}
+
+type containerImportResult struct {
+	ContainerUUID string                  `json:"container_uuid"`
+	Source        *contarchive.Metadata   `json:"source"`
+	Snapshots     []*contarchive.Snapshot `json:"snapshots_not_restored,omitempty"`
+	*archiveStats
+}
+
+func (as *archiveStats) String() string {
+	return fmt.Sprintf("%d objects, %d dkeys, %d akeys, %s",
+		as.Objects, as.DKeys, as.AKeys, humanize.IBytes(as.Bytes))
+}
+
+type containerExportCmd struct {
+	existingContainerCmd
+
+	Output   string `long:"out" short:"o" required:"1" description:"archive file to write (- for stdout)"`
+	PageSize int    `long:"page-size" default:"256" description:"number of objects or keys to retrieve per enumeration call"`
+}
+
+// exportMetadata collects the container properties, ACL, user attributes
+// and snapshot list.
+func (cmd *containerExportCmd) exportMetadata() (*contarchive.Metadata, error) {
+	md := &contarchive.Metadata{
+		Created:   time.Now().UTC(),
+		Pool:      cmd.poolUUID.String(),
+		Container: cmd.contUUID.String(),
+	}
+
+	props, cleanup, err := getContainerProperties(cmd.cContHandle, propHdlrs.keys()...)
+	defer cleanup()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get properties")
+	}
+	for _, prop := range props {
+		p := &contarchive.Property{Name: prop.Name}
+		if C.daos_prop_has_str(prop.entry) {
+			cStr := C.get_dpe_str(prop.entry)
+			if cStr == nil {
+				continue
+			}
+			p.String = C.GoString(cStr)
+		} else {
+			p.Value = uint64(C.get_dpe_val(prop.entry))
+		}
+
+		switch prop.Name {
+		case "label":
+			md.Label = p.String
+		case "layout_type":
+			var cType [10]C.char
+			C.daos_unparse_ctype(C.ushort(p.Value), &cType[0])
+			md.Layout = C.GoString(&cType[0])
+		}
+		md.Properties = append(md.Properties, p)
+	}
+
+	aclProps, cleanupACL, err := getContAcl(cmd.cContHandle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ACL")
+	}
+	defer cleanupACL()
+	for _, prop := range aclProps {
+		if prop.entry.dpe_type == C.DAOS_PROP_CO_ACL {
+			md.ACL = getAclStrings(prop.entry)
+		}
+	}
+
+	if md.Roots, err = getContRoots(cmd.cContHandle); err != nil {
+		return nil, errors.Wrap(err, "failed to get root objects")
+	}
+
+	attrs, err := listDaosAttributes(cmd.cContHandle, contAttr, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attributes")
+	}
+	for _, attr := range attrs {
+		value, err := getDaosAttributeValue(cmd.cContHandle, contAttr, attr.Name)
+		if err != nil {
+			return nil, err
+		}
+		md.Attributes = append(md.Attributes, &contarchive.Attribute{
+			Name:  attr.Name,
+			Value: value,
+		})
+	}
+
+	snaps, err := listSnapshots(cmd.cContHandle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+	for _, snap := range snaps {
+		md.Snapshots = append(md.Snapshots, &contarchive.Snapshot{
+			Epoch: snap.Epoch,
+			Name:  snap.Name,
+		})
+	}
+
+	return md, nil
+}
+
+// getContRoots returns the container's root object IDs, which are used by
+// higher-level layouts such as DFS to locate their metadata.
+func getContRoots(hdl C.daos_handle_t) ([]contarchive.ObjectID, error) {
+	props, entries, err := allocProps(1)
+	if err != nil {
+		return nil, err
+	}
+	defer C.daos_prop_free(props)
+	entries[0].dpe_type = C.DAOS_PROP_CO_ROOTS
+	props.dpp_nr++
+
+	rc := C.daos_cont_query(hdl, nil, props, nil)
+	if rc == -C.DER_NONEXIST {
+		return nil, nil
+	}
+	if err := daosError(rc); err != nil {
+		return nil, err
+	}
+
+	roots := (*C.struct_daos_prop_co_roots)(C.get_dpe_val_ptr(&entries[0]))
+	if roots == nil {
+		return nil, nil
+	}
+
+	var out []contarchive.ObjectID
+	var set bool
+	for _, oid := range roots.cr_oids {
+		out = append(out, contarchive.ObjectID{Hi: uint64(oid.hi), Lo: uint64(oid.lo)})
+		if oid.hi != 0 || oid.lo != 0 {
+			set = true
+		}
+	}
+	if !set {
+		return nil, nil
+	}
+
+	return out, nil
+}
+
+// exportObjects writes the contents of every object in the container, as
+// of a temporary snapshot, to the archive.
+func (cmd *containerExportCmd) exportObjects(w *contarchive.Writer, stats *archiveStats) error {
+	var epoch C.daos_epoch_t
+	rc := C.daos_cont_create_snap_opt(cmd.cContHandle, &epoch, nil,
+		C.DAOS_SNAP_OPT_CR|C.DAOS_SNAP_OPT_OIT, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrap(err, "failed to create snapshot")
+	}
+	defer func() {
+		epr := C.daos_epoch_range_t{epr_lo: epoch, epr_hi: epoch}
+		if err := daosError(C.daos_cont_destroy_snap(cmd.cContHandle, epr, nil)); err != nil {
+			cmd.log.Errorf("failed to destroy snapshot at epoch %d: %s", epoch, err)
+		}
+	}()
+
+	var th C.daos_handle_t
+	rc = C.daos_tx_open_snap(cmd.cContHandle, epoch, &th, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrapf(err, "failed to open snapshot at epoch %d", epoch)
+	}
+	defer C.daos_tx_close(th, nil)
+
+	var oit C.daos_handle_t
+	rc = C.daos_oit_open(cmd.cContHandle, epoch, &oit, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrap(err, "failed to open object index table")
+	}
+	defer C.daos_oit_close(oit, nil)
+
+	var anchor C.daos_anchor_t
+	oids := make([]C.daos_obj_id_t, cmd.PageSize)
+	for !C.daos_anchor_is_eof(&anchor) {
+		nr := C.uint32_t(len(oids))
+		rc = C.daos_oit_list(oit, &oids[0], &nr, &anchor, nil)
+		if err := daosError(rc); err != nil {
+			return errors.Wrap(err, "failed to list objects")
+		}
+
+		for _, oid := range oids[:nr] {
+			if err := cmd.exportObject(w, th, oid, stats); err != nil {
+				return errors.Wrapf(err, "failed to export object %d.%d",
+					oid.hi, oid.lo)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cmd *containerExportCmd) exportObject(w *contarchive.Writer, th C.daos_handle_t, oid C.daos_obj_id_t, stats *archiveStats) error {
+	var oh C.daos_handle_t
+	rc := C.daos_obj_open(cmd.cContHandle, oid, C.DAOS_OO_RO, &oh, nil)
+	if err := daosError(rc); err != nil {
+		return err
+	}
+	defer C.daos_obj_close(oh, nil)
+
+	if err := w.WriteObject(contarchive.ObjectID{Hi: uint64(oid.hi), Lo: uint64(oid.lo)}); err != nil {
+		return err
+	}
+	stats.Objects++
+
+	e := newObjEnumerator(oh, th, cmd.PageSize)
+	var dkeyAnchor C.daos_anchor_t
+	for !C.daos_anchor_is_eof(&dkeyAnchor) {
+		dkeys, err := e.listKeys(nil, &dkeyAnchor, cmd.PageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, dkey := range dkeys {
+			if err := w.WriteDKey(dkey); err != nil {
+				return err
+			}
+			stats.DKeys++
+
+			var akeyAnchor C.daos_anchor_t
+			akeys, err := e.listKeys(dkey, &akeyAnchor, 0)
+			if err != nil {
+				return err
+			}
+			for _, akey := range akeys {
+				if err := exportAKey(w, e, dkey, akey, stats); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportAKey writes the value stored under an akey, splitting array values
+// into chunks of roughly archiveChunkSize bytes.
+func exportAKey(w *contarchive.Writer, e *objEnumerator, dkey, akey []byte, stats *archiveStats) error {
+	if err := w.WriteAKey(akey); err != nil {
+		return err
+	}
+	stats.AKeys++
+
+	size, err := e.fetchSize(dkey, akey)
+	if err != nil {
+		return err
+	}
+	if size > 0 {
+		buf, err := e.fetchValue(dkey, akey, C.DAOS_IOD_SINGLE, size, 0, 1)
+		if err != nil {
+			return err
+		}
+		stats.Bytes += uint64(len(buf))
+
+		return w.WriteSingle(buf)
+	}
+
+	recSize, extents, err := e.listExtents(dkey, akey)
+	if err != nil || recSize == 0 {
+		return err
+	}
+	chunk := uint64(archiveChunkSize) / recSize
+	if chunk == 0 {
+		chunk = 1
+	}
+
+	for _, ext := range extents {
+		for off := uint64(0); off < ext.Count; off += chunk {
+			nr := ext.Count - off
+			if nr > chunk {
+				nr = chunk
+			}
+			buf, err := e.fetchValue(dkey, akey, C.DAOS_IOD_ARRAY, recSize, ext.Index+off, nr)
+			if err != nil {
+				return err
+			}
+			if err := w.WriteArray(recSize, ext.Index+off, buf); err != nil {
+				return err
+			}
+			stats.Bytes += uint64(len(buf))
+		}
+	}
+
+	return nil
+}
+
+func (cmd *containerExportCmd) Execute(_ []string) (err error) {
+	if cmd.PageSize <= 0 {
+		return errors.New("page size must be greater than 0")
+	}
+	toStdout := cmd.Output == "-"
+	if toStdout && cmd.jsonOutputEnabled() {
+		return errors.New("can't use JSON output when writing the archive to stdout")
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RO, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	md, err := cmd.exportMetadata()
+	if err != nil {
+		return errors.Wrapf(err, "failed to export container %s", cmd.ContainerID())
+	}
+
+	out := os.Stdout
+	if !toStdout {
+		out, err = os.Create(cmd.Output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cErr := out.Close(); err == nil {
+				err = cErr
+			}
+			if err != nil {
+				// don't leave a partial archive behind
+				os.Remove(cmd.Output)
+			}
+		}()
+	}
+
+	bw := bufio.NewWriterSize(out, archiveBufSize)
+	w, err := contarchive.NewWriter(bw)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteMetadata(md); err != nil {
+		return err
+	}
+
+	var stats archiveStats
+	if err := cmd.exportObjects(w, &stats); err != nil {
+		return errors.Wrapf(err, "failed to export container %s", cmd.ContainerID())
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return errors.Wrapf(err, "failed to write %s", cmd.Output)
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&containerExportResult{
+			ContainerUUID: cmd.contUUID.String(),
+			Output:        cmd.Output,
+			archiveStats:  &stats,
+		}, nil)
+	}
+	if toStdout {
+		return nil
+	}
+
+	var bld strings.Builder
+	fmt.Fprintf(&bld, "Successfully exported container %s to %s (%s)",
+		cmd.contUUID, cmd.Output, &stats)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+type containerImportCmd struct {
+	containerBaseCmd
+
+	Input string `long:"in" short:"i" required:"1" description:"archive file to read (- for stdin)"`
+	Label string `long:"label" short:"l" description:"label of the new container (default: label of the exported container)"`
+}
+
+// createProps builds the creation properties of the imported container.
+// The caller must free the returned properties.
+func (cmd *containerImportCmd) createProps(md *contarchive.Metadata) (*C.daos_prop_t, error) {
+	label := md.Label
+	if cmd.Label != "" {
+		label = cmd.Label
+	}
+
+	var archProps []*contarchive.Property
+	for _, p := range md.Properties {
+		if _, skip := importSkipProps[p.Name]; skip {
+			continue
+		}
+		if _, found := propHdlrs[p.Name]; !found {
+			cmd.log.Debugf("ignoring unknown property %q", p.Name)
+			continue
+		}
+		archProps = append(archProps, p)
+	}
+
+	props, entries, err := allocProps(len(archProps) + 3)
+	if err != nil {
+		return nil, err
+	}
+	nextEntry := func(pType C.uint32_t) *C.struct_daos_prop_entry {
+		e := &entries[props.dpp_nr]
+		e.dpe_type = pType
+		props.dpp_nr++
+		return e
+	}
+
+	for _, p := range archProps {
+		e := nextEntry(C.uint32_t(propHdlrs[p.Name].dpeType))
+		if C.daos_prop_has_str(e) {
+			cStr := C.CString(p.String)
+			rc := C.daos_prop_entry_set_str(e, cStr, C.strlen(cStr))
+			freeString(cStr)
+			if err := daosError(rc); err != nil {
+				C.daos_prop_free(props)
+				return nil, errors.Wrapf(err, "failed to set property %q", p.Name)
+			}
+			continue
+		}
+		C.set_dpe_val(e, C.uint64_t(p.Value))
+	}
+
+	if label != "" {
+		cStr := C.CString(label)
+		rc := C.daos_prop_entry_set_str(nextEntry(C.DAOS_PROP_CO_LABEL), cStr, C.strlen(cStr))
+		freeString(cStr)
+		if err := daosError(rc); err != nil {
+			C.daos_prop_free(props)
+			return nil, errors.Wrap(err, "failed to set label")
+		}
+	}
+
+	if len(md.ACL) > 0 {
+		cStrs := make([]*C.char, len(md.ACL))
+		for i, ace := range md.ACL {
+			cStrs[i] = C.CString(ace)
+			defer freeString(cStrs[i])
+		}
+
+		var acl *C.struct_daos_acl
+		rc := C.daos_acl_from_strs(&cStrs[0], C.size_t(len(cStrs)), &acl)
+		if err := daosError(rc); err != nil {
+			C.daos_prop_free(props)
+			return nil, errors.Wrap(err, "invalid ACL")
+		}
+		rc = C.daos_prop_entry_set_ptr(nextEntry(C.DAOS_PROP_CO_ACL), unsafe.Pointer(acl),
+			C.daos_size_t(C.daos_acl_get_size(acl)))
+		C.daos_acl_free(acl)
+		if err := daosError(rc); err != nil {
+			C.daos_prop_free(props)
+			return nil, errors.Wrap(err, "failed to set ACL")
+		}
+	}
+
+	if len(md.Roots) > 0 {
+		var roots C.struct_daos_prop_co_roots
+		if len(md.Roots) > len(roots.cr_oids) {
+			C.daos_prop_free(props)
+			return nil, errors.Errorf("too many root objects (%d > %d)",
+				len(md.Roots), len(roots.cr_oids))
+		}
+		for i, oid := range md.Roots {
+			roots.cr_oids[i].hi = C.uint64_t(oid.Hi)
+			roots.cr_oids[i].lo = C.uint64_t(oid.Lo)
+		}
+		rc := C.daos_prop_entry_set_ptr(nextEntry(C.DAOS_PROP_CO_ROOTS), unsafe.Pointer(&roots),
+			C.daos_size_t(unsafe.Sizeof(roots)))
+		if err := daosError(rc); err != nil {
+			C.daos_prop_free(props)
+			return nil, errors.Wrap(err, "failed to set root objects")
+		}
+	}
+
+	return props, nil
+}
+
+// archiveImporter applies archived object records to an open container.
+type archiveImporter struct {
+	coh   C.daos_handle_t
+	oh    C.daos_handle_t
+	open  bool
+	dkey  []byte
+	akey  []byte
+	stats archiveStats
+}
+
+func (imp *archiveImporter) closeObject() error {
+	if !imp.open {
+		return nil
+	}
+	imp.open = false
+
+	return daosError(C.daos_obj_close(imp.oh, nil))
+}
+
+func (imp *archiveImporter) update(iodType C.daos_iod_type_t, size, idx, nr uint64, data []byte) error {
+	if imp.akey == nil {
+		return errors.New("value record without akey")
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := updateValue(imp.oh, imp.dkey, imp.akey, iodType, size, idx, nr, data); err != nil {
+		return err
+	}
+	imp.stats.Bytes += uint64(len(data))
+
+	return nil
+}
+
+// updateValue writes a single value of size bytes, or nr records of size
+// bytes from index idx of an array value.
+func updateValue(oh C.daos_handle_t, dkey, akey []byte, iodType C.daos_iod_type_t, size, idx, nr uint64, data []byte) error {
+	rc := C.obj_update_value(oh, keyPtr(dkey), C.size_t(len(dkey)),
+		keyPtr(akey), C.size_t(len(akey)), iodType, C.daos_size_t(size),
+		C.uint64_t(idx), C.uint64_t(nr), unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	return daosError(rc)
+}
+
+func (imp *archiveImporter) apply(rec *contarchive.Record) error {
+	switch rec.Type {
+	case contarchive.RecordObject:
+		oid, err := rec.Object()
+		if err != nil {
+			return err
+		}
+		if err := imp.closeObject(); err != nil {
+			return err
+		}
+
+		cOid := C.daos_obj_id_t{hi: C.uint64_t(oid.Hi), lo: C.uint64_t(oid.Lo)}
+		rc := C.daos_obj_open(imp.coh, cOid, C.DAOS_OO_RW, &imp.oh, nil)
+		if err := daosError(rc); err != nil {
+			return errors.Wrapf(err, "failed to open object %d.%d", oid.Hi, oid.Lo)
+		}
+		imp.open = true
+		imp.dkey, imp.akey = nil, nil
+		imp.stats.Objects++
+	case contarchive.RecordDKey:
+		if !imp.open {
+			return errors.New("dkey record without object")
+		}
+		imp.dkey, imp.akey = rec.Payload, nil
+		imp.stats.DKeys++
+	case contarchive.RecordAKey:
+		if imp.dkey == nil {
+			return errors.New("akey record without dkey")
+		}
+		imp.akey = rec.Payload
+		imp.stats.AKeys++
+	case contarchive.RecordSingle:
+		return imp.update(C.DAOS_IOD_SINGLE, uint64(len(rec.Payload)), 0, 1, rec.Payload)
+	case contarchive.RecordArray:
+		recSize, idx, data, err := rec.Array()
+		if err != nil {
+			return err
+		}
+		return imp.update(C.DAOS_IOD_ARRAY, recSize, idx, uint64(len(data))/recSize, data)
+	default:
+		return errors.Errorf("unexpected %s record", rec.Type)
+	}
+
+	return nil
+}
+
+// importObjects writes the remaining records of the archive into the open
+// container.
+func (cmd *containerImportCmd) importObjects(r *contarchive.Reader) (*archiveStats, error) {
+	imp := &archiveImporter{coh: cmd.cContHandle}
+	defer imp.closeObject()
+
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := imp.apply(rec); err != nil {
+			return nil, err
+		}
+	}
+
+	return &imp.stats, imp.closeObject()
+}
+
+func (cmd *containerImportCmd) Execute(_ []string) (err error) {
+	in := os.Stdin
+	if cmd.Input != "-" {
+		in, err = os.Open(cmd.Input)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+	}
+
+	r, err := contarchive.NewReader(bufio.NewReaderSize(in, archiveBufSize))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", cmd.Input)
+	}
+	rec, err := r.Next()
+	if err == io.EOF {
+		return errors.Errorf("%s: archive has no metadata", cmd.Input)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", cmd.Input)
+	}
+	md, err := rec.Metadata()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", cmd.Input)
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	disconnectPool, err := cmd.connectPool(C.DAOS_PC_RW, ap)
+	if err != nil {
+		return err
+	}
+	defer disconnectPool()
+
+	props, err := cmd.createProps(md)
+	if err != nil {
+		return err
+	}
+	defer C.daos_prop_free(props)
+
+	var cUUID C.uuid_t
+	rc := C.daos_cont_create2(cmd.cPoolHandle, &cUUID, props, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrap(err, "failed to create container")
+	}
+	if cmd.contUUID, err = uuidFromC(cUUID); err != nil {
+		return err
+	}
+	cmd.log.Debugf("created container: %s", cmd.contUUID)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// don't leave a partially imported container behind
+		cUUIDstr := C.CString(cmd.contUUID.String())
+		defer freeString(cUUIDstr)
+		if dErr := daosError(C.daos_cont_destroy2(cmd.cPoolHandle, cUUIDstr, 1, nil)); dErr != nil {
+			cmd.log.Errorf("failed to destroy partially imported container %s: %s",
+				cmd.contUUID, dErr)
+		}
+	}()
+
+	if err = cmd.openContainer(C.DAOS_COO_RW); err != nil {
+		return errors.Wrapf(err, "failed to open new container %s", cmd.contUUID)
+	}
+	defer cmd.closeContainer()
+
+	for _, attr := range md.Attributes {
+		if err = setDaosAttributeValue(cmd.cContHandle, contAttr, attr.Name, attr.Value); err != nil {
+			return errors.Wrapf(err, "failed to set attribute %q", attr.Name)
+		}
+	}
+
+	stats, err := cmd.importObjects(r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to import %s", cmd.Input)
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&containerImportResult{
+			ContainerUUID: cmd.contUUID.String(),
+			Source:        md,
+			Snapshots:     md.Snapshots,
+			archiveStats:  stats,
+		}, nil)
+	}
+
+	var bld strings.Builder
+	fmt.Fprintf(&bld, "Successfully imported container %s from %s (%s)\n",
+		cmd.contUUID, cmd.Input, stats)
+	if len(md.Snapshots) > 0 {
+		fmt.Fprintf(&bld, "%d snapshot(s) of the exported container were not restored:\n",
+			len(md.Snapshots))
+		for _, snap := range md.Snapshots {
+			fmt.Fprintf(&bld, "  %d %s\n", snap.Epoch, snap.Name)
+		}
+	}
+	cmd.log.Info(bld.String())
+
+	return nil
+}
@@ -7,9 +7,9 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"unsafe"
 
 	"github.com/pkg/errors"
 
@@ -127,7 +127,8 @@ func listDaosAttributes(hdl C.daos_handle_t, at attrType, verbose bool) ([]*attr
 	return attrs, nil
 }
 
-func getDaosAttribute(hdl C.daos_handle_t, at attrType, name string) (*attribute, error) {
+// getDaosAttributeValue returns the raw value of the named attribute.
+func getDaosAttributeValue(hdl C.daos_handle_t, at attrType, name string) ([]byte, error) {
 	var rc C.int
 	var attrSize C.size_t
 
@@ -146,33 +147,46 @@ func getDaosAttribute(hdl C.daos_handle_t, at attrType, name string) (*attribute
 		return nil, errors.Wrapf(err, "failed to get attribute %q", name)
 	}
 
-	attr := &attribute{
-		Name: name,
+	if attrSize == 0 {
+		return nil, nil
 	}
 
-	if attrSize > 0 {
-		buf := C.malloc(attrSize)
-		if buf == nil {
-			return nil, errors.New("failed to malloc buf")
-		}
-		defer C.free(buf)
-
-		switch at {
-		case poolAttr:
-			rc = C.daos_pool_get_attr(hdl, 1, &attrName, &buf, &attrSize, nil)
-		case contAttr:
-			rc = C.daos_cont_get_attr(hdl, 1, &attrName, &buf, &attrSize, nil)
-		default:
-			return nil, errors.Errorf("unknown attr type %d", at)
-		}
-		if err := daosError(rc); err != nil {
-			return nil, errors.Wrapf(err, "failed to get attribute %q", name)
-		}
+	buf := C.malloc(attrSize)
+	if buf == nil {
+		return nil, errors.New("failed to malloc buf")
+	}
+	defer C.free(buf)
+
+	switch at {
+	case poolAttr:
+		rc = C.daos_pool_get_attr(hdl, 1, &attrName, &buf, &attrSize, nil)
+	case contAttr:
+		rc = C.daos_cont_get_attr(hdl, 1, &attrName, &buf, &attrSize, nil)
+	default:
+		return nil, errors.Errorf("unknown attr type %d", at)
+	}
+	if err := daosError(rc); err != nil {
+		return nil, errors.Wrapf(err, "failed to get attribute %q", name)
+	}
 
-		attr.Value = C.GoString((*C.char)(buf))
+	return C.GoBytes(buf, C.int(attrSize)), nil
+}
+
+func getDaosAttribute(hdl C.daos_handle_t, at attrType, name string) (*attribute, error) {
+	value, err := getDaosAttributeValue(hdl, at, name)
+	if err != nil {
+		return nil, err
 	}
 
-	return attr, nil
+	// values set by this tool are NUL-terminated strings
+	if i := bytes.IndexByte(value, 0); i >= 0 {
+		value = value[:i]
+	}
+
+	return &attribute{
+		Name:  name,
+		Value: string(value),
+	}, nil
 }
 
 func setDaosAttribute(hdl C.daos_handle_t, at attrType, attr *attribute) error {
@@ -180,20 +194,27 @@ func setDaosAttribute(hdl C.daos_handle_t, at attrType, attr *attribute) error {
 		return errors.Errorf("nil %T", attr)
 	}
 
-	attrName := C.CString(attr.Name)
+	return setDaosAttributeValue(hdl, at, attr.Name, append([]byte(attr.Value), 0))
+}
+
+// setDaosAttributeValue sets the named attribute to the raw value.
+func setDaosAttributeValue(hdl C.daos_handle_t, at attrType, name string, value []byte) error {
+	if len(value) == 0 {
+		return errors.Errorf("empty value for attribute %q", name)
+	}
+
+	attrName := C.CString(name)
 	defer freeString(attrName)
-	attrValue := C.CString(attr.Value)
-	defer freeString(attrValue)
-	valueLen := C.uint64_t(len(attr.Value) + 1)
+	attrValue := C.CBytes(value)
+	defer C.free(attrValue)
+	valueLen := C.uint64_t(len(value))
 
 	var rc C.int
 	switch at {
 	case poolAttr:
-		rc = C.daos_pool_set_attr(hdl, 1, &attrName,
-			(*unsafe.Pointer)(unsafe.Pointer(&attrValue)), &valueLen, nil)
+		rc = C.daos_pool_set_attr(hdl, 1, &attrName, &attrValue, &valueLen, nil)
 	case contAttr:
-		rc = C.daos_cont_set_attr(hdl, 1, &attrName,
-			(*unsafe.Pointer)(unsafe.Pointer(&attrValue)), &valueLen, nil)
+		rc = C.daos_cont_set_attr(hdl, 1, &attrName, &attrValue, &valueLen, nil)
 	default:
 		return errors.Errorf("unknown attr type %d", at)
 	}
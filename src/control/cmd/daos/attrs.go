@@ -0,0 +1,506 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+#include "util.h"
+*/
+import "C"
+
+const (
+	attrsFormatJSON = "json"
+	attrsFormatYAML = "yaml"
+)
+
+type (
+	// attrsFile is the content of an attributes file. Values set by this
+	// tool are NUL-terminated strings and are written as text, any other
+	// value is written in base64 so that it is restored unchanged.
+	attrsFile struct {
+		Attributes []*attrsEntry `json:"attributes" yaml:"attributes"`
+	}
+
+	attrsEntry struct {
+		Name   string  `json:"name" yaml:"name"`
+		Value  *string `json:"value,omitempty" yaml:"value,omitempty"`
+		Base64 string  `json:"base64,omitempty" yaml:"base64,omitempty"`
+	}
+
+	attrsExportResult struct {
+		Output     string `json:"output"`
+		Attributes int    `json:"attributes"`
+	}
+
+	// attrsDiff lists the changes made to the attributes by an import.
+	attrsDiff struct {
+		Added     []string `json:"added"`
+		Changed   []string `json:"changed"`
+		Deleted   []string `json:"deleted"`
+		Unchanged int      `json:"unchanged"`
+		DryRun    bool     `json:"dry_run"`
+
+		current map[string][]byte
+		wanted  map[string][]byte
+	}
+)
+
+// newAttrsEntry encodes a raw attribute value.
+func newAttrsEntry(name string, value []byte, forceBase64 bool) *attrsEntry {
+	if !forceBase64 && len(value) > 0 {
+		text := value[:len(value)-1]
+		if value[len(value)-1] == 0 && bytes.IndexByte(text, 0) < 0 && utf8.Valid(text) {
+			str := string(text)
+			return &attrsEntry{Name: name, Value: &str}
+		}
+	}
+
+	return &attrsEntry{Name: name, Base64: base64.StdEncoding.EncodeToString(value)}
+}
+
+// raw returns the raw attribute value.
+func (e *attrsEntry) raw() ([]byte, error) {
+	switch {
+	case e.Value != nil && e.Base64 != "":
+		return nil, errors.Errorf("attribute %q has both a value and a base64 value", e.Name)
+	case e.Value != nil:
+		return append([]byte(*e.Value), 0), nil
+	case e.Base64 == "":
+		return nil, errors.Errorf("attribute %q has no value", e.Name)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(e.Base64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid base64 value of attribute %q", e.Name)
+	}
+	return value, nil
+}
+
+// attrsFileFormat returns the format of an attributes file, from its
+// extension unless requested explicitly.
+func attrsFileFormat(path, format string) string {
+	if format != "" {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return attrsFormatYAML
+	}
+	return attrsFormatJSON
+}
+
+func encodeAttrsFile(attrs map[string][]byte, format string, forceBase64 bool) ([]byte, error) {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	file := &attrsFile{Attributes: []*attrsEntry{}}
+	for _, name := range names {
+		file.Attributes = append(file.Attributes, newAttrsEntry(name, attrs[name], forceBase64))
+	}
+
+	if format == attrsFormatYAML {
+		return yaml.Marshal(file)
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// decodeAttrsFile parses an attributes file, in JSON or YAML.
+func decodeAttrsFile(data []byte) (map[string][]byte, error) {
+	var file attrsFile
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&file); err != nil {
+			return nil, errors.Wrap(err, "invalid JSON attributes file")
+		}
+	} else if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, errors.Wrap(err, "invalid YAML attributes file")
+	}
+
+	attrs := make(map[string][]byte)
+	for _, entry := range file.Attributes {
+		if entry == nil || entry.Name == "" {
+			return nil, errors.New("attribute without a name")
+		}
+		if _, dup := attrs[entry.Name]; dup {
+			return nil, errors.Errorf("duplicate attribute %q", entry.Name)
+		}
+		value, err := entry.raw()
+		if err != nil {
+			return nil, err
+		}
+		if len(value) == 0 {
+			return nil, errors.Errorf("empty value for attribute %q", entry.Name)
+		}
+		attrs[entry.Name] = value
+	}
+
+	return attrs, nil
+}
+
+// readAttrs returns all the attributes of a pool or container with their raw
+// values.
+func readAttrs(hdl C.daos_handle_t, at attrType) (map[string][]byte, error) {
+	list, err := listDaosAttributes(hdl, at, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attributes")
+	}
+
+	attrs := make(map[string][]byte)
+	for _, attr := range list {
+		if attrs[attr.Name], err = getDaosAttributeValue(hdl, at, attr.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return attrs, nil
+}
+
+// diffAttrs determines the changes needed to go from the current attributes
+// to the wanted ones. Attributes missing from the wanted ones are only
+// deleted if replace is set.
+func diffAttrs(current, wanted map[string][]byte, replace bool) *attrsDiff {
+	diff := &attrsDiff{
+		Added:   []string{},
+		Changed: []string{},
+		Deleted: []string{},
+		current: current,
+		wanted:  wanted,
+	}
+
+	for name, value := range wanted {
+		cur, found := current[name]
+		switch {
+		case !found:
+			diff.Added = append(diff.Added, name)
+		case !bytes.Equal(cur, value):
+			diff.Changed = append(diff.Changed, name)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for name := range current {
+		if _, found := wanted[name]; found {
+			continue
+		}
+		if replace {
+			diff.Deleted = append(diff.Deleted, name)
+		} else {
+			diff.Unchanged++
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Deleted)
+
+	return diff
+}
+
+// apply makes the changes of the diff.
+func (diff *attrsDiff) apply(hdl C.daos_handle_t, at attrType) error {
+	for _, names := range [][]string{diff.Added, diff.Changed} {
+		for _, name := range names {
+			if err := setDaosAttributeValue(hdl, at, name, diff.wanted[name]); err != nil {
+				return errors.Wrapf(err, "failed to set attribute %q", name)
+			}
+		}
+	}
+	for _, name := range diff.Deleted {
+		if err := delDaosAttribute(hdl, at, name); err != nil {
+			return errors.Wrapf(err, "failed to delete attribute %q", name)
+		}
+	}
+
+	return nil
+}
+
+// formatAttrValue renders a raw attribute value, without the NUL terminator
+// of the values set by this tool.
+func formatAttrValue(value []byte) string {
+	if i := bytes.IndexByte(value, 0); i == len(value)-1 {
+		value = value[:i]
+	}
+	str, enc := formatBytes(value, keyFormatAuto)
+	if enc == keyFormatUTF8 {
+		return fmt.Sprintf("%q", str)
+	}
+	return str
+}
+
+func printAttrsDiff(out io.Writer, header string, diff *attrsDiff) {
+	fmt.Fprintf(out, "%s\n", header)
+
+	for _, name := range diff.Added {
+		fmt.Fprintf(out, "  + %s: %s\n", name, formatAttrValue(diff.wanted[name]))
+	}
+	for _, name := range diff.Changed {
+		fmt.Fprintf(out, "  ~ %s: %s -> %s\n", name,
+			formatAttrValue(diff.current[name]), formatAttrValue(diff.wanted[name]))
+	}
+	for _, name := range diff.Deleted {
+		fmt.Fprintf(out, "  - %s\n", name)
+	}
+	fmt.Fprintf(out, "  %d added, %d changed, %d deleted, %d unchanged\n",
+		len(diff.Added), len(diff.Changed), len(diff.Deleted), diff.Unchanged)
+}
+
+// attrsExportFlags are the options of the attribute export commands.
+type attrsExportFlags struct {
+	Output string `long:"out" short:"o" required:"1" description:"file to write (- for stdout)"`
+	Format string `long:"format" short:"f" choice:"json" choice:"yaml" description:"file format (default: from the file extension, else json)"`
+	Base64 bool   `long:"base64" description:"encode all the values in base64, including text"`
+}
+
+// export writes the attributes of a pool or container to the output file.
+func (f *attrsExportFlags) export(hdl C.daos_handle_t, at attrType) (*attrsExportResult, error) {
+	attrs, err := readAttrs(hdl, at)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeAttrsFile(attrs, attrsFileFormat(f.Output, f.Format), f.Base64)
+	if err != nil {
+		return nil, err
+	}
+	if f.Output == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(f.Output, data, 0644)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", f.Output)
+	}
+
+	return &attrsExportResult{Output: f.Output, Attributes: len(attrs)}, nil
+}
+
+// attrsImportFlags are the options of the attribute import commands.
+type attrsImportFlags struct {
+	Input   string `long:"in" short:"i" required:"1" description:"JSON or YAML file to read (- for stdin)"`
+	Replace bool   `long:"replace" description:"also delete the attributes missing from the file (default: merge)"`
+	DryRun  bool   `long:"dry-run" short:"n" description:"only show the changes that would be made"`
+}
+
+// importDiff reads the input file and determines the changes to make to the
+// attributes of a pool or container.
+func (f *attrsImportFlags) importDiff(hdl C.daos_handle_t, at attrType) (*attrsDiff, error) {
+	var data []byte
+	var err error
+	if f.Input == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(f.Input)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", f.Input)
+	}
+
+	wanted, err := decodeAttrsFile(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", f.Input)
+	}
+	current, err := readAttrs(hdl, at)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffAttrs(current, wanted, f.Replace)
+	diff.DryRun = f.DryRun
+	return diff, nil
+}
+
+type containerAttrsCmd struct {
+	Export containerAttrsExportCmd `command:"export" description:"export container user-defined attributes to a file"`
+	Import containerAttrsImportCmd `command:"import" description:"set container user-defined attributes from a file"`
+}
+
+type containerAttrsExportCmd struct {
+	existingContainerCmd
+	attrsExportFlags
+}
+
+func (cmd *containerAttrsExportCmd) Execute(_ []string) error {
+	if cmd.Output == "-" && cmd.jsonOutputEnabled() {
+		return errors.New("can't use JSON output when writing the attributes to stdout")
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RO, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := cmd.export(cmd.cContHandle, contAttr)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to export attributes of container %s", cmd.ContainerID())
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(result, nil)
+	}
+	if cmd.Output != "-" {
+		cmd.log.Infof("Exported %d attributes of container %s to %s",
+			result.Attributes, cmd.ContainerID(), cmd.Output)
+	}
+
+	return nil
+}
+
+type containerAttrsImportCmd struct {
+	existingContainerCmd
+	attrsImportFlags
+}
+
+func (cmd *containerAttrsImportCmd) Execute(_ []string) error {
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	contFlags := C.uint(C.DAOS_COO_RW)
+	if cmd.DryRun {
+		contFlags = C.DAOS_COO_RO
+	}
+	cleanup, err := cmd.resolveAndConnect(contFlags, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	diff, err := cmd.importDiff(cmd.cContHandle, contAttr)
+	if err == nil && !cmd.DryRun {
+		err = diff.apply(cmd.cContHandle, contAttr)
+	}
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to import attributes of container %s", cmd.ContainerID())
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(diff, nil)
+	}
+
+	var bld strings.Builder
+	header := fmt.Sprintf("Attributes of container %s:", cmd.ContainerID())
+	if cmd.DryRun {
+		header = fmt.Sprintf("Changes to the attributes of container %s (dry run):", cmd.ContainerID())
+	}
+	printAttrsDiff(&bld, header, diff)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+type poolAttrsCmd struct {
+	Export poolAttrsExportCmd `command:"export" description:"export pool user-defined attributes to a file"`
+	Import poolAttrsImportCmd `command:"import" description:"set pool user-defined attributes from a file"`
+}
+
+type poolAttrsExportCmd struct {
+	poolBaseCmd
+	attrsExportFlags
+}
+
+func (cmd *poolAttrsExportCmd) Execute(_ []string) error {
+	if cmd.Output == "-" && cmd.jsonOutputEnabled() {
+		return errors.New("can't use JSON output when writing the attributes to stdout")
+	}
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RO, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := cmd.export(cmd.cPoolHandle, poolAttr)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to export attributes of pool %s", cmd.PoolID())
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(result, nil)
+	}
+	if cmd.Output != "-" {
+		cmd.log.Infof("Exported %d attributes of pool %s to %s",
+			result.Attributes, cmd.PoolID(), cmd.Output)
+	}
+
+	return nil
+}
+
+type poolAttrsImportCmd struct {
+	poolBaseCmd
+	attrsImportFlags
+}
+
+func (cmd *poolAttrsImportCmd) Execute(_ []string) error {
+	poolFlags := C.uint(C.DAOS_PC_RW)
+	if cmd.DryRun {
+		poolFlags = C.DAOS_PC_RO
+	}
+	cleanup, err := cmd.resolveAndConnect(poolFlags, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	diff, err := cmd.importDiff(cmd.cPoolHandle, poolAttr)
+	if err == nil && !cmd.DryRun {
+		err = diff.apply(cmd.cPoolHandle, poolAttr)
+	}
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to import attributes of pool %s", cmd.PoolID())
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(diff, nil)
+	}
+
+	var bld strings.Builder
+	header := fmt.Sprintf("Attributes of pool %s:", cmd.PoolID())
+	if cmd.DryRun {
+		header = fmt.Sprintf("Changes to the attributes of pool %s (dry run):", cmd.PoolID())
+	}
+	printAttrsDiff(&bld, header, diff)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
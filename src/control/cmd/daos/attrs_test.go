@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestAttrs_roundTrip(t *testing.T) {
+	attrs := map[string][]byte{
+		"owner":  []byte("alice\x00"),
+		"empty":  {0},
+		"binary": {0xde, 0xad, 0x00, 0xbe, 0xef},
+		"raw":    []byte("no terminator"),
+		"multi":  []byte("line 1\nline 2\x00"),
+	}
+
+	for _, format := range []string{attrsFormatJSON, attrsFormatYAML} {
+		for _, forceBase64 := range []bool{false, true} {
+			data, err := encodeAttrsFile(attrs, format, forceBase64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !forceBase64 && !strings.Contains(string(data), "alice") {
+				t.Fatalf("expected text value in %s file:\n%s", format, data)
+			}
+
+			got, err := decodeAttrsFile(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(attrs, got); diff != "" {
+				t.Fatalf("unexpected attributes from %s file (-want, +got):\n%s\n", format, diff)
+			}
+		}
+	}
+}
+
+func TestAttrs_decodeAttrsFile(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data     string
+		expAttrs map[string][]byte
+		expErr   error
+	}{
+		"yaml": {
+			data: "attributes:\n- name: a\n  value: x\n- name: b\n  base64: AQI=\n",
+			expAttrs: map[string][]byte{
+				"a": []byte("x\x00"),
+				"b": {1, 2},
+			},
+		},
+		"json": {
+			data: `{"attributes": [{"name": "a", "value": ""}]}`,
+			expAttrs: map[string][]byte{
+				"a": {0},
+			},
+		},
+		"unknown field": {
+			data:   `{"attributes": [{"name": "a", "valeu": "x"}]}`,
+			expErr: errors.New("unknown field"),
+		},
+		"no name": {
+			data:   "attributes:\n- value: x\n",
+			expErr: errors.New("without a name"),
+		},
+		"duplicate": {
+			data:   "attributes:\n- name: a\n  value: x\n- name: a\n  value: y\n",
+			expErr: errors.New("duplicate attribute"),
+		},
+		"both values": {
+			data:   "attributes:\n- name: a\n  value: x\n  base64: AQI=\n",
+			expErr: errors.New("both a value and a base64 value"),
+		},
+		"no value": {
+			data:   "attributes:\n- name: a\n",
+			expErr: errors.New("has no value"),
+		},
+		"bad base64": {
+			data:   "attributes:\n- name: a\n  base64: '!!'\n",
+			expErr: errors.New("invalid base64"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			attrs, err := decodeAttrsFile([]byte(tc.data))
+			common.CmpErr(t, tc.expErr, err)
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expAttrs, attrs); diff != "" {
+				t.Fatalf("unexpected attributes (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAttrs_attrsFileFormat(t *testing.T) {
+	common.AssertEqual(t, attrsFormatYAML, attrsFileFormat("meta.YML", ""), "yml")
+	common.AssertEqual(t, attrsFormatYAML, attrsFileFormat("meta.yaml", ""), "yaml")
+	common.AssertEqual(t, attrsFormatJSON, attrsFileFormat("-", ""), "stdout")
+	common.AssertEqual(t, attrsFormatYAML, attrsFileFormat("meta.json", attrsFormatYAML), "explicit")
+}
+
+func TestAttrs_diffAttrs(t *testing.T) {
+	current := map[string][]byte{
+		"same":    []byte("1\x00"),
+		"changed": []byte("old\x00"),
+		"extra":   []byte("x\x00"),
+	}
+	wanted := map[string][]byte{
+		"same":    []byte("1\x00"),
+		"changed": []byte("new\x00"),
+		"added":   {0xff},
+	}
+
+	for name, tc := range map[string]struct {
+		replace bool
+		expDiff *attrsDiff
+	}{
+		"merge": {
+			expDiff: &attrsDiff{
+				Added:     []string{"added"},
+				Changed:   []string{"changed"},
+				Deleted:   []string{},
+				Unchanged: 2,
+			},
+		},
+		"replace": {
+			replace: true,
+			expDiff: &attrsDiff{
+				Added:     []string{"added"},
+				Changed:   []string{"changed"},
+				Deleted:   []string{"extra"},
+				Unchanged: 1,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff := diffAttrs(current, wanted, tc.replace)
+			if d := cmp.Diff(tc.expDiff, diff, cmpopts.IgnoreUnexported(attrsDiff{})); d != "" {
+				t.Fatalf("unexpected diff (-want, +got):\n%s\n", d)
+			}
+
+			var bld strings.Builder
+			printAttrsDiff(&bld, "Attributes:", diff)
+			for _, exp := range []string{
+				`+ added: 0xff`,
+				`~ changed: "old" -> "new"`,
+			} {
+				if !strings.Contains(bld.String(), exp) {
+					t.Fatalf("expected %q in output:\n%s", exp, bld.String())
+				}
+			}
+			if tc.replace && !strings.Contains(bld.String(), "- extra") {
+				t.Fatalf("expected deletion in output:\n%s", bld.String())
+			}
+		})
+	}
+}
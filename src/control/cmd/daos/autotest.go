@@ -0,0 +1,1059 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+/*
+#include "util.h"
+
+#include <daos/object.h>
+
+static uint32_t
+autotest_grp_size(daos_obj_id_t oid)
+{
+	struct daos_oclass_attr *oca = daos_oclass_attr_find(oid, NULL);
+
+	if (oca == NULL)
+		return 0;
+	return daos_oclass_grp_size(oca);
+}
+
+static int
+autotest_acl_update(daos_handle_t coh, const char *ace)
+{
+	struct daos_acl	*acl = NULL;
+	int		 rc;
+
+	rc = daos_acl_from_strs(&ace, 1, &acl);
+	if (rc != 0)
+		return rc;
+
+	rc = daos_cont_update_acl(coh, acl, NULL);
+	daos_acl_free(acl);
+	return rc;
+}
+*/
+import "C"
+
+// Test suites of the pool autotest. The smoke suite is run by the C handler,
+// the others on a container created for the run.
+const (
+	autotestSuiteSmoke    = "smoke"
+	autotestSuiteOclass   = "oclass"
+	autotestSuitePerf     = "perf"
+	autotestSuiteSnapshot = "snapshot"
+	autotestSuiteACL      = "acl"
+
+	autotestSuiteSetup    = "setup"
+	autotestSuiteTeardown = "teardown"
+)
+
+var autotestSuites = []string{
+	autotestSuiteSmoke,
+	autotestSuiteOclass,
+	autotestSuitePerf,
+	autotestSuiteSnapshot,
+	autotestSuiteACL,
+}
+
+const (
+	// autotestSmallIOSize is the size of the values of the small I/O
+	// benchmark.
+	autotestSmallIOSize = 4096
+	// autotestBandwidthIOSize is the size of the array extents of the
+	// bandwidth benchmark.
+	autotestBandwidthIOSize = 1 << 20
+	// autotestBandwidthExtents is the number of extents each worker of
+	// the bandwidth benchmark cycles through, to bound the data written.
+	autotestBandwidthExtents = 256
+)
+
+type (
+	autotestStep struct {
+		Suite     string  `json:"suite"`
+		Step      int     `json:"step"`
+		Operation string  `json:"operation"`
+		Passed    bool    `json:"passed"`
+		Skipped   bool    `json:"skipped,omitempty"`
+		Duration  float64 `json:"duration"`
+		Comment   string  `json:"comment,omitempty"`
+		Value     float64 `json:"value,omitempty"`
+		Unit      string  `json:"unit,omitempty"`
+		Threshold float64 `json:"threshold,omitempty"`
+	}
+
+	autotestResult struct {
+		Pool   string          `json:"pool"`
+		Passed bool            `json:"passed"`
+		Suites []string        `json:"suites"`
+		Steps  []*autotestStep `json:"steps"`
+	}
+)
+
+// parseAutotestSuites parses a comma-separated list of suites, returning
+// them in the order they are run.
+func parseAutotestSuites(spec string) ([]string, error) {
+	requested := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			return autotestSuites, nil
+		}
+
+		found := false
+		for _, suite := range autotestSuites {
+			found = found || suite == name
+		}
+		if !found {
+			return nil, errors.Errorf("unknown test suite %q, expected one of %s or all",
+				name, strings.Join(autotestSuites, ", "))
+		}
+		requested[name] = true
+	}
+	if len(requested) == 0 {
+		return nil, errors.New("no test suite specified")
+	}
+
+	var suites []string
+	for _, suite := range autotestSuites {
+		if requested[suite] {
+			suites = append(suites, suite)
+		}
+	}
+	return suites, nil
+}
+
+// autotestResults returns the results of the steps run by the autotest
+// handler.
+func autotestResults() []*autotestStep {
+	var cResults *C.struct_autotest_result
+	nr := C.pool_autotest_results(&cResults)
+	if nr <= 0 {
+		return []*autotestStep{}
+	}
+
+	steps := make([]*autotestStep, nr)
+	for i, res := range (*[1 << 16]C.struct_autotest_result)(unsafe.Pointer(cResults))[:nr:nr] {
+		steps[i] = &autotestStep{
+			Suite:     autotestSuiteSmoke,
+			Step:      int(res.ar_step),
+			Operation: C.GoString(&res.ar_op[0]),
+			Passed:    res.ar_failed == 0,
+			Duration:  float64(res.ar_duration),
+			Comment:   C.GoString(&res.ar_comment[0]),
+		}
+	}
+
+	return steps
+}
+
+// runBench calls op concurrently from the given number of workers until the
+// duration elapses, each worker passing its index and the sequence number
+// of the operation. The number of operations completed by each worker is
+// returned along with the time taken.
+func runBench(workers int, d time.Duration, op func(worker int, seq uint64) error) ([]uint64, time.Duration, error) {
+	counts := make([]uint64, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	deadline := start.Add(d)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				if err := op(w, counts[w]); err != nil {
+					errs[w] = err
+					return
+				}
+				counts[w]++
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return counts, elapsed, nil
+}
+
+func benchTotal(counts []uint64) (total uint64) {
+	for _, c := range counts {
+		total += c
+	}
+	return
+}
+
+// checkThreshold records a benchmark result in a step and fails it if the
+// result is below the threshold, when one is set.
+func checkThreshold(step *autotestStep, value, threshold float64, unit string) error {
+	format := func(v float64) string {
+		if unit == "B/s" {
+			return humanize.IBytes(uint64(v)) + "/s"
+		}
+		return fmt.Sprintf("%.0f %s", v, unit)
+	}
+
+	step.Value = value
+	step.Unit = unit
+	step.Threshold = threshold
+	step.Comment = format(value)
+	if threshold > 0 && value < threshold {
+		return errors.Errorf("%s, below the threshold of %s", format(value), format(threshold))
+	}
+	return nil
+}
+
+type poolAutoTestCmd struct {
+	poolBaseCmd
+
+	Suites       string        `long:"suites" short:"s" default:"smoke" description:"comma-separated test suites to run: smoke, oclass, perf, snapshot, acl, or all"`
+	ObjClasses   string        `long:"oclass" default:"S1,RP_2G1,RP_3G1,EC_2P1G1,EC_4P2G1" description:"comma-separated object classes verified by the oclass suite"`
+	BenchClass   ObjClassFlag  `long:"bench-oclass" default:"SX" description:"object class of the perf suite benchmarks"`
+	BenchTime    time.Duration `long:"bench-time" default:"5s" description:"duration of each benchmark of the perf suite"`
+	BenchWorkers int           `long:"bench-workers" default:"16" description:"number of concurrent I/O of the perf suite benchmarks"`
+	MinIOPS      float64       `long:"min-iops" description:"fail the small I/O benchmarks below this number of operations per second"`
+	MinBandwidth string        `long:"min-bw" description:"fail the bandwidth benchmarks below this rate per second, e.g. 1GiB"`
+	ACLUser      string        `long:"acl-user" description:"user to check container ACLs are enforced for by the acl suite, requires running as root"`
+	Report       string        `long:"report" short:"r" description:"write a report of the results to this file, in JUnit XML if it ends in .xml, else in JSON"`
+
+	classes []ObjClassFlag
+	minBW   float64
+}
+
+func (cmd *poolAutoTestCmd) parseOptions() ([]string, error) {
+	suites, err := parseAutotestSuites(cmd.Suites)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.classes = nil
+	for _, name := range strings.Split(cmd.ObjClasses, ",") {
+		var cls ObjClassFlag
+		if err := cls.UnmarshalFlag(strings.TrimSpace(name)); err != nil {
+			return nil, err
+		}
+		cmd.classes = append(cmd.classes, cls)
+	}
+
+	if cmd.BenchTime <= 0 {
+		return nil, errors.New("benchmark time must be greater than 0")
+	}
+	if cmd.BenchWorkers <= 0 {
+		return nil, errors.New("number of benchmark workers must be greater than 0")
+	}
+	if cmd.MinBandwidth != "" {
+		bw, err := humanize.ParseBytes(cmd.MinBandwidth)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bandwidth threshold %q", cmd.MinBandwidth)
+		}
+		cmd.minBW = float64(bw)
+	}
+
+	return suites, nil
+}
+
+func (cmd *poolAutoTestCmd) Execute(_ []string) error {
+	suites, err := cmd.parseOptions()
+	if err != nil {
+		return err
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RW, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result := &autotestResult{
+		Pool:   cmd.PoolID().String(),
+		Passed: true,
+		Suites: suites,
+		Steps:  []*autotestStep{},
+	}
+
+	var contSuites []string
+	for _, suite := range suites {
+		if suite != autotestSuiteSmoke {
+			contSuites = append(contSuites, suite)
+			continue
+		}
+		if err := cmd.runSmoke(ap, result); err != nil {
+			return err
+		}
+	}
+	if len(contSuites) > 0 {
+		r := &autotestRunner{
+			cmd:    cmd,
+			log:    cmd.log,
+			result: result,
+			poh:    cmd.cPoolHandle,
+		}
+		r.runSuites(contSuites)
+	}
+
+	if cmd.Report != "" {
+		if err := writeAutotestReport(cmd.Report, result); err != nil {
+			return err
+		}
+	}
+
+	var failed error
+	if !result.Passed {
+		failed = errors.Errorf("failed to run autotest for pool %s: some steps failed",
+			cmd.PoolID())
+	}
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(result, failed)
+	}
+	if len(contSuites) > 0 {
+		if result.Passed {
+			cmd.log.Info("\nAll steps \033[0;32mpassed\033[0m.")
+		} else {
+			cmd.log.Info("\nSome steps \033[0;31mfailed\033[0m.")
+		}
+	}
+
+	return failed
+}
+
+// runSmoke runs the smoke tests of the C handler, which prints its progress
+// as it goes.
+func (cmd *poolAutoTestCmd) runSmoke(ap *C.struct_cmd_args_s, result *autotestResult) error {
+	ap.pool = cmd.cPoolHandle
+	if err := copyUUID(&ap.p_uuid, cmd.poolUUID); err != nil {
+		return err
+	}
+	ap.p_op = C.POOL_AUTOTEST
+
+	if !cmd.jsonOutputEnabled() {
+		// Set outstream to stdout; don't try to redirect it.
+		var err error
+		ap.outstream, err = fd2FILE(os.Stdout.Fd(), "w")
+		if err != nil {
+			return err
+		}
+	}
+
+	rc := C.pool_autotest_hdlr(ap)
+	steps := autotestResults()
+	for _, step := range steps {
+		result.Passed = result.Passed && step.Passed
+	}
+	if rc != 0 {
+		result.Passed = false
+	}
+	result.Steps = append(result.Steps, steps...)
+
+	return nil
+}
+
+// autotestRunner runs the test suites that use a container created for the
+// run.
+type autotestRunner struct {
+	cmd    *poolAutoTestCmd
+	log    logging.Logger
+	result *autotestResult
+	header bool
+
+	poh      C.daos_handle_t
+	coh      C.daos_handle_t
+	contUUID uuid.UUID
+	nextOID  uint64
+}
+
+// run runs a step of a suite. The step fails if fn returns an error, and fn
+// may mark it as skipped.
+func (r *autotestRunner) run(suite string, id int, op string, fn func(*autotestStep) error) bool {
+	step := &autotestStep{
+		Suite:     suite,
+		Step:      id,
+		Operation: op,
+		Passed:    true,
+	}
+
+	start := time.Now()
+	if err := fn(step); err != nil {
+		step.Passed = false
+		step.Comment = err.Error()
+	}
+	step.Duration = time.Since(start).Seconds()
+
+	r.result.Steps = append(r.result.Steps, step)
+	r.result.Passed = r.result.Passed && step.Passed
+	r.print(step)
+
+	return step.Passed && !step.Skipped
+}
+
+// skip records a step that can't be run.
+func (r *autotestRunner) skip(suite string, id int, op, reason string) {
+	r.run(suite, id, op, func(step *autotestStep) error {
+		step.Skipped = true
+		step.Comment = reason
+		return nil
+	})
+}
+
+// print reports a step in the format of the C handler.
+func (r *autotestRunner) print(step *autotestStep) {
+	if !r.header {
+		r.log.Info("\033[1;35mStep Operation               Status Time(sec) Comment\033[0m")
+		r.header = true
+	}
+
+	status := "\033[0;32mOK\033[0m"
+	switch {
+	case !step.Passed:
+		status = "\033[0;31mKO\033[0m"
+	case step.Skipped:
+		status = "\033[0;33m--\033[0m"
+	}
+	r.log.Infof("%3d  %-25s  %s    %7.3f  %s", step.Step, step.Operation, status,
+		step.Duration, step.Comment)
+}
+
+func (r *autotestRunner) runSuites(suites []string) {
+	ok := r.run(autotestSuiteSetup, 1, "Creating container", func(step *autotestStep) error {
+		var cUUID C.uuid_t
+		if err := daosError(C.daos_cont_create2(r.poh, &cUUID, nil, nil)); err != nil {
+			return err
+		}
+		var err error
+		if r.contUUID, err = uuidFromC(cUUID); err != nil {
+			return err
+		}
+		step.Comment = "uuid = " + r.contUUID.String()
+		return nil
+	})
+	if !ok {
+		for _, suite := range suites {
+			r.skip(suite, 0, "Running "+suite+" suite", "no container")
+		}
+		return
+	}
+	defer r.run(autotestSuiteTeardown, 902, "Destroying container", func(_ *autotestStep) error {
+		cUUIDstr := C.CString(r.contUUID.String())
+		defer freeString(cUUIDstr)
+		return daosError(C.daos_cont_destroy2(r.poh, cUUIDstr, 1, nil))
+	})
+
+	ok = r.run(autotestSuiteSetup, 2, "Opening container", func(_ *autotestStep) error {
+		cUUIDstr := C.CString(r.contUUID.String())
+		defer freeString(cUUIDstr)
+		return daosError(C.daos_cont_open2(r.poh, cUUIDstr, C.DAOS_COO_RW, &r.coh, nil, nil))
+	})
+	if !ok {
+		for _, suite := range suites {
+			r.skip(suite, 0, "Running "+suite+" suite", "no container")
+		}
+		return
+	}
+	defer r.run(autotestSuiteTeardown, 901, "Closing container", func(_ *autotestStep) error {
+		return daosError(C.daos_cont_close(r.coh, nil))
+	})
+
+	for _, suite := range suites {
+		switch suite {
+		case autotestSuiteOclass:
+			r.runOclass()
+		case autotestSuitePerf:
+			r.runPerf()
+		case autotestSuiteSnapshot:
+			r.runSnapshot()
+		case autotestSuiteACL:
+			r.runACL()
+		}
+	}
+}
+
+// openNewObject generates the ID of a new object of the class and opens it.
+func (r *autotestRunner) openNewObject(cls ObjClassFlag) (C.daos_obj_id_t, C.daos_handle_t, error) {
+	var oh C.daos_handle_t
+
+	r.nextOID++
+	oid := C.daos_obj_id_t{lo: C.uint64_t(r.nextOID)}
+	rc := C.daos_obj_generate_oid(r.coh, &oid, 0, C.daos_oclass_id_t(cls.Class), 0, 0)
+	if err := daosError(rc); err != nil {
+		return oid, oh, errors.Wrapf(err, "failed to generate object ID of class %s", cls.String())
+	}
+
+	rc = C.daos_obj_open(r.coh, oid, C.DAOS_OO_RW, &oh, nil)
+	if err := daosError(rc); err != nil {
+		return oid, oh, errors.Wrapf(err, "failed to open object %s", newDiffOID(oid))
+	}
+	return oid, oh, nil
+}
+
+func (r *autotestRunner) closeObject(oh C.daos_handle_t) {
+	if err := daosError(C.daos_obj_close(oh, nil)); err != nil {
+		r.log.Errorf("failed to close object: %s", err)
+	}
+}
+
+// poolTargets returns the number of targets of the pool.
+func (r *autotestRunner) poolTargets() (uint32, error) {
+	pinfo := C.daos_pool_info_t{
+		pi_bits: C.DPI_SPACE,
+	}
+	rc := C.daos_pool_query(r.poh, nil, &pinfo, nil, nil)
+	if err := daosError(rc); err != nil {
+		return 0, errors.Wrap(err, "failed to query pool")
+	}
+	return uint32(pinfo.pi_space.ps_ntargets), nil
+}
+
+// verifyValues writes single values and an array extent to the object and
+// reads them back.
+func verifyValues(oh C.daos_handle_t, values map[string][]byte, array []byte) error {
+	akey := []byte("autotest")
+	for dkey, value := range values {
+		err := updateValue(oh, []byte(dkey), akey, C.DAOS_IOD_SINGLE, uint64(len(value)), 0, 1, value)
+		if err != nil {
+			return errors.Wrap(err, "failed to write value")
+		}
+	}
+	if len(array) > 0 {
+		err := updateValue(oh, []byte("array"), akey, C.DAOS_IOD_ARRAY, 1, 0, uint64(len(array)), array)
+		if err != nil {
+			return errors.Wrap(err, "failed to write array")
+		}
+	}
+
+	return checkValues(newObjEnumerator(oh, C.daos_handle_t{}, 1), values, array)
+}
+
+// checkValues reads back the values written by verifyValues.
+func checkValues(e *objEnumerator, values map[string][]byte, array []byte) error {
+	akey := []byte("autotest")
+	for dkey, value := range values {
+		buf, err := e.fetchValue([]byte(dkey), akey, C.DAOS_IOD_SINGLE, uint64(len(value)), 0, 1)
+		if err != nil {
+			return errors.Wrap(err, "failed to read value")
+		}
+		if !bytes.Equal(buf, value) {
+			return errors.Errorf("value of %s read back differs from the one written", dkey)
+		}
+	}
+	if len(array) > 0 {
+		buf, err := e.fetchValue([]byte("array"), akey, C.DAOS_IOD_ARRAY, 1, 0, uint64(len(array)))
+		if err != nil {
+			return errors.Wrap(err, "failed to read array")
+		}
+		if !bytes.Equal(buf, array) {
+			return errors.New("array read back differs from the one written")
+		}
+	}
+
+	return nil
+}
+
+func randomValues(nr, size int, prefix string) map[string][]byte {
+	values := make(map[string][]byte)
+	for i := 0; i < nr; i++ {
+		value := make([]byte, size)
+		rand.Read(value)
+		values[fmt.Sprintf("%s-%d", prefix, i)] = value
+	}
+	return values
+}
+
+// runOclass writes and reads back values with each object class, skipping
+// the classes that need more targets than the pool has.
+func (r *autotestRunner) runOclass() {
+	targets, err := r.poolTargets()
+	if err != nil {
+		r.run(autotestSuiteOclass, 100, "Querying pool targets", func(_ *autotestStep) error {
+			return err
+		})
+		return
+	}
+
+	for i, cls := range r.cmd.classes {
+		cls := cls
+		r.run(autotestSuiteOclass, 101+i, "Verifying "+cls.String(), func(step *autotestStep) error {
+			oid, oh, err := r.openNewObject(cls)
+			if err != nil {
+				return err
+			}
+			defer r.closeObject(oh)
+
+			if grp := uint32(C.autotest_grp_size(oid)); grp > targets {
+				step.Skipped = true
+				step.Comment = fmt.Sprintf("needs %d targets, pool has %d", grp, targets)
+				return nil
+			}
+
+			array := make([]byte, 4<<20)
+			rand.Read(array)
+			if err := verifyValues(oh, randomValues(16, 1024, "dkey"), array); err != nil {
+				return err
+			}
+			step.Comment = "16 values, 4.0 MiB array"
+			return nil
+		})
+	}
+}
+
+// autotestBench is a benchmark of the perf suite, writing and then reading
+// back the values written.
+type autotestBench struct {
+	op        string
+	unit      string
+	ioSize    uint64
+	threshold float64
+	write     func(oh C.daos_handle_t, worker int, seq uint64) error
+	read      func(e *objEnumerator, worker int, seq, written uint64) error
+}
+
+func (b *autotestBench) rate(counts []uint64, elapsed time.Duration) float64 {
+	total := float64(benchTotal(counts))
+	if b.unit == "B/s" {
+		total *= float64(b.ioSize)
+	}
+	return total / elapsed.Seconds()
+}
+
+// runPerf runs the small I/O and bandwidth benchmarks.
+func (r *autotestRunner) runPerf() {
+	akey := []byte("autotest")
+	buffers := make([][]byte, r.cmd.BenchWorkers)
+	for w := range buffers {
+		buffers[w] = make([]byte, autotestBandwidthIOSize)
+		rand.Read(buffers[w])
+	}
+	smallKey := func(w int, seq uint64) []byte {
+		return []byte(fmt.Sprintf("w%d-%d", w, seq))
+	}
+	bwKey := func(w int) []byte {
+		return []byte(fmt.Sprintf("w%d", w))
+	}
+
+	benches := []*autotestBench{
+		{
+			op:        "Small I/O",
+			unit:      "IOPS",
+			ioSize:    autotestSmallIOSize,
+			threshold: r.cmd.MinIOPS,
+			write: func(oh C.daos_handle_t, w int, seq uint64) error {
+				return updateValue(oh, smallKey(w, seq), akey, C.DAOS_IOD_SINGLE,
+					autotestSmallIOSize, 0, 1, buffers[w][:autotestSmallIOSize])
+			},
+			read: func(e *objEnumerator, w int, seq, written uint64) error {
+				_, err := e.fetchValue(smallKey(w, seq%written), akey, C.DAOS_IOD_SINGLE,
+					autotestSmallIOSize, 0, 1)
+				return err
+			},
+		},
+		{
+			op:        "Bandwidth",
+			unit:      "B/s",
+			ioSize:    autotestBandwidthIOSize,
+			threshold: r.cmd.minBW,
+			write: func(oh C.daos_handle_t, w int, seq uint64) error {
+				idx := (seq % autotestBandwidthExtents) * autotestBandwidthIOSize
+				return updateValue(oh, bwKey(w), akey, C.DAOS_IOD_ARRAY, 1, idx,
+					autotestBandwidthIOSize, buffers[w])
+			},
+			read: func(e *objEnumerator, w int, seq, written uint64) error {
+				if written > autotestBandwidthExtents {
+					written = autotestBandwidthExtents
+				}
+				idx := (seq % written) * autotestBandwidthIOSize
+				_, err := e.fetchValue(bwKey(w), akey, C.DAOS_IOD_ARRAY, 1, idx,
+					autotestBandwidthIOSize)
+				return err
+			},
+		},
+	}
+
+	for i, bench := range benches {
+		r.runBench(201+2*i, bench)
+	}
+}
+
+func (r *autotestRunner) runBench(id int, bench *autotestBench) {
+	_, oh, err := r.openNewObject(r.cmd.BenchClass)
+	if err != nil {
+		r.run(autotestSuitePerf, id, bench.op+" writes", func(_ *autotestStep) error {
+			return err
+		})
+		r.skip(autotestSuitePerf, id+1, bench.op+" reads", "no data written")
+		return
+	}
+	defer r.closeObject(oh)
+
+	var written []uint64
+	r.run(autotestSuitePerf, id, bench.op+" writes", func(step *autotestStep) error {
+		counts, elapsed, err := runBench(r.cmd.BenchWorkers, r.cmd.BenchTime,
+			func(w int, seq uint64) error {
+				return bench.write(oh, w, seq)
+			})
+		if err != nil {
+			return err
+		}
+		written = counts
+		return checkThreshold(step, bench.rate(counts, elapsed), bench.threshold, bench.unit)
+	})
+	if written == nil {
+		r.skip(autotestSuitePerf, id+1, bench.op+" reads", "no data written")
+		return
+	}
+
+	e := newObjEnumerator(oh, C.daos_handle_t{}, 1)
+	r.run(autotestSuitePerf, id+1, bench.op+" reads", func(step *autotestStep) error {
+		counts, elapsed, err := runBench(r.cmd.BenchWorkers, r.cmd.BenchTime,
+			func(w int, seq uint64) error {
+				if written[w] == 0 {
+					return errors.Errorf("worker %d wrote no data", w)
+				}
+				return bench.read(e, w, seq, written[w])
+			})
+		if err != nil {
+			return err
+		}
+		return checkThreshold(step, bench.rate(counts, elapsed), bench.threshold, bench.unit)
+	})
+}
+
+// runSnapshot checks that a snapshot preserves the values it was taken of
+// across overwrites, and that rolling back to it restores them.
+func (r *autotestRunner) runSnapshot() {
+	const nrValues = 64
+	v1 := randomValues(nrValues, 128, "dkey")
+	v2 := randomValues(nrValues, 128, "dkey")
+
+	var oh C.daos_handle_t
+	ok := r.run(autotestSuiteSnapshot, 301, "Writing values", func(step *autotestStep) error {
+		var err error
+		if _, oh, err = r.openNewObject(ObjClassFlag{Class: C.OC_S1}); err != nil {
+			return err
+		}
+		if err := verifyValues(oh, v1, nil); err != nil {
+			return err
+		}
+		step.Comment = fmt.Sprintf("%d values", nrValues)
+		return nil
+	})
+	if oh != (C.daos_handle_t{}) {
+		defer r.closeObject(oh)
+	}
+
+	var epoch C.daos_epoch_t
+	ops := []struct {
+		op string
+		fn func(*autotestStep) error
+	}{
+		{"Creating snapshot", func(step *autotestStep) error {
+			rc := C.daos_cont_create_snap_opt(r.coh, &epoch, nil, C.DAOS_SNAP_OPT_CR, nil)
+			if err := daosError(rc); err != nil {
+				return err
+			}
+			step.Comment = fmt.Sprintf("epoch = %d", epoch)
+			return nil
+		}},
+		{"Overwriting values", func(_ *autotestStep) error {
+			return verifyValues(oh, v2, nil)
+		}},
+		{"Reading snapshot", func(_ *autotestStep) error {
+			var th C.daos_handle_t
+			if err := daosError(C.daos_tx_open_snap(r.coh, epoch, &th, nil)); err != nil {
+				return errors.Wrap(err, "failed to open snapshot")
+			}
+			defer C.daos_tx_close(th, nil)
+
+			return checkValues(newObjEnumerator(oh, th, 1), v1, nil)
+		}},
+		{"Rolling back", func(step *autotestStep) error {
+			err := daosError(C.daos_cont_rollback(r.coh, epoch, nil))
+			if errors.Cause(err) == drpc.DaosNotImpl {
+				step.Skipped = true
+				step.Comment = "not supported"
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			return checkValues(newObjEnumerator(oh, C.daos_handle_t{}, 1), v1, nil)
+		}},
+		{"Destroying snapshot", func(_ *autotestStep) error {
+			epr := C.daos_epoch_range_t{epr_lo: epoch, epr_hi: epoch}
+			return daosError(C.daos_cont_destroy_snap(r.coh, epr, nil))
+		}},
+	}
+
+	for i, op := range ops {
+		if !ok {
+			r.skip(autotestSuiteSnapshot, 302+i, op.op, "previous step failed")
+			continue
+		}
+		// a skipped rollback doesn't prevent destroying the snapshot
+		if !r.run(autotestSuiteSnapshot, 302+i, op.op, op.fn) {
+			ok = r.result.Steps[len(r.result.Steps)-1].Passed
+		}
+	}
+}
+
+// runACL checks that the container ACL is enforced for a user, by running
+// this command as the user.
+func (r *autotestRunner) runACL() {
+	name := r.cmd.ACLUser
+	if name == "" {
+		r.skip(autotestSuiteACL, 401, "Checking ACL enforcement", "no --acl-user")
+		return
+	}
+
+	var usr *user.User
+	expect := func(want drpc.DaosStatus, args ...string) func(*autotestStep) error {
+		return func(step *autotestStep) error {
+			status, err := r.runAs(usr, args...)
+			if err != nil {
+				return err
+			}
+			if status != want {
+				return errors.Errorf("expected %s, got %s", want, status)
+			}
+			step.Comment = fmt.Sprintf("user %s: %s", name, status)
+			return nil
+		}
+	}
+	listAttrs := []string{"container", "list-attr"}
+	setAttr := []string{"container", "set-attr", "--attr", "autotest", "--value", "denied"}
+
+	ok := r.run(autotestSuiteACL, 401, "Granting read access", func(step *autotestStep) error {
+		if os.Geteuid() != 0 {
+			return errors.New("running as another user requires root privileges")
+		}
+		var err error
+		if usr, err = user.Lookup(name); err != nil {
+			return err
+		}
+
+		ace := C.CString(fmt.Sprintf("A::%s@:r", name))
+		defer freeString(ace)
+		if err := daosError(C.autotest_acl_update(r.coh, ace)); err != nil {
+			return errors.Wrap(err, "failed to update container ACL")
+		}
+		step.Comment = "user " + name
+		return nil
+	})
+
+	ops := []struct {
+		op string
+		fn func(*autotestStep) error
+	}{
+		{"Reading as user", expect(drpc.DaosSuccess, listAttrs...)},
+		{"Writing as user", expect(drpc.DaosNoPermission, setAttr...)},
+		{"Revoking access", func(step *autotestStep) error {
+			cName := C.CString(name + "@")
+			defer freeString(cName)
+			rc := C.daos_cont_delete_acl(r.coh, C.DAOS_ACL_USER, cName, nil)
+			if err := daosError(rc); err != nil {
+				return errors.Wrap(err, "failed to update container ACL")
+			}
+			step.Comment = "user " + name
+			return nil
+		}},
+		{"Reading after revoking", expect(drpc.DaosNoPermission, listAttrs...)},
+	}
+	for i, op := range ops {
+		if !ok {
+			r.skip(autotestSuiteACL, 402+i, op.op, "previous step failed")
+			continue
+		}
+		ok = r.run(autotestSuiteACL, 402+i, op.op, op.fn)
+	}
+}
+
+// runAs runs a command on the container of the run as a user, returning
+// the status it reported.
+func (r *autotestRunner) runAs(usr *user.User, args ...string) (drpc.DaosStatus, error) {
+	uid, err := strconv.ParseUint(usr.Uid, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid uid of user %s", usr.Username)
+	}
+	gid, err := strconv.ParseUint(usr.Gid, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid gid of user %s", usr.Username)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	args = append([]string{"-j"}, args...)
+	args = append(args, r.cmd.poolUUID.String(), r.contUUID.String())
+	if r.cmd.SysName != "" {
+		args = append(args, "--sys-name", r.cmd.SysName)
+	}
+	child := exec.Command(exe, args...)
+	child.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}
+
+	// the command exits with an error status if it reports one
+	out, runErr := child.Output()
+	var resp struct {
+		Status int `json:"status"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		if runErr != nil {
+			err = runErr
+		}
+		return 0, errors.Wrapf(err, "failed to run %s as user %s", args[1], usr.Username)
+	}
+
+	return drpc.DaosStatus(resp.Status), nil
+}
+
+type (
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure"`
+		Skipped   *junitFailure `xml:"skipped"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	junitTestSuite struct {
+		Name      string           `xml:"name,attr"`
+		Tests     int              `xml:"tests,attr"`
+		Failures  int              `xml:"failures,attr"`
+		Skipped   int              `xml:"skipped,attr"`
+		Time      string           `xml:"time,attr"`
+		TestCases []*junitTestCase `xml:"testcase"`
+	}
+
+	junitTestSuites struct {
+		XMLName  xml.Name          `xml:"testsuites"`
+		Name     string            `xml:"name,attr"`
+		Tests    int               `xml:"tests,attr"`
+		Failures int               `xml:"failures,attr"`
+		Skipped  int               `xml:"skipped,attr"`
+		Time     string            `xml:"time,attr"`
+		Suites   []*junitTestSuite `xml:"testsuite"`
+	}
+)
+
+// junitReport converts autotest results to a JUnit XML report, with a test
+// suite for each suite in the order they were run.
+func junitReport(result *autotestResult) ([]byte, error) {
+	report := &junitTestSuites{
+		Name: "daos pool autotest " + result.Pool,
+	}
+
+	var total float64
+	durations := make(map[*junitTestSuite]float64)
+	suites := make(map[string]*junitTestSuite)
+	for _, step := range result.Steps {
+		suite, found := suites[step.Suite]
+		if !found {
+			suite = &junitTestSuite{Name: step.Suite}
+			suites[step.Suite] = suite
+			report.Suites = append(report.Suites, suite)
+		}
+
+		tc := &junitTestCase{
+			Name:      fmt.Sprintf("%d %s", step.Step, step.Operation),
+			ClassName: "daos.autotest." + step.Suite,
+			Time:      fmt.Sprintf("%.3f", step.Duration),
+		}
+		switch {
+		case !step.Passed:
+			tc.Failure = &junitFailure{Message: step.Comment}
+			suite.Failures++
+		case step.Skipped:
+			tc.Skipped = &junitFailure{Message: step.Comment}
+			suite.Skipped++
+		default:
+			tc.SystemOut = step.Comment
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, tc)
+		durations[suite] += step.Duration
+		total += step.Duration
+	}
+
+	for _, suite := range report.Suites {
+		suite.Time = fmt.Sprintf("%.3f", durations[suite])
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+	report.Time = fmt.Sprintf("%.3f", total)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// writeAutotestReport writes a report of the results to a file, in JUnit
+// XML if its name ends in .xml, else in JSON.
+func writeAutotestReport(path string, result *autotestResult) error {
+	var data []byte
+	var err error
+	if strings.HasSuffix(strings.ToLower(path), ".xml") {
+		data, err = junitReport(result)
+	} else {
+		data, err = json.MarshalIndent(result, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write report to %s", path)
+	}
+	return nil
+}
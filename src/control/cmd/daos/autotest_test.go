@@ -0,0 +1,178 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestAutotest_parseAutotestSuites(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec      string
+		expSuites []string
+		expErr    error
+	}{
+		"default": {
+			spec:      "smoke",
+			expSuites: []string{"smoke"},
+		},
+		"run order": {
+			spec:      "acl, perf,smoke,perf",
+			expSuites: []string{"smoke", "perf", "acl"},
+		},
+		"all": {
+			spec:      "perf,all",
+			expSuites: autotestSuites,
+		},
+		"unknown": {
+			spec:   "smoke,speed",
+			expErr: errors.New("unknown test suite \"speed\""),
+		},
+		"empty": {
+			spec:   " , ",
+			expErr: errors.New("no test suite"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			suites, err := parseAutotestSuites(tc.spec)
+			common.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expSuites, suites); diff != "" {
+				t.Fatalf("unexpected suites (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAutotest_runBench(t *testing.T) {
+	var calls uint64
+	counts, elapsed, err := runBench(4, 20*time.Millisecond, func(w int, seq uint64) error {
+		atomic.AddUint64(&calls, 1)
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 4 {
+		t.Fatalf("expected 4 counts, got %v", counts)
+	}
+	common.AssertEqual(t, calls, benchTotal(counts), "total")
+	if elapsed < 20*time.Millisecond {
+		t.Fatalf("benchmark ended after %s", elapsed)
+	}
+
+	_, _, err = runBench(2, time.Second, func(w int, seq uint64) error {
+		if w == 1 && seq == 2 {
+			return errors.New("write failed")
+		}
+		return nil
+	})
+	common.CmpErr(t, errors.New("write failed"), err)
+}
+
+func TestAutotest_checkThreshold(t *testing.T) {
+	step := new(autotestStep)
+	common.CmpErr(t, nil, checkThreshold(step, 1500, 0, "IOPS"))
+	common.AssertEqual(t, "1500 IOPS", step.Comment, "comment")
+
+	err := checkThreshold(step, 512<<20, 1<<30, "B/s")
+	common.CmpErr(t, errors.New("512 MiB/s, below the threshold of 1.0 GiB/s"), err)
+	common.AssertEqual(t, float64(1<<30), step.Threshold, "threshold")
+}
+
+func testAutotestResult() *autotestResult {
+	return &autotestResult{
+		Pool:   "tank",
+		Suites: []string{"smoke", "perf"},
+		Steps: []*autotestStep{
+			{Suite: "smoke", Step: 0, Operation: "Initializing DAOS", Passed: true, Duration: 0.5},
+			{Suite: "setup", Step: 1, Operation: "Creating container", Passed: true, Duration: 0.25},
+			{Suite: "perf", Step: 201, Operation: "Small I/O writes", Duration: 5,
+				Comment: "900 IOPS, below the threshold of 1000 IOPS", Value: 900, Unit: "IOPS", Threshold: 1000},
+			{Suite: "perf", Step: 202, Operation: "Small I/O reads", Passed: true, Skipped: true,
+				Comment: "no data written"},
+		},
+	}
+}
+
+func TestAutotest_junitReport(t *testing.T) {
+	data, err := junitReport(testAutotestResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid report: %s\n%s", err, data)
+	}
+	common.AssertEqual(t, 4, report.Tests, "tests")
+	common.AssertEqual(t, 1, report.Failures, "failures")
+	common.AssertEqual(t, 1, report.Skipped, "skipped")
+	common.AssertEqual(t, "5.750", report.Time, "time")
+
+	var names []string
+	for _, suite := range report.Suites {
+		names = append(names, suite.Name)
+	}
+	common.AssertEqual(t, []string{"smoke", "setup", "perf"}, names, "suites")
+
+	perf := report.Suites[2]
+	common.AssertEqual(t, "201 Small I/O writes", perf.TestCases[0].Name, "name")
+	if perf.TestCases[0].Failure == nil || perf.TestCases[1].Skipped == nil {
+		t.Fatalf("expected failed and skipped test cases:\n%s", data)
+	}
+}
+
+func TestAutotest_writeAutotestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	result := testAutotestResult()
+	for _, name := range []string{"report.json", "report.XML"} {
+		path := filepath.Join(dir, name)
+		if err := writeAutotestReport(path, result); err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.HasSuffix(name, ".XML") {
+			if !strings.HasPrefix(string(data), "<?xml") {
+				t.Fatalf("expected XML report, got:\n%s", data)
+			}
+			continue
+		}
+		var got autotestResult
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(result, &got); diff != "" {
+			t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+		}
+	}
+
+	err = writeAutotestReport(filepath.Join(dir, "missing", "report.json"), result)
+	common.CmpErr(t, errors.New("failed to write report"), err)
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/dustin/go-humanize"
@@ -39,11 +40,20 @@ type containerCmd struct {
 	Stat        containerStatCmd        `command:"stat" description:"get container statistics"`
 	Clone       containerCloneCmd       `command:"clone" description:"clone a container"`
 	Check       containerCheckCmd       `command:"check" description:"check objects' consistency in a container"`
+	Export      containerExportCmd      `command:"export" description:"export a container to an archive file"`
+	Import      containerImportCmd      `command:"import" description:"create a container from an archive file"`
+	Diff        containerDiffCmd        `command:"diff" description:"list the objects, keys and paths modified between two snapshots"`
+
+	Link      containerLinkCmd      `command:"link" description:"link a container to an existing path in the unified namespace"`
+	Unlink    containerUnlinkCmd    `command:"unlink" description:"remove the unified namespace link of a path"`
+	Resolve   containerResolveCmd   `command:"resolve" description:"show the pool and container a path is linked to"`
+	FindLinks containerFindLinksCmd `command:"find-links" description:"list the unified namespace links under a directory"`
 
 	ListAttributes  containerListAttributesCmd  `command:"list-attr" alias:"list-attrs" alias:"lsattr" description:"list container user-defined attributes"`
 	DeleteAttribute containerDeleteAttributeCmd `command:"del-attr" alias:"delattr" description:"delete container user-defined attribute"`
 	GetAttribute    containerGetAttributeCmd    `command:"get-attr" alias:"getattr" description:"get container user-defined attribute"`
 	SetAttribute    containerSetAttributeCmd    `command:"set-attr" alias:"setattr" description:"set container user-defined attribute"`
+	Attributes      containerAttrsCmd           `command:"attrs" description:"export or import container user-defined attributes"`
 
 	GetProperty containerGetPropertyCmd `command:"get-prop" alias:"getprop" description:"get container user-defined attribute"`
 	SetProperty containerSetPropertyCmd `command:"set-prop" alias:"setprop" description:"set container user-defined attribute"`
@@ -586,6 +596,10 @@ func (cmd *containerDestroyCmd) Execute(_ []string) error {
 	return nil
 }
 
+// oidPageSize is the number of object IDs retrieved per call when listing the
+// objects of a container.
+const oidPageSize = 256
+
 type containerListObjectsCmd struct {
 	existingContainerCmd
 
@@ -593,38 +607,49 @@ type containerListObjectsCmd struct {
 }
 
 func (cmd *containerListObjectsCmd) Execute(_ []string) error {
-	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RO, nil)
 	if err != nil {
 		return err
 	}
-	defer deallocCmdArgs()
+	defer cleanup()
 
-	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RO, ap)
+	snap, destroySnap, err := cmd.snapshotAt(cmd.Epoch)
 	if err != nil {
 		return err
 	}
-	defer cleanup()
-
-	if cmd.Epoch > 0 {
-		ap.epc = C.uint64_t(cmd.Epoch)
-	}
+	defer destroySnap()
 
-	// TODO: Build a Go slice so that we can JSON-format the list.
-	rc := C.cont_list_objs_hdlr(ap)
-	if err := daosError(rc); err != nil {
+	oids := []string{}
+	if err := listOIT(cmd.cContHandle, snap, oidPageSize, func(oid C.daos_obj_id_t) error {
+		oids = append(oids, newDiffOID(oid).String())
+		return nil
+	}); err != nil {
 		return errors.Wrapf(err,
 			"failed to list objects in container %s", cmd.ContainerID())
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(oids, nil)
+	}
+
+	var bld strings.Builder
+	for _, oid := range oids {
+		fmt.Fprintln(&bld, oid)
+	}
+	cmd.log.Info(bld.String())
+
 	return nil
 }
 
-type containerStatCmd struct {
-	existingContainerCmd
-}
+// snapshotAt returns the snapshot at epoch, which must have an object index
+// table, or a temporary snapshot of the current state of the container if
+// epoch is 0, along with a function to release it.
+func (cmd *existingContainerCmd) snapshotAt(epoch uint64) (*snapshot, func(), error) {
+	if epoch > 0 {
+		return &snapshot{Epoch: epoch}, func() {}, nil
+	}
 
-func (cmd *containerStatCmd) Execute(_ []string) error {
-	return nil
+	return snapshotCurrent(cmd.log, cmd.cContHandle)
 }
 
 func printContainerInfo(out io.Writer, ci *containerInfo, verbose bool) error {
@@ -756,6 +781,12 @@ type containerCloneCmd struct {
 	Destination string `long:"dst" short:"D" description:"destination container" required:"1"`
 }
 
+type containerCloneResult struct {
+	Source        string    `json:"source"`
+	Destination   string    `json:"destination"`
+	ContainerUUID uuid.UUID `json:"container_uuid"`
+}
+
 func (cmd *containerCloneCmd) Execute(_ []string) error {
 	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
 	if err != nil {
@@ -783,6 +814,19 @@ func (cmd *containerCloneCmd) Execute(_ []string) error {
 			cmd.Source)
 	}
 
+	if cmd.jsonOutputEnabled() {
+		// the handler returns the UUID of the destination container
+		dstUUID, err := uuidFromC(ap.c_uuid)
+		if err != nil {
+			return err
+		}
+		return cmd.outputJSON(&containerCloneResult{
+			Source:        cmd.Source,
+			Destination:   cmd.Destination,
+			ContainerUUID: dstUUID,
+		}, nil)
+	}
+
 	return nil
 }
 
@@ -792,30 +836,82 @@ type containerCheckCmd struct {
 	Epoch uint64 `long:"epc" short:"e" description:"container epoch"`
 }
 
+type containerCheckResult struct {
+	Container    string    `json:"container"`
+	Epoch        uint64    `json:"epoch"`
+	Started      time.Time `json:"started"`
+	Completed    time.Time `json:"completed"`
+	Checked      uint64    `json:"checked"`
+	Skipped      uint64    `json:"skipped"`
+	Inconsistent []string  `json:"inconsistent"`
+	RunTime      uint64    `json:"run_time"`
+	ScanSpeed    uint64    `json:"scan_speed"`
+}
+
 func (cmd *containerCheckCmd) Execute(_ []string) error {
-	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RW, nil)
 	if err != nil {
 		return err
 	}
-	defer deallocCmdArgs()
+	defer cleanup()
 
-	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RW, ap)
+	snap, destroySnap, err := cmd.snapshotAt(cmd.Epoch)
 	if err != nil {
-		return err
+		return errors.Wrapf(err,
+			"failed to check container %s", cmd.ContainerID())
 	}
-	defer cleanup()
+	defer destroySnap()
 
-	if cmd.Epoch > 0 {
-		ap.epc = C.uint64_t(cmd.Epoch)
+	result := &containerCheckResult{
+		Container:    cmd.ContainerID().String(),
+		Epoch:        snap.Epoch,
+		Started:      time.Now(),
+		Inconsistent: []string{},
 	}
+	cmd.log.Infof("check container %s started at: %s\n", result.Container,
+		result.Started.Format(time.ANSIC))
 
-	rc := C.cont_check_hdlr(ap)
-	if err := daosError(rc); err != nil {
+	err = listOIT(cmd.cContHandle, snap, oidPageSize, func(oid C.daos_obj_id_t) error {
+		rc := C.daos_obj_verify(cmd.cContHandle, oid, C.daos_epoch_t(snap.Epoch))
+		switch {
+		case rc == -C.DER_NOSYS:
+			// verification of EC objects isn't supported yet
+			result.Skipped++
+			return nil
+		case rc == -C.DER_MISMATCH:
+			cmd.log.Errorf("found data inconsistency for object: %s", newDiffOID(oid))
+			result.Inconsistent = append(result.Inconsistent, newDiffOID(oid).String())
+		case rc < 0:
+			return errors.Wrapf(daosError(rc), "check object %s failed", newDiffOID(oid))
+		}
+		result.Checked++
+		return nil
+	})
+	if err != nil {
 		return errors.Wrapf(err,
-			"failed to check container %s",
-			cmd.ContainerID())
+			"failed to check container %s", cmd.ContainerID())
 	}
 
+	result.Completed = time.Now()
+	result.RunTime = uint64(result.Completed.Sub(result.Started).Seconds())
+	if result.RunTime == 0 {
+		result.RunTime = 1
+	}
+	result.ScanSpeed = (result.Checked + result.Skipped) / result.RunTime
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(result, nil)
+	}
+
+	cmd.log.Infof("check container %s completed at: %s\n"+
+		"checked: %d\n"+
+		"skipped: %d\n"+
+		"inconsistent: %d\n"+
+		"run_time: %d seconds\n"+
+		"scan_speed: %d objs/sec",
+		result.Container, result.Completed.Format(time.ANSIC), result.Checked,
+		result.Skipped, len(result.Inconsistent), result.RunTime, result.ScanSpeed)
+
 	return nil
 }
 
@@ -0,0 +1,673 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+/*
+#include "util.h"
+*/
+import "C"
+
+const (
+	diffAdded    = "added"
+	diffRemoved  = "removed"
+	diffModified = "modified"
+
+	// DFS stores the entries of a directory under a dkey holding the entry
+	// name, in a byte array akey holding the mode, object ID, access,
+	// modification and change times, chunk size and object class.
+	dfsInodeAKey   = "DFS_INODE"
+	dfsEntryOIDOff = 4
+	dfsEntryMTime  = dfsEntryOIDOff + 16 + 8
+	dfsEntrySize   = dfsEntryMTime + 8 + 8 + 8 + 2
+
+	unixTypeMask = 0170000
+	unixDir      = 0040000
+	unixRegular  = 0100000
+	unixSymlink  = 0120000
+)
+
+type (
+	diffKey struct {
+		objKey
+		Change string     `json:"change"`
+		AKeys  []*diffKey `json:"akeys,omitempty"`
+	}
+
+	diffObject struct {
+		OID    string     `json:"oid"`
+		Change string     `json:"change"`
+		DKeys  []*diffKey `json:"dkeys,omitempty"`
+		oid    diffOID
+	}
+
+	diffPath struct {
+		Path   string `json:"path"`
+		Type   string `json:"type"`
+		Change string `json:"change"`
+	}
+
+	contDiff struct {
+		From *snapshot `json:"from"`
+		To   *snapshot `json:"to"`
+		// Current is set when To is a temporary snapshot of the
+		// current state of the container.
+		Current bool          `json:"current,omitempty"`
+		Objects []*diffObject `json:"objects"`
+		Paths   []*diffPath   `json:"paths,omitempty"`
+		posix   bool
+	}
+
+	// diffOID is a comparable object ID.
+	diffOID struct {
+		hi uint64
+		lo uint64
+	}
+
+	// dfsEntry is the part of a DFS directory entry relevant to a diff.
+	dfsEntry struct {
+		mode  uint32
+		oid   diffOID
+		mtime uint64
+	}
+)
+
+func newDiffOID(oid C.daos_obj_id_t) diffOID {
+	return diffOID{hi: uint64(oid.hi), lo: uint64(oid.lo)}
+}
+
+func (oid diffOID) String() string {
+	return fmt.Sprintf("%d.%d", oid.hi, oid.lo)
+}
+
+func (oid diffOID) less(other diffOID) bool {
+	if oid.hi != other.hi {
+		return oid.hi < other.hi
+	}
+	return oid.lo < other.lo
+}
+
+func (oid diffOID) toC() C.daos_obj_id_t {
+	return C.daos_obj_id_t{hi: C.uint64_t(oid.hi), lo: C.uint64_t(oid.lo)}
+}
+
+// diffKeys compares two lists of keys, returning the keys only found in the
+// second list, the keys only found in the first one, and the keys found in
+// both, each in sorted order.
+func diffKeys(from, to [][]byte) (added, removed, common [][]byte) {
+	sortKeys := func(keys [][]byte) {
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+	}
+	sortKeys(from)
+	sortKeys(to)
+
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case j == len(to) || (i < len(from) && bytes.Compare(from[i], to[j]) < 0):
+			removed = append(removed, from[i])
+			i++
+		case i == len(from) || bytes.Compare(from[i], to[j]) > 0:
+			added = append(added, to[j])
+			j++
+		default:
+			common = append(common, from[i])
+			i++
+			j++
+		}
+	}
+
+	return
+}
+
+// decodeDFSEntry decodes the fixed part of a DFS directory entry, which is
+// stored in the byte order of the client that wrote it.
+func decodeDFSEntry(buf []byte) (*dfsEntry, error) {
+	if len(buf) < dfsEntrySize {
+		return nil, errors.Errorf("invalid DFS entry size %d", len(buf))
+	}
+
+	order := binary.LittleEndian
+	return &dfsEntry{
+		mode: order.Uint32(buf),
+		oid: diffOID{
+			lo: order.Uint64(buf[dfsEntryOIDOff:]),
+			hi: order.Uint64(buf[dfsEntryOIDOff+8:]),
+		},
+		mtime: order.Uint64(buf[dfsEntryMTime:]),
+	}, nil
+}
+
+func (e *dfsEntry) typeName() string {
+	switch e.mode & unixTypeMask {
+	case unixDir:
+		return "directory"
+	case unixRegular:
+		return "file"
+	case unixSymlink:
+		return "symlink"
+	default:
+		return "other"
+	}
+}
+
+// diffPaths compares the paths of a DFS namespace at two epochs. A path is
+// modified if its entry changed, or if it is a file whose object was modified.
+func diffPaths(from, to map[string]*dfsEntry, modified map[diffOID]bool) []*diffPath {
+	paths := []*diffPath{}
+
+	for p, te := range to {
+		fe, found := from[p]
+		switch {
+		case !found:
+			paths = append(paths, &diffPath{Path: p, Type: te.typeName(), Change: diffAdded})
+		case *fe != *te, te.mode&unixTypeMask == unixRegular && modified[te.oid]:
+			paths = append(paths, &diffPath{Path: p, Type: te.typeName(), Change: diffModified})
+		}
+	}
+	for p, fe := range from {
+		if _, found := to[p]; !found {
+			paths = append(paths, &diffPath{Path: p, Type: fe.typeName(), Change: diffRemoved})
+		}
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Path < paths[j].Path
+	})
+
+	return paths
+}
+
+type containerDiffCmd struct {
+	existingContainerCmd
+
+	FromSnap string `long:"from-snap" short:"F" required:"1" description:"snapshot to compare from (name or epoch)"`
+	ToSnap   string `long:"to-snap" short:"T" description:"snapshot to compare to (name or epoch, default: current state of the container)"`
+	Keys     bool   `long:"keys" short:"k" description:"list the modified keys of each object"`
+	Format   string `long:"format" short:"f" default:"auto" choice:"auto" choice:"utf8" choice:"hex" description:"key rendering; auto renders printable UTF-8 as-is and anything else as hex"`
+	PageSize int    `long:"page-size" default:"256" description:"number of objects or keys to retrieve per enumeration call"`
+}
+
+// diffView is a read-only view of the container as of a snapshot.
+type diffView struct {
+	snap *snapshot
+	th   C.daos_handle_t
+}
+
+func (cmd *containerDiffCmd) openView(snap *snapshot) (*diffView, error) {
+	v := &diffView{snap: snap}
+	rc := C.daos_tx_open_snap(cmd.cContHandle, C.daos_epoch_t(snap.Epoch), &v.th, nil)
+	if err := daosError(rc); err != nil {
+		return nil, errors.Wrapf(err, "failed to open snapshot at epoch %d", snap.Epoch)
+	}
+
+	return v, nil
+}
+
+func (cmd *containerDiffCmd) closeView(v *diffView) {
+	if err := daosError(C.daos_tx_close(v.th, nil)); err != nil {
+		cmd.log.Errorf("failed to close snapshot at epoch %d: %s", v.snap.Epoch, err)
+	}
+}
+
+// listObjects returns the objects recorded in the object index table of a
+// snapshot.
+func (cmd *containerDiffCmd) listObjects(snap *snapshot) (map[diffOID]bool, error) {
+	objs := make(map[diffOID]bool)
+	err := listOIT(cmd.cContHandle, snap, cmd.PageSize, func(oid C.daos_obj_id_t) error {
+		objs[newDiffOID(oid)] = true
+		return nil
+	})
+	if errors.Cause(err) == drpc.DaosNonexistant {
+		return nil, errors.Errorf("snapshot at epoch %d has no object index table, "+
+			"create snapshots to compare with create-snap --oit", snap.Epoch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return objs, nil
+}
+
+// akeyDigest summarizes the value stored under an akey. Single values are
+// compared by content. Array values are compared by their extents, which
+// record the epoch they were written at, so that large arrays don't need to
+// be read.
+func akeyDigest(e *objEnumerator, dkey, akey []byte) ([]byte, error) {
+	digest := sha256.New()
+
+	size, err := e.fetchSize(dkey, akey)
+	if err != nil {
+		return nil, err
+	}
+	if size > 0 {
+		buf, err := e.fetchValue(dkey, akey, C.DAOS_IOD_SINGLE, size, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		digest.Write([]byte("single"))
+		digest.Write(buf)
+
+		return digest.Sum(nil), nil
+	}
+
+	recSize, extents, err := e.listExtents(dkey, akey)
+	if err != nil {
+		return nil, err
+	}
+	digest.Write([]byte("array"))
+	binary.Write(digest, binary.LittleEndian, recSize)
+	for _, ext := range extents {
+		binary.Write(digest, binary.LittleEndian, []uint64{ext.Index, ext.Count, ext.Epoch})
+	}
+
+	return digest.Sum(nil), nil
+}
+
+func (cmd *containerDiffCmd) newDiffKeys(keys [][]byte, change string) []*diffKey {
+	out := make([]*diffKey, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, &diffKey{objKey: newObjKey(key, cmd.Format), Change: change})
+	}
+	return out
+}
+
+// diffDKey compares the akeys of a dkey found at both epochs.
+func (cmd *containerDiffCmd) diffDKey(from, to *objEnumerator, dkey []byte) ([]*diffKey, error) {
+	var fromAnchor, toAnchor C.daos_anchor_t
+	fromKeys, err := from.listKeys(dkey, &fromAnchor, 0)
+	if err != nil {
+		return nil, err
+	}
+	toKeys, err := to.listKeys(dkey, &toAnchor, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed, common := diffKeys(fromKeys, toKeys)
+	akeys := cmd.newDiffKeys(added, diffAdded)
+	akeys = append(akeys, cmd.newDiffKeys(removed, diffRemoved)...)
+	for _, akey := range common {
+		fromDigest, err := akeyDigest(from, dkey, akey)
+		if err != nil {
+			return nil, err
+		}
+		toDigest, err := akeyDigest(to, dkey, akey)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(fromDigest, toDigest) {
+			akeys = append(akeys, &diffKey{objKey: newObjKey(akey, cmd.Format), Change: diffModified})
+		}
+	}
+
+	return akeys, nil
+}
+
+// diffObject compares the keys of an object found at both epochs, returning
+// nil if it wasn't modified.
+func (cmd *containerDiffCmd) diffObject(from, to *diffView, oid diffOID) (*diffObject, error) {
+	var oh C.daos_handle_t
+	rc := C.daos_obj_open(cmd.cContHandle, oid.toC(), C.DAOS_OO_RO, &oh, nil)
+	if err := daosError(rc); err != nil {
+		return nil, err
+	}
+	defer C.daos_obj_close(oh, nil)
+
+	fromEnum := newObjEnumerator(oh, from.th, cmd.PageSize)
+	toEnum := newObjEnumerator(oh, to.th, cmd.PageSize)
+
+	var fromAnchor, toAnchor C.daos_anchor_t
+	fromKeys, err := fromEnum.listKeys(nil, &fromAnchor, 0)
+	if err != nil {
+		return nil, err
+	}
+	toKeys, err := toEnum.listKeys(nil, &toAnchor, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed, common := diffKeys(fromKeys, toKeys)
+	dkeys := cmd.newDiffKeys(added, diffAdded)
+	dkeys = append(dkeys, cmd.newDiffKeys(removed, diffRemoved)...)
+	for _, dkey := range common {
+		akeys, err := cmd.diffDKey(fromEnum, toEnum, dkey)
+		if err != nil {
+			key := newObjKey(dkey, cmd.Format)
+			return nil, errors.Wrapf(err, "dkey %s", &key)
+		}
+		if len(akeys) > 0 {
+			dkeys = append(dkeys, &diffKey{
+				objKey: newObjKey(dkey, cmd.Format),
+				Change: diffModified,
+				AKeys:  akeys,
+			})
+		}
+	}
+	if len(dkeys) == 0 {
+		return nil, nil
+	}
+
+	return &diffObject{OID: oid.String(), Change: diffModified, DKeys: dkeys, oid: oid}, nil
+}
+
+func (cmd *containerDiffCmd) diffObjects(from, to *diffView) ([]*diffObject, error) {
+	fromObjs, err := cmd.listObjects(from.snap)
+	if err != nil {
+		return nil, err
+	}
+	toObjs, err := cmd.listObjects(to.snap)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := []*diffObject{}
+	for oid := range toObjs {
+		if !fromObjs[oid] {
+			objs = append(objs, &diffObject{OID: oid.String(), Change: diffAdded, oid: oid})
+			continue
+		}
+
+		obj, err := cmd.diffObject(from, to, oid)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compare object %s", oid)
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	for oid := range fromObjs {
+		if !toObjs[oid] {
+			objs = append(objs, &diffObject{OID: oid.String(), Change: diffRemoved, oid: oid})
+		}
+	}
+
+	sort.Slice(objs, func(i, j int) bool {
+		return objs[i].oid.less(objs[j].oid)
+	})
+
+	return objs, nil
+}
+
+// readDFSTree returns the entries of a DFS namespace as of a snapshot, keyed
+// by path, by walking its directory objects from the root.
+func (cmd *containerDiffCmd) readDFSTree(v *diffView, root diffOID) (map[string]*dfsEntry, error) {
+	entries := make(map[string]*dfsEntry)
+
+	var walk func(dir string, oid diffOID) error
+	walk = func(dir string, oid diffOID) error {
+		var oh C.daos_handle_t
+		rc := C.daos_obj_open(cmd.cContHandle, oid.toC(), C.DAOS_OO_RO, &oh, nil)
+		if err := daosError(rc); err != nil {
+			return errors.Wrapf(err, "failed to open directory %s", dir)
+		}
+		defer C.daos_obj_close(oh, nil)
+
+		e := newObjEnumerator(oh, v.th, cmd.PageSize)
+		var anchor C.daos_anchor_t
+		names, err := e.listKeys(nil, &anchor, 0)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read directory %s", dir)
+		}
+
+		for _, name := range names {
+			p := path.Join(dir, string(name))
+			buf, err := e.fetchValue(name, []byte(dfsInodeAKey), C.DAOS_IOD_ARRAY, 1, 0, dfsEntrySize)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read entry %s", p)
+			}
+			entry, err := decodeDFSEntry(buf)
+			if err != nil {
+				return errors.Wrapf(err, "entry %s", p)
+			}
+			entries[p] = entry
+
+			if entry.mode&unixTypeMask == unixDir {
+				if err := walk(p, entry.oid); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk("/", root); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// diffPOSIX compares the paths of the DFS namespace of a POSIX container.
+func (cmd *containerDiffCmd) diffPOSIX(from, to *diffView, objs []*diffObject) ([]*diffPath, error) {
+	roots, err := getContRoots(cmd.cContHandle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get container roots")
+	}
+	if len(roots) < 2 {
+		return nil, errors.New("POSIX container has no root directory")
+	}
+	root := diffOID{hi: roots[1].Hi, lo: roots[1].Lo}
+
+	fromTree, err := cmd.readDFSTree(from, root)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := cmd.readDFSTree(to, root)
+	if err != nil {
+		return nil, err
+	}
+
+	modified := make(map[diffOID]bool)
+	for _, obj := range objs {
+		if obj.Change == diffModified {
+			modified[obj.oid] = true
+		}
+	}
+
+	return diffPaths(fromTree, toTree, modified), nil
+}
+
+func (cmd *containerDiffCmd) isPOSIX() (bool, error) {
+	props, cleanup, err := getContainerProperties(cmd.cContHandle, "layout_type")
+	defer cleanup()
+	if err != nil {
+		return false, err
+	}
+
+	return len(props) > 0 &&
+		C.get_dpe_val(props[0].entry) == C.DAOS_PROP_CO_LAYOUT_POSIX, nil
+}
+
+func (cmd *containerDiffCmd) Execute(_ []string) error {
+	if cmd.PageSize <= 0 {
+		return errors.New("page size must be greater than 0")
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	// comparing with the current state requires taking a snapshot
+	flags := C.uint(C.DAOS_COO_RO)
+	if cmd.ToSnap == "" {
+		flags = C.DAOS_COO_RW
+	}
+	cleanup, err := cmd.resolveAndConnect(flags, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	snaps, err := listSnapshots(cmd.cContHandle)
+	if err != nil {
+		return errors.Wrap(err, "failed to list snapshots")
+	}
+	diff := new(contDiff)
+	if diff.From, err = findSnapshot(snaps, cmd.FromSnap); err != nil {
+		return err
+	}
+	if cmd.ToSnap != "" {
+		if diff.To, err = findSnapshot(snaps, cmd.ToSnap); err != nil {
+			return err
+		}
+	} else {
+		var destroySnap func()
+		if diff.To, destroySnap, err = snapshotCurrent(cmd.log, cmd.cContHandle); err != nil {
+			return err
+		}
+		defer destroySnap()
+		diff.Current = true
+	}
+
+	from, err := cmd.openView(diff.From)
+	if err != nil {
+		return err
+	}
+	defer cmd.closeView(from)
+	to, err := cmd.openView(diff.To)
+	if err != nil {
+		return err
+	}
+	defer cmd.closeView(to)
+
+	if diff.Objects, err = cmd.diffObjects(from, to); err != nil {
+		return errors.Wrapf(err, "failed to compare container %s", cmd.ContainerID())
+	}
+
+	posix, err := cmd.isPOSIX()
+	if err != nil {
+		return errors.Wrap(err, "failed to get container layout")
+	}
+	if posix {
+		diff.posix = true
+		if diff.Paths, err = cmd.diffPOSIX(from, to, diff.Objects); err != nil {
+			return errors.Wrapf(err, "failed to compare namespace of container %s",
+				cmd.ContainerID())
+		}
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(diff, nil)
+	}
+
+	var bld strings.Builder
+	printContDiff(&bld, diff, cmd.Keys)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+// keyChangeSummary counts the changes of each kind among the keys.
+func keyChangeSummary(keys []*diffKey, kind string) string {
+	counts := make(map[string]int)
+	for _, key := range keys {
+		counts[key.Change]++
+	}
+
+	var parts []string
+	for _, change := range []string{diffAdded, diffRemoved, diffModified} {
+		if counts[change] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[change], change))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(parts, ", ") + " " + kind
+}
+
+func printContDiff(out io.Writer, diff *contDiff, keys bool) {
+	to := diff.To.String()
+	if diff.Current {
+		to = "current state"
+	}
+	fmt.Fprintf(out, "Changes from %s to %s\n", diff.From, to)
+
+	if len(diff.Objects) == 0 {
+		fmt.Fprintln(out, "No objects modified")
+	} else {
+		printDiffObjects(out, diff.Objects, keys)
+	}
+
+	if !diff.posix {
+		return
+	}
+	fmt.Fprintln(out)
+	if len(diff.Paths) == 0 {
+		fmt.Fprintln(out, "No paths modified")
+		return
+	}
+
+	pathTitle := "Path"
+	typeTitle := "Type"
+	changeTitle := "Change"
+	table := []txtfmt.TableRow{}
+	for _, p := range diff.Paths {
+		table = append(table, txtfmt.TableRow{
+			pathTitle:   p.Path,
+			typeTitle:   p.Type,
+			changeTitle: p.Change,
+		})
+	}
+	tf := txtfmt.NewTableFormatter(pathTitle, typeTitle, changeTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
+
+func printDiffObjects(out io.Writer, objs []*diffObject, keys bool) {
+	oidTitle := "Object"
+	changeTitle := "Change"
+	keysTitle := "DKeys"
+	table := []txtfmt.TableRow{}
+	for _, obj := range objs {
+		table = append(table, txtfmt.TableRow{
+			oidTitle:    obj.OID,
+			changeTitle: obj.Change,
+			keysTitle:   keyChangeSummary(obj.DKeys, "dkeys"),
+		})
+	}
+	tf := txtfmt.NewTableFormatter(oidTitle, changeTitle, keysTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+
+	if keys {
+		for _, obj := range objs {
+			if len(obj.DKeys) == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "\nobject %s\n", obj.OID)
+			for _, dk := range obj.DKeys {
+				fmt.Fprintf(out, "  %-8s dkey %s\n", dk.Change, dk)
+				for _, ak := range dk.AKeys {
+					fmt.Fprintf(out, "    %-8s akey %s\n", ak.Change, ak)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func toKeys(strs ...string) [][]byte {
+	var keys [][]byte
+	for _, s := range strs {
+		keys = append(keys, []byte(s))
+	}
+	return keys
+}
+
+func TestDiff_diffKeys(t *testing.T) {
+	for name, tc := range map[string]struct {
+		from       [][]byte
+		to         [][]byte
+		expAdded   [][]byte
+		expRemoved [][]byte
+		expCommon  [][]byte
+	}{
+		"empty": {},
+		"all added": {
+			to:       toKeys("b", "a"),
+			expAdded: toKeys("a", "b"),
+		},
+		"all removed": {
+			from:       toKeys("b", "a"),
+			expRemoved: toKeys("a", "b"),
+		},
+		"mixed": {
+			from:       toKeys("e", "a", "c", "d"),
+			to:         toKeys("b", "d", "c", "f"),
+			expAdded:   toKeys("b", "f"),
+			expRemoved: toKeys("a", "e"),
+			expCommon:  toKeys("c", "d"),
+		},
+		"binary keys": {
+			from:      [][]byte{{0, 1}, {0}},
+			to:        [][]byte{{0}, {0, 1}, {0, 0}},
+			expAdded:  [][]byte{{0, 0}},
+			expCommon: [][]byte{{0}, {0, 1}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			added, removed, common := diffKeys(tc.from, tc.to)
+			if diff := cmp.Diff(tc.expAdded, added); diff != "" {
+				t.Fatalf("unexpected added keys (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expRemoved, removed); diff != "" {
+				t.Fatalf("unexpected removed keys (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expCommon, common); diff != "" {
+				t.Fatalf("unexpected common keys (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func encodeDFSEntry(mode uint32, oid diffOID, mtime uint64) []byte {
+	buf := make([]byte, dfsEntrySize)
+	binary.LittleEndian.PutUint32(buf, mode)
+	binary.LittleEndian.PutUint64(buf[dfsEntryOIDOff:], oid.lo)
+	binary.LittleEndian.PutUint64(buf[dfsEntryOIDOff+8:], oid.hi)
+	binary.LittleEndian.PutUint64(buf[dfsEntryMTime-8:], mtime-1)
+	binary.LittleEndian.PutUint64(buf[dfsEntryMTime:], mtime)
+	return buf
+}
+
+func TestDiff_decodeDFSEntry(t *testing.T) {
+	for name, tc := range map[string]struct {
+		buf      []byte
+		expEntry *dfsEntry
+		expErr   error
+	}{
+		"short": {
+			buf:    make([]byte, dfsEntrySize-1),
+			expErr: errors.New("invalid DFS entry size"),
+		},
+		"file": {
+			buf: encodeDFSEntry(0100644, diffOID{hi: 2, lo: 3}, 1622548800),
+			expEntry: &dfsEntry{
+				mode:  0100644,
+				oid:   diffOID{hi: 2, lo: 3},
+				mtime: 1622548800,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			entry, err := decodeDFSEntry(tc.buf)
+			common.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expEntry, entry, cmp.AllowUnexported(dfsEntry{}, diffOID{})); diff != "" {
+				t.Fatalf("unexpected entry (-want, +got):\n%s\n", diff)
+			}
+			if entry != nil {
+				common.AssertEqual(t, "file", entry.typeName(), "type")
+			}
+		})
+	}
+}
+
+func TestDiff_diffPaths(t *testing.T) {
+	file := func(oid uint64, mtime uint64) *dfsEntry {
+		return &dfsEntry{mode: 0100644, oid: diffOID{hi: 1, lo: oid}, mtime: mtime}
+	}
+	dir := func(oid uint64) *dfsEntry {
+		return &dfsEntry{mode: 040755, oid: diffOID{hi: 2, lo: oid}}
+	}
+
+	from := map[string]*dfsEntry{
+		"/a":      file(1, 10),
+		"/b":      file(2, 10),
+		"/c":      file(3, 10),
+		"/d":      dir(4),
+		"/d/e":    file(5, 10),
+		"/d/gone": file(6, 10),
+	}
+	to := map[string]*dfsEntry{
+		"/a":     file(1, 10),
+		"/b":     file(2, 10),
+		"/c":     file(3, 11),
+		"/d":     dir(4),
+		"/d/e":   file(5, 10),
+		"/d/new": file(7, 10),
+	}
+	// the content of /b and the entries of /d were modified
+	modified := map[diffOID]bool{
+		{hi: 1, lo: 2}: true,
+		{hi: 2, lo: 4}: true,
+	}
+
+	expPaths := []*diffPath{
+		{Path: "/b", Type: "file", Change: diffModified},
+		{Path: "/c", Type: "file", Change: diffModified},
+		{Path: "/d/gone", Type: "file", Change: diffRemoved},
+		{Path: "/d/new", Type: "file", Change: diffAdded},
+	}
+	if diff := cmp.Diff(expPaths, diffPaths(from, to, modified)); diff != "" {
+		t.Fatalf("unexpected paths (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestDiff_findSnapshot(t *testing.T) {
+	snaps := []*snapshot{
+		{Epoch: 100, Name: "first"},
+		{Epoch: 200},
+		{Epoch: 300, Name: "100"},
+	}
+
+	for name, tc := range map[string]struct {
+		id      string
+		expSnap *snapshot
+		expErr  error
+	}{
+		"by name": {
+			id:      "first",
+			expSnap: snaps[0],
+		},
+		"by epoch": {
+			id:      "200",
+			expSnap: snaps[1],
+		},
+		"name takes precedence": {
+			id:      "100",
+			expSnap: snaps[2],
+		},
+		"not found": {
+			id:     "missing",
+			expErr: errors.New("no snapshot with name or epoch"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			snap, err := findSnapshot(snaps, tc.id)
+			common.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expSnap, snap); diff != "" {
+				t.Fatalf("unexpected snapshot (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDiff_printContDiff(t *testing.T) {
+	diff := &contDiff{
+		From:    &snapshot{Epoch: 100, Name: "before"},
+		To:      &snapshot{Epoch: 200},
+		Current: true,
+		Objects: []*diffObject{
+			{OID: "1.2", Change: diffAdded},
+			{
+				OID:    "1.3",
+				Change: diffModified,
+				DKeys: []*diffKey{
+					{objKey: newObjKey([]byte("new"), keyFormatAuto), Change: diffAdded},
+					{
+						objKey: newObjKey([]byte("dk"), keyFormatAuto),
+						Change: diffModified,
+						AKeys: []*diffKey{
+							{objKey: newObjKey([]byte("ak"), keyFormatAuto), Change: diffModified},
+						},
+					},
+				},
+			},
+		},
+		Paths: []*diffPath{
+			{Path: "/file", Type: "file", Change: diffModified},
+		},
+		posix: true,
+	}
+
+	var bld strings.Builder
+	printContDiff(&bld, diff, true)
+	out := bld.String()
+
+	for _, exp := range []string{
+		"Changes from before (epoch 100) to current state",
+		"1 added, 1 modified dkeys",
+		"object 1.3",
+		"  added    dkey new",
+		"    modified akey ak",
+		"/file",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Fatalf("expected %q in output:\n%s", exp, out)
+		}
+	}
+}
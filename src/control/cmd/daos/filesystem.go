@@ -36,34 +36,9 @@ type fsCmd struct {
 	ResetObjClass  fsAttrCmd `command:"reset-oclass" description:"reset fs obj class"`
 }
 
-type fsCopyCmd struct {
-	daosCmd
-
-	Source string `long:"src" short:"s" description:"copy source" required:"1"`
-	Dest   string `long:"dst" short:"d" description:"copy destination" required:"1"`
-}
-
-func (cmd *fsCopyCmd) Execute(_ []string) error {
-	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
-	if err != nil {
-		return err
-	}
-	defer deallocCmdArgs()
-
-	ap.src = C.CString(cmd.Source)
-	defer freeString(ap.src)
-	ap.dst = C.CString(cmd.Dest)
-	defer freeString(ap.dst)
-
-	ap.fs_op = C.FS_COPY
-	rc := C.fs_copy_hdlr(ap)
-	if err := daosError(rc); err != nil {
-		return errors.Wrapf(err,
-			"failed to copy %s -> %s",
-			cmd.Source, cmd.Dest)
-	}
-
-	return nil
+type fsAttributes struct {
+	ObjectClass string `json:"oclass"`
+	ChunkSize   uint64 `json:"chunk_size"`
 }
 
 type fsAttrCmd struct {
@@ -137,5 +112,15 @@ func (cmd *fsAttrCmd) Execute(_ []string) error {
 		return errors.Wrapf(err, "%s failed", op)
 	}
 
+	if op == "get-attr" && cmd.jsonOutputEnabled() {
+		// the handler returns the attributes in the arguments
+		var oclass [16]C.char
+		C.daos_oclass_id2name(ap.oclass, &oclass[0])
+		return cmd.outputJSON(&fsAttributes{
+			ObjectClass: C.GoString(&oclass[0]),
+			ChunkSize:   uint64(ap.chunk_size),
+		}, nil)
+	}
+
 	return nil
 }
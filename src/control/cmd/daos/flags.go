@@ -7,6 +7,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
@@ -160,6 +161,38 @@ func (f *OidFlag) UnmarshalFlag(fv string) error {
 	return nil
 }
 
+type ObjKeyFlag struct {
+	Set bool
+	Key []byte
+}
+
+func (f *ObjKeyFlag) String() string {
+	str, _ := formatBytes(f.Key, keyFormatAuto)
+	return str
+}
+
+func (f *ObjKeyFlag) UnmarshalFlag(fv string) error {
+	if fv == "" {
+		return errors.New("empty key")
+	}
+
+	if strings.HasPrefix(fv, "0x") {
+		key, err := hex.DecodeString(fv[2:])
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %q as hex key", fv)
+		}
+		if len(key) == 0 {
+			return errors.New("empty key")
+		}
+		f.Key = key
+	} else {
+		f.Key = []byte(fv)
+	}
+
+	f.Set = true
+	return nil
+}
+
 type ConsModeFlag struct {
 	Set  bool
 	Mode C.uint32_t
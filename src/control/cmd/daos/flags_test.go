@@ -282,3 +282,53 @@ func TestFlags_ConsModeFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestFlags_ObjKeyFlag(t *testing.T) {
+	for name, tc := range map[string]struct {
+		arg       string
+		expKey    []byte
+		expString string
+		expErr    error
+	}{
+		"unset": {
+			expErr: errors.New("empty key"),
+		},
+		"empty hex": {
+			arg:    "0x",
+			expErr: errors.New("empty key"),
+		},
+		"invalid hex": {
+			arg:    "0xzz",
+			expErr: errors.New(`failed to parse "0xzz"`),
+		},
+		"string key": {
+			arg:       "dkey1",
+			expKey:    []byte("dkey1"),
+			expString: "dkey1",
+		},
+		"hex key": {
+			arg:       "0x0100000000000000",
+			expKey:    []byte{1, 0, 0, 0, 0, 0, 0, 0},
+			expString: "0x0100000000000000",
+		},
+		"printable hex key": {
+			arg:       "0x616b6579",
+			expKey:    []byte("akey"),
+			expString: "akey",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := ObjKeyFlag{}
+			gotErr := f.UnmarshalFlag(tc.arg)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expKey, f.Key); diff != "" {
+				t.Fatalf("unexpected key: (-want, +got)\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expString, f.String(), "unexpected String()")
+		})
+	}
+}
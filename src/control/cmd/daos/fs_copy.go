@@ -0,0 +1,503 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+/*
+#include "util.h"
+#include "daos_fs_sys.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/lib/fscopy"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const progressBarWidth = 30
+
+type fsCopyCmd struct {
+	daosCmd
+
+	Source    string        `long:"src" short:"s" description:"copy source" required:"1"`
+	Dest      string        `long:"dst" short:"d" description:"copy destination" required:"1"`
+	Workers   int           `long:"workers" short:"w" description:"number of concurrent copy workers" default:"4"`
+	SplitSize ChunkSizeFlag `long:"split-size" description:"size of the parts that large files are split into to be copied concurrently (default 64 MiB)"`
+	Progress  string        `long:"progress" description:"report progress on stderr" choice:"none" choice:"bar" choice:"json" default:"none"`
+	Skip      string        `long:"skip-unchanged" description:"skip files that are unchanged at the destination, comparing size and modification time or content" optional:"1" optional-value:"size-mtime" choice:"size-mtime" choice:"checksum"`
+	Journal   string        `long:"journal" description:"record completed files in a journal to resume an interrupted copy"`
+	Verify    bool          `long:"verify" description:"compare the checksums of all files once the copy is complete"`
+}
+
+func (cmd *fsCopyCmd) options() (fscopy.Options, error) {
+	opts := fscopy.Options{
+		Workers:     cmd.Workers,
+		Verify:      cmd.Verify,
+		JournalPath: cmd.Journal,
+	}
+	if cmd.Workers < 1 {
+		return opts, errors.New("--workers must be at least 1")
+	}
+	if cmd.SplitSize.Set {
+		if cmd.SplitSize.Size == 0 {
+			return opts, errors.New("--split-size must be greater than 0")
+		}
+		opts.SplitSize = int64(cmd.SplitSize.Size)
+	}
+
+	switch cmd.Skip {
+	case "":
+	case "size-mtime":
+		opts.Skip = fscopy.SkipSizeMtime
+	case "checksum":
+		opts.Skip = fscopy.SkipChecksum
+	}
+
+	switch cmd.Progress {
+	case "bar":
+		opts.Progress = func(p *fscopy.Progress) {
+			end := ""
+			if p.Phase == fscopy.PhaseDone {
+				end = "\n"
+			}
+			fmt.Fprintf(os.Stderr, "\r%s%s", p.Bar(progressBarWidth), end)
+		}
+	case "json":
+		enc := json.NewEncoder(os.Stderr)
+		opts.Progress = func(p *fscopy.Progress) {
+			if err := enc.Encode(p); err != nil {
+				cmd.log.Errorf("failed to write progress: %s", err)
+			}
+		}
+	}
+
+	return opts, nil
+}
+
+func (cmd *fsCopyCmd) Execute(_ []string) error {
+	opts, err := cmd.options()
+	if err != nil {
+		return err
+	}
+
+	src, srcCleanup, err := cmd.openEndpoint(cmd.Source, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open source %s", cmd.Source)
+	}
+	defer srcCleanup()
+
+	dst, dstCleanup, err := cmd.openEndpoint(cmd.Dest, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open destination %s", cmd.Dest)
+	}
+	defer dstCleanup()
+
+	// stop cleanly on interrupt so that the journal and the DAOS
+	// handles are left in a consistent state
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cmd.log.Info("interrupted, stopping copy")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	result, err := fscopy.Copy(ctx, *src, *dst, opts)
+	if result != nil && cmd.jsonOutputEnabled() {
+		if jErr := cmd.outputJSON(result, nil); jErr != nil {
+			return jErr
+		}
+	}
+	if err != nil && (result == nil || len(result.Mismatches) == 0) {
+		return errors.Wrapf(err, "failed to copy %s -> %s", cmd.Source, cmd.Dest)
+	}
+
+	if !cmd.jsonOutputEnabled() {
+		cmd.log.Info(fsCopyResultString(dst, result))
+	}
+
+	return err
+}
+
+func fsCopyResultString(dst *fscopy.Endpoint, result *fscopy.Result) string {
+	var bld strings.Builder
+
+	target := "POSIX"
+	if _, isLocal := dst.FS.(fscopy.LocalFS); !isLocal {
+		target = "DAOS"
+	}
+	if len(result.Mismatches) > 0 {
+		fmt.Fprintf(&bld, "Copied to %s with checksum mismatches: %s\n", target, result.Dest)
+	} else {
+		fmt.Fprintf(&bld, "Successfully copied to %s: %s\n", target, result.Dest)
+	}
+	fmt.Fprintf(&bld, "    Directories: %d\n", result.Dirs)
+	fmt.Fprintf(&bld, "    Files:       %d\n", result.FilesCopied)
+	if result.FilesSkipped > 0 {
+		fmt.Fprintf(&bld, "    Skipped:     %d (%s)\n", result.FilesSkipped,
+			humanize.IBytes(result.BytesSkipped))
+	}
+	fmt.Fprintf(&bld, "    Bytes:       %s\n", humanize.IBytes(result.BytesCopied))
+	if result.FilesVerified > 0 {
+		fmt.Fprintf(&bld, "    Verified:    %d\n", result.FilesVerified)
+	}
+	for _, path := range result.Mismatches {
+		fmt.Fprintf(&bld, "    Mismatch:    %s\n", path)
+	}
+
+	return strings.TrimSuffix(bld.String(), "\n")
+}
+
+// openEndpoint resolves a copy source or destination, which is either a
+// POSIX path or a DAOS path such as daos://pool/cont/path or a UNS path.
+// The destination container is created if it doesn't exist.
+func (cmd *fsCopyCmd) openEndpoint(path string, dest bool) (*fscopy.Endpoint, func(), error) {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	var dattr C.struct_duns_attr_t
+	rc := C.duns_resolve_path(cPath, &dattr)
+	defer C.duns_destroy_attr(&dattr)
+	switch {
+	case rc == C.ENOMEM:
+		return nil, nil, dfsError(rc)
+	case rc != 0 && strings.HasPrefix(path, "daos://"):
+		return nil, nil, errors.Wrap(dfsError(rc), "invalid DAOS path")
+	case rc != 0:
+		return &fscopy.Endpoint{
+			FS:   fscopy.LocalFS{},
+			Path: path,
+			Name: path,
+		}, func() {}, nil
+	}
+
+	poolID := C.GoString(&dattr.da_pool[0])
+	contID := C.GoString(&dattr.da_cont[0])
+	relPath := "/"
+	if dattr.da_rel_path != nil {
+		relPath = C.GoString(dattr.da_rel_path)
+	}
+	sysName := build.DefaultSystemName
+	if dattr.da_sys != nil {
+		sysName = C.GoString(dattr.da_sys)
+	}
+
+	dfs, err := cmd.mountDFS(sysName, poolID, contID, dest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &fscopy.Endpoint{
+		FS:   dfs,
+		Path: relPath,
+		Name: path,
+	}, dfs.unmount, nil
+}
+
+// dfsCopyFS implements fscopy.FS on top of a DFS container.
+type dfsCopyFS struct {
+	log *logging.LeveledLogger
+	poh C.daos_handle_t
+	coh C.daos_handle_t
+	sys *C.dfs_sys_t
+}
+
+func (cmd *fsCopyCmd) mountDFS(sysName, poolID, contID string, dest bool) (*dfsCopyFS, error) {
+	dfs := &dfsCopyFS{log: cmd.log}
+
+	poolFlags := C.uint(C.DAOS_PC_RO)
+	contFlags := C.uint(C.DAOS_COO_RO)
+	mountFlags := C.int(C.O_RDONLY)
+	if dest {
+		poolFlags = C.DAOS_PC_RW
+		contFlags = C.DAOS_COO_RW
+		mountFlags = C.O_RDWR
+	}
+
+	cSysName := C.CString(sysName)
+	defer freeString(cSysName)
+	cPoolID := C.CString(poolID)
+	defer freeString(cPoolID)
+
+	rc := C.daos_pool_connect2(cPoolID, cSysName, poolFlags, &dfs.poh, nil, nil)
+	if err := daosError(rc); err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to pool %s", poolID)
+	}
+
+	rc = -C.DER_NONEXIST
+	if contID != "" {
+		cContID := C.CString(contID)
+		rc = C.daos_cont_open2(dfs.poh, cContID, contFlags, &dfs.coh, nil, nil)
+		freeString(cContID)
+	}
+	if rc == -C.DER_NONEXIST && dest {
+		rc = 0
+		if err := cmd.createContainer(dfs, contID); err != nil {
+			dfs.unmount()
+			return nil, err
+		}
+	}
+	if err := daosError(rc); err != nil {
+		C.daos_pool_disconnect(dfs.poh, nil)
+		return nil, errors.Wrapf(err, "failed to open container %s", contID)
+	}
+
+	if rc := C.dfs_sys_mount(dfs.poh, dfs.coh, mountFlags, 0, &dfs.sys); rc != 0 {
+		dfs.unmount()
+		return nil, errors.Wrap(dfsError(rc), "failed to mount container")
+	}
+
+	return dfs, nil
+}
+
+// createContainer creates the destination POSIX container, identified by
+// either a UUID or a label, or with a generated UUID if contID is empty.
+func (cmd *fsCopyCmd) createContainer(dfs *dfsCopyFS, contID string) error {
+	var attr C.dfs_attr_t
+	var rc C.int
+
+	if contUUID, err := uuid.Parse(contID); err == nil {
+		cUUID := uuidToC(contUUID)
+		rc = C.dfs_cont_create1(dfs.poh, &cUUID[0], &attr, &dfs.coh, nil)
+	} else {
+		if contID != "" {
+			props, entries, err := allocProps(1)
+			if err != nil {
+				return err
+			}
+			defer C.daos_prop_free(props)
+
+			cLabel := C.CString(contID)
+			defer freeString(cLabel)
+			entries[0].dpe_type = C.DAOS_PROP_CO_LABEL
+			rc = C.daos_prop_entry_set_str(&entries[0], cLabel, C.strlen(cLabel))
+			if err := daosError(rc); err != nil {
+				return errors.Wrap(err, "failed to set label")
+			}
+			props.dpp_nr++
+			attr.da_props = props
+		}
+
+		var cUUID C.uuid_t
+		rc = C.dfs_cont_create2(dfs.poh, &cUUID, &attr, &dfs.coh, nil)
+		if rc == 0 && contID == "" {
+			contUUID, err := uuidFromC(cUUID)
+			if err != nil {
+				return err
+			}
+			contID = contUUID.String()
+		}
+	}
+	if err := dfsError(rc); err != nil {
+		return errors.Wrapf(err, "failed to create container %s", contID)
+	}
+
+	if !cmd.jsonOutputEnabled() {
+		cmd.log.Infof("Successfully created container %s", contID)
+	}
+
+	return nil
+}
+
+func (dfs *dfsCopyFS) unmount() {
+	if dfs.sys != nil {
+		if rc := C.dfs_sys_umount(dfs.sys); rc != 0 {
+			dfs.log.Errorf("failed to unmount container: %s", dfsError(rc))
+		}
+	}
+	if C.daos_handle_is_valid(dfs.coh) {
+		if rc := C.daos_cont_close(dfs.coh, nil); rc != 0 {
+			dfs.log.Errorf("failed to close container: %s", daosError(rc))
+		}
+	}
+	if rc := C.daos_pool_disconnect(dfs.poh, nil); rc != 0 {
+		dfs.log.Errorf("failed to disconnect from pool: %s", daosError(rc))
+	}
+}
+
+// Stat implements fscopy.FS.
+func (dfs *dfsCopyFS) Stat(path string) (*fscopy.FileInfo, error) {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	var st C.struct_stat
+	if rc := C.dfs_sys_stat(dfs.sys, cPath, C.O_NOFOLLOW, &st); rc != 0 {
+		return nil, syscall.Errno(rc)
+	}
+
+	return &fscopy.FileInfo{
+		Mode:    fscopy.ModeFromUnix(uint32(st.st_mode)),
+		Size:    int64(st.st_size),
+		ModTime: time.Unix(int64(st.st_mtim.tv_sec), int64(st.st_mtim.tv_nsec)),
+	}, nil
+}
+
+// ReadDir implements fscopy.FS.
+func (dfs *dfsCopyFS) ReadDir(path string) ([]string, error) {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	var dir *C.DIR
+	if rc := C.dfs_sys_opendir(dfs.sys, cPath, C.O_RDONLY, &dir); rc != 0 {
+		return nil, syscall.Errno(rc)
+	}
+	defer C.dfs_sys_closedir(dir)
+
+	var names []string
+	for {
+		var entry *C.struct_dirent
+		if rc := C.dfs_sys_readdir(dfs.sys, dir, &entry); rc != 0 {
+			return nil, syscall.Errno(rc)
+		}
+		if entry == nil {
+			return names, nil
+		}
+		names = append(names, C.GoString(&entry.d_name[0]))
+	}
+}
+
+// Mkdir implements fscopy.FS.
+func (dfs *dfsCopyFS) Mkdir(path string, perm os.FileMode) error {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	if rc := C.dfs_sys_mkdir(dfs.sys, cPath, C.mode_t(perm.Perm()), 0); rc != 0 {
+		return syscall.Errno(rc)
+	}
+
+	return nil
+}
+
+func (dfs *dfsCopyFS) open(path string, mode C.mode_t, flags C.int) (*dfsCopyFile, error) {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	f := &dfsCopyFile{sys: dfs.sys}
+	if rc := C.dfs_sys_open(dfs.sys, cPath, C.S_IFREG|mode, flags, 0, 0, nil, &f.obj); rc != 0 {
+		return nil, syscall.Errno(rc)
+	}
+
+	return f, nil
+}
+
+// Create implements fscopy.FS.
+func (dfs *dfsCopyFS) Create(path string, perm os.FileMode) error {
+	f, err := dfs.open(path, C.mode_t(perm.Perm()), C.O_RDWR|C.O_CREAT|C.O_TRUNC)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// Open implements fscopy.FS.
+func (dfs *dfsCopyFS) Open(path string) (fscopy.File, error) {
+	return dfs.open(path, 0, C.O_RDONLY)
+}
+
+// OpenWrite implements fscopy.FS.
+func (dfs *dfsCopyFS) OpenWrite(path string) (fscopy.File, error) {
+	return dfs.open(path, 0, C.O_RDWR)
+}
+
+// Chmod implements fscopy.FS.
+func (dfs *dfsCopyFS) Chmod(path string, perm os.FileMode) error {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	if rc := C.dfs_sys_chmod(dfs.sys, cPath, C.mode_t(perm.Perm())); rc != 0 {
+		return syscall.Errno(rc)
+	}
+
+	return nil
+}
+
+// Chtimes implements fscopy.FS.
+func (dfs *dfsCopyFS) Chtimes(path string, mtime time.Time) error {
+	cPath := C.CString(path)
+	defer freeString(cPath)
+
+	var times [2]C.struct_timespec
+	for i := range times {
+		times[i].tv_sec = C.time_t(mtime.Unix())
+		times[i].tv_nsec = C.long(mtime.Nanosecond())
+	}
+	if rc := C.dfs_sys_utimens(dfs.sys, cPath, &times[0], C.O_NOFOLLOW); rc != 0 {
+		return syscall.Errno(rc)
+	}
+
+	return nil
+}
+
+// dfsCopyFile implements fscopy.File on top of an open DFS file.
+type dfsCopyFile struct {
+	sys *C.dfs_sys_t
+	obj *C.dfs_obj_t
+}
+
+func (f *dfsCopyFile) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	size := C.daos_size_t(len(p))
+	rc := C.dfs_sys_read(f.sys, f.obj, unsafe.Pointer(&p[0]), C.daos_off_t(off), &size, nil)
+	if rc != 0 {
+		return 0, syscall.Errno(rc)
+	}
+	if int(size) < len(p) {
+		return int(size), io.EOF
+	}
+
+	return int(size), nil
+}
+
+func (f *dfsCopyFile) WriteAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	size := C.daos_size_t(len(p))
+	rc := C.dfs_sys_write(f.sys, f.obj, unsafe.Pointer(&p[0]), C.daos_off_t(off), &size, nil)
+	if rc != 0 {
+		return 0, syscall.Errno(rc)
+	}
+	if int(size) < len(p) {
+		return int(size), io.ErrShortWrite
+	}
+
+	return int(size), nil
+}
+
+func (f *dfsCopyFile) Close() error {
+	if rc := C.dfs_sys_close(f.obj); rc != 0 {
+		return syscall.Errno(rc)
+	}
+
+	return nil
+}
@@ -0,0 +1,489 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/fscopy"
+)
+
+const jsonSchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// jsonResponses maps each command to the type of the response it outputs
+// with --json. Commands mapped to nil always output a null response, only the
+// error and status of the command being meaningful.
+var jsonResponses = map[string]interface{}{
+	"container create":            (*containerInfo)(nil),
+	"container list":              []*ContainerID(nil),
+	"container destroy":           nil,
+	"container list-objects":      []string(nil),
+	"container query":             (*containerInfo)(nil),
+	"container stat":              (*contUsage)(nil),
+	"container clone":             (*containerCloneResult)(nil),
+	"container check":             (*containerCheckResult)(nil),
+	"container export":            (*containerExportResult)(nil),
+	"container import":            (*containerImportResult)(nil),
+	"container diff":              (*contDiff)(nil),
+	"container link":              (*unsLinkInfo)(nil),
+	"container unlink":            (*unsLinkInfo)(nil),
+	"container resolve":           (*unsLinkInfo)(nil),
+	"container find-links":        []*unsLinkInfo(nil),
+	"container list-attr":         []*attribute(nil),
+	"container del-attr":          nil,
+	"container get-attr":          (*attribute)(nil),
+	"container set-attr":          nil,
+	"container attrs export":      (*attrsExportResult)(nil),
+	"container attrs import":      (*attrsDiff)(nil),
+	"container get-prop":          []*property(nil),
+	"container set-prop":          nil,
+	"container get-acl":           (*control.AccessControlList)(nil),
+	"container overwrite-acl":     (*control.AccessControlList)(nil),
+	"container update-acl":        (*control.AccessControlList)(nil),
+	"container delete-acl":        (*control.AccessControlList)(nil),
+	"container set-owner":         (*control.AccessControlList)(nil),
+	"container create-snap":       (*snapshot)(nil),
+	"container destroy-snap":      nil,
+	"container list-snap":         []*snapshot(nil),
+	"container rollback":          (*snapshot)(nil),
+	"pool query":                  (*control.PoolQueryResp)(nil),
+	"pool list-containers":        []*poolContainer(nil),
+	"pool list-attr":              []*attribute(nil),
+	"pool get-attr":               (*attribute)(nil),
+	"pool set-attr":               nil,
+	"pool del-attr":               nil,
+	"pool attrs export":           (*attrsExportResult)(nil),
+	"pool attrs import":           (*attrsDiff)(nil),
+	"pool autotest":               (*autotestResult)(nil),
+	"filesystem copy":             (*fscopy.Result)(nil),
+	"filesystem set-attr":         nil,
+	"filesystem get-attr":         (*fsAttributes)(nil),
+	"filesystem reset-attr":       nil,
+	"filesystem reset-chunk-size": nil,
+	"filesystem reset-oclass":     nil,
+	"object query":                (*objLayout)(nil),
+	"object list-keys":            (*objKeyList)(nil),
+	"object dump":                 (*objDump)(nil),
+	"snapshot-scheduler set":      nil,
+	"snapshot-scheduler run":      []*schedContainer(nil),
+	"snapshot-scheduler report":   []*schedContainer(nil),
+	"version":                     (*versionInfo)(nil),
+}
+
+// jsonSchema is the subset of JSON Schema used to describe the output of the
+// commands.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+func schemaType(typ string) *jsonSchema {
+	return &jsonSchema{Type: typ}
+}
+
+// nullable allows a value to also be null, as nil pointers, slices and maps
+// are marshaled.
+func nullable(s *jsonSchema) *jsonSchema {
+	switch typ := s.Type.(type) {
+	case string:
+		return &jsonSchema{
+			Type:                 []string{typ, "null"},
+			Format:               s.Format,
+			ContentEncoding:      s.ContentEncoding,
+			Properties:           s.Properties,
+			Required:             s.Required,
+			AdditionalProperties: s.AdditionalProperties,
+			Items:                s.Items,
+		}
+	case []string:
+		// already nullable
+		return s
+	case nil:
+		if s.Ref == "" {
+			// any value, including null
+			return s
+		}
+	}
+	return &jsonSchema{AnyOf: []*jsonSchema{s, schemaType("null")}}
+}
+
+// schemaGenerator derives JSON schemas from Go types, following the rules of
+// encoding/json.
+type schemaGenerator struct {
+	definitions map[string]*jsonSchema
+	inProgress  map[reflect.Type]bool
+	recursive   map[reflect.Type]bool
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		definitions: make(map[string]*jsonSchema),
+		inProgress:  make(map[reflect.Type]bool),
+		recursive:   make(map[reflect.Type]bool),
+	}
+}
+
+// override describes the types with custom JSON marshaling.
+func (g *schemaGenerator) override(t reflect.Type) (*jsonSchema, bool, error) {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return &jsonSchema{Type: "string", Format: "date-time"}, true, nil
+	case reflect.TypeOf(uuid.UUID{}):
+		return &jsonSchema{Type: "string", Format: "uuid"}, true, nil
+	case reflect.TypeOf(containerInfo{}):
+		s, err := g.structSchema(t)
+		if err != nil {
+			return nil, true, err
+		}
+		s.Properties["snapshot_epochs"] = nullable(&jsonSchema{
+			Type:  "array",
+			Items: schemaType("integer"),
+		})
+		s.Required = append(s.Required, "snapshot_epochs")
+		sort.Strings(s.Required)
+		return s, true, nil
+	case reflect.TypeOf(property{}):
+		return &jsonSchema{
+			Type: "object",
+			Properties: map[string]*jsonSchema{
+				"name":        schemaType("string"),
+				"description": schemaType("string"),
+				// string, number or list of ACEs depending on the property
+				"value": {},
+			},
+			Required: []string{"name", "value"},
+		}, true, nil
+	case reflect.TypeOf(control.AccessControlList{}):
+		return nullable(&jsonSchema{Type: "array", Items: schemaType("string")}), true, nil
+	case reflect.TypeOf(control.PoolRebuildState(0)):
+		return schemaType("string"), true, nil
+	}
+
+	return nil, false, nil
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func (g *schemaGenerator) schemaOf(t reflect.Type) (*jsonSchema, error) {
+	if t.Kind() == reflect.Ptr {
+		s, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	}
+
+	if s, found, err := g.override(t); found {
+		return s, err
+	}
+	if implements(t, jsonMarshalerType) {
+		return nil, errors.Errorf("no JSON schema for type %s with custom marshaling", t)
+	}
+	if implements(t, textMarshalerType) {
+		return schemaType("string"), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return schemaType("boolean"), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schemaType("integer"), nil
+	case reflect.Float32, reflect.Float64:
+		return schemaType("number"), nil
+	case reflect.String:
+		return schemaType("string"), nil
+	case reflect.Interface:
+		return &jsonSchema{}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) {
+			return nullable(&jsonSchema{Type: "string", ContentEncoding: "base64"}), nil
+		}
+		items, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(&jsonSchema{Type: "array", Items: items}), nil
+	case reflect.Array:
+		items, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String && !implements(t.Key(), textMarshalerType) {
+			return nil, errors.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(&jsonSchema{Type: "object", AdditionalProperties: values}), nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+
+	return nil, errors.Errorf("unsupported type %s", t)
+}
+
+// structSchema describes a struct. Recursive types are described once in the
+// definitions and referenced.
+func (g *schemaGenerator) structSchema(t reflect.Type) (*jsonSchema, error) {
+	ref := &jsonSchema{Ref: "#/definitions/" + t.Name()}
+	if g.inProgress[t] {
+		g.recursive[t] = true
+		return ref, nil
+	}
+	g.inProgress[t] = true
+	defer delete(g.inProgress, t)
+
+	s := &jsonSchema{
+		Type:       "object",
+		Properties: make(map[string]*jsonSchema),
+	}
+	required := make(map[string]bool)
+	if err := g.addFields(s, required, t); err != nil {
+		return nil, err
+	}
+	for name := range required {
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+
+	if g.recursive[t] {
+		g.definitions[t.Name()] = s
+		return ref, nil
+	}
+	return s, nil
+}
+
+// addFields adds the fields of a struct to its schema. Fields of embedded
+// structs are added unless shadowed by a field of the embedding struct.
+func (g *schemaGenerator) addFields(s *jsonSchema, required map[string]bool, t reflect.Type) error {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		name := opts[0]
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fs, err := g.schemaOf(f.Type)
+		if err != nil {
+			return errors.Wrapf(err, "field %s of %s", f.Name, t)
+		}
+		for _, opt := range opts[1:] {
+			switch opt {
+			case "omitempty":
+				required[name] = false
+			case "string":
+				fs = schemaType("string")
+			}
+		}
+		if _, set := required[name]; !set {
+			required[name] = true
+		}
+		s.Properties[name] = fs
+	}
+
+	for _, et := range embedded {
+		fields := &jsonSchema{Properties: make(map[string]*jsonSchema)}
+		fieldsRequired := make(map[string]bool)
+		if err := g.addFields(fields, fieldsRequired, et); err != nil {
+			return err
+		}
+		for name, fs := range fields.Properties {
+			if _, shadowed := s.Properties[name]; shadowed {
+				continue
+			}
+			s.Properties[name] = fs
+			required[name] = fieldsRequired[name]
+		}
+	}
+
+	for name, req := range required {
+		if !req {
+			delete(required, name)
+		}
+	}
+
+	return nil
+}
+
+// commandJSONSchema returns the schema of the JSON output of a command,
+// including the error and status reported along with the response.
+func commandJSONSchema(path string) (*jsonSchema, error) {
+	resp, found := jsonResponses[path]
+	if !found {
+		return nil, errors.Errorf("no JSON schema for command %q", path)
+	}
+
+	g := newSchemaGenerator()
+	respSchema := schemaType("null")
+	if resp != nil {
+		var err error
+		if respSchema, err = g.schemaOf(reflect.TypeOf(resp)); err != nil {
+			return nil, errors.Wrapf(err, "command %q", path)
+		}
+	}
+
+	s := &jsonSchema{
+		Schema: jsonSchemaVersion,
+		Title:  "daos " + path,
+		Type:   "object",
+		Properties: map[string]*jsonSchema{
+			"response": respSchema,
+			"error":    schemaType("string"),
+			"status":   schemaType("integer"),
+		},
+		Required: []string{"error", "response", "status"},
+	}
+	s.Properties["error"] = nullable(s.Properties["error"])
+	if len(g.definitions) > 0 {
+		s.Definitions = g.definitions
+	}
+
+	return s, nil
+}
+
+// commandPaths returns the paths of all the commands that can be run.
+func commandPaths(cmds []*flags.Command, prefix string) []string {
+	var paths []string
+	for _, cmd := range cmds {
+		if cmd.Hidden {
+			continue
+		}
+		if len(cmd.Commands()) == 0 {
+			paths = append(paths, prefix+cmd.Name)
+			continue
+		}
+		paths = append(paths, commandPaths(cmd.Commands(), prefix+cmd.Name+" ")...)
+	}
+	return paths
+}
+
+// isJSONSchemaRequest checks whether the JSON schema of a command is requested
+// and returns the command words, ignoring any other option.
+func isJSONSchemaRequest(args []string) ([]string, bool) {
+	var requested bool
+	var words []string
+	for _, arg := range args {
+		switch {
+		case arg == "--json-schema":
+			requested = true
+		case strings.HasPrefix(arg, "-"):
+		default:
+			words = append(words, arg)
+		}
+	}
+	return words, requested
+}
+
+// printJSONSchema prints the JSON schema of the output of the command named by
+// words, which may use aliases. Without a command, the schemas of all the
+// commands are printed, keyed by command.
+func printJSONSchema(out io.Writer, p *flags.Parser, words []string) error {
+	var schema interface{}
+
+	if len(words) == 0 {
+		all := make(map[string]*jsonSchema)
+		for _, path := range commandPaths(p.Commands(), "") {
+			s, err := commandJSONSchema(path)
+			if err != nil {
+				return err
+			}
+			all[path] = s
+		}
+		schema = all
+	} else {
+		cmd := p.Command
+		var path []string
+		for _, word := range words {
+			if len(cmd.Commands()) == 0 {
+				break
+			}
+			sub := cmd.Find(word)
+			if sub == nil {
+				return errors.Errorf("unknown command %q", strings.Join(append(path, word), " "))
+			}
+			cmd = sub
+			path = append(path, cmd.Name)
+		}
+		if len(cmd.Commands()) != 0 {
+			var names []string
+			for _, sub := range cmd.Commands() {
+				if !sub.Hidden {
+					names = append(names, sub.Name)
+				}
+			}
+			return errors.Errorf("specify one of the %q subcommands: %s",
+				strings.Join(path, " "), strings.Join(names, ", "))
+		}
+
+		s, err := commandJSONSchema(strings.Join(path, " "))
+		if err != nil {
+			return err
+		}
+		schema = s
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
@@ -0,0 +1,205 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestJSONSchema_allCommands(t *testing.T) {
+	p := flags.NewParser(&cliOptions{}, flags.Default)
+
+	paths := commandPaths(p.Commands(), "")
+	if len(paths) == 0 {
+		t.Fatal("no commands found")
+	}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		seen[path] = true
+		if _, err := commandJSONSchema(path); err != nil {
+			t.Errorf("command %q: %s", path, err)
+		}
+	}
+	for path := range jsonResponses {
+		if !seen[path] {
+			t.Errorf("JSON response described for unknown command %q", path)
+		}
+	}
+}
+
+type testSchemaInner struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+}
+
+type testSchemaNode struct {
+	Value    int               `json:"value"`
+	Children []*testSchemaNode `json:"children"`
+}
+
+type testSchemaOuter struct {
+	testSchemaInner
+	Count   string            `json:"count"`
+	Data    []byte            `json:"data"`
+	Big     uint64            `json:"big,string"`
+	Ptr     *testSchemaInner  `json:"ptr,omitempty"`
+	Attrs   map[string]string `json:"attrs"`
+	Skipped int               `json:"-"`
+	private int
+}
+
+func TestJSONSchema_schemaOf(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in      interface{}
+		expDefs map[string]*jsonSchema
+		exp     *jsonSchema
+		expErr  error
+	}{
+		"nullable pointer": {
+			in:  (*int)(nil),
+			exp: &jsonSchema{Type: []string{"integer", "null"}},
+		},
+		"struct": {
+			in: testSchemaOuter{},
+			exp: &jsonSchema{
+				Type: "object",
+				Properties: map[string]*jsonSchema{
+					"name":  schemaType("string"),
+					"count": schemaType("string"),
+					"data":  {Type: []string{"string", "null"}, ContentEncoding: "base64"},
+					"big":   schemaType("string"),
+					"ptr": {
+						Type: []string{"object", "null"},
+						Properties: map[string]*jsonSchema{
+							"name":  schemaType("string"),
+							"count": schemaType("integer"),
+						},
+						Required: []string{"name"},
+					},
+					"attrs": {
+						Type:                 []string{"object", "null"},
+						AdditionalProperties: schemaType("string"),
+					},
+				},
+				Required: []string{"attrs", "big", "count", "data", "name"},
+			},
+		},
+		"recursive": {
+			in:  testSchemaNode{},
+			exp: &jsonSchema{Ref: "#/definitions/testSchemaNode"},
+			expDefs: map[string]*jsonSchema{
+				"testSchemaNode": {
+					Type: "object",
+					Properties: map[string]*jsonSchema{
+						"value": schemaType("integer"),
+						"children": {
+							Type: []string{"array", "null"},
+							Items: &jsonSchema{AnyOf: []*jsonSchema{
+								{Ref: "#/definitions/testSchemaNode"},
+								schemaType("null"),
+							}},
+						},
+					},
+					Required: []string{"children", "value"},
+				},
+			},
+		},
+		"custom marshaling": {
+			in:     json.RawMessage{},
+			expErr: errors.New("custom marshaling"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := newSchemaGenerator()
+			s, err := g.schemaOf(reflect.TypeOf(tc.in))
+			common.CmpErr(t, tc.expErr, err)
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.exp, s); diff != "" {
+				t.Fatalf("unexpected schema (-want, +got):\n%s\n", diff)
+			}
+			if tc.expDefs == nil {
+				tc.expDefs = map[string]*jsonSchema{}
+			}
+			if diff := cmp.Diff(tc.expDefs, g.definitions); diff != "" {
+				t.Fatalf("unexpected definitions (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_isJSONSchemaRequest(t *testing.T) {
+	words, ok := isJSONSchemaRequest([]string{"-j", "cont", "--json-schema", "query"})
+	common.AssertTrue(t, ok, "request not detected")
+	common.AssertEqual(t, []string{"cont", "query"}, words, "words")
+
+	_, ok = isJSONSchemaRequest([]string{"-j", "cont", "query"})
+	common.AssertFalse(t, ok, "unexpected request")
+}
+
+func TestJSONSchema_printJSONSchema(t *testing.T) {
+	for name, tc := range map[string]struct {
+		words    []string
+		expTitle string
+		expErr   error
+	}{
+		"alias": {
+			words:    []string{"cont", "query", "pool", "cont"},
+			expTitle: "daos container query",
+		},
+		"top-level command": {
+			words:    []string{"version"},
+			expTitle: "daos version",
+		},
+		"not a leaf": {
+			words:  []string{"pool"},
+			expErr: errors.New("specify one of the \"pool\" subcommands"),
+		},
+		"unknown": {
+			words:  []string{"cont", "frobnicate"},
+			expErr: errors.New("unknown command \"container frobnicate\""),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := flags.NewParser(&cliOptions{}, flags.Default)
+
+			var bld strings.Builder
+			err := printJSONSchema(&bld, p, tc.words)
+			common.CmpErr(t, tc.expErr, err)
+			if err != nil {
+				return
+			}
+
+			var s jsonSchema
+			if err := json.Unmarshal([]byte(bld.String()), &s); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expTitle, s.Title, "title")
+		})
+	}
+
+	p := flags.NewParser(&cliOptions{}, flags.Default)
+	var bld strings.Builder
+	if err := printJSONSchema(&bld, p, nil); err != nil {
+		t.Fatal(err)
+	}
+	all := make(map[string]*jsonSchema)
+	if err := json.Unmarshal([]byte(bld.String()), &all); err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, len(jsonResponses), len(all), "number of schemas")
+}
@@ -113,17 +113,33 @@ type cliOptions struct {
 	Debug      bool          `long:"debug" description:"enable debug output"`
 	Verbose    bool          `long:"verbose" description:"enable verbose output (when applicable)"`
 	JSON       bool          `long:"json" short:"j" description:"enable JSON output"`
+	JSONSchema bool          `long:"json-schema" description:"print the JSON schema of the output of a command instead of running it"`
 	Container  containerCmd  `command:"container" alias:"cont" description:"perform tasks related to DAOS containers"`
 	Pool       poolCmd       `command:"pool" description:"perform tasks related to DAOS pools"`
 	Filesystem fsCmd         `command:"filesystem" alias:"fs" description:"POSIX filesystem operations"`
 	Object     objectCmd     `command:"object" alias:"obj" description:"DAOS object operations"`
+	SnapSched  snapSchedCmd  `command:"snapshot-scheduler" description:"scheduled snapshots with retention policies"`
 	Version    versionCmd    `command:"version" description:"print daos version"`
 	ManPage    common.ManCmd `command:"manpage" hidden:"true"`
 }
 
-type versionCmd struct{}
+type versionCmd struct {
+	jsonOutputCmd
+}
+
+type versionInfo struct {
+	Version    string `json:"version"`
+	APIVersion string `json:"api_version"`
+}
 
 func (cmd *versionCmd) Execute(_ []string) error {
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&versionInfo{
+			Version:    build.DaosVersion,
+			APIVersion: apiVersion(),
+		}, nil)
+	}
+
 	fmt.Printf("daos version %s, libdaos %s\n", build.DaosVersion, apiVersion())
 	os.Exit(0)
 	return nil
@@ -193,6 +209,10 @@ or query/manage an object inside a container.`
 		return nil
 	}
 
+	if words, ok := isJSONSchemaRequest(args); ok {
+		return printJSONSchema(os.Stdout, p, words)
+	}
+
 	// Initialize the daos debug system first so that
 	// any allocations made as part of argument parsing
 	// are logged when running under NLT.
@@ -7,19 +7,122 @@
 package main
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
 	"github.com/jessevdk/go-flags"
 	"github.com/pkg/errors"
 )
 
 /*
 #include "util.h"
+
+#include <daos/object.h>
+
+// cgo doesn't allow passing Go memory containing Go pointers to C, so the
+// keys and scatter/gather lists are assembled on the C side.
+
+static int
+obj_list_keys(daos_handle_t oh, daos_handle_t th, void *dkey_buf,
+	      size_t dkey_len, uint32_t *nr, daos_key_desc_t *kds, void *buf,
+	      size_t buf_len, daos_anchor_t *anchor)
+{
+	daos_key_t	dkey;
+	d_sg_list_t	sgl;
+	d_iov_t		iov;
+
+	d_iov_set(&iov, buf, buf_len);
+	sgl.sg_nr = 1;
+	sgl.sg_nr_out = 0;
+	sgl.sg_iovs = &iov;
+
+	if (dkey_buf == NULL)
+		return daos_obj_list_dkey(oh, th, nr, kds, &sgl, anchor, NULL);
+
+	d_iov_set(&dkey, dkey_buf, dkey_len);
+	return daos_obj_list_akey(oh, th, &dkey, nr, kds, &sgl, anchor, NULL);
+}
+
+static int
+obj_list_recx(daos_handle_t oh, daos_handle_t th, void *dkey_buf,
+	      size_t dkey_len, void *akey_buf, size_t akey_len,
+	      daos_size_t *size, uint32_t *nr, daos_recx_t *recxs,
+	      daos_epoch_range_t *eprs, daos_anchor_t *anchor)
+{
+	daos_key_t	dkey;
+	daos_key_t	akey;
+
+	d_iov_set(&dkey, dkey_buf, dkey_len);
+	d_iov_set(&akey, akey_buf, akey_len);
+
+	return daos_obj_list_recx(oh, th, &dkey, &akey, size, nr, recxs, eprs,
+				  anchor, true, NULL);
+}
+
+static int
+obj_fetch_value(daos_handle_t oh, daos_handle_t th, void *dkey_buf,
+		size_t dkey_len, void *akey_buf, size_t akey_len,
+		daos_iod_type_t type, daos_size_t *size, uint64_t idx,
+		uint64_t nr, void *buf, size_t buf_len)
+{
+	daos_key_t	dkey;
+	daos_iod_t	iod = {0};
+	daos_recx_t	recx = {.rx_idx = idx, .rx_nr = nr};
+	d_sg_list_t	sgl;
+	d_iov_t		iov;
+	int		rc;
+
+	d_iov_set(&dkey, dkey_buf, dkey_len);
+	d_iov_set(&iod.iod_name, akey_buf, akey_len);
+	iod.iod_type = type;
+	iod.iod_size = *size;
+	iod.iod_nr = 1;
+	if (type == DAOS_IOD_ARRAY)
+		iod.iod_recxs = &recx;
+
+	if (buf == NULL) {
+		// with a NULL sgl only the value size is returned
+		rc = daos_obj_fetch(oh, th, 0, &dkey, 1, &iod, NULL, NULL,
+				    NULL);
+	} else {
+		d_iov_set(&iov, buf, buf_len);
+		sgl.sg_nr = 1;
+		sgl.sg_nr_out = 0;
+		sgl.sg_iovs = &iov;
+		rc = daos_obj_fetch(oh, th, 0, &dkey, 1, &iod, &sgl, NULL,
+				    NULL);
+	}
+	if (rc == 0)
+		*size = iod.iod_size;
+
+	return rc;
+}
+// the layout of an object holds a flexible array of shard pointers, each
+// shard a flexible array of locations
+static struct daos_obj_shard *
+obj_layout_shard(struct daos_obj_layout *layout, uint32_t i)
+{
+	return layout->ol_shards[i];
+}
+
+static uint32_t
+obj_shard_rank(struct daos_obj_shard *shard, uint32_t i)
+{
+	return shard->os_shard_loc[i].sd_rank;
+}
 */
 import "C"
 
 type objectCmd struct {
-	Query objQueryCmd `command:"query" description:"query an object's layout"`
-	//ListKeys objListKeysCmd `command:"list-keys" description:"list an object's keys"`
-	//Dump     objDumpCmd     `command:"dump" description:"dump an object's contents"`
+	Query    objQueryCmd    `command:"query" description:"query an object's layout"`
+	ListKeys objListKeysCmd `command:"list-keys" description:"list an object's keys"`
+	Dump     objDumpCmd     `command:"dump" description:"dump an object's contents"`
 }
 
 type objBaseCmd struct {
@@ -68,13 +171,624 @@ func (cmd *objQueryCmd) Execute(_ []string) error {
 	}
 	defer cleanup()
 
-	ap.oid = oid
-	rc := C.obj_query_hdlr(ap)
-
+	var cLayout *C.struct_daos_obj_layout
+	rc := C.daos_obj_layout_get(cmd.cContHandle, oid, &cLayout)
 	if err := daosError(rc); err != nil {
 		return errors.Wrapf(err,
 			"failed to query object %s", cmd.Args.ObjectID.String())
 	}
+	defer C.daos_obj_layout_free(cLayout)
+
+	layout := &objLayout{
+		OID:     cmd.Args.ObjectID.String(),
+		Version: uint32(cLayout.ol_ver),
+		Groups:  make([]*objLayoutGroup, cLayout.ol_nr),
+	}
+	for i := range layout.Groups {
+		shard := C.obj_layout_shard(cLayout, C.uint32_t(i))
+		group := &objLayoutGroup{
+			Group:    i,
+			Replicas: make([]uint32, shard.os_replica_nr),
+		}
+		for j := range group.Replicas {
+			group.Replicas[j] = uint32(C.obj_shard_rank(shard, C.uint32_t(j)))
+		}
+		layout.Groups[i] = group
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(layout, nil)
+	}
+
+	var bld strings.Builder
+	printObjLayout(&bld, layout)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+type (
+	objLayoutGroup struct {
+		Group int `json:"group"`
+		// Replicas holds the rank of each replica of the group.
+		Replicas []uint32 `json:"replicas"`
+	}
+
+	objLayout struct {
+		OID     string            `json:"oid"`
+		Version uint32            `json:"version"`
+		Groups  []*objLayoutGroup `json:"groups"`
+	}
+)
+
+func printObjLayout(out io.Writer, layout *objLayout) {
+	fmt.Fprintf(out, "oid: %s ver %d grp_nr: %d\n", layout.OID, layout.Version,
+		len(layout.Groups))
+	for _, group := range layout.Groups {
+		fmt.Fprintf(out, "grp: %d\n", group.Group)
+		for i, rank := range group.Replicas {
+			fmt.Fprintf(out, "replica %d %d\n", i, rank)
+		}
+	}
+}
+
+const (
+	keyFormatAuto = "auto"
+	keyFormatUTF8 = "utf8"
+	keyFormatHex  = "hex"
+
+	// initial size of the buffer used to receive enumerated keys,
+	// grown on demand if a key doesn't fit
+	keyBufSize = 4096
+)
+
+// formatBytes renders a key or value in the requested format. In auto format
+// printable UTF-8 is rendered as-is and anything else as hex with a 0x prefix,
+// so that a rendered key can be passed back to the key flags.
+func formatBytes(buf []byte, format string) (string, string) {
+	switch format {
+	case keyFormatUTF8:
+		return string(buf), keyFormatUTF8
+	case keyFormatHex:
+		return "0x" + hex.EncodeToString(buf), keyFormatHex
+	}
+
+	str := string(buf)
+	printable := len(buf) > 0 && utf8.Valid(buf) && !strings.HasPrefix(str, "0x")
+	for _, r := range str {
+		if !printable {
+			break
+		}
+		printable = unicode.IsPrint(r)
+	}
+	if printable {
+		return str, keyFormatUTF8
+	}
+
+	return "0x" + hex.EncodeToString(buf), keyFormatHex
+}
+
+type (
+	objKey struct {
+		Key      string `json:"key"`
+		Encoding string `json:"encoding"`
+		raw      []byte
+	}
+
+	objExtent struct {
+		Index   uint64  `json:"index"`
+		Count   uint64  `json:"count"`
+		Epoch   uint64  `json:"epoch"`
+		Value   *objKey `json:"value,omitempty"`
+		Partial bool    `json:"partial,omitempty"`
+	}
+
+	objAKey struct {
+		objKey
+		Type    string       `json:"type,omitempty"`
+		Size    uint64       `json:"size,omitempty"`
+		Extents []*objExtent `json:"extents,omitempty"`
+		Value   *objKey      `json:"value,omitempty"`
+		Partial bool         `json:"partial,omitempty"`
+	}
+
+	objDKey struct {
+		objKey
+		AKeys []*objAKey `json:"akeys,omitempty"`
+	}
+
+	objKeyList struct {
+		DKey   *objKey    `json:"dkey,omitempty"`
+		DKeys  []*objDKey `json:"dkeys,omitempty"`
+		AKeys  []*objAKey `json:"akeys,omitempty"`
+		Anchor string     `json:"anchor,omitempty"`
+	}
+
+	objDump struct {
+		OID   string     `json:"oid"`
+		DKeys []*objDKey `json:"dkeys"`
+	}
+)
+
+func newObjKey(raw []byte, format string) objKey {
+	key, enc := formatBytes(raw, format)
+	return objKey{Key: key, Encoding: enc, raw: raw}
+}
+
+func (k *objKey) String() string {
+	return k.Key
+}
+
+// encodeAnchor returns an opaque token that can be used to resume an
+// enumeration from the supplied anchor in a later invocation. Anchors that
+// reference per-shard sub-anchors (EC objects) hold client memory and can't be
+// resumed.
+func encodeAnchor(anchor *C.daos_anchor_t) (string, error) {
+	if anchor.da_sub_anchors != 0 {
+		return "", errors.New("enumeration of this object can't be resumed, list without --limit")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(
+		C.GoBytes(unsafe.Pointer(anchor), C.sizeof_daos_anchor_t)), nil
+}
+
+func decodeAnchor(token string, anchor *C.daos_anchor_t) error {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != C.sizeof_daos_anchor_t {
+		return errors.Errorf("invalid anchor %q", token)
+	}
+	copy((*[C.sizeof_daos_anchor_t]byte)(unsafe.Pointer(anchor))[:], buf)
+	if anchor.da_sub_anchors != 0 {
+		return errors.Errorf("invalid anchor %q", token)
+	}
+
+	return nil
+}
+
+// keyPtr returns a pointer suitable for passing a key buffer to C.
+func keyPtr(key []byte) unsafe.Pointer {
+	if len(key) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&key[0])
+}
+
+// objEnumerator wraps an open object handle, and optional snapshot
+// transaction handle, for enumerating the object's keys and values.
+type objEnumerator struct {
+	oh       C.daos_handle_t
+	th       C.daos_handle_t
+	pageSize int
+	kds      []C.daos_key_desc_t
+	buf      []byte
+}
+
+func newObjEnumerator(oh, th C.daos_handle_t, pageSize int) *objEnumerator {
+	return &objEnumerator{
+		oh:       oh,
+		th:       th,
+		pageSize: pageSize,
+		kds:      make([]C.daos_key_desc_t, pageSize),
+		buf:      make([]byte, keyBufSize),
+	}
+}
+
+// listKeys enumerates dkeys, or the akeys under the supplied dkey, from the
+// position in anchor. At most limit keys are returned if limit is non-zero,
+// the anchor can then be used to continue the enumeration.
+func (e *objEnumerator) listKeys(dkey []byte, anchor *C.daos_anchor_t, limit int) ([][]byte, error) {
+	var keys [][]byte
+
+	for !C.daos_anchor_is_eof(anchor) {
+		nr := e.pageSize
+		if limit > 0 && limit-len(keys) < nr {
+			nr = limit - len(keys)
+		}
+		if nr == 0 {
+			break
+		}
+
+		cNr := C.uint32_t(nr)
+		rc := C.obj_list_keys(e.oh, e.th, keyPtr(dkey), C.size_t(len(dkey)),
+			&cNr, &e.kds[0], unsafe.Pointer(&e.buf[0]), C.size_t(len(e.buf)), anchor)
+		if rc == -C.DER_KEY2BIG && int(e.kds[0].kd_key_len) > len(e.buf) {
+			e.buf = make([]byte, e.kds[0].kd_key_len*2)
+			continue
+		}
+		if err := daosError(rc); err != nil {
+			return nil, err
+		}
+
+		var off C.daos_size_t
+		for _, kd := range e.kds[:cNr] {
+			key := make([]byte, kd.kd_key_len)
+			copy(key, e.buf[off:off+kd.kd_key_len])
+			keys = append(keys, key)
+			off += kd.kd_key_len
+		}
+	}
+
+	return keys, nil
+}
+
+// fetchSize returns the size of the single value stored under the akey, or
+// zero if the akey doesn't hold a single value.
+func (e *objEnumerator) fetchSize(dkey, akey []byte) (uint64, error) {
+	size := C.daos_size_t(C.DAOS_REC_ANY)
+	rc := C.obj_fetch_value(e.oh, e.th, keyPtr(dkey), C.size_t(len(dkey)),
+		keyPtr(akey), C.size_t(len(akey)), C.DAOS_IOD_SINGLE, &size, 0, 0, nil, 0)
+	if err := daosError(rc); err != nil {
+		return 0, err
+	}
+
+	return uint64(size), nil
+}
+
+// fetchValue reads a single value, or nr records of recSize bytes from index
+// idx of an array value.
+func (e *objEnumerator) fetchValue(dkey, akey []byte, iodType C.daos_iod_type_t, recSize, idx, nr uint64) ([]byte, error) {
+	buf := make([]byte, recSize*nr)
+	if len(buf) == 0 {
+		return buf, nil
+	}
+
+	size := C.daos_size_t(recSize)
+	rc := C.obj_fetch_value(e.oh, e.th, keyPtr(dkey), C.size_t(len(dkey)),
+		keyPtr(akey), C.size_t(len(akey)), iodType, &size, C.uint64_t(idx), C.uint64_t(nr),
+		unsafe.Pointer(&buf[0]), C.size_t(len(buf)))
+	if err := daosError(rc); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// listExtents enumerates the valid extents of an array value, returning them
+// along with the record size.
+func (e *objEnumerator) listExtents(dkey, akey []byte) (uint64, []*objExtent, error) {
+	var anchor C.daos_anchor_t
+	var size C.daos_size_t
+	var extents []*objExtent
+
+	recxs := make([]C.daos_recx_t, e.pageSize)
+	eprs := make([]C.daos_epoch_range_t, e.pageSize)
+	for !C.daos_anchor_is_eof(&anchor) {
+		nr := C.uint32_t(e.pageSize)
+		rc := C.obj_list_recx(e.oh, e.th, keyPtr(dkey), C.size_t(len(dkey)),
+			keyPtr(akey), C.size_t(len(akey)), &size, &nr, &recxs[0], &eprs[0], &anchor)
+		if err := daosError(rc); err != nil {
+			return 0, nil, err
+		}
+
+		for i := C.uint32_t(0); i < nr; i++ {
+			extents = append(extents, &objExtent{
+				Index: uint64(recxs[i].rx_idx),
+				Count: uint64(recxs[i].rx_nr),
+				Epoch: uint64(eprs[i].epr_lo),
+			})
+		}
+	}
+
+	return uint64(size), extents, nil
+}
+
+// dumpAKey describes the value stored under an akey, optionally including up
+// to maxValue bytes of the value itself. The enumeration API doesn't report
+// the value type, so a single value is probed for first.
+func (e *objEnumerator) dumpAKey(dkey []byte, ak *objAKey, format string, values bool, maxValue uint64) error {
+	size, err := e.fetchSize(dkey, ak.raw)
+	if err != nil {
+		return err
+	}
+
+	if size > 0 {
+		ak.Type = "single"
+		ak.Size = size
+		if !values {
+			return nil
+		}
+
+		buf, err := e.fetchValue(dkey, ak.raw, C.DAOS_IOD_SINGLE, size, 0, 1)
+		if err != nil {
+			return err
+		}
+		if maxValue > 0 && uint64(len(buf)) > maxValue {
+			buf = buf[:maxValue]
+			ak.Partial = true
+		}
+		val := newObjKey(buf, format)
+		ak.Value = &val
+
+		return nil
+	}
+
+	ak.Type = "array"
+	ak.Size, ak.Extents, err = e.listExtents(dkey, ak.raw)
+	if err != nil || !values || ak.Size == 0 {
+		return err
+	}
+
+	for _, ext := range ak.Extents {
+		nr := ext.Count
+		if maxValue > 0 && nr*ak.Size > maxValue {
+			nr = maxValue / ak.Size
+			ext.Partial = true
+		}
+		buf, err := e.fetchValue(dkey, ak.raw, C.DAOS_IOD_ARRAY, ak.Size, ext.Index, nr)
+		if err != nil {
+			return err
+		}
+		val := newObjKey(buf, format)
+		ext.Value = &val
+	}
+
+	return nil
+}
+
+// objKeyBaseCmd contains the options common to the object commands that read
+// an object's keys and values.
+type objKeyBaseCmd struct {
+	objBaseCmd
+
+	Epoch    uint64 `long:"epc" short:"e" description:"snapshot epoch to read from (default: latest)"`
+	Format   string `long:"format" short:"f" default:"auto" choice:"auto" choice:"utf8" choice:"hex" description:"key and value rendering; auto renders printable UTF-8 as-is and anything else as hex"`
+	PageSize int    `long:"page-size" default:"256" description:"number of keys to retrieve per enumeration call"`
+}
+
+// openObject opens the object, and a read-only transaction on the requested
+// snapshot epoch if any, returning an enumerator for it.
+func (cmd *objKeyBaseCmd) openObject(oid C.daos_obj_id_t) (*objEnumerator, func(), error) {
+	if cmd.PageSize <= 0 {
+		return nil, nil, errors.New("page size must be greater than 0")
+	}
+
+	var oh, th C.daos_handle_t
+	rc := C.daos_obj_open(cmd.cContHandle, oid, C.DAOS_OO_RO, &oh, nil)
+	if err := daosError(rc); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to open object %s",
+			cmd.Args.ObjectID.String())
+	}
+
+	if cmd.Epoch > 0 {
+		rc = C.daos_tx_open_snap(cmd.cContHandle, C.daos_epoch_t(cmd.Epoch), &th, nil)
+		if err := daosError(rc); err != nil {
+			C.daos_obj_close(oh, nil)
+			return nil, nil, errors.Wrapf(err,
+				"failed to open snapshot at epoch %d", cmd.Epoch)
+		}
+	}
+
+	return newObjEnumerator(oh, th, cmd.PageSize), func() {
+		if cmd.Epoch > 0 {
+			if err := daosError(C.daos_tx_close(th, nil)); err != nil {
+				cmd.log.Errorf("failed to close snapshot: %s", err)
+			}
+		}
+		if err := daosError(C.daos_obj_close(oh, nil)); err != nil {
+			cmd.log.Errorf("failed to close object: %s", err)
+		}
+	}, nil
+}
+
+// connect resolves the container and opens the object, returning an
+// enumerator for it and a function to release everything.
+func (cmd *objKeyBaseCmd) connect() (*objEnumerator, func(), error) {
+	oid, err := cmd.getOid()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	disconnect, err := cmd.resolveAndConnect(C.DAOS_COO_RO, ap)
+	if err != nil {
+		deallocCmdArgs()
+		return nil, nil, err
+	}
+
+	e, closeObject, err := cmd.openObject(oid)
+	if err != nil {
+		disconnect()
+		deallocCmdArgs()
+		return nil, nil, err
+	}
+
+	return e, func() {
+		closeObject()
+		disconnect()
+		deallocCmdArgs()
+	}, nil
+}
+
+type objListKeysCmd struct {
+	objKeyBaseCmd
+
+	DKey   ObjKeyFlag `long:"dkey" short:"k" description:"list the akeys under this dkey instead of the object's dkeys (0x prefix for hex)"`
+	AKeys  bool       `long:"akeys" short:"a" description:"also list the akeys under each dkey"`
+	Limit  int        `long:"limit" short:"l" description:"maximum number of keys to list, an anchor is returned to continue from"`
+	Anchor string     `long:"anchor" description:"continue a previous listing from the returned anchor"`
+}
+
+func (cmd *objListKeysCmd) Execute(_ []string) error {
+	if cmd.DKey.Set && cmd.AKeys {
+		return errors.New("--akeys may not be combined with --dkey")
+	}
+	if cmd.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+
+	var anchor C.daos_anchor_t
+	if cmd.Anchor != "" {
+		if err := decodeAnchor(cmd.Anchor, &anchor); err != nil {
+			return err
+		}
+	}
+
+	e, cleanup, err := cmd.connect()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	keys, err := e.listKeys(cmd.DKey.Key, &anchor, cmd.Limit)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list keys for object %s",
+			cmd.Args.ObjectID.String())
+	}
+
+	list := new(objKeyList)
+	if !C.daos_anchor_is_eof(&anchor) {
+		if list.Anchor, err = encodeAnchor(&anchor); err != nil {
+			return err
+		}
+	}
+
+	if cmd.DKey.Set {
+		dkey := newObjKey(cmd.DKey.Key, cmd.Format)
+		list.DKey = &dkey
+		for _, key := range keys {
+			list.AKeys = append(list.AKeys, &objAKey{objKey: newObjKey(key, cmd.Format)})
+		}
+	} else {
+		for _, key := range keys {
+			dk := &objDKey{objKey: newObjKey(key, cmd.Format)}
+			if cmd.AKeys {
+				var akAnchor C.daos_anchor_t
+				akeys, err := e.listKeys(key, &akAnchor, 0)
+				if err != nil {
+					return errors.Wrapf(err, "failed to list akeys for dkey %s", dk)
+				}
+				for _, akey := range akeys {
+					dk.AKeys = append(dk.AKeys, &objAKey{objKey: newObjKey(akey, cmd.Format)})
+				}
+			}
+			list.DKeys = append(list.DKeys, dk)
+		}
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(list, nil)
+	}
+
+	var bld strings.Builder
+	printObjKeyList(&bld, list)
+	cmd.log.Info(bld.String())
 
 	return nil
 }
+
+func printObjKeyList(out io.Writer, list *objKeyList) {
+	if list.DKey != nil {
+		fmt.Fprintf(out, "dkey %s\n", list.DKey)
+		for _, ak := range list.AKeys {
+			fmt.Fprintf(out, "  akey %s\n", ak)
+		}
+	}
+	for _, dk := range list.DKeys {
+		fmt.Fprintf(out, "dkey %s\n", dk)
+		for _, ak := range dk.AKeys {
+			fmt.Fprintf(out, "  akey %s\n", ak)
+		}
+	}
+	if list.Anchor != "" {
+		fmt.Fprintf(out, "more keys available, continue with --anchor %s\n", list.Anchor)
+	}
+}
+
+type objDumpCmd struct {
+	objKeyBaseCmd
+
+	DKey      ObjKeyFlag `long:"dkey" short:"k" description:"only dump this dkey (0x prefix for hex)"`
+	AKey      ObjKeyFlag `long:"akey" short:"a" description:"only dump this akey, requires --dkey (0x prefix for hex)"`
+	Values    bool       `long:"values" short:"V" description:"include values"`
+	ValueSize uint64     `long:"value-size" short:"s" default:"4096" description:"maximum number of bytes of each value or extent to include (0 for no limit)"`
+}
+
+func (cmd *objDumpCmd) Execute(_ []string) error {
+	if cmd.AKey.Set && !cmd.DKey.Set {
+		return errors.New("--akey requires --dkey")
+	}
+
+	e, cleanup, err := cmd.connect()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	dump := &objDump{OID: cmd.Args.ObjectID.String()}
+
+	dkeys := [][]byte{cmd.DKey.Key}
+	if !cmd.DKey.Set {
+		var anchor C.daos_anchor_t
+		if dkeys, err = e.listKeys(nil, &anchor, 0); err != nil {
+			return errors.Wrapf(err, "failed to list dkeys for object %s", dump.OID)
+		}
+	}
+
+	for _, dkey := range dkeys {
+		dk := &objDKey{objKey: newObjKey(dkey, cmd.Format)}
+
+		akeys := [][]byte{cmd.AKey.Key}
+		if !cmd.AKey.Set {
+			var anchor C.daos_anchor_t
+			if akeys, err = e.listKeys(dkey, &anchor, 0); err != nil {
+				return errors.Wrapf(err, "failed to list akeys for dkey %s", dk)
+			}
+		}
+
+		for _, akey := range akeys {
+			ak := &objAKey{objKey: newObjKey(akey, cmd.Format)}
+			if err := e.dumpAKey(dkey, ak, cmd.Format, cmd.Values, cmd.ValueSize); err != nil {
+				return errors.Wrapf(err, "failed to dump dkey %s akey %s", dk, ak)
+			}
+			dk.AKeys = append(dk.AKeys, ak)
+		}
+		dump.DKeys = append(dump.DKeys, dk)
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(dump, nil)
+	}
+
+	var bld strings.Builder
+	printObjDump(&bld, dump)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+func printObjValue(out io.Writer, indent string, val *objKey, partial bool) {
+	if val == nil {
+		return
+	}
+	suffix := ""
+	if partial {
+		suffix = " ..."
+	}
+	fmt.Fprintf(out, "%svalue: %s%s\n", indent, val, suffix)
+}
+
+func printObjDump(out io.Writer, dump *objDump) {
+	fmt.Fprintf(out, "Object %s\n", dump.OID)
+	for _, dk := range dump.DKeys {
+		fmt.Fprintf(out, "dkey %s\n", dk)
+		for _, ak := range dk.AKeys {
+			switch ak.Type {
+			case "single":
+				fmt.Fprintf(out, "  akey %s (single, %d bytes)\n", ak, ak.Size)
+				printObjValue(out, "    ", ak.Value, ak.Partial)
+			default:
+				fmt.Fprintf(out, "  akey %s (array, record size %d, %d extents)\n",
+					ak, ak.Size, len(ak.Extents))
+				for _, ext := range ak.Extents {
+					fmt.Fprintf(out, "    extent [%d-%d] epoch %d\n",
+						ext.Index, ext.Index+ext.Count-1, ext.Epoch)
+					printObjValue(out, "      ", ext.Value, ext.Partial)
+				}
+			}
+		}
+	}
+}
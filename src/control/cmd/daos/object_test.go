@@ -0,0 +1,121 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+// parseObjectArgs parses arguments through the real command-line parser
+// without executing the command, returning the command they select.
+func parseObjectArgs(t *testing.T, args ...string) flags.Commander {
+	t.Helper()
+
+	var opts cliOptions
+	var parsed flags.Commander
+	p := flags.NewParser(&opts, flags.Default^flags.PrintErrors)
+	p.CommandHandler = func(cmd flags.Commander, _ []string) error {
+		parsed = cmd
+		return nil
+	}
+	if _, err := p.ParseArgs(args); err != nil {
+		t.Fatalf("failed to parse %v: %s", args, err)
+	}
+
+	return parsed
+}
+
+func TestObject_parseArgs(t *testing.T) {
+	pool := "0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22"
+	cont := "d3c4e3a4-2d4a-4d2a-9e0c-4b3f0e3b7c11"
+
+	cmd := parseObjectArgs(t, "object", "list-keys", "--page-size", "16",
+		"--pool", pool, "--cont", cont, "--oid", "1.2", "--akeys")
+	lk, ok := cmd.(*objListKeysCmd)
+	if !ok {
+		t.Fatalf("expected list-keys command, got %T", cmd)
+	}
+	common.AssertEqual(t, 16, lk.PageSize, "list-keys page size")
+	common.AssertEqual(t, pool, lk.PoolID().String(), "list-keys pool")
+	common.AssertTrue(t, lk.AKeys, "list-keys akeys")
+
+	cmd = parseObjectArgs(t, "object", "dump", pool, cont, "1.2", "-p", pool,
+		"--values", "--value-size", "64")
+	dump, ok := cmd.(*objDumpCmd)
+	if !ok {
+		t.Fatalf("expected dump command, got %T", cmd)
+	}
+	common.AssertEqual(t, 256, dump.PageSize, "dump default page size")
+	common.AssertEqual(t, uint64(64), dump.ValueSize, "dump value size")
+}
+
+func TestObject_formatBytes(t *testing.T) {
+	for name, tc := range map[string]struct {
+		buf    []byte
+		format string
+		expStr string
+		expEnc string
+	}{
+		"auto printable": {
+			buf:    []byte("file.txt"),
+			format: keyFormatAuto,
+			expStr: "file.txt",
+			expEnc: keyFormatUTF8,
+		},
+		"auto unicode": {
+			buf:    []byte("répertoire"),
+			format: keyFormatAuto,
+			expStr: "répertoire",
+			expEnc: keyFormatUTF8,
+		},
+		"auto binary": {
+			buf:    []byte{0, 0x10, 0, 0, 0, 0, 0, 0},
+			format: keyFormatAuto,
+			expStr: "0x0010000000000000",
+			expEnc: keyFormatHex,
+		},
+		"auto control characters": {
+			buf:    []byte("a\nb"),
+			format: keyFormatAuto,
+			expStr: "0x610a62",
+			expEnc: keyFormatHex,
+		},
+		"auto hex-like string": {
+			buf:    []byte("0x10"),
+			format: keyFormatAuto,
+			expStr: "0x30783130",
+			expEnc: keyFormatHex,
+		},
+		"auto empty": {
+			format: keyFormatAuto,
+			expStr: "0x",
+			expEnc: keyFormatHex,
+		},
+		"forced utf8": {
+			buf:    []byte("a\nb"),
+			format: keyFormatUTF8,
+			expStr: "a\nb",
+			expEnc: keyFormatUTF8,
+		},
+		"forced hex": {
+			buf:    []byte("akey"),
+			format: keyFormatHex,
+			expStr: "0x616b6579",
+			expEnc: keyFormatHex,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotStr, gotEnc := formatBytes(tc.buf, tc.format)
+			common.AssertEqual(t, tc.expStr, gotStr, "unexpected string")
+			common.AssertEqual(t, tc.expEnc, gotEnc, "unexpected encoding")
+		})
+	}
+}
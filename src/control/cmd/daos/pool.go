@@ -8,7 +8,6 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"unsafe"
 
@@ -149,12 +148,14 @@ func (cmd *poolBaseCmd) getAttr(name string) (*attribute, error) {
 }
 
 type poolCmd struct {
-	Query     poolQueryCmd     `command:"query" description:"query pool info"`
-	ListAttrs poolListAttrsCmd `command:"list-attr" alias:"list-attrs" alias:"lsattr" description:"list pool user-defined attributes"`
-	GetAttr   poolGetAttrCmd   `command:"get-attr" alias:"getattr" description:"get pool user-defined attribute"`
-	SetAttr   poolSetAttrCmd   `command:"set-attr" alias:"setattr" description:"set pool user-defined attribute"`
-	DelAttr   poolDelAttrCmd   `command:"del-attr" alias:"delattr" description:"delete pool user-defined attribute"`
-	AutoTest  poolAutoTestCmd  `command:"autotest" description:"verify setup with smoke tests"`
+	Query          poolQueryCmd          `command:"query" description:"query pool info"`
+	ListContainers poolListContainersCmd `command:"list-containers" alias:"list-cont" description:"list the containers of the pool, optionally with the space they use"`
+	ListAttrs      poolListAttrsCmd      `command:"list-attr" alias:"list-attrs" alias:"lsattr" description:"list pool user-defined attributes"`
+	GetAttr        poolGetAttrCmd        `command:"get-attr" alias:"getattr" description:"get pool user-defined attribute"`
+	SetAttr        poolSetAttrCmd        `command:"set-attr" alias:"setattr" description:"set pool user-defined attribute"`
+	DelAttr        poolDelAttrCmd        `command:"del-attr" alias:"delattr" description:"delete pool user-defined attribute"`
+	Attributes     poolAttrsCmd          `command:"attrs" description:"export or import pool user-defined attributes"`
+	AutoTest       poolAutoTestCmd       `command:"autotest" description:"verify setup with smoke tests"`
 }
 
 type poolQueryCmd struct {
@@ -389,41 +390,3 @@ func (cmd *poolDelAttrCmd) Execute(_ []string) error {
 
 	return nil
 }
-
-type poolAutoTestCmd struct {
-	poolBaseCmd
-}
-
-func (cmd *poolAutoTestCmd) Execute(_ []string) error {
-	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
-	if err != nil {
-		return err
-	}
-	defer deallocCmdArgs()
-
-	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RW, nil)
-	if err != nil {
-		return err
-	}
-	defer cleanup()
-
-	ap.pool = cmd.cPoolHandle
-	if err := copyUUID(&ap.p_uuid, cmd.poolUUID); err != nil {
-		return err
-	}
-	ap.p_op = C.POOL_AUTOTEST
-
-	// Set outstream to stdout; don't try to redirect it.
-	ap.outstream, err = fd2FILE(os.Stdout.Fd(), "w")
-	if err != nil {
-		return err
-	}
-
-	rc := C.pool_autotest_hdlr(ap)
-	if err := daosError(rc); err != nil {
-		return errors.Wrapf(err, "failed to run autotest for pool %s",
-			cmd.poolUUID)
-	}
-
-	return nil
-}
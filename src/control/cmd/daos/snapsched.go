@@ -0,0 +1,456 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/snapsched"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+/*
+#include "util.h"
+*/
+import "C"
+
+type snapSchedCmd struct {
+	Set    snapSchedSetCmd    `command:"set" description:"set or clear the snapshot schedule of a container"`
+	Run    snapSchedRunCmd    `command:"run" description:"create and prune scheduled snapshots of the containers of a pool"`
+	Report snapSchedReportCmd `command:"report" description:"report the scheduled snapshots of the containers of a pool"`
+}
+
+type snapSchedSetCmd struct {
+	existingContainerCmd
+
+	Schedule string `long:"schedule" short:"s" description:"snapshot schedule as period:keep[,period:keep...], where period is hourly, daily, weekly, monthly or a duration (e.g. hourly:24,daily:7)"`
+	Clear    bool   `long:"clear" description:"remove the snapshot schedule; existing snapshots are kept"`
+}
+
+func (cmd *snapSchedSetCmd) Execute(_ []string) error {
+	var sched snapsched.Schedule
+	switch {
+	case cmd.Clear && cmd.Schedule != "":
+		return errors.New("cannot specify both --schedule and --clear")
+	case !cmd.Clear && cmd.Schedule == "":
+		return errors.New("one of --schedule or --clear must be specified")
+	case cmd.Schedule != "":
+		var err error
+		if sched, err = snapsched.ParseSchedule(cmd.Schedule); err != nil {
+			return err
+		}
+	}
+
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RW, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if cmd.Clear {
+		err := delDaosAttribute(cmd.cContHandle, contAttr, snapsched.Attribute)
+		if err != nil && errors.Cause(err) != drpc.DaosNonexistant {
+			return errors.Wrapf(err, "failed to clear snapshot schedule of container %s",
+				cmd.ContainerID())
+		}
+		cmd.log.Infof("snapshot schedule of container %s cleared", cmd.ContainerID())
+		return nil
+	}
+
+	if err := setDaosAttribute(cmd.cContHandle, contAttr, &attribute{
+		Name:  snapsched.Attribute,
+		Value: sched.String(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set snapshot schedule of container %s",
+			cmd.ContainerID())
+	}
+	cmd.log.Infof("snapshot schedule of container %s set to %s", cmd.ContainerID(), sched)
+
+	return nil
+}
+
+// snapSchedPoolCmd applies the snapshot schedules of the containers of a
+// pool.
+type snapSchedPoolCmd struct {
+	poolBaseCmd
+
+	Containers []string `long:"cont" description:"only consider this container (may be repeated); default is all containers of the pool"`
+}
+
+// schedContainer is the state of the snapshot schedule of a container.
+type schedContainer struct {
+	Container string                `json:"container"`
+	Schedule  string                `json:"schedule"`
+	Plan      *snapsched.Plan       `json:"plan,omitempty"`
+	Created   []*snapshot           `json:"created,omitempty"`
+	Pruned    []*snapsched.Snapshot `json:"pruned,omitempty"`
+	Error     string                `json:"error,omitempty"`
+}
+
+func (sc *schedContainer) setError(err error) {
+	sc.Error = err.Error()
+}
+
+// containers returns the IDs of the containers to consider.
+func (cmd *snapSchedPoolCmd) containers() ([]string, error) {
+	if len(cmd.Containers) > 0 {
+		return cmd.Containers, nil
+	}
+
+	contIDs, err := listContainers(cmd.cPoolHandle)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(contIDs))
+	for i, id := range contIDs {
+		ids[i] = id.String()
+	}
+
+	return ids, nil
+}
+
+// forEachScheduled opens each container with a snapshot schedule and calls
+// fn with its handle. Containers without a schedule are skipped unless they
+// were requested explicitly. Failures are recorded per container so that one
+// bad container doesn't prevent scheduling the others.
+func (cmd *snapSchedPoolCmd) forEachScheduled(flags C.uint, fn func(C.daos_handle_t, *schedContainer, snapsched.Schedule) error) ([]*schedContainer, error) {
+	ids, err := cmd.containers()
+	if err != nil {
+		return nil, err
+	}
+
+	var conts []*schedContainer
+	for _, id := range ids {
+		sc := &schedContainer{Container: id}
+
+		var coh C.daos_handle_t
+		cID := C.CString(id)
+		rc := C.daos_cont_open2(cmd.cPoolHandle, cID, flags, &coh, nil, nil)
+		freeString(cID)
+		if err := daosError(rc); err != nil {
+			sc.setError(errors.Wrap(err, "failed to open container"))
+			conts = append(conts, sc)
+			continue
+		}
+
+		attr, err := getDaosAttribute(coh, contAttr, snapsched.Attribute)
+		switch {
+		case errors.Cause(err) == drpc.DaosNonexistant:
+			if len(cmd.Containers) > 0 {
+				sc.Error = "no snapshot schedule"
+				conts = append(conts, sc)
+			}
+		case err != nil:
+			sc.setError(err)
+			conts = append(conts, sc)
+		default:
+			sc.Schedule = attr.Value
+			sched, err := snapsched.ParseSchedule(attr.Value)
+			if err == nil {
+				err = fn(coh, sc, sched)
+			}
+			if err != nil {
+				sc.setError(err)
+			}
+			conts = append(conts, sc)
+		}
+
+		if err := daosError(C.daos_cont_close(coh, nil)); err != nil {
+			cmd.log.Errorf("failed to close container %s: %s", id, err)
+		}
+	}
+
+	return conts, nil
+}
+
+// planSnapshots lists the snapshots of the open container and plans the
+// schedule against them.
+func planSnapshots(coh C.daos_handle_t, now time.Time, sched snapsched.Schedule) (*snapsched.Plan, error) {
+	snaps, err := listSnapshots(coh)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+
+	schedSnaps := make([]*snapsched.Snapshot, len(snaps))
+	for i, snap := range snaps {
+		schedSnaps[i] = &snapsched.Snapshot{Epoch: snap.Epoch, Name: snap.Name}
+	}
+
+	return sched.Plan(now, schedSnaps), nil
+}
+
+type snapSchedRunCmd struct {
+	snapSchedPoolCmd
+
+	Once     bool          `long:"once" description:"apply the schedules once and exit instead of running as a daemon"`
+	DryRun   bool          `long:"dry-run" short:"n" description:"only report the snapshots that would be created and pruned"`
+	Interval time.Duration `long:"interval" short:"i" default:"1m" description:"maximum time between two passes in daemon mode"`
+	OIT      bool          `long:"oit" description:"also record the object index table of the created snapshots"`
+}
+
+func (cmd *snapSchedRunCmd) Execute(_ []string) error {
+	if cmd.Interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RW, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if cmd.Once || cmd.DryRun {
+		conts, err := cmd.runPass(time.Now())
+		if cmd.jsonOutputEnabled() {
+			return cmd.outputJSON(conts, err)
+		}
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	cmd.log.Infof("snapshot scheduler started on pool %s", cmd.PoolID())
+	for {
+		conts, err := cmd.runPass(time.Now())
+		if err != nil {
+			cmd.log.Errorf("snapshot scheduler pass failed: %s", err)
+		}
+
+		wait := cmd.Interval
+		if next := nextDue(conts); !next.IsZero() {
+			if d := time.Until(next); d < wait {
+				wait = d
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		cmd.log.Debugf("next snapshot scheduler pass in %s", wait)
+
+		select {
+		case <-sigCh:
+			cmd.log.Infof("snapshot scheduler stopped on pool %s", cmd.PoolID())
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// nextDue returns the earliest time a scheduled snapshot is due.
+func nextDue(conts []*schedContainer) time.Time {
+	var next time.Time
+	for _, sc := range conts {
+		if sc.Plan == nil {
+			continue
+		}
+		for _, status := range sc.Plan.Policies {
+			if next.IsZero() || status.Next.Before(next) {
+				next = status.Next
+			}
+		}
+	}
+	return next
+}
+
+// runPass creates and prunes the scheduled snapshots of the containers.
+func (cmd *snapSchedRunCmd) runPass(now time.Time) ([]*schedContainer, error) {
+	flags := C.uint(C.DAOS_COO_RW)
+	if cmd.DryRun {
+		flags = C.DAOS_COO_RO
+	}
+
+	conts, err := cmd.forEachScheduled(flags, func(coh C.daos_handle_t, sc *schedContainer, sched snapsched.Schedule) error {
+		plan, err := planSnapshots(coh, now, sched)
+		if err != nil {
+			return err
+		}
+		sc.Plan = plan
+
+		for _, status := range plan.Policies {
+			if status.Create != "" {
+				if cmd.DryRun {
+					cmd.log.Infof("%s: would create snapshot %s", sc.Container, status.Create)
+				} else {
+					snap, err := cmd.createSnapshot(coh, status.Create)
+					if err != nil {
+						return err
+					}
+					sc.Created = append(sc.Created, snap)
+					cmd.log.Infof("%s: created snapshot %s", sc.Container, snap)
+				}
+			}
+
+			for _, snap := range status.Prune {
+				if cmd.DryRun {
+					cmd.log.Infof("%s: would prune snapshot %s (epoch %d)", sc.Container, snap.Name, snap.Epoch)
+					continue
+				}
+
+				epr := C.daos_epoch_range_t{epr_lo: C.daos_epoch_t(snap.Epoch), epr_hi: C.daos_epoch_t(snap.Epoch)}
+				if err := daosError(C.daos_cont_destroy_snap(coh, epr, nil)); err != nil {
+					return errors.Wrapf(err, "failed to prune snapshot %s", snap.Name)
+				}
+				sc.Pruned = append(sc.Pruned, snap)
+				cmd.log.Infof("%s: pruned snapshot %s (epoch %d)", sc.Container, snap.Name, snap.Epoch)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []string
+	for _, sc := range conts {
+		if sc.Error != "" {
+			cmd.log.Errorf("%s: %s", sc.Container, sc.Error)
+			failed = append(failed, sc.Container)
+		}
+	}
+	if len(failed) > 0 {
+		return conts, errors.Errorf("snapshot schedule failed for container(s) %s",
+			strings.Join(failed, ", "))
+	}
+
+	return conts, nil
+}
+
+func (cmd *snapSchedRunCmd) createSnapshot(coh C.daos_handle_t, name string) (*snapshot, error) {
+	cName := C.CString(name)
+	defer freeString(cName)
+
+	var epoch C.daos_epoch_t
+	var rc C.int
+	if cmd.OIT {
+		rc = C.daos_cont_create_snap_opt(coh, &epoch, cName,
+			C.DAOS_SNAP_OPT_CR|C.DAOS_SNAP_OPT_OIT, nil)
+	} else {
+		rc = C.daos_cont_create_snap_opt(coh, &epoch, cName, C.DAOS_SNAP_OPT_CR, nil)
+	}
+	if err := daosError(rc); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot %s", name)
+	}
+
+	return &snapshot{Epoch: uint64(epoch), Name: name}, nil
+}
+
+type snapSchedReportCmd struct {
+	snapSchedPoolCmd
+}
+
+func (cmd *snapSchedReportCmd) Execute(_ []string) error {
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RO, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	now := time.Now()
+	conts, err := cmd.forEachScheduled(C.DAOS_COO_RO, func(coh C.daos_handle_t, sc *schedContainer, sched snapsched.Schedule) error {
+		plan, err := planSnapshots(coh, now, sched)
+		sc.Plan = plan
+		return err
+	})
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(conts, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	printSnapSchedReport(&bld, conts)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+func printSnapSchedReport(out io.Writer, conts []*schedContainer) {
+	if len(conts) == 0 {
+		fmt.Fprintln(out, "No containers with a snapshot schedule.")
+		return
+	}
+
+	contTitle := "Container"
+	policyTitle := "Policy"
+	keepTitle := "Keep"
+	snapsTitle := "Snapshots"
+	latestTitle := "Latest"
+	nextTitle := "Next Due"
+	pendingTitle := "Pending"
+
+	var table []txtfmt.TableRow
+	for _, sc := range conts {
+		if sc.Plan == nil {
+			table = append(table, txtfmt.TableRow{
+				contTitle:    sc.Container,
+				policyTitle:  "-",
+				keepTitle:    "-",
+				snapsTitle:   "-",
+				latestTitle:  "-",
+				nextTitle:    "-",
+				pendingTitle: "error: " + sc.Error,
+			})
+			continue
+		}
+
+		for _, status := range sc.Plan.Policies {
+			latest := "none"
+			if len(status.Snapshots) > 0 {
+				latest = status.Snapshots[0].Name
+			}
+
+			next := status.Next.Local().Format("2006-01-02 15:04:05")
+			var pending []string
+			if status.Create != "" {
+				next = "now"
+				pending = append(pending, "create")
+			}
+			if len(status.Prune) > 0 {
+				pending = append(pending, fmt.Sprintf("prune %d", len(status.Prune)))
+			}
+			if len(pending) == 0 {
+				pending = append(pending, "none")
+			}
+
+			table = append(table, txtfmt.TableRow{
+				contTitle:    sc.Container,
+				policyTitle:  status.Policy.Period,
+				keepTitle:    strconv.Itoa(status.Policy.Keep),
+				snapsTitle:   strconv.Itoa(len(status.Snapshots) + len(status.Prune)),
+				latestTitle:  latest,
+				nextTitle:    next,
+				pendingTitle: strings.Join(pending, ", "),
+			})
+		}
+	}
+
+	tf := txtfmt.NewTableFormatter(contTitle, policyTitle, keepTitle, snapsTitle,
+		latestTitle, nextTitle, pendingTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
@@ -0,0 +1,59 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/snapsched"
+)
+
+func TestSnapSched_printSnapSchedReport(t *testing.T) {
+	sched, err := snapsched.ParseSchedule("hourly:2,daily:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2021, 6, 16, 13, 47, 0, 0, time.UTC)
+	snaps := []*snapsched.Snapshot{
+		{Epoch: 1, Name: "sched-hourly-20210616T100000Z"},
+		{Epoch: 2, Name: "sched-hourly-20210616T110000Z"},
+		{Epoch: 3, Name: "sched-hourly-20210616T120000Z"},
+		{Epoch: 4, Name: "sched-daily-20210616T000000Z"},
+	}
+	conts := []*schedContainer{
+		{Container: "c1", Schedule: sched.String(), Plan: sched.Plan(now, snaps)},
+		{Container: "c2", Error: "no snapshot schedule"},
+	}
+
+	var bld strings.Builder
+	printSnapSchedReport(&bld, conts)
+	// ignore column widths
+	var lines []string
+	for _, line := range strings.Split(bld.String(), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	out := strings.Join(lines, "\n")
+
+	for _, exp := range []string{
+		"c1 hourly 2 3 sched-hourly-20210616T120000Z now create, prune 2",
+		"c1 daily 1 1 sched-daily-20210616T000000Z",
+		"c2 - - - - - error: no snapshot schedule",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Fatalf("expected %q in output:\n%s", exp, out)
+		}
+	}
+
+	common.AssertEqual(t, time.Date(2021, 6, 16, 14, 0, 0, 0, time.UTC), nextDue(conts), "next due")
+
+	bld.Reset()
+	printSnapSchedReport(&bld, nil)
+	common.AssertEqual(t, "No containers with a snapshot schedule.\n", bld.String(), "empty report")
+}
@@ -7,19 +7,186 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unsafe"
+
 	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
 )
 
 /*
 #include "util.h"
+
+static int
+cont_list_snaps(daos_handle_t coh, int *nr, daos_epoch_t *epochs,
+		char *names_buf)
+{
+	daos_anchor_t	anchor = {0};
+	char		**names = NULL;
+	int		i;
+	int		rc;
+
+	if (names_buf != NULL && *nr > 0) {
+		D_ALLOC_ARRAY(names, *nr);
+		if (names == NULL)
+			return -DER_NOMEM;
+		for (i = 0; i < *nr; i++)
+			names[i] = names_buf + i * DAOS_SNAPSHOT_MAX_LEN;
+	}
+
+	rc = daos_cont_list_snap(coh, nr, epochs, names, &anchor, NULL);
+	D_FREE(names);
+
+	return rc;
+}
 */
 import "C"
 
+type snapshot struct {
+	Epoch uint64 `json:"epoch"`
+	Name  string `json:"name,omitempty"`
+}
+
+func (s *snapshot) String() string {
+	if s.Name == "" {
+		return fmt.Sprintf("epoch %d", s.Epoch)
+	}
+	return fmt.Sprintf("%s (epoch %d)", s.Name, s.Epoch)
+}
+
+// listSnapshots returns the snapshots of the open container.
+func listSnapshots(hdl C.daos_handle_t) ([]*snapshot, error) {
+	var nr C.int
+	if err := daosError(C.cont_list_snaps(hdl, &nr, nil, nil)); err != nil {
+		return nil, err
+	}
+
+	for nr > 0 {
+		expected := nr
+		epochs := make([]C.daos_epoch_t, expected)
+		names := make([]byte, int(expected)*C.DAOS_SNAPSHOT_MAX_LEN)
+
+		rc := C.cont_list_snaps(hdl, &nr, &epochs[0], (*C.char)(unsafe.Pointer(&names[0])))
+		if err := daosError(rc); err != nil {
+			return nil, err
+		}
+		if nr > expected {
+			// snapshots were created in the meantime
+			continue
+		}
+
+		snaps := make([]*snapshot, nr)
+		for i := range snaps {
+			name := names[i*C.DAOS_SNAPSHOT_MAX_LEN : (i+1)*C.DAOS_SNAPSHOT_MAX_LEN]
+			if end := bytes.IndexByte(name, 0); end >= 0 {
+				name = name[:end]
+			}
+			snaps[i] = &snapshot{
+				Epoch: uint64(epochs[i]),
+				Name:  string(name),
+			}
+		}
+		return snaps, nil
+	}
+
+	return nil, nil
+}
+
+// findSnapshot looks up a snapshot by name or epoch.
+func findSnapshot(snaps []*snapshot, id string) (*snapshot, error) {
+	for _, snap := range snaps {
+		if snap.Name != "" && snap.Name == id {
+			return snap, nil
+		}
+	}
+
+	epoch, err := strconv.ParseUint(id, 10, 64)
+	if err == nil {
+		for _, snap := range snaps {
+			if snap.Epoch == epoch {
+				return snap, nil
+			}
+		}
+	}
+
+	return nil, errors.Errorf("no snapshot with name or epoch %q", id)
+}
+
+// snapshotByName looks up a snapshot of the open container by name.
+func (cmd *existingContainerCmd) snapshotByName(name string) (*snapshot, error) {
+	snaps, err := listSnapshots(cmd.cContHandle)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"failed to list snapshots for container %s", cmd.ContainerID())
+	}
+	for _, snap := range snaps {
+		if snap.Name == name {
+			return snap, nil
+		}
+	}
+
+	return nil, errors.Wrapf(drpc.DaosNonexistant, "no snapshot named %q", name)
+}
+
+// snapshotCurrent creates a temporary snapshot of the current state of the
+// open container along with its object index table, returning a function to
+// destroy it.
+func snapshotCurrent(log logging.Logger, coh C.daos_handle_t) (*snapshot, func(), error) {
+	var epoch C.daos_epoch_t
+	rc := C.daos_cont_create_snap_opt(coh, &epoch, nil,
+		C.DAOS_SNAP_OPT_CR|C.DAOS_SNAP_OPT_OIT, nil)
+	if err := daosError(rc); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create snapshot")
+	}
+
+	return &snapshot{Epoch: uint64(epoch)}, func() {
+		epr := C.daos_epoch_range_t{epr_lo: epoch, epr_hi: epoch}
+		if err := daosError(C.daos_cont_destroy_snap(coh, epr, nil)); err != nil {
+			log.Errorf("failed to destroy snapshot at epoch %d: %s", epoch, err)
+		}
+	}, nil
+}
+
+// listOIT calls fn for each object recorded in the object index table of a
+// snapshot of the open container, retrieving pageSize object IDs at a time.
+func listOIT(coh C.daos_handle_t, snap *snapshot, pageSize int, fn func(C.daos_obj_id_t) error) error {
+	var oit C.daos_handle_t
+	rc := C.daos_oit_open(coh, C.daos_epoch_t(snap.Epoch), &oit, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrapf(err, "failed to open object index table of epoch %d", snap.Epoch)
+	}
+	defer C.daos_oit_close(oit, nil)
+
+	var anchor C.daos_anchor_t
+	oids := make([]C.daos_obj_id_t, pageSize)
+	for !C.daos_anchor_is_eof(&anchor) {
+		nr := C.uint32_t(len(oids))
+		rc = C.daos_oit_list(oit, &oids[0], &nr, &anchor, nil)
+		if err := daosError(rc); err != nil {
+			return errors.Wrapf(err, "failed to list objects of epoch %d", snap.Epoch)
+		}
+		for _, oid := range oids[:nr] {
+			if err := fn(oid); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 type containerSnapshotCreateCmd struct {
 	existingContainerCmd
 
 	Epoch uint64 `long:"epc" short:"e" description:"epoch to use for snapshot"`
 	Name  string `long:"snap" short:"s" description:"snapshot name"`
+	OIT   bool   `long:"oit" description:"also record the object index table of the snapshot, required to compare it with container diff"`
 }
 
 func (cmd *containerSnapshotCreateCmd) Execute(args []string) error {
@@ -35,6 +202,10 @@ func (cmd *containerSnapshotCreateCmd) Execute(args []string) error {
 	}
 	defer cleanup()
 
+	if cmd.OIT {
+		return cmd.createSnapshotOIT()
+	}
+
 	if cmd.Epoch > 0 {
 		ap.epc = C.uint64_t(cmd.Epoch)
 	}
@@ -49,6 +220,39 @@ func (cmd *containerSnapshotCreateCmd) Execute(args []string) error {
 			cmd.contUUID)
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&snapshot{Epoch: uint64(ap.epc), Name: cmd.Name}, nil)
+	}
+
+	return nil
+}
+
+// createSnapshotOIT creates a snapshot of the current epoch along with its
+// object index table, which can't be added to an existing snapshot.
+func (cmd *containerSnapshotCreateCmd) createSnapshotOIT() error {
+	if cmd.Epoch > 0 {
+		return errors.New("cannot specify an epoch with --oit")
+	}
+
+	var cName *C.char
+	if cmd.Name != "" {
+		cName = C.CString(cmd.Name)
+		defer freeString(cName)
+	}
+
+	var epoch C.daos_epoch_t
+	rc := C.daos_cont_create_snap_opt(cmd.cContHandle, &epoch, cName,
+		C.DAOS_SNAP_OPT_CR|C.DAOS_SNAP_OPT_OIT, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrapf(err, "failed to create snapshot of container %s",
+			cmd.contUUID)
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&snapshot{Epoch: uint64(epoch), Name: cmd.Name}, nil)
+	}
+	cmd.log.Infof("snapshot/epoch %d has been created", epoch)
+
 	return nil
 }
 
@@ -75,9 +279,6 @@ func (cmd *containerSnapshotDestroyCmd) Execute(args []string) error {
 		if cmd.Epoch > 0 {
 			return errors.New("cannot specify both snapshot name and epoch")
 		}
-
-		ap.snapname_str = C.CString(cmd.Name)
-		defer freeString(ap.snapname_str)
 	case cmd.Epoch > 0:
 		if cmd.EpochRange.Set {
 			return errors.New("cannot specify both snapshot epoch and epoch range")
@@ -107,6 +308,15 @@ func (cmd *containerSnapshotDestroyCmd) Execute(args []string) error {
 	}
 	defer cleanup()
 
+	if cmd.Name != "" {
+		// the handler only destroys snapshots by epoch
+		snap, err := cmd.snapshotByName(cmd.Name)
+		if err != nil {
+			return err
+		}
+		ap.epc = C.uint64_t(snap.Epoch)
+	}
+
 	rc := C.cont_destroy_snap_hdlr(ap)
 	if err := daosError(rc); err != nil {
 		return errors.Wrapf(err, "failed to destroy snapshot of container %s",
@@ -133,16 +343,39 @@ func (cmd *containerSnapshotListCmd) Execute(args []string) error {
 	}
 	defer cleanup()
 
-	rc := C.cont_list_snaps_hdlr(ap)
-	if err := daosError(rc); err != nil {
+	snaps, err := listSnapshots(cmd.cContHandle)
+	if err != nil {
 		return errors.Wrapf(err,
 			"failed to list snapshots for container %s",
 			cmd.contUUID)
 	}
 
+	if cmd.jsonOutputEnabled() {
+		if snaps == nil {
+			snaps = []*snapshot{}
+		}
+		return cmd.outputJSON(snaps, nil)
+	}
+
+	var bld strings.Builder
+	printSnapshots(&bld, snaps)
+	cmd.log.Info(bld.String())
+
 	return nil
 }
 
+func printSnapshots(out io.Writer, snaps []*snapshot) {
+	fmt.Fprintln(out, "Container's snapshots :")
+	if len(snaps) == 0 {
+		fmt.Fprintln(out, "no snapshots")
+		return
+	}
+
+	for _, snap := range snaps {
+		fmt.Fprintf(out, "%d %s\n", snap.Epoch, snap.Name)
+	}
+}
+
 type containerSnapshotRollbackCmd struct {
 	existingContainerCmd
 
@@ -163,13 +396,17 @@ func (cmd *containerSnapshotRollbackCmd) Execute(args []string) error {
 	}
 	defer cleanup()
 
-	if cmd.Epoch > 0 {
-		ap.epc = C.uint64_t(cmd.Epoch)
+	if cmd.Epoch > 0 && cmd.Name != "" {
+		return errors.New("cannot specify both snapshot epoch and name")
 	}
+
+	snap := &snapshot{Epoch: cmd.Epoch}
 	if cmd.Name != "" {
-		ap.snapname_str = C.CString(cmd.Name)
-		defer freeString(ap.snapname_str)
+		if snap, err = cmd.snapshotByName(cmd.Name); err != nil {
+			return err
+		}
 	}
+	ap.epc = C.uint64_t(snap.Epoch)
 
 	rc := C.cont_rollback_hdlr(ap)
 	if err := daosError(rc); err != nil {
@@ -177,5 +414,9 @@ func (cmd *containerSnapshotRollbackCmd) Execute(args []string) error {
 			cmd.contUUID)
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(snap, nil)
+	}
+
 	return nil
 }
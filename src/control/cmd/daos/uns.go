@@ -0,0 +1,579 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+/*
+#include "util.h"
+*/
+import "C"
+
+const (
+	// unsXattrName is the extended attribute of a path linking it to a
+	// container (DUNS_XATTR_NAME).
+	unsXattrName = "user.daos"
+	// unsMaxXattrLen is the maximum length of its value
+	// (DUNS_MAX_XATTR_LEN).
+	unsMaxXattrLen = 170
+)
+
+// unsLink is the value of the UNS extended attribute of a path, in the
+// format DAOS.<container type>://<pool UUID>/<container UUID>.
+type unsLink struct {
+	Type      string
+	Pool      uuid.UUID
+	Container uuid.UUID
+}
+
+func (l *unsLink) String() string {
+	return fmt.Sprintf("DAOS.%s://%s/%s", l.Type, l.Pool, l.Container)
+}
+
+// parseUNSLink parses the value of the UNS extended attribute of a path.
+func parseUNSLink(value []byte) (*unsLink, error) {
+	str := string(bytes.TrimRight(value, "\x00"))
+	invalid := func() error {
+		return errors.Errorf("invalid UNS attribute %q", str)
+	}
+
+	if !strings.HasPrefix(str, "DAOS.") {
+		return nil, invalid()
+	}
+	fields := strings.SplitN(strings.TrimPrefix(str, "DAOS."), "://", 2)
+	if len(fields) != 2 || fields[0] == "" {
+		return nil, invalid()
+	}
+	ids := strings.Split(fields[1], "/")
+	if len(ids) != 2 {
+		return nil, invalid()
+	}
+
+	link := &unsLink{Type: fields[0]}
+	var err error
+	if link.Pool, err = uuid.Parse(ids[0]); err != nil {
+		return nil, errors.Wrapf(invalid(), "pool UUID: %s", err)
+	}
+	if link.Container, err = uuid.Parse(ids[1]); err != nil {
+		return nil, errors.Wrapf(invalid(), "container UUID: %s", err)
+	}
+
+	return link, nil
+}
+
+// readUNSLink reads the UNS extended attribute of a path, returning nil if
+// it has none.
+func readUNSLink(path string) (*unsLink, error) {
+	buf := make([]byte, unsMaxXattrLen+1)
+	n, err := unix.Lgetxattr(path, unsXattrName, buf)
+	switch err {
+	case nil:
+	case unix.ENODATA, unix.ENOTSUP:
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(err, "failed to read UNS attribute of %s", path)
+	}
+
+	link, err := parseUNSLink(buf[:n])
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return link, nil
+}
+
+// writeUNSLink sets the UNS extended attribute of a path, replacing an
+// existing one only if force is set.
+func writeUNSLink(path string, link *unsLink, force bool) error {
+	flags := unix.XATTR_CREATE
+	if force {
+		flags = 0
+	}
+
+	err := unix.Lsetxattr(path, unsXattrName, append([]byte(link.String()), 0), flags)
+	switch err {
+	case nil:
+		return nil
+	case unix.EEXIST:
+		return errors.Errorf("%s is already linked to a container, use --force to replace the link", path)
+	case unix.ENOTSUP:
+		return errors.Errorf("the filesystem of %s does not support the unified namespace", path)
+	default:
+		return errors.Wrapf(err, "failed to set UNS attribute of %s", path)
+	}
+}
+
+// removeUNSLink removes the UNS extended attribute of a path.
+func removeUNSLink(path string) error {
+	err := unix.Lremovexattr(path, unsXattrName)
+	switch err {
+	case nil:
+		return nil
+	case unix.ENODATA, unix.ENOTSUP:
+		return errors.Errorf("%s is not linked to a container", path)
+	default:
+		return errors.Wrapf(err, "failed to remove UNS attribute of %s", path)
+	}
+}
+
+// findUNSLinks walks the tree under root, calling fn for each directory or
+// file linked to a container, without descending into linked directories.
+// Paths that can't be read are passed to fn with the error.
+func findUNSLinks(root string, fn func(path string, link *unsLink, err error) error) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return fn(path, nil, err)
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		link, err := readUNSLink(path)
+		switch {
+		case err != nil:
+			return fn(path, nil, err)
+		case link == nil:
+			return nil
+		}
+
+		if err := fn(path, link, nil); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// unsLinkInfo describes the container a path is linked to.
+type unsLinkInfo struct {
+	Path      string `json:"path"`
+	RelPath   string `json:"relative_path,omitempty"`
+	Type      string `json:"container_type"`
+	PoolUUID  string `json:"pool_uuid"`
+	PoolLabel string `json:"pool_label,omitempty"`
+	ContUUID  string `json:"container_uuid"`
+	ContLabel string `json:"container_label,omitempty"`
+	Dangling  bool   `json:"dangling"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newUNSLinkInfo(path string, link *unsLink) *unsLinkInfo {
+	return &unsLinkInfo{
+		Path:     path,
+		Type:     link.Type,
+		PoolUUID: link.Pool.String(),
+		ContUUID: link.Container.String(),
+	}
+}
+
+// Status returns whether the linked container exists.
+func (li *unsLinkInfo) Status() string {
+	switch {
+	case li.Dangling:
+		return "dangling: " + li.Error
+	case li.Error != "":
+		return "error: " + li.Error
+	default:
+		return "ok"
+	}
+}
+
+// unsPool is a pool connected to by an unsResolver.
+type unsPool struct {
+	poh       C.daos_handle_t
+	connected bool
+	uuid      string
+	label     string
+	err       error
+}
+
+// unsResolver looks up the pools and containers linked to by paths,
+// connecting to each pool once.
+type unsResolver struct {
+	sysName string
+	pools   map[string]*unsPool
+}
+
+func newUNSResolver(sysName string) *unsResolver {
+	if sysName == "" {
+		sysName = build.DefaultSystemName
+	}
+	return &unsResolver{
+		sysName: sysName,
+		pools:   make(map[string]*unsPool),
+	}
+}
+
+func (r *unsResolver) connect(poolID string) *unsPool {
+	if pool, found := r.pools[poolID]; found {
+		return pool
+	}
+
+	pool := new(unsPool)
+	r.pools[poolID] = pool
+
+	cSysName := C.CString(r.sysName)
+	defer freeString(cSysName)
+	cPoolID := C.CString(poolID)
+	defer freeString(cPoolID)
+
+	var pinfo C.daos_pool_info_t
+	rc := C.daos_pool_connect2(cPoolID, cSysName, C.DAOS_PC_RO, &pool.poh, &pinfo, nil)
+	if pool.err = daosError(rc); pool.err != nil {
+		return pool
+	}
+	pool.connected = true
+
+	poolUUID, err := uuidFromC(pinfo.pi_uuid)
+	if err != nil {
+		pool.err = err
+		return pool
+	}
+	pool.uuid = poolUUID.String()
+
+	props, entries, err := allocProps(1)
+	if err != nil {
+		pool.err = err
+		return pool
+	}
+	defer C.daos_prop_free(props)
+	entries[0].dpe_type = C.DAOS_PROP_PO_LABEL
+	props.dpp_nr++
+
+	// the label is only informative, don't fail if it can't be queried
+	rc = C.daos_pool_query(pool.poh, nil, nil, props, nil)
+	if rc == 0 && C.get_dpe_str(&entries[0]) != nil {
+		pool.label = C.GoString(C.get_dpe_str(&entries[0]))
+	}
+
+	return pool
+}
+
+// lookup fills in the UUIDs and labels of the pool and container a path is
+// linked to, marking the link as dangling if either no longer exists.
+func (r *unsResolver) lookup(li *unsLinkInfo) {
+	pool := r.connect(li.PoolUUID)
+	if pool.err != nil {
+		li.Error = errors.Wrapf(pool.err, "failed to connect to pool %s", li.PoolUUID).Error()
+		li.Dangling = errors.Cause(pool.err) == drpc.DaosNonexistant
+		return
+	}
+	li.PoolUUID = pool.uuid
+	li.PoolLabel = pool.label
+
+	cContID := C.CString(li.ContUUID)
+	defer freeString(cContID)
+
+	var coh C.daos_handle_t
+	var cinfo C.daos_cont_info_t
+	rc := C.daos_cont_open2(pool.poh, cContID, C.DAOS_COO_RO, &coh, &cinfo, nil)
+	if err := daosError(rc); err != nil {
+		li.Error = errors.Wrapf(err, "failed to open container %s", li.ContUUID).Error()
+		li.Dangling = errors.Cause(err) == drpc.DaosNonexistant
+		return
+	}
+	defer C.daos_cont_close(coh, nil)
+
+	contUUID, err := uuidFromC(cinfo.ci_uuid)
+	if err != nil {
+		li.Error = err.Error()
+		return
+	}
+	li.ContUUID = contUUID.String()
+
+	props, entries, err := allocProps(1)
+	if err != nil {
+		li.Error = err.Error()
+		return
+	}
+	defer C.daos_prop_free(props)
+	entries[0].dpe_type = C.DAOS_PROP_CO_LABEL
+	props.dpp_nr++
+
+	rc = C.daos_cont_query(coh, nil, props, nil)
+	if rc == 0 && C.get_dpe_str(&entries[0]) != nil {
+		li.ContLabel = C.GoString(C.get_dpe_str(&entries[0]))
+	}
+}
+
+func (r *unsResolver) close() {
+	for _, pool := range r.pools {
+		if pool.connected {
+			C.daos_pool_disconnect(pool.poh, nil)
+		}
+	}
+}
+
+func printUNSLinkInfo(out io.Writer, li *unsLinkInfo) {
+	rows := []txtfmt.TableRow{
+		{"Path": li.Path},
+	}
+	if li.RelPath != "" && li.RelPath != "/" {
+		rows = append(rows, txtfmt.TableRow{"Path in container": li.RelPath})
+	}
+	rows = append(rows,
+		txtfmt.TableRow{"Container type": li.Type},
+		txtfmt.TableRow{"Pool UUID": li.PoolUUID},
+	)
+	if li.PoolLabel != "" {
+		rows = append(rows, txtfmt.TableRow{"Pool label": li.PoolLabel})
+	}
+	rows = append(rows, txtfmt.TableRow{"Container UUID": li.ContUUID})
+	if li.ContLabel != "" {
+		rows = append(rows, txtfmt.TableRow{"Container label": li.ContLabel})
+	}
+	rows = append(rows, txtfmt.TableRow{"Status": li.Status()})
+
+	fmt.Fprint(out, txtfmt.FormatEntity("", rows))
+}
+
+func printUNSLinks(out io.Writer, links []*unsLinkInfo) {
+	if len(links) == 0 {
+		fmt.Fprintf(out, "No links found.\n")
+		return
+	}
+
+	pathTitle := "Path"
+	typeTitle := "Type"
+	poolTitle := "Pool"
+	contTitle := "Container"
+	statusTitle := "Status"
+
+	label := func(label, uuid string) string {
+		if label != "" {
+			return label
+		}
+		return uuid
+	}
+
+	table := []txtfmt.TableRow{}
+	for _, li := range links {
+		table = append(table, txtfmt.TableRow{
+			pathTitle:   li.Path,
+			typeTitle:   li.Type,
+			poolTitle:   label(li.PoolLabel, li.PoolUUID),
+			contTitle:   label(li.ContLabel, li.ContUUID),
+			statusTitle: li.Status(),
+		})
+	}
+
+	tf := txtfmt.NewTableFormatter(pathTitle, typeTitle, poolTitle, contTitle, statusTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
+
+type containerLinkCmd struct {
+	existingContainerCmd
+
+	Force bool `long:"force" short:"f" description:"replace an existing link"`
+	Args  struct {
+		LinkPath string `positional-arg-name:"<path>"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *containerLinkCmd) Execute(_ []string) error {
+	if cmd.Path != "" {
+		return errors.New("can't specify --path, the path to link is the last argument")
+	}
+	path := cmd.Args.LinkPath
+
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_COO_RO, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ci, err := cmd.queryContainer()
+	if err != nil {
+		return errors.Wrapf(err, "failed to query container %s", cmd.ContainerID())
+	}
+	switch {
+	case ci.Type == "POSIX" && !fi.IsDir():
+		return errors.Errorf("%s must be a directory to link a POSIX container", path)
+	case ci.Type != "POSIX" && !fi.Mode().IsRegular():
+		return errors.Errorf("%s must be a regular file to link a %s container", path, ci.Type)
+	}
+
+	link := &unsLink{
+		Type:      ci.Type,
+		Pool:      cmd.poolUUID,
+		Container: cmd.contUUID,
+	}
+	if err := writeUNSLink(path, link, cmd.Force); err != nil {
+		return err
+	}
+
+	li := newUNSLinkInfo(path, link)
+	li.PoolLabel = cmd.PoolID().Label
+	li.ContLabel = ci.ContainerLabel
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(li, nil)
+	}
+	cmd.log.Infof("Linked %s to container %s", path, cmd.ContainerID())
+
+	return nil
+}
+
+type containerUnlinkCmd struct {
+	daosCmd
+
+	Args struct {
+		Path string `positional-arg-name:"<path>"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *containerUnlinkCmd) Execute(_ []string) error {
+	path := cmd.Args.Path
+
+	link, err := readUNSLink(path)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return errors.Errorf("%s is not linked to a container", path)
+	}
+	if err := removeUNSLink(path); err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(newUNSLinkInfo(path, link), nil)
+	}
+	cmd.log.Infof("Unlinked %s from container %s", path, link.Container)
+
+	return nil
+}
+
+type containerResolveCmd struct {
+	daosCmd
+
+	SysName string `long:"sys-name" short:"G" description:"DAOS system name"`
+	Args    struct {
+		Path string `positional-arg-name:"<path>"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *containerResolveCmd) Execute(_ []string) error {
+	cPath := C.CString(cmd.Args.Path)
+	defer freeString(cPath)
+
+	var dattr C.struct_duns_attr_t
+	rc := C.duns_resolve_path(cPath, &dattr)
+	defer C.duns_destroy_attr(&dattr)
+	if err := dfsError(rc); err != nil {
+		return errors.Wrapf(err, "failed to resolve path %s", cmd.Args.Path)
+	}
+
+	var cType [10]C.char
+	C.daos_unparse_ctype(C.ushort(dattr.da_type), &cType[0])
+	li := &unsLinkInfo{
+		Path:     cmd.Args.Path,
+		Type:     C.GoString(&cType[0]),
+		PoolUUID: C.GoString(&dattr.da_pool[0]),
+		ContUUID: C.GoString(&dattr.da_cont[0]),
+	}
+	if dattr.da_rel_path != nil {
+		li.RelPath = C.GoString(dattr.da_rel_path)
+	}
+	sysName := cmd.SysName
+	if sysName == "" && dattr.da_sys != nil {
+		sysName = C.GoString(dattr.da_sys)
+	}
+
+	resolver := newUNSResolver(sysName)
+	defer resolver.close()
+	resolver.lookup(li)
+
+	var err error
+	if li.Error != "" {
+		err = errors.Errorf("path %s is linked to container %s of pool %s: %s",
+			li.Path, li.ContUUID, li.PoolUUID, li.Status())
+	}
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(li, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	printUNSLinkInfo(&bld, li)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+type containerFindLinksCmd struct {
+	daosCmd
+
+	SysName  string `long:"sys-name" short:"G" description:"DAOS system name"`
+	Dangling bool   `long:"dangling" description:"only list links to containers that no longer exist"`
+	Args     struct {
+		Dir string `positional-arg-name:"<directory>"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *containerFindLinksCmd) Execute(_ []string) error {
+	resolver := newUNSResolver(cmd.SysName)
+	defer resolver.close()
+
+	links := []*unsLinkInfo{}
+	err := findUNSLinks(cmd.Args.Dir, func(path string, link *unsLink, err error) error {
+		if err != nil {
+			cmd.log.Errorf("skipping %s: %s", path, err)
+			return nil
+		}
+
+		li := newUNSLinkInfo(path, link)
+		resolver.lookup(li)
+		if li.Dangling || !cmd.Dangling {
+			links = append(links, li)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to scan %s", cmd.Args.Dir)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Path < links[j].Path
+	})
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(links, nil)
+	}
+
+	var bld strings.Builder
+	printUNSLinks(&bld, links)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
@@ -0,0 +1,168 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestUNS_parseUNSLink(t *testing.T) {
+	poolUUID := uuid.MustParse("0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22")
+	contUUID := uuid.MustParse("d3c4e3a4-2d4a-4d2a-9e0c-4b3f0e3b7c11")
+
+	for name, tc := range map[string]struct {
+		value   string
+		expLink *unsLink
+		expErr  error
+	}{
+		"POSIX": {
+			value:   "DAOS.POSIX://" + poolUUID.String() + "/" + contUUID.String() + "\x00",
+			expLink: &unsLink{Type: "POSIX", Pool: poolUUID, Container: contUUID},
+		},
+		"HDF5 without terminator": {
+			value:   "DAOS.HDF5://" + poolUUID.String() + "/" + contUUID.String(),
+			expLink: &unsLink{Type: "HDF5", Pool: poolUUID, Container: contUUID},
+		},
+		"no prefix": {
+			value:  "POSIX://" + poolUUID.String() + "/" + contUUID.String(),
+			expErr: errors.New("invalid UNS attribute"),
+		},
+		"no type": {
+			value:  "DAOS.://" + poolUUID.String() + "/" + contUUID.String(),
+			expErr: errors.New("invalid UNS attribute"),
+		},
+		"no container": {
+			value:  "DAOS.POSIX://" + poolUUID.String(),
+			expErr: errors.New("invalid UNS attribute"),
+		},
+		"bad container": {
+			value:  "DAOS.POSIX://" + poolUUID.String() + "/cont",
+			expErr: errors.New("container UUID"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			link, err := parseUNSLink([]byte(tc.value))
+			common.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expLink, link); diff != "" {
+				t.Fatalf("unexpected link (-want, +got):\n%s\n", diff)
+			}
+			if link != nil {
+				common.AssertEqual(t, strings.TrimSuffix(tc.value, "\x00"), link.String(), "string")
+			}
+		})
+	}
+}
+
+func TestUNS_findUNSLinks(t *testing.T) {
+	root, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	link := &unsLink{Type: "POSIX", Pool: uuid.New(), Container: uuid.New()}
+	if err := unix.Lsetxattr(root, "user.test", []byte("x"), 0); err != nil {
+		t.Skipf("extended attributes not supported: %s", err)
+	}
+
+	for _, dir := range []string{"a/linked/sub", "b"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := filepath.Join(root, "b", "data.h5")
+	if err := ioutil.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	linked := filepath.Join(root, "a", "linked")
+	for _, path := range []string{linked, filepath.Join(linked, "sub"), file} {
+		if err := writeUNSLink(path, link, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	common.CmpErr(t, errors.New("already linked"), writeUNSLink(file, link, false))
+	common.CmpErr(t, nil, writeUNSLink(file, link, true))
+
+	var found []string
+	err = findUNSLinks(root, func(path string, l *unsLink, err error) error {
+		if err != nil {
+			return err
+		}
+		if diff := cmp.Diff(link, l); diff != "" {
+			t.Fatalf("unexpected link (-want, +got):\n%s\n", diff)
+		}
+		found = append(found, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the linked directory isn't descended into
+	common.AssertEqual(t, []string{linked, file}, found, "links")
+
+	common.CmpErr(t, nil, removeUNSLink(file))
+	common.CmpErr(t, errors.New("not linked"), removeUNSLink(file))
+	got, err := readUNSLink(file)
+	common.CmpErr(t, nil, err)
+	if got != nil {
+		t.Fatalf("expected no link, got %s", got)
+	}
+}
+
+func TestUNS_printUNSLinks(t *testing.T) {
+	links := []*unsLinkInfo{
+		{
+			Path:      "/mnt/proj",
+			Type:      "POSIX",
+			PoolUUID:  "0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22",
+			PoolLabel: "tank",
+			ContUUID:  "d3c4e3a4-2d4a-4d2a-9e0c-4b3f0e3b7c11",
+			ContLabel: "proj",
+		},
+		{
+			Path:     "/mnt/old",
+			Type:     "POSIX",
+			PoolUUID: "0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22",
+			ContUUID: "5d0e3b52-2c3f-4d7e-a0c5-0b5b4bd1c0a8",
+			Dangling: true,
+			Error:    "DER_NONEXIST",
+		},
+	}
+
+	var bld strings.Builder
+	printUNSLinks(&bld, links)
+	var lines []string
+	for _, line := range strings.Split(bld.String(), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	out := strings.Join(lines, "\n")
+
+	for _, exp := range []string{
+		"Path Type Pool Container Status",
+		"/mnt/proj POSIX tank proj ok",
+		"/mnt/old POSIX 0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22 5d0e3b52-2c3f-4d7e-a0c5-0b5b4bd1c0a8 dangling: DER_NONEXIST",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Fatalf("expected %q in output:\n%s", exp, out)
+		}
+	}
+
+	bld.Reset()
+	printUNSLinks(&bld, nil)
+	common.AssertEqual(t, "No links found.\n", bld.String(), "empty")
+}
@@ -0,0 +1,486 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+/*
+#include "util.h"
+
+#include <daos/object.h>
+
+// values of EC objects are split in data cells over k targets, plus p parity
+// cells, values of replicated objects are stored in full on each replica
+static void
+obj_redundancy(daos_obj_id_t oid, uint32_t *data, uint32_t *total)
+{
+	struct daos_oclass_attr *oca = daos_oclass_attr_find(oid, NULL);
+
+	*data = 1;
+	*total = 1;
+	if (oca == NULL)
+		return;
+
+	if (daos_oclass_is_ec(oca)) {
+		*data = oca->u.ec.e_k;
+		*total = oca->u.ec.e_k + oca->u.ec.e_p;
+	} else if (oca->u.rp.r_num > 1) {
+		*total = oca->u.rp.r_num;
+	}
+}
+*/
+import "C"
+
+// usageNVMeThreshold is the size from which the servers store a value on NVMe
+// rather than SCM, when the pool has NVMe storage.
+const usageNVMeThreshold = 4096
+
+type (
+	// contUsage accounts for the space used by the objects of a container
+	// at a snapshot. Data is the size of the values as written, SCM and
+	// NVMe the space they use on each tier, including redundancy.
+	contUsage struct {
+		Epoch      uint64 `json:"epoch"`
+		Snapshot   string `json:"snapshot,omitempty"`
+		Objects    uint64 `json:"objects"`
+		DataBytes  uint64 `json:"data_bytes"`
+		ScmBytes   uint64 `json:"scm_bytes"`
+		NvmeBytes  uint64 `json:"nvme_bytes"`
+		TotalBytes uint64 `json:"total_bytes"`
+	}
+
+	// objRedundancy describes how the values of an object are stored: each
+	// value is split over data targets, and total/data times its size is
+	// stored in all.
+	objRedundancy struct {
+		data  uint64
+		total uint64
+	}
+
+	// poolContainer is a container of a pool along with its usage, if
+	// requested.
+	poolContainer struct {
+		UUID  string     `json:"uuid"`
+		Label string     `json:"label,omitempty"`
+		Usage *contUsage `json:"usage,omitempty"`
+		Error string     `json:"error,omitempty"`
+	}
+)
+
+func (pc *poolContainer) String() string {
+	if pc.Label != "" {
+		return pc.Label
+	}
+	return pc.UUID
+}
+
+func objectRedundancy(oid C.daos_obj_id_t) objRedundancy {
+	var data, total C.uint32_t
+	C.obj_redundancy(oid, &data, &total)
+	return objRedundancy{data: uint64(data), total: uint64(total)}
+}
+
+// addValue accounts for a value, or an array extent, of size bytes. The tier
+// is chosen as the servers do, on the size written to each target.
+func (u *contUsage) addValue(size uint64, red objRedundancy, hasNVMe bool) {
+	stored := size * red.total / red.data
+
+	u.DataBytes += size
+	u.TotalBytes += stored
+	if hasNVMe && size/red.data >= usageNVMeThreshold {
+		u.NvmeBytes += stored
+	} else {
+		u.ScmBytes += stored
+	}
+}
+
+// addObject accounts for all the values of an object.
+func (u *contUsage) addObject(e *objEnumerator, red objRedundancy, hasNVMe bool) error {
+	u.Objects++
+
+	var dAnchor C.daos_anchor_t
+	for !C.daos_anchor_is_eof(&dAnchor) {
+		dkeys, err := e.listKeys(nil, &dAnchor, e.pageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to list dkeys")
+		}
+
+		for _, dkey := range dkeys {
+			var aAnchor C.daos_anchor_t
+			for !C.daos_anchor_is_eof(&aAnchor) {
+				akeys, err := e.listKeys(dkey, &aAnchor, e.pageSize)
+				if err != nil {
+					return errors.Wrap(err, "failed to list akeys")
+				}
+
+				for _, akey := range akeys {
+					if err := u.addAKey(e, dkey, akey, red, hasNVMe); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *contUsage) addAKey(e *objEnumerator, dkey, akey []byte, red objRedundancy, hasNVMe bool) error {
+	size, err := e.fetchSize(dkey, akey)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch value size")
+	}
+	if size > 0 {
+		u.addValue(size, red, hasNVMe)
+		return nil
+	}
+
+	recSize, extents, err := e.listExtents(dkey, akey)
+	if err != nil {
+		return errors.Wrap(err, "failed to list array extents")
+	}
+	for _, ext := range extents {
+		u.addValue(ext.Count*recSize, red, hasNVMe)
+	}
+
+	return nil
+}
+
+// poolHasNVMe returns whether the pool has NVMe storage, in which case large
+// values are stored on it.
+func poolHasNVMe(poh C.daos_handle_t) (bool, error) {
+	pinfo := C.daos_pool_info_t{
+		pi_bits: C.DPI_SPACE,
+	}
+	rc := C.daos_pool_query(poh, nil, &pinfo, nil, nil)
+	if err := daosError(rc); err != nil {
+		return false, errors.Wrap(err, "failed to query pool space")
+	}
+
+	return pinfo.pi_space.ps_space.s_total[C.DAOS_MEDIA_NVME] > 0, nil
+}
+
+// containerUsage accounts for the space used by the open container at the
+// snapshot, reading the objects recorded in its object index table. Only the
+// objects are counted unless withBytes is set, as accounting for the bytes
+// requires reading all the keys of all the objects.
+func containerUsage(coh C.daos_handle_t, snap *snapshot, hasNVMe, withBytes bool) (*contUsage, error) {
+	usage := &contUsage{
+		Epoch:    snap.Epoch,
+		Snapshot: snap.Name,
+	}
+
+	var th C.daos_handle_t
+	if withBytes {
+		rc := C.daos_tx_open_snap(coh, C.daos_epoch_t(snap.Epoch), &th, nil)
+		if err := daosError(rc); err != nil {
+			return nil, errors.Wrapf(err, "failed to open snapshot at epoch %d", snap.Epoch)
+		}
+		defer C.daos_tx_close(th, nil)
+	}
+
+	err := listOIT(coh, snap, oidPageSize, func(oid C.daos_obj_id_t) error {
+		if !withBytes {
+			usage.Objects++
+			return nil
+		}
+
+		var oh C.daos_handle_t
+		rc := C.daos_obj_open(coh, oid, C.DAOS_OO_RO, &oh, nil)
+		if err := daosError(rc); err != nil {
+			return errors.Wrapf(err, "failed to open object %s", newDiffOID(oid))
+		}
+		defer C.daos_obj_close(oh, nil)
+
+		e := newObjEnumerator(oh, th, oidPageSize)
+		if err := usage.addObject(e, objectRedundancy(oid), hasNVMe); err != nil {
+			return errors.Wrapf(err, "object %s", newDiffOID(oid))
+		}
+		return nil
+	})
+	if errors.Cause(err) == drpc.DaosNonexistant {
+		return nil, errors.Errorf("snapshot at epoch %d has no object index table, "+
+			"create snapshots to account for with create-snap --oit", snap.Epoch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// usageSnapshot returns the snapshot of the open container to account for:
+// the one at epoch, or named name, or else a temporary snapshot of its
+// current state, along with a function to release it.
+func usageSnapshot(log logging.Logger, coh C.daos_handle_t, epoch uint64, name string) (*snapshot, func(), error) {
+	switch {
+	case epoch > 0 && name != "":
+		return nil, nil, errors.New("only one of --epc and --snap may be specified")
+	case epoch > 0:
+		return &snapshot{Epoch: epoch}, func() {}, nil
+	case name != "":
+		snaps, err := listSnapshots(coh)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to list snapshots")
+		}
+		for _, snap := range snaps {
+			if snap.Name == name {
+				return snap, func() {}, nil
+			}
+		}
+		return nil, nil, errors.Wrapf(drpc.DaosNonexistant, "no snapshot named %q", name)
+	}
+
+	return snapshotCurrent(log, coh)
+}
+
+type containerStatCmd struct {
+	existingContainerCmd
+
+	Usage    bool   `long:"usage" description:"also account for the bytes used on each storage tier, which reads all the keys of all the objects"`
+	Epoch    uint64 `long:"epc" short:"e" description:"snapshot epoch to report at (default: current state)"`
+	Snapshot string `long:"snap" short:"s" description:"name of the snapshot to report at"`
+}
+
+func (cmd *containerStatCmd) Execute(_ []string) error {
+	ap, deallocCmdArgs, err := allocCmdArgs(cmd.log)
+	if err != nil {
+		return err
+	}
+	defer deallocCmdArgs()
+
+	// a temporary snapshot is created to report on the current state
+	contFlags := C.uint(C.DAOS_COO_RO)
+	if cmd.Epoch == 0 && cmd.Snapshot == "" {
+		contFlags = C.DAOS_COO_RW
+	}
+	cleanup, err := cmd.resolveAndConnect(contFlags, ap)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var hasNVMe bool
+	if cmd.Usage {
+		if hasNVMe, err = poolHasNVMe(cmd.cPoolHandle); err != nil {
+			return err
+		}
+	}
+
+	snap, destroySnap, err := usageSnapshot(cmd.log, cmd.cContHandle, cmd.Epoch, cmd.Snapshot)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to get statistics for container %s", cmd.ContainerID())
+	}
+	defer destroySnap()
+
+	usage, err := containerUsage(cmd.cContHandle, snap, hasNVMe, cmd.Usage)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to get statistics for container %s", cmd.ContainerID())
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(usage, nil)
+	}
+
+	var bld strings.Builder
+	printContainerUsage(&bld, usage, cmd.Usage)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+func printContainerUsage(out io.Writer, usage *contUsage, withBytes bool) {
+	epoch := fmt.Sprintf("%d", usage.Epoch)
+	if usage.Snapshot != "" {
+		epoch += fmt.Sprintf(" (%s)", usage.Snapshot)
+	}
+	rows := []txtfmt.TableRow{
+		{"Epoch": epoch},
+		{"Objects": fmt.Sprintf("%d", usage.Objects)},
+	}
+	if withBytes {
+		rows = append(rows, []txtfmt.TableRow{
+			{"Data": humanize.IBytes(usage.DataBytes)},
+			{"SCM": humanize.IBytes(usage.ScmBytes)},
+			{"NVMe": humanize.IBytes(usage.NvmeBytes)},
+			{"Total": humanize.IBytes(usage.TotalBytes)},
+		}...)
+	}
+
+	fmt.Fprintln(out, txtfmt.FormatEntity("", rows))
+}
+
+type poolListContainersCmd struct {
+	poolBaseCmd
+
+	Usage    bool   `long:"usage" description:"account for the space used by each container, largest first, which reads all the keys of all the objects"`
+	Snapshot string `long:"snap" short:"s" description:"account for the snapshot with this name in each container (default: current state)"`
+}
+
+func (cmd *poolListContainersCmd) Execute(_ []string) error {
+	if cmd.Snapshot != "" && !cmd.Usage {
+		return errors.New("--snap requires --usage")
+	}
+
+	cleanup, err := cmd.resolveAndConnect(C.DAOS_PC_RO, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	contIDs, err := listContainers(cmd.cPoolHandle)
+	if err != nil {
+		return errors.Wrapf(err,
+			"unable to list containers for pool %s", cmd.PoolID())
+	}
+
+	var hasNVMe bool
+	if cmd.Usage {
+		if hasNVMe, err = poolHasNVMe(cmd.cPoolHandle); err != nil {
+			return err
+		}
+	}
+
+	conts := make([]*poolContainer, len(contIDs))
+	for i, id := range contIDs {
+		conts[i] = &poolContainer{UUID: id.UUID.String(), Label: id.Label}
+		if !cmd.Usage {
+			continue
+		}
+		if err := cmd.accountContainer(conts[i], hasNVMe); err != nil {
+			conts[i].Error = err.Error()
+		}
+	}
+	if cmd.Usage {
+		sortPoolContainers(conts)
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(conts, nil)
+	}
+
+	var bld strings.Builder
+	printPoolContainers(&bld, conts, cmd.Usage)
+	cmd.log.Info(bld.String())
+
+	return nil
+}
+
+// accountContainer opens a container of the pool and accounts for the space
+// it uses. Failures are recorded per container so that one bad container
+// doesn't prevent reporting on the others.
+func (cmd *poolListContainersCmd) accountContainer(pc *poolContainer, hasNVMe bool) error {
+	// a temporary snapshot is created to report on the current state
+	contFlags := C.uint(C.DAOS_COO_RO)
+	if cmd.Snapshot == "" {
+		contFlags = C.DAOS_COO_RW
+	}
+
+	var coh C.daos_handle_t
+	cID := C.CString(pc.UUID)
+	defer freeString(cID)
+	rc := C.daos_cont_open2(cmd.cPoolHandle, cID, contFlags, &coh, nil, nil)
+	if err := daosError(rc); err != nil {
+		return errors.Wrap(err, "failed to open container")
+	}
+	defer func() {
+		if err := daosError(C.daos_cont_close(coh, nil)); err != nil {
+			cmd.log.Errorf("failed to close container %s: %s", pc, err)
+		}
+	}()
+
+	snap, destroySnap, err := usageSnapshot(cmd.log, coh, 0, cmd.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer destroySnap()
+
+	pc.Usage, err = containerUsage(coh, snap, hasNVMe, true)
+	return err
+}
+
+// sortPoolContainers sorts containers by the space they use, largest first,
+// with the containers that couldn't be accounted for last.
+func sortPoolContainers(conts []*poolContainer) {
+	total := func(pc *poolContainer) uint64 {
+		if pc.Usage == nil {
+			return 0
+		}
+		return pc.Usage.TotalBytes
+	}
+
+	sort.SliceStable(conts, func(i, j int) bool {
+		if (conts[i].Usage == nil) != (conts[j].Usage == nil) {
+			return conts[i].Usage != nil
+		}
+		if total(conts[i]) != total(conts[j]) {
+			return total(conts[i]) > total(conts[j])
+		}
+		return conts[i].String() < conts[j].String()
+	})
+}
+
+func printPoolContainers(out io.Writer, conts []*poolContainer, withUsage bool) {
+	if len(conts) == 0 {
+		fmt.Fprintf(out, "No containers.\n")
+		return
+	}
+
+	uuidTitle := "UUID"
+	labelTitle := "Label"
+	objectsTitle := "Objects"
+	dataTitle := "Data"
+	scmTitle := "SCM"
+	nvmeTitle := "NVMe"
+	totalTitle := "Total"
+	titles := []string{uuidTitle, labelTitle}
+	if withUsage {
+		titles = append(titles, objectsTitle, dataTitle, scmTitle, nvmeTitle, totalTitle)
+	}
+
+	table := []txtfmt.TableRow{}
+	for _, pc := range conts {
+		row := txtfmt.TableRow{
+			uuidTitle:  pc.UUID,
+			labelTitle: pc.Label,
+		}
+		switch {
+		case !withUsage:
+		case pc.Usage == nil:
+			row[objectsTitle] = "-"
+			row[dataTitle] = "-"
+			row[scmTitle] = "-"
+			row[nvmeTitle] = "-"
+			row[totalTitle] = "error: " + pc.Error
+		default:
+			row[objectsTitle] = fmt.Sprintf("%d", pc.Usage.Objects)
+			row[dataTitle] = humanize.IBytes(pc.Usage.DataBytes)
+			row[scmTitle] = humanize.IBytes(pc.Usage.ScmBytes)
+			row[nvmeTitle] = humanize.IBytes(pc.Usage.NvmeBytes)
+			row[totalTitle] = humanize.IBytes(pc.Usage.TotalBytes)
+		}
+		table = append(table, row)
+	}
+
+	tf := txtfmt.NewTableFormatter(titles...)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
@@ -0,0 +1,135 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestUsage_addValue(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sizes    []uint64
+		red      objRedundancy
+		hasNVMe  bool
+		expUsage *contUsage
+	}{
+		"no NVMe": {
+			sizes: []uint64{100, 1 << 20},
+			red:   objRedundancy{data: 1, total: 1},
+			expUsage: &contUsage{
+				DataBytes:  100 + 1<<20,
+				ScmBytes:   100 + 1<<20,
+				TotalBytes: 100 + 1<<20,
+			},
+		},
+		"small values on SCM": {
+			sizes:   []uint64{100, 4095, 4096},
+			red:     objRedundancy{data: 1, total: 1},
+			hasNVMe: true,
+			expUsage: &contUsage{
+				DataBytes:  100 + 4095 + 4096,
+				ScmBytes:   100 + 4095,
+				NvmeBytes:  4096,
+				TotalBytes: 100 + 4095 + 4096,
+			},
+		},
+		"replicated": {
+			sizes:   []uint64{100, 8192},
+			red:     objRedundancy{data: 1, total: 3},
+			hasNVMe: true,
+			expUsage: &contUsage{
+				DataBytes:  100 + 8192,
+				ScmBytes:   300,
+				NvmeBytes:  3 * 8192,
+				TotalBytes: 300 + 3*8192,
+			},
+		},
+		"erasure coded": {
+			// 8 KiB split over 4 data targets is below the threshold
+			sizes:   []uint64{8192, 1 << 20},
+			red:     objRedundancy{data: 4, total: 6},
+			hasNVMe: true,
+			expUsage: &contUsage{
+				DataBytes:  8192 + 1<<20,
+				ScmBytes:   12288,
+				NvmeBytes:  3 << 19,
+				TotalBytes: 12288 + 3<<19,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			usage := new(contUsage)
+			for _, size := range tc.sizes {
+				usage.addValue(size, tc.red, tc.hasNVMe)
+			}
+			if diff := cmp.Diff(tc.expUsage, usage); diff != "" {
+				t.Fatalf("unexpected usage (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestUsage_sortPoolContainers(t *testing.T) {
+	conts := []*poolContainer{
+		{UUID: "u1", Label: "small", Usage: &contUsage{TotalBytes: 10}},
+		{UUID: "u2", Error: "failed"},
+		{UUID: "u3", Label: "big", Usage: &contUsage{TotalBytes: 1000}},
+		{UUID: "u4", Usage: &contUsage{TotalBytes: 10}},
+	}
+
+	sortPoolContainers(conts)
+
+	var order []string
+	for _, pc := range conts {
+		order = append(order, pc.String())
+	}
+	common.AssertEqual(t, []string{"big", "small", "u4", "u2"}, order, "order")
+}
+
+func TestUsage_printPoolContainers(t *testing.T) {
+	conts := []*poolContainer{
+		{
+			UUID:  "d3c4e3a4-2d4a-4d2a-9e0c-4b3f0e3b7c11",
+			Label: "proj1",
+			Usage: &contUsage{Objects: 3, DataBytes: 2048, ScmBytes: 6144, TotalBytes: 6144},
+		},
+		{
+			UUID:  "0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22",
+			Error: "failed to open container",
+		},
+	}
+
+	var bld strings.Builder
+	printPoolContainers(&bld, conts, true)
+	// ignore column widths
+	var lines []string
+	for _, line := range strings.Split(bld.String(), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	out := strings.Join(lines, "\n")
+
+	for _, exp := range []string{
+		"UUID Label Objects Data SCM NVMe Total",
+		"d3c4e3a4-2d4a-4d2a-9e0c-4b3f0e3b7c11 proj1 3 2.0 KiB 6.0 KiB 0 B 6.0 KiB",
+		"0b7d9a4c-6f1e-4c43-8f0a-2e2f8a6b0c22 - - - - error: failed to open container",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Fatalf("expected %q in output:\n%s", exp, out)
+		}
+	}
+
+	bld.Reset()
+	printPoolContainers(&bld, conts[:1], false)
+	if strings.Contains(bld.String(), "Objects") {
+		t.Fatalf("unexpected usage in output:\n%s", bld.String())
+	}
+}
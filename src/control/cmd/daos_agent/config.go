@@ -25,13 +25,14 @@ const (
 
 // Config defines the agent configuration.
 type Config struct {
-	SystemName       string                    `yaml:"name"`
-	AccessPoints     []string                  `yaml:"access_points"`
-	ControlPort      int                       `yaml:"port"`
-	RuntimeDir       string                    `yaml:"runtime_dir"`
-	LogFile          string                    `yaml:"log_file"`
-	TransportConfig  *security.TransportConfig `yaml:"transport_config"`
-	FabricInterfaces []*NUMAFabricConfig       `yaml:"fabric_ifaces,omitempty"`
+	SystemName       string                     `yaml:"name"`
+	AccessPoints     []string                   `yaml:"access_points"`
+	ControlPort      int                        `yaml:"port"`
+	RuntimeDir       string                     `yaml:"runtime_dir"`
+	LogFile          string                     `yaml:"log_file"`
+	TransportConfig  *security.TransportConfig  `yaml:"transport_config"`
+	CredentialConfig *security.CredentialConfig `yaml:"credential_config,omitempty"`
+	FabricInterfaces []*NUMAFabricConfig        `yaml:"fabric_ifaces,omitempty"`
 }
 
 // NUMAFabricConfig defines a list of fabric interfaces that belong to a NUMA
@@ -61,6 +62,14 @@ func LoadConfig(cfgPath string) (*Config, error) {
 	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
 		return nil, err
 	}
+
+	if cfg.CredentialConfig == nil {
+		cfg.CredentialConfig = security.DefaultCredentialConfig()
+	}
+	if err := cfg.CredentialConfig.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid credential_config")
+	}
+
 	return cfg, nil
 }
 
@@ -68,11 +77,12 @@ func LoadConfig(cfgPath string) (*Config, error) {
 func DefaultConfig() *Config {
 	localServer := fmt.Sprintf("localhost:%d", build.DefaultControlPort)
 	return &Config{
-		SystemName:      build.DefaultSystemName,
-		ControlPort:     build.DefaultControlPort,
-		AccessPoints:    []string{localServer},
-		RuntimeDir:      defaultRuntimeDir,
-		LogFile:         defaultLogFile,
-		TransportConfig: security.DefaultAgentTransportConfig(),
+		SystemName:       build.DefaultSystemName,
+		ControlPort:      build.DefaultControlPort,
+		AccessPoints:     []string{localServer},
+		RuntimeDir:       defaultRuntimeDir,
+		LogFile:          defaultLogFile,
+		TransportConfig:  security.DefaultAgentTransportConfig(),
+		CredentialConfig: security.DefaultCredentialConfig(),
 	}
 }
@@ -8,6 +8,7 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -63,6 +64,20 @@ fabric_ifaces:
      domain: mlx5_3
 `)
 
+	credCfg := common.CreateTestFile(t, dir, `
+name: shire
+transport_config:
+  allow_insecure: true
+credential_config:
+  validity: 2m
+  clock_skew: 10s
+`)
+
+	badCredCfg := common.CreateTestFile(t, dir, `
+credential_config:
+  validity: 0s
+`)
+
 	for name, tc := range map[string]struct {
 		path      string
 		expResult *Config
@@ -95,8 +110,26 @@ fabric_ifaces:
 					AllowInsecure:     true,
 					CertificateConfig: DefaultConfig().TransportConfig.CertificateConfig,
 				},
+				CredentialConfig: security.DefaultCredentialConfig(),
 			},
 		},
+		"credential config": {
+			path: credCfg,
+			expResult: func() *Config {
+				cfg := DefaultConfig()
+				cfg.SystemName = "shire"
+				cfg.TransportConfig.AllowInsecure = true
+				cfg.CredentialConfig = &security.CredentialConfig{
+					Validity:  2 * time.Minute,
+					ClockSkew: 10 * time.Second,
+				}
+				return cfg
+			}(),
+		},
+		"invalid credential config": {
+			path:   badCredCfg,
+			expErr: errors.New("invalid credential_config"),
+		},
 		"manual fabric config": {
 			path: fabricCfg,
 			expResult: &Config{
@@ -109,6 +142,7 @@ fabric_ifaces:
 					AllowInsecure:     true,
 					CertificateConfig: DefaultConfig().TransportConfig.CertificateConfig,
 				},
+				CredentialConfig: security.DefaultCredentialConfig(),
 				FabricInterfaces: []*NUMAFabricConfig{
 					{
 						NUMANode: 0,
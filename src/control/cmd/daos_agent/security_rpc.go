@@ -17,16 +17,22 @@ import (
 
 // SecurityModule is the security drpc module struct
 type SecurityModule struct {
-	log    logging.Logger
-	ext    auth.UserExt
-	config *security.TransportConfig
+	log     logging.Logger
+	ext     auth.UserExt
+	config  *security.TransportConfig
+	credCfg *security.CredentialConfig
 }
 
-//NewSecurityModule creates a new module with the given initialized TransportConfig
-func NewSecurityModule(log logging.Logger, tc *security.TransportConfig) *SecurityModule {
+// NewSecurityModule creates a new module with the given initialized TransportConfig
+// and CredentialConfig. If no CredentialConfig is supplied, the default is used.
+func NewSecurityModule(log logging.Logger, tc *security.TransportConfig, cc *security.CredentialConfig) *SecurityModule {
+	if cc == nil {
+		cc = security.DefaultCredentialConfig()
+	}
 	mod := SecurityModule{
-		log:    log,
-		config: tc,
+		log:     log,
+		config:  tc,
+		credCfg: cc,
 	}
 	mod.ext = &auth.External{}
 	return &mod
@@ -62,7 +68,7 @@ func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
 		return m.credRespWithStatus(drpc.DaosInvalidInput)
 	}
 
-	cred, err := auth.AuthSysRequestFromCreds(m.ext, info, signingKey)
+	cred, err := auth.AuthSysRequestFromCreds(m.ext, info, signingKey, m.credCfg.Validity)
 	if err != nil {
 		m.log.Errorf("Failed to get AuthSys struct: %s", err)
 		return m.credRespWithStatus(drpc.DaosMiscError)
@@ -24,7 +24,7 @@ func TestAgentSecurityModule_ID(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, nil)
+	mod := NewSecurityModule(log, nil, nil)
 
 	common.AssertEqual(t, mod.ID(), drpc.ModuleSecurityAgent, "wrong drpc module")
 }
@@ -42,7 +42,7 @@ func TestAgentSecurityModule_BadMethod(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, nil)
+	mod := NewSecurityModule(log, nil, nil)
 	method, err := mod.ID().GetMethod(-1)
 	if method != nil {
 		t.Errorf("Expected no method, got %+v", method)
@@ -103,7 +103,7 @@ func TestAgentSecurityModule_RequestCreds_OK(t *testing.T) {
 	conn, cleanup := setupTestUnixConn(t)
 	defer cleanup()
 
-	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod := NewSecurityModule(log, defaultTestTransportConfig(), nil)
 	respBytes, err := callRequestCreds(mod, t, log, conn)
 
 	if err != nil {
@@ -117,7 +117,7 @@ func TestAgentSecurityModule_RequestCreds_NotUnixConn(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod := NewSecurityModule(log, defaultTestTransportConfig(), nil)
 	respBytes, err := callRequestCreds(mod, t, log, &net.TCPConn{})
 
 	common.CmpErr(t, drpc.NewFailureWithMessage("connection is not a unix socket"), err)
@@ -136,7 +136,7 @@ func TestAgentSecurityModule_RequestCreds_NotConnected(t *testing.T) {
 	defer cleanup()
 	conn.Close() // can't get uid/gid from a closed connection
 
-	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod := NewSecurityModule(log, defaultTestTransportConfig(), nil)
 	respBytes, err := callRequestCreds(mod, t, log, conn)
 
 	if err != nil {
@@ -155,7 +155,7 @@ func TestAgentSecurityModule_RequestCreds_BadConfig(t *testing.T) {
 	defer cleanup()
 
 	// Empty TransportConfig is incomplete
-	mod := NewSecurityModule(log, &security.TransportConfig{})
+	mod := NewSecurityModule(log, &security.TransportConfig{}, nil)
 	respBytes, err := callRequestCreds(mod, t, log, conn)
 
 	if err != nil {
@@ -173,7 +173,7 @@ func TestAgentSecurityModule_RequestCreds_BadUid(t *testing.T) {
 	conn, cleanup := setupTestUnixConn(t)
 	defer cleanup()
 
-	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod := NewSecurityModule(log, defaultTestTransportConfig(), nil)
 	mod.ext = &auth.MockExt{
 		LookupUserIDErr:  errors.New("LookupUserID"),
 		LookupGroupIDErr: errors.New("LookupGroupID"),
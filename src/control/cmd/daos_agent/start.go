@@ -16,10 +16,14 @@ import (
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
 )
 
 const (
 	agentSockName = "daos_agent.sock"
+	// certCheckInterval is the period between checks for expiring certificates.
+	certCheckInterval = 12 * time.Hour
 )
 
 type startCmd struct {
@@ -77,7 +81,7 @@ func (cmd *startCmd) Execute(_ []string) error {
 		fabricCache.Cache(ctx, nf)
 	}
 
-	drpcServer.RegisterRPCModule(NewSecurityModule(cmd.log, cmd.cfg.TransportConfig))
+	drpcServer.RegisterRPCModule(NewSecurityModule(cmd.log, cmd.cfg.TransportConfig, cmd.cfg.CredentialConfig))
 	drpcServer.RegisterRPCModule(&mgmtModule{
 		log:        cmd.log,
 		sys:        cmd.cfg.SystemName,
@@ -98,27 +102,35 @@ func (cmd *startCmd) Execute(_ []string) error {
 	cmd.log.Debugf("startup complete in %s", time.Since(startedAt))
 	cmd.log.Infof("%s (pid %d) listening on %s", versionString(), os.Getpid(), sockPath)
 
+	startCertMonitor(ctx, cmd.log, cmd.cfg.TransportConfig)
+
 	// Setup signal handlers so we can block till we get SIGINT or SIGTERM
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	finish := make(chan struct{})
 
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGPIPE)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGPIPE, syscall.SIGHUP)
 	// Anonymous goroutine to wait on the signals channel and tell the
 	// program to finish when it receives a signal. Since we notify on
 	// SIGINT and SIGTERM we should only catch these on a kill or ctrl+c
 	// SIGPIPE is caught and logged to avoid killing the agent.
+	// SIGHUP causes the agent's certificates to be reloaded.
 	// The syntax looks odd but <- Channel means wait on any input on the
 	// channel.
 	var shutdownRcvd time.Time
 	go func() {
-		sig := <-signals
-		switch sig {
-		case syscall.SIGPIPE:
-			cmd.log.Infof("Signal received.  Caught non-fatal %s; continuing", sig)
-		default:
-			shutdownRcvd = time.Now()
-			cmd.log.Infof("Signal received.  Caught %s; shutting down", sig)
-			close(finish)
+		for sig := range signals {
+			switch sig {
+			case syscall.SIGPIPE:
+				cmd.log.Infof("Signal received.  Caught non-fatal %s; continuing", sig)
+			case syscall.SIGHUP:
+				cmd.log.Infof("Signal received.  Caught %s; reloading certificates", sig)
+				reloadCerts(cmd.log, cmd.cfg.TransportConfig)
+			default:
+				shutdownRcvd = time.Now()
+				cmd.log.Infof("Signal received.  Caught %s; shutting down", sig)
+				close(finish)
+				return
+			}
 		}
 	}()
 	<-finish
@@ -127,3 +139,49 @@ func (cmd *startCmd) Execute(_ []string) error {
 	cmd.log.Debugf("shutdown complete in %s", time.Since(shutdownRcvd))
 	return nil
 }
+
+// checkCertExpiry logs a warning for each of the agent's certificates which
+// will expire within the configured warning period.
+func checkCertExpiry(log logging.Logger, tc *security.TransportConfig) {
+	expiring, err := tc.ExpiringCertificates(time.Now())
+	if err != nil {
+		log.Errorf("failed to check certificate expiry: %s", err)
+	}
+	for _, ci := range expiring {
+		log.Errorf("%s certificate %s expires on %s", ci.Name, ci.Path,
+			ci.NotAfter.Format(time.RFC3339))
+	}
+}
+
+// startCertMonitor periodically checks for certificates approaching expiry
+// until the context is canceled.
+func startCertMonitor(ctx context.Context, log logging.Logger, tc *security.TransportConfig) {
+	if tc == nil || tc.AllowInsecure {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(certCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			checkCertExpiry(log, tc)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reloadCerts reloads the agent's certificates so that they are used for
+// subsequent connections and credential signing.
+func reloadCerts(log logging.Logger, tc *security.TransportConfig) {
+	if err := tc.ReloadCertData(); err != nil {
+		log.Errorf("failed to reload certificates: %s", err)
+		return
+	}
+	checkCertExpiry(log, tc)
+}
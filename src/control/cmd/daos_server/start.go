@@ -8,21 +8,22 @@ package main
 
 import (
 	"os"
+	"syscall"
 
 	"github.com/pkg/errors"
 
-	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server"
 	"github.com/daos-stack/daos/src/control/server/config"
 )
 
-type serverStarter func(*logging.LeveledLogger, *config.Server) error
+type serverStarter func(*logging.LeveledLogger, *logging.LogFile, *config.Server) error
 
 type startCmd struct {
 	logCmd
 	cfgCmd
 	start               serverStarter
+	logFile             *logging.LogFile
 	Port                uint16  `short:"p" long:"port" description:"Port for the gRPC management interfect to listen on"`
 	MountPath           string  `short:"s" long:"storage" description:"Storage path"`
 	Modules             *string `short:"m" long:"modules" description:"List of server modules to load"`
@@ -110,26 +111,25 @@ func (cmd *startCmd) configureLogging() error {
 	}
 
 	// Set log file for default logger if specified in config.
-	if cmd.config.ControlLogFile != "" {
-		f, err := common.AppendFile(cmd.config.ControlLogFile)
-		if err != nil {
-			return errors.WithMessage(err, "create log file")
-		}
+	f, err := logging.OpenLogFile(cmd.config.ControlLogFile)
+	if err != nil {
+		return errors.WithMessage(err, "create log file")
+	}
+	cmd.logFile = f
 
+	if cmd.config.ControlLogFile != "" {
 		cmd.log.Infof("%s logging to file %s",
 			os.Args[0], cmd.config.ControlLogFile)
-		// Create an additional set of loggers which append everything
-		// to the specified file.
-		cmd.log = cmd.log.
-			WithErrorLogger(logging.NewErrorLogger(hostname, f)).
-			WithInfoLogger(logging.NewInfoLogger(hostname, f)).
-			WithDebugLogger(logging.NewDebugLogger(f))
-		applyLogConfig()
-
-		return nil
+	} else {
+		cmd.log.Info("no control log file specified; logging to stdout")
 	}
 
-	cmd.log.Info("no control log file specified; logging to stdout")
+	// Create an additional set of loggers which append everything to the
+	// log file. The file may be opened or changed on config reload.
+	cmd.log = cmd.log.
+		WithErrorLogger(logging.NewErrorLogger(hostname, f)).
+		WithInfoLogger(logging.NewInfoLogger(hostname, f)).
+		WithDebugLogger(logging.NewDebugLogger(f))
 	applyLogConfig()
 
 	return nil
@@ -144,5 +144,23 @@ func (cmd *startCmd) Execute(args []string) error {
 		return err
 	}
 
-	return cmd.start(cmd.log, cmd.config)
+	err := cmd.start(cmd.log, cmd.logFile, cmd.config)
+	if err == server.ErrRestartRequested {
+		return restartServer(cmd.log)
+	}
+
+	return err
+}
+
+// restartServer replaces the running process with a new instance of the
+// server, started with the same arguments and environment so that any CLI
+// overrides are retained and the config file is read again.
+func restartServer(log logging.Logger) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "restart server")
+	}
+	log.Infof("restarting %s", exe)
+
+	return errors.Wrap(syscall.Exec(exe, os.Args, os.Environ()), "restart server")
 }
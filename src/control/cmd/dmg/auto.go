@@ -8,6 +8,13 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -16,11 +23,18 @@ import (
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	configGenFileFmt = "daos_server.%d.yml"
+	configGenMapFile = "host_config_map.yml"
 )
 
 // configCmd is the struct representing the top-level config subcommand.
 type configCmd struct {
-	Generate configGenCmd `command:"generate" alias:"g" description:"Generate DAOS server configuration file based on discoverable hardware devices"`
+	Generate configGenCmd   `command:"generate" alias:"g" description:"Generate DAOS server configuration file based on discoverable hardware devices"`
+	Apply    configApplyCmd `command:"apply" alias:"a" description:"Validate a DAOS server configuration file on hosts and write it in place of their current config file"`
 }
 
 type configGenCmd struct {
@@ -29,28 +43,114 @@ type configGenCmd struct {
 	ctlInvokerCmd
 	hostListCmd
 	jsonOutputCmd
-	AccessPoints string `short:"a" long:"access-points" description:"Comma separated list of access point addresses <ipv4addr/hostname>"`
-	NrEngines    int    `short:"e" long:"num-engines" description:"Set the number of DAOS Engine sections to be populated in the config file output. If unset then the value will be set to the number of NUMA nodes on storage hosts in the DAOS system."`
-	MinNrSSDs    int    `default:"1" short:"s" long:"min-ssds" description:"Minimum number of NVMe SSDs required per DAOS Engine (SSDs must reside on the host that is managing the engine). Set to 0 to generate a config with no NVMe."`
-	NetClass     string `default:"best-available" short:"c" long:"net-class" description:"Network class preferred" choice:"best-available" choice:"ethernet" choice:"infiniband"`
+	AccessPoints string               `short:"a" long:"access-points" description:"Comma separated list of access point addresses <ipv4addr/hostname>"`
+	NrEngines    int                  `short:"e" long:"num-engines" description:"Set the number of DAOS Engine sections to be populated in the config file output. If unset then the value will be set to the number of NUMA nodes on storage hosts in the DAOS system."`
+	MinNrSSDs    int                  `default:"1" short:"s" long:"min-ssds" description:"Minimum number of NVMe SSDs required per DAOS Engine (SSDs must reside on the host that is managing the engine). Set to 0 to generate a config with no NVMe."`
+	NetClass     string               `default:"best-available" short:"c" long:"net-class" description:"Network class preferred" choice:"best-available" choice:"ethernet" choice:"infiniband"`
+	HelperRatio  float64              `long:"helper-ratio" description:"Number of helper threads per target (0-1). If unset then helper threads are allocated from the spare cores on each NUMA node."`
+	ScmClass     string               `default:"dcpm" long:"scm-class" description:"SCM class to use, ram emulates SCM on test clusters" choice:"dcpm" choice:"ram"`
+	ScmSize      int                  `long:"scm-size" description:"Size of ram SCM in GiB (default 16)"`
+	BdevClass    string               `default:"nvme" long:"bdev-class" description:"Bdev class to use, kdev and file emulate NVMe on test clusters" choice:"nvme" choice:"kdev" choice:"file"`
+	BdevList     string               `long:"bdev-list" description:"Comma separated list of kernel block devices to distribute between engines (kdev class)"`
+	BdevSize     int                  `long:"bdev-size" description:"Size of bdev backing files in GiB (file class, default 16)"`
+	Classes      []configGenClassFlag `long:"class" description:"Override parameters for hosts with identical hardware, e.g. hosts=node[1-4],engines=2,min-ssds=4,helper-ratio=0.25 (may be repeated)"`
+	OutputDir    string               `long:"output-dir" description:"Write a config file per hardware class and a host to config mapping file to this directory"`
+}
+
+// configGenClassFlag parses per-class parameter overrides in the form
+// hosts=<hostlist>[,engines=<n>][,min-ssds=<n>][,helper-ratio=<f>].
+type configGenClassFlag struct {
+	control.ConfigGenerateClassParams
+}
+
+// splitClassFlag splits on commas outside of hostlist brackets.
+func splitClassFlag(fv string) []string {
+	var fields []string
+	var depth, start int
+	for i, c := range fv {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				fields = append(fields, fv[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(fields, fv[start:])
+}
+
+// UnmarshalFlag implements the flags.Unmarshaler interface.
+func (f *configGenClassFlag) UnmarshalFlag(fv string) error {
+	for _, field := range splitClassFlag(fv) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return errors.Errorf("invalid class parameter %q (expected key=value)", field)
+		}
+
+		key, val := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "hosts":
+			f.HostList = val
+		case "engines", "min-ssds":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return errors.Errorf("invalid %s value %q", key, val)
+			}
+			if key == "engines" {
+				f.NrEngines = &n
+			} else {
+				f.MinNrSSDs = &n
+			}
+		case "helper-ratio":
+			r, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return errors.Errorf("invalid %s value %q", key, val)
+			}
+			f.HelperRatio = &r
+		default:
+			return errors.Errorf("unknown class parameter %q", key)
+		}
+	}
+
+	if f.HostList == "" {
+		return errors.New("class parameters require hosts to be specified")
+	}
+
+	return nil
 }
 
 // Execute is run when configGenCmd activates.
 //
-// Attempt to auto generate a server config file with populated storage and
-// network hardware parameters suitable to be used on all hosts in provided host
-// list.
+// Attempt to auto generate server config files with populated storage and
+// network hardware parameters, one for each set of hosts in the provided host
+// list with identical hardware.
 func (cmd *configGenCmd) Execute(_ []string) error {
 	ctx := context.Background()
 
 	cmd.log.Debugf("configGenCmd input control config: %+v", cmd.config)
 
 	req := control.ConfigGenerateReq{
-		NrEngines: cmd.NrEngines,
-		MinNrSSDs: cmd.MinNrSSDs,
-		HostList:  cmd.config.HostList,
-		Client:    cmd.ctlInvoker,
-		Log:       cmd.log,
+		NrEngines:   cmd.NrEngines,
+		MinNrSSDs:   cmd.MinNrSSDs,
+		HelperRatio: cmd.HelperRatio,
+		ScmClass:    storage.Class(cmd.ScmClass),
+		ScmSize:     cmd.ScmSize,
+		BdevClass:   storage.Class(cmd.BdevClass),
+		BdevSize:    cmd.BdevSize,
+		HostList:    cmd.config.HostList,
+		Client:      cmd.ctlInvoker,
+		Log:         cmd.log,
+	}
+	if cmd.BdevList != "" {
+		req.BdevList = strings.Split(cmd.BdevList, ",")
+	}
+	for i := range cmd.Classes {
+		req.ClassParams = append(req.ClassParams, &cmd.Classes[i].ConfigGenerateClassParams)
 	}
 	switch cmd.NetClass {
 	case "ethernet":
@@ -86,17 +186,217 @@ func (cmd *configGenCmd) Execute(_ []string) error {
 		return err
 	}
 
-	// includes hardware validation errors e.g. hardware across hostset differs
+	if cmd.OutputDir != "" {
+		return cmd.writeConfigs(resp)
+	}
+
+	// output recommended server config yaml file, preceded by the hosts it
+	// applies to if hardware differs between hosts
+	var bld strings.Builder
+	for i, class := range resp.Classes {
+		bytes, err := yaml.Marshal(class.Config)
+		if err != nil {
+			return err
+		}
+		if len(resp.Classes) > 1 {
+			if i > 0 {
+				bld.WriteString("---\n")
+			}
+			fmt.Fprintf(&bld, "# hosts: %s\n", class.HostSet)
+		}
+		bld.Write(bytes)
+	}
+
+	cmd.log.Info(bld.String())
+	return nil
+}
+
+// stripPort returns the host address without any port.
+func stripPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+// writeConfigs writes a config file for each hardware class to the output
+// directory along with a file mapping each host to its config file.
+func (cmd *configGenCmd) writeConfigs(resp *control.ConfigGenerateResp) error {
+	if err := os.MkdirAll(cmd.OutputDir, 0755); err != nil {
+		return errors.Wrap(err, "create output directory")
+	}
+
+	hostMap := make(map[string]string)
+	for i, class := range resp.Classes {
+		bytes, err := yaml.Marshal(class.Config)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(cmd.OutputDir, fmt.Sprintf(configGenFileFmt, i))
+		if err := ioutil.WriteFile(path, bytes, 0644); err != nil {
+			return errors.Wrapf(err, "write config file %s", path)
+		}
+		cmd.log.Infof("Wrote config for hosts %s to %s", class.HostSet, path)
+
+		// paths in the map are relative to the output directory
+		for _, addr := range class.HostSet.Slice() {
+			hostMap[stripPort(addr)] = filepath.Base(path)
+		}
+	}
+
+	bytes, err := yaml.Marshal(hostMap)
 	if err != nil {
 		return err
 	}
+	path := filepath.Join(cmd.OutputDir, configGenMapFile)
+	if err := ioutil.WriteFile(path, bytes, 0644); err != nil {
+		return errors.Wrapf(err, "write host config map %s", path)
+	}
+	cmd.log.Infof("Wrote host to config mapping to %s", path)
+
+	return nil
+}
 
-	bytes, err := yaml.Marshal(resp.ConfigOut)
+// configApplyCmd is the struct representing the command to push a server
+// config file to a set of hosts.
+type configApplyCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+	File    string `short:"f" long:"file" description:"Server config file to apply to hosts in the hostlist"`
+	HostMap string `short:"m" long:"host-map" description:"Host to config mapping file written by 'config generate --output-dir', each host is sent its mapped config"`
+	DryRun  bool   `short:"n" long:"dry-run" description:"Validate the config on each host without writing it"`
+	Restart bool   `short:"r" long:"restart" description:"Restart daos_server on each host after the config is written"`
+}
+
+// readHostMap reads a host to config mapping file and returns the hosts
+// grouped by config file path. Relative paths are taken to be relative to the
+// directory containing the mapping file.
+func readHostMap(path string) (map[string][]string, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, "read host config map")
+	}
+
+	hostMap := make(map[string]string)
+	if err := yaml.UnmarshalStrict(data, &hostMap); err != nil {
+		return nil, errors.Wrapf(err, "parse host config map %s", path)
+	}
+	if len(hostMap) == 0 {
+		return nil, errors.Errorf("no hosts in host config map %s", path)
+	}
+
+	fileHosts := make(map[string][]string)
+	for host, cfgPath := range hostMap {
+		if !filepath.IsAbs(cfgPath) {
+			cfgPath = filepath.Join(filepath.Dir(path), cfgPath)
+		}
+		fileHosts[cfgPath] = append(fileHosts[cfgPath], host)
+	}
+	for _, hosts := range fileHosts {
+		sort.Strings(hosts)
+	}
+
+	return fileHosts, nil
+}
+
+// mergeApplyConfigResp adds the results and host errors from one response to
+// another.
+func mergeApplyConfigResp(dst, src *control.ApplyConfigResp) error {
+	dst.HostResults = append(dst.HostResults, src.HostResults...)
+	for _, hes := range src.HostErrors {
+		if dst.HostErrors == nil {
+			dst.HostErrors = make(control.HostErrorsMap)
+		}
+		for _, addr := range hes.HostSet.Slice() {
+			if err := dst.HostErrors.Add(addr, hes.HostError); err != nil {
+				return err
+			}
+		}
 	}
 
-	// output recommended server config yaml file
-	cmd.log.Info(string(bytes))
 	return nil
 }
+
+// Execute is run when configApplyCmd activates.
+//
+// Send the config file to each host in the hostlist, or the config files
+// listed in the mapping file to their respective hosts, to be validated against
+// the host hardware and written in place of the current config file.
+func (cmd *configApplyCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "config apply failed")
+	}()
+
+	fileHosts := make(map[string][]string)
+	switch {
+	case cmd.File != "" && cmd.HostMap != "":
+		return errors.New("--file and --host-map may not be combined")
+	case cmd.File != "":
+		fileHosts[cmd.File] = cmd.hostlist
+	case cmd.HostMap != "":
+		if len(cmd.hostlist) != 0 {
+			return errors.New("--host-map may not be combined with a hostlist")
+		}
+		var err error
+		if fileHosts, err = readHostMap(cmd.HostMap); err != nil {
+			return err
+		}
+	default:
+		return errors.New("one of --file or --host-map must be specified")
+	}
+
+	paths := make([]string, 0, len(fileHosts))
+	for path := range fileHosts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	resp := &control.ApplyConfigResp{DryRun: cmd.DryRun}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "read config file")
+		}
+
+		req := &control.ApplyConfigReq{
+			Config:  data,
+			DryRun:  cmd.DryRun,
+			Restart: cmd.Restart,
+		}
+		req.SetHostList(fileHosts[path])
+
+		cmd.log.Debugf("applying %s to hosts %v", path, fileHosts[path])
+		fileResp, err := control.ApplyConfig(context.Background(), cmd.ctlInvoker, req)
+		if err != nil {
+			return errors.Wrap(err, path) // control api returned an error, disregard response
+		}
+		if err := mergeApplyConfigResp(resp, fileResp); err != nil {
+			return err
+		}
+	}
+	sort.Slice(resp.HostResults, func(i, j int) bool {
+		return resp.HostResults[i].Addr < resp.HostResults[j].Addr
+	})
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if err := pretty.PrintApplyConfigResp(resp, &out); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+	cmd.log.Info(out.String())
+
+	return resp.Errors()
+}
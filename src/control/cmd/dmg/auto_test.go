@@ -7,11 +7,16 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
 
@@ -89,6 +94,54 @@ func TestDmg_ConfigCommands(t *testing.T) {
 			}, " "),
 			errors.New("Invalid value"),
 		},
+		{
+			"Generate with emulated storage classes",
+			"config generate -a foo --scm-class ram --scm-size 8 --bdev-class file --bdev-size 4",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate with kdev bdev class",
+			"config generate -a foo --bdev-class kdev --bdev-list /dev/sda,/dev/sdb",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate with kdev bdev class and no bdev list",
+			"config generate -a foo --bdev-class kdev",
+			"",
+			errors.New("insufficient number of kdev devices"),
+		},
+		{
+			"Generate with unsupported scm class",
+			"config generate -a foo --scm-class nvme",
+			"",
+			errors.New("Invalid value"),
+		},
+		{
+			"Generate with helper ratio and class parameters",
+			"config generate -a foo --helper-ratio 0.5 --class hosts=node[1,3],engines=1 --class hosts=node2,min-ssds=0,helper-ratio=0.25",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate with invalid helper ratio",
+			"config generate -a foo --helper-ratio 2",
+			"",
+			errors.New("helper ratio should be between 0 and 1"),
+		},
+		{
+			"Generate with class parameters missing hosts",
+			"config generate -a foo --class engines=1",
+			"",
+			errors.New("require hosts"),
+		},
 		{
 			"Nonexistent subcommand",
 			"network quack",
@@ -97,3 +150,148 @@ func TestDmg_ConfigCommands(t *testing.T) {
 		},
 	})
 }
+
+func TestDmg_configGenClassFlag(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	for name, tc := range map[string]struct {
+		flag      string
+		expParams control.ConfigGenerateClassParams
+		expErr    error
+	}{
+		"hosts only": {
+			flag:      "hosts=node[1-4]",
+			expParams: control.ConfigGenerateClassParams{HostList: "node[1-4]"},
+		},
+		"all parameters": {
+			flag: "hosts=node[1,3-4],engines=2,min-ssds=0,helper-ratio=0.25",
+			expParams: control.ConfigGenerateClassParams{
+				HostList:    "node[1,3-4]",
+				NrEngines:   intPtr(2),
+				MinNrSSDs:   intPtr(0),
+				HelperRatio: floatPtr(0.25),
+			},
+		},
+		"missing hosts": {
+			flag:   "engines=2",
+			expErr: errors.New("require hosts"),
+		},
+		"missing value": {
+			flag:   "hosts=node1,engines",
+			expErr: errors.New("invalid class parameter"),
+		},
+		"invalid engines": {
+			flag:   "hosts=node1,engines=two",
+			expErr: errors.New("invalid engines value"),
+		},
+		"unknown parameter": {
+			flag:   "hosts=node1,targets=8",
+			expErr: errors.New("unknown class parameter"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var f configGenClassFlag
+			gotErr := f.UnmarshalFlag(tc.flag)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expParams, f.ConfigGenerateClassParams); diff != "" {
+				t.Fatalf("unexpected class params (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDmg_ConfigApplyCommand(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(testDir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	cfg0 := "name: daos_server\nport: 10001\n"
+	cfg1 := "name: daos_server\nport: 10002\n"
+	cfg0Path := writeFile("daos_server.0.yml", cfg0)
+	writeFile("daos_server.1.yml", cfg1)
+	badCfgPath := writeFile("bad.yml", "foo: bar\n")
+	mapPath := writeFile(configGenMapFile,
+		"host1: daos_server.0.yml\nhost2: daos_server.1.yml\nhost3: daos_server.0.yml\n")
+	emptyMapPath := writeFile("empty_map.yml", "")
+
+	applyReq := func(content string, dryRun, restart bool, hosts ...string) control.UnaryRequest {
+		req := &control.ApplyConfigReq{
+			Config:  []byte(content),
+			DryRun:  dryRun,
+			Restart: restart,
+		}
+		req.SetHostList(hosts)
+		return req
+	}
+
+	runCmdTests(t, []cmdTest{
+		{
+			"Apply with no file or host map",
+			"config apply",
+			"",
+			errors.New("one of --file or --host-map"),
+		},
+		{
+			"Apply with file and host map",
+			fmt.Sprintf("config apply -f %s -m %s", cfg0Path, mapPath),
+			"",
+			errors.New("may not be combined"),
+		},
+		{
+			"Apply with host map and hostlist",
+			fmt.Sprintf("config apply -l host1 -m %s", mapPath),
+			"",
+			errors.New("may not be combined with a hostlist"),
+		},
+		{
+			"Apply with missing file",
+			fmt.Sprintf("config apply -f %s", filepath.Join(testDir, "missing.yml")),
+			"",
+			errors.New("no such file"),
+		},
+		{
+			"Apply with invalid config",
+			fmt.Sprintf("config apply -f %s", badCfgPath),
+			"",
+			errors.New("parse config"),
+		},
+		{
+			"Apply with empty host map",
+			fmt.Sprintf("config apply -m %s", emptyMapPath),
+			"",
+			errors.New("no hosts"),
+		},
+		{
+			"Apply file",
+			fmt.Sprintf("config apply -l host1,host2 -f %s", cfg0Path),
+			printRequest(t, applyReq(cfg0, false, false, "host1", "host2")),
+			nil,
+		},
+		{
+			"Apply file dry run",
+			fmt.Sprintf("config apply -f %s --dry-run", cfg0Path),
+			printRequest(t, applyReq(cfg0, true, false)),
+			nil,
+		},
+		{
+			"Apply host map with restart",
+			fmt.Sprintf("config apply -m %s --restart", mapPath),
+			strings.Join([]string{
+				printRequest(t, applyReq(cfg0, false, true, "host1", "host3")),
+				printRequest(t, applyReq(cfg1, false, true, "host2")),
+			}, " "),
+			nil,
+		},
+	})
+}
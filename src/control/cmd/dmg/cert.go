@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"time"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// certCmd is the struct representing the top-level cert subcommand.
+type certCmd struct {
+	InitCA    certInitCACmd    `command:"init-ca" description:"Create a local certificate authority for issuing DAOS certificates"`
+	Issue     certIssueCmd     `command:"issue" description:"Issue a certificate and key for a DAOS component"`
+	Revoke    certRevokeCmd    `command:"revoke" description:"Revoke a certificate issued by the local certificate authority"`
+	UpdateCRL certUpdateCRLCmd `command:"update-crl" description:"Re-sign the certificate revocation list of the local certificate authority before it expires"`
+}
+
+type certBaseCmd struct {
+	localCmd
+	logCmd
+	jsonOutputCmd
+	CADir string `short:"c" long:"ca-dir" default:"daosCA" description:"Directory containing the certificate authority"`
+	Days  int    `long:"days" default:"365" description:"Number of days the certificate will be valid"`
+}
+
+func (cmd *certBaseCmd) validity() time.Duration {
+	return time.Duration(cmd.Days) * 24 * time.Hour
+}
+
+// certInitCACmd is the struct representing the command to create a CA.
+type certInitCACmd struct {
+	certBaseCmd
+}
+
+// Execute is run when certInitCACmd activates.
+func (cmd *certInitCACmd) Execute(_ []string) error {
+	ca, err := security.InitCA(cmd.CADir, cmd.validity())
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(map[string]string{
+			"ca_cert": ca.CertPath(),
+			"ca_key":  ca.KeyPath(),
+			"crl":     ca.CRLPath(),
+		}, nil)
+	}
+
+	cmd.log.Infof("Created certificate authority in %s:\n  %s\n  %s\n  %s",
+		cmd.CADir, ca.CertPath(), ca.KeyPath(), ca.CRLPath())
+	return nil
+}
+
+// certIssueCmd is the struct representing the command to issue a certificate.
+type certIssueCmd struct {
+	certBaseCmd
+	Component string   `short:"t" long:"component" required:"1" choice:"server" choice:"agent" choice:"admin" description:"Component the certificate will identify"`
+	OutputDir string   `short:"O" long:"output-dir" default:"." description:"Directory in which to write the certificate and key"`
+	Roles     []string `short:"r" long:"role" description:"Role granted to an admin certificate (may be repeated)"`
+}
+
+// Execute is run when certIssueCmd activates.
+func (cmd *certIssueCmd) Execute(_ []string) error {
+	ca, err := security.LoadCA(cmd.CADir)
+	if err != nil {
+		return err
+	}
+
+	ci, err := ca.Issue(security.CommonNameToComponent(cmd.Component), cmd.OutputDir, cmd.validity(), cmd.Roles...)
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(ci, nil)
+	}
+
+	cmd.log.Infof("Issued %s certificate %s (expires %s)", cmd.Component, ci.Path,
+		ci.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// certRevokeCmd is the struct representing the command to revoke a certificate.
+type certRevokeCmd struct {
+	localCmd
+	logCmd
+	jsonOutputCmd
+	CADir string `short:"c" long:"ca-dir" default:"daosCA" description:"Directory containing the certificate authority"`
+	Args  struct {
+		CertPath string `positional-arg-name:"<certificate file>" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when certRevokeCmd activates.
+func (cmd *certRevokeCmd) Execute(_ []string) error {
+	ca, err := security.LoadCA(cmd.CADir)
+	if err != nil {
+		return err
+	}
+
+	ci, err := ca.Revoke(cmd.Args.CertPath)
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(ci, nil)
+	}
+
+	cmd.log.Infof("Revoked certificate %s (%s); distribute %s to all hosts and reload their certificates",
+		ci.Path, ci.Subject, ca.CRLPath())
+	return nil
+}
+
+// certUpdateCRLCmd is the struct representing the command to update the CRL.
+type certUpdateCRLCmd struct {
+	localCmd
+	logCmd
+	jsonOutputCmd
+	CADir string `short:"c" long:"ca-dir" default:"daosCA" description:"Directory containing the certificate authority"`
+}
+
+// Execute is run when certUpdateCRLCmd activates.
+func (cmd *certUpdateCRLCmd) Execute(_ []string) error {
+	ca, err := security.LoadCA(cmd.CADir)
+	if err != nil {
+		return err
+	}
+
+	nextUpdate, err := ca.UpdateCRL()
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(map[string]string{
+			"crl":         ca.CRLPath(),
+			"next_update": nextUpdate.Format(time.RFC3339),
+		}, nil)
+	}
+
+	cmd.log.Infof("Updated %s (next update due %s); distribute it to all hosts and reload their certificates",
+		ca.CRLPath(), nextUpdate.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,133 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func TestDmg_CertCommands(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	caDir := filepath.Join(tmpDir, "ca")
+	certDir := filepath.Join(tmpDir, "certs")
+	agentCert := filepath.Join(certDir, "agent.crt")
+
+	for _, tc := range []struct {
+		name   string
+		cmd    string
+		expErr error
+	}{
+		{
+			name:   "issue without CA",
+			cmd:    fmt.Sprintf("cert issue -c %s -t agent -O %s", caDir, certDir),
+			expErr: errors.New("no such file"),
+		},
+		{
+			name: "init CA",
+			cmd:  fmt.Sprintf("cert init-ca -c %s --days 30", caDir),
+		},
+		{
+			name:   "init CA again",
+			cmd:    fmt.Sprintf("cert init-ca -c %s", caDir),
+			expErr: errors.New("already exists"),
+		},
+		{
+			name:   "issue invalid component",
+			cmd:    fmt.Sprintf("cert issue -c %s -t client -O %s", caDir, certDir),
+			expErr: errors.New("Invalid value"),
+		},
+		{
+			name:   "issue missing component",
+			cmd:    fmt.Sprintf("cert issue -c %s -O %s", caDir, certDir),
+			expErr: errors.New("required flag"),
+		},
+		{
+			name: "issue agent",
+			cmd:  fmt.Sprintf("cert issue -c %s -t agent -O %s", caDir, certDir),
+		},
+		{
+			name: "issue admin with roles",
+			cmd:  fmt.Sprintf("cert issue -c %s -t admin -O %s -r read-only -r operator", caDir, certDir),
+		},
+		{
+			name:   "issue server with roles",
+			cmd:    fmt.Sprintf("cert issue -c %s -t server -O %s -r read-only", caDir, certDir),
+			expErr: errors.New("roles may only be granted"),
+		},
+		{
+			name:   "issue agent again",
+			cmd:    fmt.Sprintf("cert issue -c %s -t agent -O %s", caDir, certDir),
+			expErr: errors.New("already exists"),
+		},
+		{
+			name: "revoke agent",
+			cmd:  fmt.Sprintf("cert revoke -c %s %s", caDir, agentCert),
+		},
+		{
+			name:   "revoke agent again",
+			cmd:    fmt.Sprintf("cert revoke -c %s %s", caDir, agentCert),
+			expErr: errors.New("already revoked"),
+		},
+		{
+			name:   "revoke missing certificate argument",
+			cmd:    fmt.Sprintf("cert revoke -c %s", caDir),
+			expErr: errors.New("required argument"),
+		},
+		{
+			name: "update CRL",
+			cmd:  fmt.Sprintf("cert update-crl -c %s", caDir),
+		},
+		{
+			name:   "update CRL without CA",
+			cmd:    fmt.Sprintf("cert update-crl -c %s", filepath.Join(tmpDir, "missing")),
+			expErr: errors.New("no such file"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			err := runCmd(t, tc.cmd, log, control.DefaultMockInvoker(log))
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+
+	for _, path := range []string{
+		filepath.Join(caDir, security.CACertFileName),
+		filepath.Join(caDir, security.CAKeyFileName),
+		filepath.Join(caDir, security.CRLFileName),
+		filepath.Join(certDir, security.CACertFileName),
+		filepath.Join(certDir, "agent.key"),
+		agentCert,
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	adminCert, err := security.LoadCertificate(filepath.Join(certDir, "admin.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roles, err := security.CertificateRoles(adminCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, roles, []string{"read-only", "operator"}, "unexpected admin roles")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -138,6 +139,8 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemQueryResp{})
 	case *control.LeaderQueryReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.LeaderQueryResp{})
+	case *control.SystemConfigCheckReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemConfigCheckResp{})
 	case *control.ListPoolsReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.ListPoolsResp{})
 	case *control.ContSetOwnerReq:
@@ -155,6 +158,21 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolExtendResp{})
 	case *control.PoolReintegrateReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolReintegrateResp{})
+	case *control.AuditQueryReq:
+		if req.SystemDB {
+			resp = control.MockMSResponse("", nil, &ctlpb.AuditQueryResp{})
+		}
+	case *control.CrashFetchReq:
+		resp.Responses = []*control.HostResponse{
+			{
+				Addr: "host1",
+				Message: &ctlpb.CollectCrashResp{
+					Archives: []*ctlpb.CrashArchive{
+						{Name: "daos_engine0-crash.tar.gz"},
+					},
+				},
+			},
+		}
 	}
 
 	return resp, nil
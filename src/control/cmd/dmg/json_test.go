@@ -10,7 +10,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -61,6 +63,10 @@ func TestDmg_JsonOutput(t *testing.T) {
 	defer cleanup()
 	aclContent := "A::OWNER@:rw\nA::user1@:rw\nA:g:group1@:r\n"
 	aclPath := common.CreateTestFile(t, testDir, aclContent)
+	srvCfgPath := filepath.Join(testDir, "daos_server.yml")
+	if err := ioutil.WriteFile(srvCfgPath, []byte("name: daos_server\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
 
 	for _, args := range cmdArgs {
 		t.Run(strings.Join(args, " "), func(t *testing.T) {
@@ -69,6 +75,12 @@ func TestDmg_JsonOutput(t *testing.T) {
 			case "version", "telemetry config", "telemetry run", "config generate",
 				"manpage":
 				return
+			case "server collect-crash":
+				testArgs = append(testArgs, []string{"--host", "foo", "-o", testDir}...)
+			case "support collect-log":
+				testArgs = append(testArgs, []string{"-o", filepath.Join(testDir, "support.tar")}...)
+			case "config apply":
+				testArgs = append(testArgs, []string{"-f", srvCfgPath}...)
 			case "storage prepare":
 				testArgs = append(testArgs, "--force")
 			case "storage query target-health":
@@ -99,6 +111,16 @@ func TestDmg_JsonOutput(t *testing.T) {
 				testArgs = append(testArgs, []string{common.MockUUID(), "--rank", "0"}...)
 			case "container set-owner":
 				testArgs = append(testArgs, []string{"--user", "foo", "--pool", common.MockUUID(), "--cont", common.MockUUID()}...)
+			case "cert init-ca":
+				testArgs = append(testArgs, []string{"-c", filepath.Join(testDir, "ca")}...)
+			case "cert issue":
+				testArgs = append(testArgs, []string{"-c", filepath.Join(testDir, "ca"), "-t", "agent",
+					"-O", filepath.Join(testDir, "certs")}...)
+			case "cert revoke":
+				testArgs = append(testArgs, []string{"-c", filepath.Join(testDir, "ca"),
+					filepath.Join(testDir, "certs", "agent.crt")}...)
+			case "cert update-crl":
+				testArgs = append(testArgs, []string{"-c", filepath.Join(testDir, "ca")}...)
 			case "telemetry metrics list", "telemetry metrics query":
 				return // These commands query via http directly
 			}
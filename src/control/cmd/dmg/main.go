@@ -134,6 +134,17 @@ func (c *logCmd) setLog(log *logging.LeveledLogger) {
 	c.log = log
 }
 
+// localRunner is implemented by commands which operate only on local files
+// and do not need the control config or transport certificates to be loaded.
+type localRunner interface {
+	runsLocally()
+}
+
+// localCmd is embedded in commands which run locally.
+type localCmd struct{}
+
+func (localCmd) runsLocally() {}
+
 // cmdConfigSetter is an interface for setting the control config on a command
 type cmdConfigSetter interface {
 	setConfig(*control.Config)
@@ -166,6 +177,8 @@ type cliOptions struct {
 	Cont           ContCmd       `command:"container" alias:"cont" description:"Perform tasks related to DAOS containers"`
 	Version        versionCmd    `command:"version" description:"Print dmg version"`
 	Telemetry      telemCmd      `command:"telemetry" alias:"telem" description:"Perform telemetry operations"`
+	Cert           certCmd       `command:"cert" description:"Manage the certificates used to secure DAOS components"`
+	Support        supportCmd    `command:"support" description:"Collect logs and diagnostics for troubleshooting"`
 	firmwareOption               // build with tag "firmware" to enable
 	ManPage        common.ManCmd `command:"manpage" hidden:"true"`
 }
@@ -234,6 +247,12 @@ and access control settings, along with system wide operations.`
 			logCmd.setLog(log)
 		}
 
+		if _, ok := cmd.(localRunner); ok {
+			// Local commands do not connect to servers, so skip
+			// loading the control config and certificates.
+			return cmd.Execute(args)
+		}
+
 		ctlCfg, err := control.LoadConfig(opts.ConfigPath)
 		if err != nil {
 			if errors.Cause(err) != control.ErrNoConfigFile {
@@ -0,0 +1,75 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// auditFieldsString returns the key request fields of an audit record as a
+// sorted list of key=value pairs.
+func auditFieldsString(rec *control.AuditRecord) string {
+	fields := make([]string, 0, len(rec.Fields))
+	for key, val := range rec.Fields {
+		fields = append(fields, fmt.Sprintf("%s=%s", key, val))
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, " ")
+}
+
+// PrintAuditQueryResp generates a human-readable representation of the audit
+// records in the supplied response and writes it to the supplied io.Writer.
+func PrintAuditQueryResp(resp *control.AuditQueryResp, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.Records) == 0 {
+		fmt.Fprintln(out, "No audit records found")
+		return nil
+	}
+
+	timeTitle := "Time"
+	hostTitle := "Host"
+	callerTitle := "Caller"
+	methodTitle := "Method"
+	fieldsTitle := "Fields"
+	outcomeTitle := "Outcome"
+	durationTitle := "Duration"
+
+	formatter := txtfmt.NewTableFormatter(timeTitle, hostTitle, callerTitle, methodTitle,
+		fieldsTitle, outcomeTitle, durationTitle)
+	var table []txtfmt.TableRow
+
+	for _, rec := range resp.Records {
+		caller := rec.Caller
+		if len(rec.Roles) > 0 {
+			caller = fmt.Sprintf("%s (%s)", caller, strings.Join(rec.Roles, ","))
+		}
+		outcome := rec.Outcome
+		if rec.Error != "" {
+			outcome = fmt.Sprintf("%s: %s", outcome, rec.Error)
+		}
+
+		table = append(table, txtfmt.TableRow{
+			timeTitle:     rec.Time.UTC().Format(time.RFC3339),
+			hostTitle:     getPrintHosts(rec.Host, opts...),
+			callerTitle:   caller,
+			methodTitle:   rec.Method,
+			fieldsTitle:   auditFieldsString(rec),
+			outcomeTitle:  outcome,
+			durationTitle: rec.Duration.Round(time.Millisecond).String(),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
@@ -0,0 +1,77 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintAuditQueryResp(t *testing.T) {
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.AuditQueryResp
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.AuditQueryResp{},
+			expPrintStr: `
+No audit records found
+`,
+		},
+		"mixed results": {
+			resp: &control.AuditQueryResp{
+				Records: []*control.AuditRecord{
+					{
+						Time:     start,
+						Host:     "host1",
+						Caller:   "admin",
+						Roles:    []string{"operator"},
+						Method:   "/mgmt.MgmtSvc/SystemStop",
+						Fields:   map[string]string{"ranks": "0-1", "force": "true"},
+						Outcome:  "success",
+						Duration: 1500 * time.Microsecond,
+					},
+					{
+						Time:     start.Add(time.Minute),
+						Host:     "host2",
+						Caller:   "admin",
+						Method:   "/mgmt.MgmtSvc/PoolDestroy",
+						Fields:   map[string]string{"id": "pool1"},
+						Outcome:  "failure",
+						Error:    "denied",
+						Duration: time.Second,
+					},
+				},
+			},
+			expPrintStr: `
+Time                 Host  Caller           Method                    Fields               Outcome         Duration 
+----                 ----  ------           ------                    ------               -------         -------- 
+2021-06-01T00:00:00Z host1 admin (operator) /mgmt.MgmtSvc/SystemStop  force=true ranks=0-1 success         2ms      
+2021-06-01T00:01:00Z host2 admin            /mgmt.MgmtSvc/PoolDestroy id=pool1             failure: denied 1s       
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintAuditQueryResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// certDaysLeft returns a description of the time remaining before a
+// certificate expires.
+func certDaysLeft(ci *control.CertInfo, now time.Time) string {
+	if !ci.NotAfter.After(now) {
+		return "expired"
+	}
+	return fmt.Sprintf("%d", int(math.Floor(ci.NotAfter.Sub(now).Hours()/24)))
+}
+
+// PrintCertQueryResp generates a human-readable representation of the
+// certificate expiry details in the supplied response, relative to the
+// given time, and writes it to the supplied io.Writer.
+func PrintCertQueryResp(resp *control.CertQueryResp, now time.Time, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.HostCerts) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	nameTitle := "Certificate"
+	subjectTitle := "Subject"
+	expiresTitle := "Expires"
+	daysTitle := "Days Left"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, nameTitle, subjectTitle, expiresTitle, daysTitle)
+	var table []txtfmt.TableRow
+
+	for _, hc := range resp.HostCerts {
+		host := getPrintHosts(hc.Addr, opts...)
+
+		if hc.Insecure {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:    host,
+				nameTitle:    "-",
+				subjectTitle: "transport security disabled",
+				expiresTitle: "-",
+				daysTitle:    "-",
+			})
+			continue
+		}
+
+		for _, ci := range hc.Certs {
+			row := txtfmt.TableRow{
+				hostTitle: host,
+				nameTitle: ci.Name,
+			}
+			if ci.Error != "" {
+				row[subjectTitle] = fmt.Sprintf("error: %s", ci.Error)
+				row[expiresTitle] = "-"
+				row[daysTitle] = "-"
+			} else {
+				row[subjectTitle] = ci.Subject
+				row[expiresTitle] = ci.NotAfter.UTC().Format(time.RFC3339)
+				row[daysTitle] = certDaysLeft(ci, now)
+			}
+			table = append(table, row)
+		}
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
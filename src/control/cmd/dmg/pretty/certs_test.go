@@ -0,0 +1,84 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintCertQueryResp(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.CertQueryResp
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.CertQueryResp{},
+		},
+		"mixed results": {
+			resp: &control.CertQueryResp{
+				HostCerts: []*control.HostCerts{
+					{
+						Addr: "host1:10001",
+						Certs: []*control.CertInfo{
+							{
+								Name:     "ca_cert",
+								Subject:  "CN=DAOS CA,O=DAOS",
+								NotAfter: now.Add(-time.Hour),
+							},
+							{
+								Name:     "cert",
+								Subject:  "CN=server,O=DAOS",
+								NotAfter: now.Add(30*24*time.Hour + time.Hour),
+							},
+						},
+					},
+					{
+						Addr: "host2:10001",
+						Certs: []*control.CertInfo{
+							{
+								Name:  "cert",
+								Error: "no such file",
+							},
+						},
+					},
+					{
+						Addr:     "host3:10001",
+						Insecure: true,
+					},
+				},
+			},
+			expPrintStr: `
+Host  Certificate Subject                     Expires              Days Left 
+----  ----------- -------                     -------              --------- 
+host1 ca_cert     CN=DAOS CA,O=DAOS           2021-05-31T23:00:00Z expired   
+host1 cert        CN=server,O=DAOS            2021-07-01T01:00:00Z 30        
+host2 cert        error: no such file         -                    -         
+host3 -           transport security disabled -                    -         
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintCertQueryResp(tc.resp, now, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
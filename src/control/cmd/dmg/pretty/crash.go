@@ -0,0 +1,48 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// PrintCrashListResp generates a human-readable representation of the crash
+// archives in the supplied response and writes it to the supplied io.Writer.
+func PrintCrashListResp(resp *control.CrashListResp, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.Archives) == 0 {
+		fmt.Fprintln(out, "No crash archives found")
+		return nil
+	}
+
+	hostTitle := "Host"
+	nameTitle := "Name"
+	timeTitle := "Collected"
+	sizeTitle := "Size"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, nameTitle, timeTitle, sizeTitle)
+	var table []txtfmt.TableRow
+
+	for _, archive := range resp.Archives {
+		table = append(table, txtfmt.TableRow{
+			hostTitle: getPrintHosts(archive.Host, opts...),
+			nameTitle: archive.Name,
+			timeTitle: archive.Time.UTC().Format(time.RFC3339),
+			sizeTitle: humanize.IBytes(archive.Size),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
@@ -0,0 +1,69 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintCrashListResp(t *testing.T) {
+	ts := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp   *control.CrashListResp
+		expOut string
+	}{
+		"no archives": {
+			resp: &control.CrashListResp{},
+			expOut: `
+No crash archives found
+`,
+		},
+		"archives": {
+			resp: &control.CrashListResp{
+				Archives: []*control.CrashArchive{
+					{
+						Host: "host1:10001",
+						Name: "daos_engine0-crash-20210601-120000.000000.tar.gz",
+						Time: ts,
+						Size: 2048,
+					},
+					{
+						Host: "host2:10001",
+						Name: "daos_engine1-crash-20210601-120100.000000.tar.gz",
+						Time: ts.Add(time.Minute),
+						Size: 3 * 1024 * 1024,
+					},
+				},
+			},
+			expOut: `
+Host  Name                                             Collected            Size    
+----  ----                                             ---------            ----    
+host1 daos_engine0-crash-20210601-120000.000000.tar.gz 2021-06-01T12:00:00Z 2.0 KiB 
+host2 daos_engine1-crash-20210601-120100.000000.tar.gz 2021-06-01T12:01:00Z 3.0 MiB 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintCrashListResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expOut, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
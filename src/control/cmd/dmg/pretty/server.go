@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// configChangeResult returns a description of the action taken for a changed
+// config parameter.
+func configChangeResult(cc *control.ConfigChange) string {
+	switch cc.Action {
+	case "applied":
+		return "applied"
+	case "engine_restart":
+		return "requires engine restart"
+	case "server_restart":
+		return "requires server restart"
+	case "failed":
+		return fmt.Sprintf("failed: %s", cc.Error)
+	default:
+		return cc.Action
+	}
+}
+
+// PrintReloadConfigResp generates a human-readable representation of the
+// config changes found on each host in the supplied response and writes it
+// to the supplied io.Writer.
+func PrintReloadConfigResp(resp *control.ReloadConfigResp, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.HostChanges) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	fieldTitle := "Parameter"
+	resultTitle := "Result"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, fieldTitle, resultTitle)
+	var table []txtfmt.TableRow
+
+	for _, hc := range resp.HostChanges {
+		host := getPrintHosts(hc.Addr, opts...)
+
+		if len(hc.Changes) == 0 {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   host,
+				fieldTitle:  "-",
+				resultTitle: "no changes",
+			})
+			continue
+		}
+
+		for _, cc := range hc.Changes {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   host,
+				fieldTitle:  cc.Field,
+				resultTitle: configChangeResult(cc),
+			})
+		}
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintApplyConfigResp generates a human-readable representation of the
+// outcome of applying a config on each host in the supplied response and
+// writes it to the supplied io.Writer.
+func PrintApplyConfigResp(resp *control.ApplyConfigResp, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.HostResults) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	resultTitle := "Result"
+	changesTitle := "Changed Parameters"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, resultTitle, changesTitle)
+	var table []txtfmt.TableRow
+
+	for _, hr := range resp.HostResults {
+		result := "written"
+		switch {
+		case resp.DryRun:
+			result = "validated"
+		case hr.Restarting:
+			result = "written, restarting"
+		}
+
+		changes := "none"
+		if len(hr.Changes) > 0 {
+			changes = strings.Join(hr.Changes, ", ")
+		}
+
+		table = append(table, txtfmt.TableRow{
+			hostTitle:    getPrintHosts(hr.Addr, opts...),
+			resultTitle:  result,
+			changesTitle: changes,
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
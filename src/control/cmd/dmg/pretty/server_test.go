@@ -0,0 +1,129 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintReloadConfigResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.ReloadConfigResp
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.ReloadConfigResp{},
+		},
+		"mixed results": {
+			resp: &control.ReloadConfigResp{
+				HostChanges: []*control.HostConfigChanges{
+					{
+						Addr: "host1:10001",
+						Changes: []*control.ConfigChange{
+							{Field: "control_log_mask", Action: "applied"},
+							{Field: "engines[0].targets", Action: "engine_restart"},
+							{Field: "port", Action: "server_restart"},
+							{Field: "telemetry_port", Action: "failed", Error: "address in use"},
+						},
+					},
+					{
+						Addr:    "host2:10001",
+						Changes: []*control.ConfigChange{},
+					},
+				},
+			},
+			expPrintStr: `
+Host  Parameter          Result                  
+----  ---------          ------                  
+host1 control_log_mask   applied                 
+host1 engines[0].targets requires engine restart 
+host1 port               requires server restart 
+host1 telemetry_port     failed: address in use  
+host2 -                  no changes              
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintReloadConfigResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintApplyConfigResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.ApplyConfigResp
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.ApplyConfigResp{},
+		},
+		"dry run": {
+			resp: &control.ApplyConfigResp{
+				DryRun: true,
+				HostResults: []*control.HostConfigApplyResult{
+					{
+						Addr:    "host1:10001",
+						Changes: []string{"control_log_mask", "port"},
+					},
+				},
+			},
+			expPrintStr: `
+Host  Result    Changed Parameters     
+----  ------    ------------------     
+host1 validated control_log_mask, port 
+
+`,
+		},
+		"written": {
+			resp: &control.ApplyConfigResp{
+				HostResults: []*control.HostConfigApplyResult{
+					{
+						Addr:       "host1:10001",
+						BackupPath: "/etc/daos/daos_server.yml.bak",
+						Changes:    []string{"port"},
+						Restarting: true,
+					},
+					{
+						Addr:       "host2:10001",
+						BackupPath: "/etc/daos/daos_server.yml.bak",
+					},
+				},
+			},
+			expPrintStr: `
+Host  Result              Changed Parameters 
+----  ------              ------------------ 
+host1 written, restarting port               
+host2 written             none               
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintApplyConfigResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
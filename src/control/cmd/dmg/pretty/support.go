@@ -0,0 +1,46 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// PrintSupportCollectLogResp generates a human-readable representation of the
+// support bundles in the supplied response and writes it to the supplied
+// io.Writer.
+func PrintSupportCollectLogResp(resp *control.SupportCollectLogResp, out io.Writer, opts ...PrintConfigOption) error {
+	if len(resp.Bundles) == 0 {
+		fmt.Fprintln(out, "No support bundles collected")
+		return nil
+	}
+
+	hostTitle := "Host"
+	nameTitle := "Bundle"
+	sizeTitle := "Size"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, nameTitle, sizeTitle)
+	var table []txtfmt.TableRow
+
+	for _, bundle := range resp.Bundles {
+		table = append(table, txtfmt.TableRow{
+			hostTitle: getPrintHosts(bundle.Host, opts...),
+			nameTitle: bundle.Name,
+			sizeTitle: humanize.IBytes(bundle.Size),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
@@ -0,0 +1,64 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintSupportCollectLogResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp   *control.SupportCollectLogResp
+		expOut string
+	}{
+		"no bundles": {
+			resp: &control.SupportCollectLogResp{},
+			expOut: `
+No support bundles collected
+`,
+		},
+		"bundles": {
+			resp: &control.SupportCollectLogResp{
+				Bundles: []*control.SupportBundle{
+					{
+						Host: "host1:10001",
+						Name: "host1_10001.tar.gz",
+						Size: 2048,
+					},
+					{
+						Host: "host2:10001",
+						Name: "host2_10001.tar.gz",
+						Size: 3 * 1024 * 1024,
+					},
+				},
+			},
+			expOut: `
+Host  Bundle             Size    
+----  ------             ----    
+host1 host1_10001.tar.gz 2.0 KiB 
+host2 host2_10001.tar.gz 3.0 MiB 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSupportCollectLogResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expOut, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -89,9 +89,11 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 	addrTitle := "Control Address"
 	faultDomainTitle := "Fault Domain"
 	stateTitle := "State"
+	restartsTitle := "Restarts"
 	reasonTitle := "Reason"
 
-	formatter := txtfmt.NewTableFormatter(rankTitle, uuidTitle, addrTitle, faultDomainTitle, stateTitle, reasonTitle)
+	formatter := txtfmt.NewTableFormatter(rankTitle, uuidTitle, addrTitle, faultDomainTitle, stateTitle,
+		restartsTitle, reasonTitle)
 	var table []txtfmt.TableRow
 
 	for _, m := range members {
@@ -100,6 +102,7 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 		row[addrTitle] = m.Addr.String()
 		row[faultDomainTitle] = m.FaultDomain.String()
 		row[stateTitle] = m.State().String()
+		row[restartsTitle] = fmt.Sprintf("%d", m.Restarts)
 		row[reasonTitle] = m.Info
 
 		table = append(table, row)
@@ -180,3 +183,87 @@ func PrintSystemStartResponse(out, outErr io.Writer, resp *control.SystemStartRe
 func PrintSystemStopResponse(out, outErr io.Writer, resp *control.SystemStopResp) error {
 	return printSystemResults(out, outErr, resp.Results, &resp.AbsentHosts, &resp.AbsentRanks)
 }
+
+// configHashPrintLen is the number of characters of a config hash to print.
+const configHashPrintLen = 12
+
+func printConfigGroupHosts(hosts []string, opts ...PrintConfigOption) string {
+	printHosts := getPrintHosts(strings.Join(hosts, ","), opts...)
+	if hs, err := hostlist.CreateSet(printHosts); err == nil {
+		return hs.String()
+	}
+	return printHosts
+}
+
+func printConfigGroupHash(group *control.SystemConfigGroup) string {
+	if group.Hash == "" {
+		return "unknown"
+	}
+	if len(group.Hash) > configHashPrintLen {
+		return group.Hash[:configHashPrintLen]
+	}
+	return group.Hash
+}
+
+// PrintSystemConfigCheckResp generates a human-readable representation of the
+// system members grouped by server config and the parameters which differ
+// between the groups, and writes it to the supplied io.Writer.
+func PrintSystemConfigCheckResp(resp *control.SystemConfigCheckResp, out io.Writer, opts ...PrintConfigOption) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	switch {
+	case len(resp.Groups) == 0:
+		fmt.Fprintln(out, "No system members found")
+		return nil
+	case len(resp.Groups) == 1 && resp.Groups[0].Hash != "":
+		fmt.Fprintf(out, "Server config is consistent across ranks %s (%s)\n",
+			resp.Groups[0].Ranks, printConfigGroupHash(resp.Groups[0]))
+		return nil
+	}
+
+	groupTitle := "Group"
+	hashTitle := "Config Hash"
+	ranksTitle := "Ranks"
+	hostsTitle := "Hosts"
+
+	formatter := txtfmt.NewTableFormatter(groupTitle, hashTitle, ranksTitle, hostsTitle)
+	var table []txtfmt.TableRow
+
+	for i, group := range resp.Groups {
+		table = append(table, txtfmt.TableRow{
+			groupTitle: fmt.Sprintf("%d", i+1),
+			hashTitle:  printConfigGroupHash(group),
+			ranksTitle: group.Ranks,
+			hostsTitle: printConfigGroupHosts(group.Hosts, opts...),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	fieldTitle := "Parameter"
+	valueTitle := "Value"
+	refTitle := "Reference"
+
+	for i, group := range resp.Groups[1:] {
+		if group.Hash == "" {
+			fmt.Fprintf(out, "Group %d joined without a config fingerprint\n", i+2)
+			continue
+		}
+
+		fmt.Fprintf(out, "Group %d differs from group 1:\n", i+2)
+		formatter := txtfmt.NewTableFormatter(fieldTitle, valueTitle, refTitle)
+		var table []txtfmt.TableRow
+		for _, diff := range group.Diffs {
+			table = append(table, txtfmt.TableRow{
+				fieldTitle: diff.Field,
+				valueTitle: diff.Value,
+				refTitle:   diff.Reference,
+			})
+		}
+		fmt.Fprintln(out, formatter.Format(table))
+	}
+
+	return nil
+}
@@ -143,9 +143,9 @@ Unknown 3 hosts: foo[7-9]
 			},
 			verbose: true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State  Reason 
----- ----                                 --------------- ------------ -----  ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        
+Rank UUID                                 Control Address Fault Domain State  Restarts Reason 
+---- ----                                 --------------- ------------ -----  -------- ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined 0               
 
 `,
 		},
@@ -159,9 +159,9 @@ Rank UUID                                 Control Address Fault Domain State  Re
 			absentRanks: "7-9",
 			verbose:     true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State  Reason 
----- ----                                 --------------- ------------ -----  ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        
+Rank UUID                                 Control Address Fault Domain State  Restarts Reason 
+---- ----                                 --------------- ------------ -----  -------- ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined 0               
 
 Unknown 3 hosts: foo[7-9]
 Unknown 3 ranks: 7-9
@@ -273,15 +273,15 @@ Unknown 3 hosts: foo[7-9]
 			},
 			verbose: true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State    Reason 
----- ----                                 --------------- ------------ -----    ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          
-1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          
-2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         
-3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        
-4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         
-5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          
-6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          
+Rank UUID                                 Control Address Fault Domain State    Restarts Reason 
+---- ----                                 --------------- ------------ -----    -------- ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined   0               
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined   0               
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped  0               
+3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded 0               
+4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped  0               
+5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined   0               
+6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined   0               
 
 `,
 		},
@@ -301,15 +301,15 @@ Rank UUID                                 Control Address Fault Domain State
 			absentRanks: "7-9",
 			verbose:     true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State    Reason 
----- ----                                 --------------- ------------ -----    ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          
-1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          
-2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         
-3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        
-4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         
-5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          
-6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          
+Rank UUID                                 Control Address Fault Domain State    Restarts Reason 
+---- ----                                 --------------- ------------ -----    -------- ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined   0               
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined   0               
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped  0               
+3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded 0               
+4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped  0               
+5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined   0               
+6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined   0               
 
 Unknown 3 hosts: foo[7-9]
 Unknown 3 ranks: 7-9
@@ -522,3 +522,81 @@ Unknown 3 hosts: foo[7-9]
 		})
 	}
 }
+
+func TestPretty_PrintSystemConfigCheckResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemConfigCheckResp
+		expPrintStr string
+	}{
+		"no members": {
+			resp: &control.SystemConfigCheckResp{},
+			expPrintStr: `
+No system members found
+`,
+		},
+		"consistent": {
+			resp: &control.SystemConfigCheckResp{
+				Groups: []*control.SystemConfigGroup{
+					{
+						Hash:  "b27071a24a72cffe8c831a034d17944a",
+						Ranks: "0-3",
+						Hosts: []string{"10.0.0.1:10001", "10.0.0.2:10001"},
+					},
+				},
+			},
+			expPrintStr: `
+Server config is consistent across ranks 0-3 (b27071a24a72)
+`,
+		},
+		"config drift": {
+			resp: &control.SystemConfigCheckResp{
+				Groups: []*control.SystemConfigGroup{
+					{
+						Hash:  "b27071a24a72cffe8c831a034d17944a",
+						Ranks: "0-1,5",
+						Hosts: []string{"10.0.0.1:10001", "10.0.0.4:10001"},
+					},
+					{
+						Hash:  "311f435f4ce99a36020cb3098009f8d8",
+						Ranks: "2",
+						Hosts: []string{"10.0.0.2:10001"},
+						Diffs: []*control.ConfigFieldDiff{
+							{Field: "engines[0].targets", Value: "16", Reference: "8"},
+							{Field: "provider", Value: "ofi+tcp", Reference: "ofi+verbs"},
+						},
+					},
+					{
+						Ranks: "6",
+						Hosts: []string{"10.0.0.5:10001"},
+					},
+				},
+			},
+			expPrintStr: `
+Group Config Hash  Ranks Hosts        
+----- -----------  ----- -----        
+1     b27071a24a72 0-1,5 10.0.0.[1,4] 
+2     311f435f4ce9 2     10.0.0.2     
+3     unknown      6     10.0.0.5     
+
+Group 2 differs from group 1:
+Parameter          Value   Reference 
+---------          -----   --------- 
+engines[0].targets 16      8         
+provider           ofi+tcp ofi+verbs 
+
+Group 3 joined without a config fingerprint
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemConfigCheckResp(tc.resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
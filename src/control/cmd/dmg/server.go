@@ -8,6 +8,10 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -18,7 +22,9 @@ import (
 
 // serverCmd is the struct representing the top-level server subcommand.
 type serverCmd struct {
-	SetLogMasks serverSetLogMasksCmd `command:"set-logmasks" alias:"slm" description:"Set log masks for a set of facilities to a given level. Setting will be applied to all running DAOS I/O Engines present in the configured dmg hostlist."`
+	SetLogMasks  serverSetLogMasksCmd  `command:"set-logmasks" alias:"slm" description:"Set log masks for a set of facilities to a given level. Setting will be applied to all running DAOS I/O Engines present in the configured dmg hostlist."`
+	CollectCrash serverCollectCrashCmd `command:"collect-crash" alias:"cc" description:"Fetch an archive of the artifacts collected after an abnormal DAOS I/O Engine exit on a host"`
+	ReloadConfig serverReloadConfigCmd `command:"reload-config" alias:"rc" description:"Re-read the server config file on hosts in the configured dmg hostlist, apply changes that do not require a restart and report those that do. Control plane and helper log file changes are applied immediately and engine log file changes on engine restart. Events are forwarded to the access points, which are applied immediately"`
 }
 
 // serverSetLogMasksCmd is the struct representing the command to set engine log
@@ -72,3 +78,132 @@ func (cmd *serverSetLogMasksCmd) Execute(_ []string) (errOut error) {
 
 	return resp.Errors()
 }
+
+// serverReloadConfigCmd is the struct representing the command to reload the
+// server config file on a set of hosts.
+type serverReloadConfigCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+}
+
+// Execute is run when serverReloadConfigCmd activates.
+func (cmd *serverReloadConfigCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "reload config failed")
+	}()
+
+	req := new(control.ReloadConfigReq)
+	req.SetHostList(cmd.hostlist)
+
+	resp, err := control.ReloadConfig(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if err := pretty.PrintReloadConfigResp(resp, &out); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+	cmd.log.Info(out.String())
+
+	return resp.Errors()
+}
+
+// serverCollectCrashCmd is the struct representing the command to list or
+// fetch the engine crash archives held on a host.
+type serverCollectCrashCmd struct {
+	logCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+
+	Host   string `long:"host" required:"1" description:"Host from which to fetch the crash archive"`
+	List   bool   `short:"L" long:"list" description:"List the crash archives held on the host instead of fetching one"`
+	Name   string `short:"n" long:"name" description:"Name of the crash archive to fetch (default: most recent)"`
+	Output string `short:"o" long:"output" default:"." description:"Directory in which to write the fetched archive"`
+}
+
+// Execute is run when serverCollectCrashCmd activates.
+func (cmd *serverCollectCrashCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "collect crash failed")
+	}()
+
+	if cmd.List {
+		req := new(control.CrashListReq)
+		req.SetHostList([]string{cmd.Host})
+
+		resp, err := control.CrashList(context.Background(), cmd.ctlInvoker, req)
+		if err != nil {
+			return err
+		}
+
+		if cmd.jsonOutputEnabled() {
+			return cmd.outputJSON(resp, resp.Errors())
+		}
+
+		var out, outErr strings.Builder
+		if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+			return err
+		}
+		if err := pretty.PrintCrashListResp(resp, &out); err != nil {
+			return err
+		}
+		if outErr.Len() > 0 {
+			cmd.log.Error(outErr.String())
+		}
+		cmd.log.Info(out.String())
+
+		return resp.Errors()
+	}
+
+	// Fetch into a temporary file as the archive name is not known until
+	// the first chunk has been received.
+	tmpFile, err := ioutil.TempFile(cmd.Output, ".daos_crash-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+
+	req := &control.CrashFetchReq{Name: cmd.Name}
+	req.SetHostList([]string{cmd.Host})
+
+	archive, err := control.CrashFetch(context.Background(), cmd.ctlInvoker, req, tmpFile)
+	if err != nil {
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	hostPrefix := strings.NewReplacer(":", "_", "/", "_").Replace(cmd.Host)
+	path := filepath.Join(cmd.Output, fmt.Sprintf("%s-%s", hostPrefix, archive.Name))
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(struct {
+			*control.CrashArchive
+			Path string `json:"path"`
+		}{archive, path}, nil)
+	}
+
+	cmd.log.Infof("Crash archive %s (%d bytes) written to %s", archive.Name, archive.Size, path)
+
+	return nil
+}
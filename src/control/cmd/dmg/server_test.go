@@ -7,10 +7,14 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
 
@@ -40,5 +44,54 @@ func TestServerCommands(t *testing.T) {
 			"",
 			errors.New("expected 0-1 positional args but got 3"),
 		},
+		{
+			"Reload config",
+			"server reload-config",
+			printRequest(t, &control.ReloadConfigReq{}),
+			nil,
+		},
+	})
+}
+
+func TestServerCollectCrashCommand(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	withHost := func(req control.UnaryRequest, host string) control.UnaryRequest {
+		req.SetHostList([]string{host})
+		return req
+	}
+
+	runCmdTests(t, []cmdTest{
+		{
+			"List crash archives",
+			"server collect-crash --host host1 --list",
+			printRequest(t, withHost(&control.CrashListReq{}, "host1")),
+			nil,
+		},
+		{
+			"Fetch most recent crash archive",
+			fmt.Sprintf("server collect-crash --host host1 -o %s", testDir),
+			printRequest(t, withHost(&control.CrashFetchReq{}, "host1")),
+			nil,
+		},
+		{
+			"Fetch named crash archive",
+			fmt.Sprintf("server collect-crash --host host1:10001 -n foo.tar.gz -o %s", testDir),
+			printRequest(t, withHost(&control.CrashFetchReq{Name: "foo.tar.gz"}, "host1:10001")),
+			nil,
+		},
+		{
+			"Missing host",
+			"server collect-crash --list",
+			"",
+			errors.New("required flag"),
+		},
 	})
+
+	for _, name := range []string{"host1-daos_engine0-crash.tar.gz", "host1_10001-daos_engine0-crash.tar.gz"} {
+		if _, err := os.Stat(filepath.Join(testDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,97 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// supportCmd is the struct representing the top-level support subcommand.
+type supportCmd struct {
+	CollectLog supportCollectLogCmd `command:"collect-log" description:"Collect a support bundle of logs and diagnostics from each host in the hostlist"`
+}
+
+// supportCollectLogCmd is the struct representing the command to collect
+// support bundles from hosts into a local archive.
+type supportCollectLogCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+
+	Output string `short:"o" long:"output" description:"Path of the archive in which to write the collected bundles (default: daos_support-<time>.tar)"`
+}
+
+// Execute is run when supportCollectLogCmd activates.
+func (cmd *supportCollectLogCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "collect support bundles failed")
+	}()
+
+	path := cmd.Output
+	if path == "" {
+		path = fmt.Sprintf("daos_support-%s.tar", time.Now().Format("20060102T150405"))
+	}
+
+	// Write to a temporary file so that an incomplete archive is not left
+	// at the requested path if collection fails.
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), ".daos_support-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+
+	req := new(control.SupportCollectLogReq)
+	req.SetHostList(cmd.hostlist)
+
+	resp, err := control.SupportCollectLog(context.Background(), cmd.ctlInvoker, req, tmpFile)
+	if err != nil {
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(struct {
+			*control.SupportCollectLogResp
+			Path string `json:"path"`
+		}{resp, path}, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if err := pretty.PrintSupportCollectLogResp(resp, &out); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+	cmd.log.Info(out.String())
+	cmd.log.Infof("Support bundles written to %s", path)
+
+	return resp.Errors()
+}
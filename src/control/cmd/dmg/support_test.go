@@ -0,0 +1,56 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestSupportCollectLogCommand(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	withHosts := func(req control.UnaryRequest, hosts ...string) control.UnaryRequest {
+		req.SetHostList(hosts)
+		return req
+	}
+
+	runCmdTests(t, []cmdTest{
+		{
+			"Collect from configured hosts",
+			fmt.Sprintf("support collect-log -o %s", filepath.Join(testDir, "all.tar")),
+			printRequest(t, &control.SupportCollectLogReq{}),
+			nil,
+		},
+		{
+			"Collect from hostlist",
+			fmt.Sprintf("support collect-log -l host1,host2 -o %s", filepath.Join(testDir, "some.tar")),
+			printRequest(t, withHosts(&control.SupportCollectLogReq{}, "host1", "host2")),
+			nil,
+		},
+		{
+			"Output directory does not exist",
+			fmt.Sprintf("support collect-log -o %s", filepath.Join(testDir, "missing", "out.tar")),
+			"",
+			errors.New("no such file or directory"),
+		},
+	})
+
+	for _, name := range []string{"all.tar", "some.tar"} {
+		if _, err := os.Stat(filepath.Join(testDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ package main
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -20,12 +21,15 @@ import (
 
 // SystemCmd is the struct representing the top-level system subcommand.
 type SystemCmd struct {
-	LeaderQuery leaderQueryCmd `command:"leader-query" alias:"l" description:"Query for current Management Service leader"`
-	Query       systemQueryCmd `command:"query" alias:"q" description:"Query DAOS system status"`
-	Stop        systemStopCmd  `command:"stop" alias:"s" description:"Perform controlled shutdown of DAOS system"`
-	Start       systemStartCmd `command:"start" alias:"r" description:"Perform start of stopped DAOS system"`
-	Erase       systemEraseCmd `command:"erase" alias:"e" description:"Erase system metadata prior to reformat"`
-	ListPools   PoolListCmd    `command:"list-pools" alias:"p" description:"List all pools in the DAOS system"`
+	LeaderQuery leaderQueryCmd       `command:"leader-query" alias:"l" description:"Query for current Management Service leader"`
+	Query       systemQueryCmd       `command:"query" alias:"q" description:"Query DAOS system status"`
+	Stop        systemStopCmd        `command:"stop" alias:"s" description:"Perform controlled shutdown of DAOS system"`
+	Start       systemStartCmd       `command:"start" alias:"r" description:"Perform start of stopped DAOS system"`
+	Erase       systemEraseCmd       `command:"erase" alias:"e" description:"Erase system metadata prior to reformat"`
+	ConfigCheck systemConfigCheckCmd `command:"config-check" alias:"cc" description:"Compare server configs across DAOS system members"`
+	ListPools   PoolListCmd          `command:"list-pools" alias:"p" description:"List all pools in the DAOS system"`
+	Certs       systemCertsCmd       `command:"certs" alias:"c" description:"Report expiry of certificates in use on each host"`
+	Audit       systemAuditCmd       `command:"audit" alias:"a" description:"Query the audit log of mutating administrative requests"`
 }
 
 type leaderQueryCmd struct {
@@ -62,6 +66,40 @@ func (cmd *leaderQueryCmd) Execute(_ []string) (errOut error) {
 	return nil
 }
 
+// systemConfigCheckCmd is the struct representing the command to compare the
+// server configs of system members.
+type systemConfigCheckCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+}
+
+// Execute is run when systemConfigCheckCmd activates.
+func (cmd *systemConfigCheckCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system config check failed")
+	}()
+
+	req := new(control.SystemConfigCheckReq)
+	resp, err := control.SystemConfigCheck(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemConfigCheckResp(resp, &out); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
 // rankListCmd enables rank or host list to be supplied with command to filter
 // which ranks are operated upon.
 type rankListCmd struct {
@@ -239,3 +277,122 @@ func (cmd *systemStartCmd) Execute(_ []string) (errOut error) {
 
 	return resp.Errors()
 }
+
+// systemCertsCmd is the struct representing the command to report the expiry
+// of the certificates in use on each host.
+type systemCertsCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+}
+
+// Execute is run when systemCertsCmd activates.
+func (cmd *systemCertsCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "certificate query failed")
+	}()
+
+	req := new(control.CertQueryReq)
+	req.SetHostList(cmd.hostlist)
+
+	resp, err := control.CertQuery(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintCertQueryResp(resp, time.Now(), &out); err != nil {
+		return err
+	}
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if out.Len() > 0 {
+		cmd.log.Info(out.String())
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
+
+// systemAuditCmd is the struct representing the command to query the audit
+// log of mutating administrative requests.
+type systemAuditCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+	Since    string `short:"s" long:"since" description:"Only show records after this time, given as an RFC3339 timestamp or a duration before now (e.g. 24h)"`
+	Method   string `short:"m" long:"method" description:"Only show records for requests containing this string (e.g. PoolDestroy)"`
+	Caller   string `short:"c" long:"caller" description:"Only show records for requests made by this caller (e.g. admin)"`
+	Limit    uint32 `short:"n" long:"limit" description:"Only show this many of the most recent records"`
+	SystemDB bool   `short:"d" long:"system-db" description:"Read records from the system database on the management service leader"`
+}
+
+// parseSince converts the value of the since option to a time.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid since value %q: must be a duration or RFC3339 timestamp", since)
+	}
+	return t, nil
+}
+
+// Execute is run when systemAuditCmd activates.
+func (cmd *systemAuditCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "audit query failed")
+	}()
+
+	since, err := parseSince(cmd.Since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req := &control.AuditQueryReq{
+		Since:    since,
+		Method:   cmd.Method,
+		Caller:   cmd.Caller,
+		Limit:    cmd.Limit,
+		SystemDB: cmd.SystemDB,
+	}
+	req.SetHostList(cmd.hostlist)
+
+	resp, err := control.AuditQuery(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintAuditQueryResp(resp, &out); err != nil {
+		return err
+	}
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if out.Len() > 0 {
+		cmd.log.Info(out.String())
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -193,6 +194,12 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system config check",
+			"system config-check",
+			printRequest(t, &control.SystemConfigCheckReq{}),
+			nil,
+		},
 		{
 			"system list-pools with default config",
 			"system list-pools",
@@ -201,6 +208,36 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system certs",
+			"system certs",
+			printRequest(t, &control.CertQueryReq{}),
+			nil,
+		},
+		{
+			"system audit",
+			"system audit",
+			printRequest(t, &control.AuditQueryReq{}),
+			nil,
+		},
+		{
+			"system audit with filters",
+			"system audit --since 2021-06-01T00:00:00Z -m PoolDestroy -c admin -n 10 --system-db",
+			printRequest(t, &control.AuditQueryReq{
+				Since:    time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+				Method:   "PoolDestroy",
+				Caller:   "admin",
+				Limit:    10,
+				SystemDB: true,
+			}),
+			nil,
+		},
+		{
+			"system audit with bad since",
+			"system audit --since yesterday",
+			"",
+			errors.New("invalid since value"),
+		},
 		{
 			"Non-existent subcommand",
 			"system quack",
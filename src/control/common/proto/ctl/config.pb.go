@@ -0,0 +1,539 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.6.1
+// source: ctl/config.proto
+
+package ctl
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigChange_Action int32
+
+const (
+	ConfigChange_APPLIED        ConfigChange_Action = 0 // change applied to the running server
+	ConfigChange_ENGINE_RESTART ConfigChange_Action = 1 // change takes effect on engine restart
+	ConfigChange_SERVER_RESTART ConfigChange_Action = 2 // change takes effect on daos_server restart
+	ConfigChange_FAILED         ConfigChange_Action = 3 // change could not be applied
+)
+
+// Enum value maps for ConfigChange_Action.
+var (
+	ConfigChange_Action_name = map[int32]string{
+		0: "APPLIED",
+		1: "ENGINE_RESTART",
+		2: "SERVER_RESTART",
+		3: "FAILED",
+	}
+	ConfigChange_Action_value = map[string]int32{
+		"APPLIED":        0,
+		"ENGINE_RESTART": 1,
+		"SERVER_RESTART": 2,
+		"FAILED":         3,
+	}
+)
+
+func (x ConfigChange_Action) Enum() *ConfigChange_Action {
+	p := new(ConfigChange_Action)
+	*p = x
+	return p
+}
+
+func (x ConfigChange_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigChange_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_ctl_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigChange_Action) Type() protoreflect.EnumType {
+	return &file_ctl_config_proto_enumTypes[0]
+}
+
+func (x ConfigChange_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigChange_Action.Descriptor instead.
+func (ConfigChange_Action) EnumDescriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type ReloadConfigReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReloadConfigReq) Reset() {
+	*x = ReloadConfigReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigReq) ProtoMessage() {}
+
+func (x *ReloadConfigReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigReq.ProtoReflect.Descriptor instead.
+func (*ReloadConfigReq) Descriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{0}
+}
+
+// ConfigChange describes a parameter which differs between the running
+// configuration and the configuration file.
+type ConfigChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field  string              `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // config file path of the changed parameter
+	Action ConfigChange_Action `protobuf:"varint,2,opt,name=action,proto3,enum=ctl.ConfigChange_Action" json:"action,omitempty"`
+	Error  string              `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // reason change could not be applied, if any
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetAction() ConfigChange_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ConfigChange_APPLIED
+}
+
+func (x *ConfigChange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ReloadConfigResp returns the changes found when re-reading a server's
+// configuration file.
+type ReloadConfigResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path    string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // path of the reloaded config file
+	Changes []*ConfigChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ReloadConfigResp) Reset() {
+	*x = ReloadConfigResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResp) ProtoMessage() {}
+
+func (x *ReloadConfigResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResp.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResp) Descriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReloadConfigResp) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ReloadConfigResp) GetChanges() []*ConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// ApplyConfigReq supplies a candidate config file to be validated and written
+// in place of a server's config file.
+type ApplyConfigReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config  []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`                // candidate config file contents
+	DryRun  bool   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // validate the candidate without writing it
+	Restart bool   `protobuf:"varint,3,opt,name=restart,proto3" json:"restart,omitempty"`             // restart daos_server once the candidate is written
+}
+
+func (x *ApplyConfigReq) Reset() {
+	*x = ApplyConfigReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyConfigReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyConfigReq) ProtoMessage() {}
+
+func (x *ApplyConfigReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyConfigReq.ProtoReflect.Descriptor instead.
+func (*ApplyConfigReq) Descriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApplyConfigReq) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ApplyConfigReq) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ApplyConfigReq) GetRestart() bool {
+	if x != nil {
+		return x.Restart
+	}
+	return false
+}
+
+// ApplyConfigResp returns the outcome of applying a candidate config file.
+type ApplyConfigResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                               // path of the server config file
+	BackupPath string   `protobuf:"bytes,2,opt,name=backup_path,json=backupPath,proto3" json:"backup_path,omitempty"` // path of the previous config file, if written
+	Changes    []string `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`                         // config file paths of parameters which differ from the running config
+	Restarting bool     `protobuf:"varint,4,opt,name=restarting,proto3" json:"restarting,omitempty"`                  // daos_server is restarting with the new config
+}
+
+func (x *ApplyConfigResp) Reset() {
+	*x = ApplyConfigResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyConfigResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyConfigResp) ProtoMessage() {}
+
+func (x *ApplyConfigResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyConfigResp.ProtoReflect.Descriptor instead.
+func (*ApplyConfigResp) Descriptor() ([]byte, []int) {
+	return file_ctl_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApplyConfigResp) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ApplyConfigResp) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *ApplyConfigResp) GetChanges() []string {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ApplyConfigResp) GetRestarting() bool {
+	if x != nil {
+		return x.Restarting
+	}
+	return false
+}
+
+var File_ctl_config_proto protoreflect.FileDescriptor
+
+var file_ctl_config_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x63, 0x74, 0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0x11, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x22, 0xb7, 0x01, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x49, 0x0a, 0x06, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x50, 0x50, 0x4c, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x54, 0x41,
+	0x52, 0x54, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x52,
+	0x45, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c,
+	0x45, 0x44, 0x10, 0x03, 0x22, 0x53, 0x0a, 0x10, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x63, 0x74, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x0e, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f,
+	0x0a, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61,
+	0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ctl_config_proto_rawDescOnce sync.Once
+	file_ctl_config_proto_rawDescData = file_ctl_config_proto_rawDesc
+)
+
+func file_ctl_config_proto_rawDescGZIP() []byte {
+	file_ctl_config_proto_rawDescOnce.Do(func() {
+		file_ctl_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_config_proto_rawDescData)
+	})
+	return file_ctl_config_proto_rawDescData
+}
+
+var file_ctl_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ctl_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ctl_config_proto_goTypes = []interface{}{
+	(ConfigChange_Action)(0), // 0: ctl.ConfigChange.Action
+	(*ReloadConfigReq)(nil),  // 1: ctl.ReloadConfigReq
+	(*ConfigChange)(nil),     // 2: ctl.ConfigChange
+	(*ReloadConfigResp)(nil), // 3: ctl.ReloadConfigResp
+	(*ApplyConfigReq)(nil),   // 4: ctl.ApplyConfigReq
+	(*ApplyConfigResp)(nil),  // 5: ctl.ApplyConfigResp
+}
+var file_ctl_config_proto_depIdxs = []int32{
+	0, // 0: ctl.ConfigChange.action:type_name -> ctl.ConfigChange.Action
+	2, // 1: ctl.ReloadConfigResp.changes:type_name -> ctl.ConfigChange
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_ctl_config_proto_init() }
+func file_ctl_config_proto_init() {
+	if File_ctl_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_config_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyConfigReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyConfigResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ctl_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ctl_config_proto_goTypes,
+		DependencyIndexes: file_ctl_config_proto_depIdxs,
+		EnumInfos:         file_ctl_config_proto_enumTypes,
+		MessageInfos:      file_ctl_config_proto_msgTypes,
+	}.Build()
+	File_ctl_config_proto = out.File
+	file_ctl_config_proto_rawDesc = nil
+	file_ctl_config_proto_goTypes = nil
+	file_ctl_config_proto_depIdxs = nil
+}
@@ -0,0 +1,330 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.6.1
+// source: ctl/crash.proto
+
+package ctl
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CrashArchive describes an archive of artifacts collected after an engine
+// exited abnormally.
+type CrashArchive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`  // archive file name
+	Time int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // time of collection (nanoseconds since epoch)
+	Size uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"` // archive size in bytes
+}
+
+func (x *CrashArchive) Reset() {
+	*x = CrashArchive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_crash_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrashArchive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashArchive) ProtoMessage() {}
+
+func (x *CrashArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_crash_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashArchive.ProtoReflect.Descriptor instead.
+func (*CrashArchive) Descriptor() ([]byte, []int) {
+	return file_ctl_crash_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CrashArchive) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CrashArchive) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *CrashArchive) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// CollectCrashReq requests the list of crash archives held on a host or a
+// chunk of the contents of one of them.
+type CollectCrashReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List   bool   `protobuf:"varint,1,opt,name=list,proto3" json:"list,omitempty"`     // only list the available archives
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`      // archive to fetch, most recent if empty
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // offset of the chunk to fetch
+}
+
+func (x *CollectCrashReq) Reset() {
+	*x = CollectCrashReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_crash_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectCrashReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectCrashReq) ProtoMessage() {}
+
+func (x *CollectCrashReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_crash_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectCrashReq.ProtoReflect.Descriptor instead.
+func (*CollectCrashReq) Descriptor() ([]byte, []int) {
+	return file_ctl_crash_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CollectCrashReq) GetList() bool {
+	if x != nil {
+		return x.List
+	}
+	return false
+}
+
+func (x *CollectCrashReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CollectCrashReq) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// CollectCrashResp contains the available archives or, if an archive was
+// fetched, its details and the requested chunk of its contents.
+type CollectCrashResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Archives []*CrashArchive `protobuf:"bytes,1,rep,name=archives,proto3" json:"archives,omitempty"` // available archives, or the fetched archive
+	Data     []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`         // archive contents starting at the requested offset
+}
+
+func (x *CollectCrashResp) Reset() {
+	*x = CollectCrashResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_crash_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectCrashResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectCrashResp) ProtoMessage() {}
+
+func (x *CollectCrashResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_crash_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectCrashResp.ProtoReflect.Descriptor instead.
+func (*CollectCrashResp) Descriptor() ([]byte, []int) {
+	return file_ctl_crash_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CollectCrashResp) GetArchives() []*CrashArchive {
+	if x != nil {
+		return x.Archives
+	}
+	return nil
+}
+
+func (x *CollectCrashResp) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_ctl_crash_proto protoreflect.FileDescriptor
+
+var file_ctl_crash_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x63, 0x74, 0x6c, 0x2f, 0x63, 0x72, 0x61, 0x73, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0x4a, 0x0a, 0x0c, 0x43, 0x72, 0x61, 0x73, 0x68, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x22, 0x51, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x43, 0x72, 0x61,
+	0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x55, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x43, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2d, 0x0a, 0x08, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x43, 0x72, 0x61, 0x73, 0x68, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x08,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x39, 0x5a, 0x37,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d,
+	0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ctl_crash_proto_rawDescOnce sync.Once
+	file_ctl_crash_proto_rawDescData = file_ctl_crash_proto_rawDesc
+)
+
+func file_ctl_crash_proto_rawDescGZIP() []byte {
+	file_ctl_crash_proto_rawDescOnce.Do(func() {
+		file_ctl_crash_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_crash_proto_rawDescData)
+	})
+	return file_ctl_crash_proto_rawDescData
+}
+
+var file_ctl_crash_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ctl_crash_proto_goTypes = []interface{}{
+	(*CrashArchive)(nil),     // 0: ctl.CrashArchive
+	(*CollectCrashReq)(nil),  // 1: ctl.CollectCrashReq
+	(*CollectCrashResp)(nil), // 2: ctl.CollectCrashResp
+}
+var file_ctl_crash_proto_depIdxs = []int32{
+	0, // 0: ctl.CollectCrashResp.archives:type_name -> ctl.CrashArchive
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_ctl_crash_proto_init() }
+func file_ctl_crash_proto_init() {
+	if File_ctl_crash_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_crash_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrashArchive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_crash_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectCrashReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_crash_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectCrashResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ctl_crash_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ctl_crash_proto_goTypes,
+		DependencyIndexes: file_ctl_crash_proto_depIdxs,
+		MessageInfos:      file_ctl_crash_proto_msgTypes,
+	}.Build()
+	File_ctl_crash_proto = out.File
+	file_ctl_crash_proto_rawDesc = nil
+	file_ctl_crash_proto_goTypes = nil
+	file_ctl_crash_proto_depIdxs = nil
+}
@@ -42,6 +42,19 @@ type CtlSvcClient interface {
 	ResetFormatRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
 	// Start DAOS I/O Engines on a host. (gRPC fanout)
 	StartRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
+	// Query the expiry of certificates in use on a host.
+	CertQuery(ctx context.Context, in *CertQueryReq, opts ...grpc.CallOption) (*CertQueryResp, error)
+	// Query the audit log of mutating control-plane operations on a host.
+	AuditQuery(ctx context.Context, in *AuditQueryReq, opts ...grpc.CallOption) (*AuditQueryResp, error)
+	// Retrieve artifacts collected after abnormal engine exits on a host.
+	CollectCrash(ctx context.Context, in *CollectCrashReq, opts ...grpc.CallOption) (*CollectCrashResp, error)
+	// Assemble and retrieve a support bundle of logs and diagnostics on a host.
+	CollectLog(ctx context.Context, in *CollectLogReq, opts ...grpc.CallOption) (*CollectLogResp, error)
+	// Re-read the configuration file and apply dynamic changes on a host.
+	ReloadConfig(ctx context.Context, in *ReloadConfigReq, opts ...grpc.CallOption) (*ReloadConfigResp, error)
+	// Validate a candidate configuration file and write it in place of the
+	// current file on a host, optionally restarting the server.
+	ApplyConfig(ctx context.Context, in *ApplyConfigReq, opts ...grpc.CallOption) (*ApplyConfigResp, error)
 }
 
 type ctlSvcClient struct {
@@ -160,6 +173,60 @@ func (c *ctlSvcClient) StartRanks(ctx context.Context, in *RanksReq, opts ...grp
 	return out, nil
 }
 
+func (c *ctlSvcClient) CertQuery(ctx context.Context, in *CertQueryReq, opts ...grpc.CallOption) (*CertQueryResp, error) {
+	out := new(CertQueryResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/CertQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) AuditQuery(ctx context.Context, in *AuditQueryReq, opts ...grpc.CallOption) (*AuditQueryResp, error) {
+	out := new(AuditQueryResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/AuditQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) CollectCrash(ctx context.Context, in *CollectCrashReq, opts ...grpc.CallOption) (*CollectCrashResp, error) {
+	out := new(CollectCrashResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/CollectCrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) CollectLog(ctx context.Context, in *CollectLogReq, opts ...grpc.CallOption) (*CollectLogResp, error) {
+	out := new(CollectLogResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/CollectLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) ReloadConfig(ctx context.Context, in *ReloadConfigReq, opts ...grpc.CallOption) (*ReloadConfigResp, error) {
+	out := new(ReloadConfigResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) ApplyConfig(ctx context.Context, in *ApplyConfigReq, opts ...grpc.CallOption) (*ApplyConfigResp, error) {
+	out := new(ApplyConfigResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/ApplyConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CtlSvcServer is the server API for CtlSvc service.
 // All implementations must embed UnimplementedCtlSvcServer
 // for forward compatibility
@@ -188,6 +255,19 @@ type CtlSvcServer interface {
 	ResetFormatRanks(context.Context, *RanksReq) (*RanksResp, error)
 	// Start DAOS I/O Engines on a host. (gRPC fanout)
 	StartRanks(context.Context, *RanksReq) (*RanksResp, error)
+	// Query the expiry of certificates in use on a host.
+	CertQuery(context.Context, *CertQueryReq) (*CertQueryResp, error)
+	// Query the audit log of mutating control-plane operations on a host.
+	AuditQuery(context.Context, *AuditQueryReq) (*AuditQueryResp, error)
+	// Retrieve artifacts collected after abnormal engine exits on a host.
+	CollectCrash(context.Context, *CollectCrashReq) (*CollectCrashResp, error)
+	// Assemble and retrieve a support bundle of logs and diagnostics on a host.
+	CollectLog(context.Context, *CollectLogReq) (*CollectLogResp, error)
+	// Re-read the configuration file and apply dynamic changes on a host.
+	ReloadConfig(context.Context, *ReloadConfigReq) (*ReloadConfigResp, error)
+	// Validate a candidate configuration file and write it in place of the
+	// current file on a host, optionally restarting the server.
+	ApplyConfig(context.Context, *ApplyConfigReq) (*ApplyConfigResp, error)
 	mustEmbedUnimplementedCtlSvcServer()
 }
 
@@ -231,6 +311,24 @@ func (UnimplementedCtlSvcServer) ResetFormatRanks(context.Context, *RanksReq) (*
 func (UnimplementedCtlSvcServer) StartRanks(context.Context, *RanksReq) (*RanksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartRanks not implemented")
 }
+func (UnimplementedCtlSvcServer) CertQuery(context.Context, *CertQueryReq) (*CertQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CertQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) AuditQuery(context.Context, *AuditQueryReq) (*AuditQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) CollectCrash(context.Context, *CollectCrashReq) (*CollectCrashResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectCrash not implemented")
+}
+func (UnimplementedCtlSvcServer) CollectLog(context.Context, *CollectLogReq) (*CollectLogResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectLog not implemented")
+}
+func (UnimplementedCtlSvcServer) ReloadConfig(context.Context, *ReloadConfigReq) (*ReloadConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedCtlSvcServer) ApplyConfig(context.Context, *ApplyConfigReq) (*ApplyConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyConfig not implemented")
+}
 func (UnimplementedCtlSvcServer) mustEmbedUnimplementedCtlSvcServer() {}
 
 // UnsafeCtlSvcServer may be embedded to opt out of forward compatibility for this service.
@@ -460,6 +558,114 @@ func _CtlSvc_StartRanks_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CtlSvc_CertQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CertQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).CertQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/CertQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).CertQuery(ctx, req.(*CertQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_AuditQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).AuditQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/AuditQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).AuditQuery(ctx, req.(*AuditQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_CollectCrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectCrashReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).CollectCrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/CollectCrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).CollectCrash(ctx, req.(*CollectCrashReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_CollectLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectLogReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).CollectLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/CollectLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).CollectLog(ctx, req.(*CollectLogReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).ReloadConfig(ctx, req.(*ReloadConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_ApplyConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).ApplyConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/ApplyConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).ApplyConfig(ctx, req.(*ApplyConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CtlSvc_ServiceDesc is the grpc.ServiceDesc for CtlSvc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -515,6 +721,30 @@ var CtlSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartRanks",
 			Handler:    _CtlSvc_StartRanks_Handler,
 		},
+		{
+			MethodName: "CertQuery",
+			Handler:    _CtlSvc_CertQuery_Handler,
+		},
+		{
+			MethodName: "AuditQuery",
+			Handler:    _CtlSvc_AuditQuery_Handler,
+		},
+		{
+			MethodName: "CollectCrash",
+			Handler:    _CtlSvc_CollectCrash_Handler,
+		},
+		{
+			MethodName: "CollectLog",
+			Handler:    _CtlSvc_CollectLog_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _CtlSvc_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "ApplyConfig",
+			Handler:    _CtlSvc_ApplyConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ctl/ctl.proto",
@@ -0,0 +1,246 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.6.1
+// source: ctl/support.proto
+
+package ctl
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CollectLogReq requests a chunk of a support bundle. If no bundle is
+// specified, a new bundle is assembled on the host and its first chunk
+// returned.
+type CollectLogReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle string `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`  // bundle to fetch, assemble a new bundle if empty
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // offset of the chunk to fetch
+}
+
+func (x *CollectLogReq) Reset() {
+	*x = CollectLogReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_support_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectLogReq) ProtoMessage() {}
+
+func (x *CollectLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_support_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectLogReq.ProtoReflect.Descriptor instead.
+func (*CollectLogReq) Descriptor() ([]byte, []int) {
+	return file_ctl_support_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CollectLogReq) GetBundle() string {
+	if x != nil {
+		return x.Bundle
+	}
+	return ""
+}
+
+func (x *CollectLogReq) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// CollectLogResp contains the details of a support bundle and a chunk of its
+// contents.
+type CollectLogResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle string `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"` // bundle name
+	Size   uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`    // bundle size in bytes
+	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`     // bundle contents starting at the requested offset
+}
+
+func (x *CollectLogResp) Reset() {
+	*x = CollectLogResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_support_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectLogResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectLogResp) ProtoMessage() {}
+
+func (x *CollectLogResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_support_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectLogResp.ProtoReflect.Descriptor instead.
+func (*CollectLogResp) Descriptor() ([]byte, []int) {
+	return file_ctl_support_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CollectLogResp) GetBundle() string {
+	if x != nil {
+		return x.Bundle
+	}
+	return ""
+}
+
+func (x *CollectLogResp) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CollectLogResp) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_ctl_support_proto protoreflect.FileDescriptor
+
+var file_ctl_support_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0x3f, 0x0a, 0x0d, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x50, 0x0a, 0x0e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x39, 0x5a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73,
+	0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ctl_support_proto_rawDescOnce sync.Once
+	file_ctl_support_proto_rawDescData = file_ctl_support_proto_rawDesc
+)
+
+func file_ctl_support_proto_rawDescGZIP() []byte {
+	file_ctl_support_proto_rawDescOnce.Do(func() {
+		file_ctl_support_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_support_proto_rawDescData)
+	})
+	return file_ctl_support_proto_rawDescData
+}
+
+var file_ctl_support_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ctl_support_proto_goTypes = []interface{}{
+	(*CollectLogReq)(nil),  // 0: ctl.CollectLogReq
+	(*CollectLogResp)(nil), // 1: ctl.CollectLogResp
+}
+var file_ctl_support_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ctl_support_proto_init() }
+func file_ctl_support_proto_init() {
+	if File_ctl_support_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_support_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectLogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_support_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectLogResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ctl_support_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ctl_support_proto_goTypes,
+		DependencyIndexes: file_ctl_support_proto_depIdxs,
+		MessageInfos:      file_ctl_support_proto_msgTypes,
+	}.Build()
+	File_ctl_support_proto = out.File
+	file_ctl_support_proto_rawDesc = nil
+	file_ctl_support_proto_goTypes = nil
+	file_ctl_support_proto_depIdxs = nil
+}
@@ -162,6 +162,11 @@ func Debug(msg proto.Message) string {
 		for state, set := range stateRanks {
 			fmt.Fprintf(&bld, "%s: %s ", state, set.String())
 		}
+	case *SystemConfigCheckResp:
+		fmt.Fprintf(&bld, "%T ", m)
+		for _, g := range m.Groups {
+			fmt.Fprintf(&bld, "%q: %s (%d diffs) ", g.Hash, g.Ranks, len(g.Diffs))
+		}
 	case *PoolCreateReq:
 		fmt.Fprintf(&bld, "%T uuid:%s u:%s g:%s ", m, m.Uuid, m.User, m.Usergroup)
 		if len(m.Properties) > 0 {
@@ -70,6 +70,8 @@ type MgmtSvcClient interface {
 	SystemStart(ctx context.Context, in *SystemStartReq, opts ...grpc.CallOption) (*SystemStartResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(ctx context.Context, in *SystemEraseReq, opts ...grpc.CallOption) (*SystemEraseResp, error)
+	// Compare server configs across DAOS system members
+	SystemConfigCheck(ctx context.Context, in *SystemConfigCheckReq, opts ...grpc.CallOption) (*SystemConfigCheckResp, error)
 }
 
 type mgmtSvcClient struct {
@@ -305,6 +307,15 @@ func (c *mgmtSvcClient) SystemErase(ctx context.Context, in *SystemEraseReq, opt
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SystemConfigCheck(ctx context.Context, in *SystemConfigCheckReq, opts ...grpc.CallOption) (*SystemConfigCheckResp, error) {
+	out := new(SystemConfigCheckResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemConfigCheck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MgmtSvcServer is the server API for MgmtSvc service.
 // All implementations must embed UnimplementedMgmtSvcServer
 // for forward compatibility
@@ -360,6 +371,8 @@ type MgmtSvcServer interface {
 	SystemStart(context.Context, *SystemStartReq) (*SystemStartResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error)
+	// Compare server configs across DAOS system members
+	SystemConfigCheck(context.Context, *SystemConfigCheckReq) (*SystemConfigCheckResp, error)
 	mustEmbedUnimplementedMgmtSvcServer()
 }
 
@@ -442,6 +455,9 @@ func (UnimplementedMgmtSvcServer) SystemStart(context.Context, *SystemStartReq)
 func (UnimplementedMgmtSvcServer) SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemErase not implemented")
 }
+func (UnimplementedMgmtSvcServer) SystemConfigCheck(context.Context, *SystemConfigCheckReq) (*SystemConfigCheckResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemConfigCheck not implemented")
+}
 func (UnimplementedMgmtSvcServer) mustEmbedUnimplementedMgmtSvcServer() {}
 
 // UnsafeMgmtSvcServer may be embedded to opt out of forward compatibility for this service.
@@ -905,6 +921,24 @@ func _MgmtSvc_SystemErase_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SystemConfigCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemConfigCheckReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemConfigCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemConfigCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemConfigCheck(ctx, req.(*SystemConfigCheckReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MgmtSvc_ServiceDesc is the grpc.ServiceDesc for MgmtSvc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1012,6 +1046,10 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SystemErase",
 			Handler:    _MgmtSvc_SystemErase_Handler,
 		},
+		{
+			MethodName: "SystemConfigCheck",
+			Handler:    _MgmtSvc_SystemConfigCheck_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mgmt/mgmt.proto",
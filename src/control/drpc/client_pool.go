@@ -0,0 +1,306 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultClientPoolSize is the default maximum number of concurrent
+// connections held by a ClientPool.
+const DefaultClientPoolSize = 8
+
+// ContextClient is the interface to a dRPC client that honors the deadline
+// and cancellation of the supplied context and can be used for concurrent
+// calls without external locking.
+type ContextClient interface {
+	SendMsgContext(ctx context.Context, call *Call) (*Response, error)
+}
+
+// ClientPoolStats contains metrics describing the use of a ClientPool.
+type ClientPoolStats struct {
+	InFlight    int           // calls currently in progress
+	Idle        int           // idle connections available for reuse
+	Calls       uint64        // calls made since creation
+	Waits       uint64        // calls that waited for a free connection
+	WaitTime    time.Duration // total time spent waiting for a connection
+	MaxWaitTime time.Duration // longest time spent waiting for a connection
+}
+
+// ClientPool is a dRPC client which maintains a bounded pool of connections
+// to a dRPC server so that a slow call does not block others. Each call is
+// assigned a sequence number unique within the pool and the response is
+// matched against it.
+//
+// ClientPool satisfies DomainSocketClient so that it can be used wherever a
+// ClientConnection is expected; the Lock and Connect methods are not required
+// for concurrent use via SendMsgContext.
+type ClientPool struct {
+	sync.Mutex
+	socketPath string
+	dialer     domainSocketDialer
+	slots      chan struct{}
+	idle       []net.Conn
+	closed     bool
+	sequence   int64
+	stats      ClientPoolStats
+	observer   CallObserver
+}
+
+// NewClientPool creates a dRPC client that holds up to size concurrent
+// connections to the socket.
+func NewClientPool(socket string, size int) *ClientPool {
+	if size <= 0 {
+		size = DefaultClientPoolSize
+	}
+
+	return &ClientPool{
+		socketPath: socket,
+		dialer:     &clientDialer{},
+		slots:      make(chan struct{}, size),
+	}
+}
+
+// IsConnected indicates whether the pool holds any open connections.
+func (p *ClientPool) IsConnected() bool {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.idle) > 0 || p.stats.InFlight > 0
+}
+
+// Connect verifies that the dRPC server is accepting connections, retaining
+// the connection for use by subsequent calls.
+func (p *ClientPool) Connect() error {
+	if p.IsConnected() {
+		return nil
+	}
+
+	conn, err := p.dialer.dial(p.socketPath)
+	if err != nil {
+		return errors.Wrap(err, "dRPC connect")
+	}
+	p.release(conn)
+
+	return nil
+}
+
+// Close shuts down the idle connections in the pool. Connections in use are
+// closed when their calls complete.
+func (p *ClientPool) Close() error {
+	p.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.Unlock()
+
+	var firstErr error
+	for _, conn := range idle {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = errors.Wrap(err, "dRPC close")
+		}
+	}
+	return firstErr
+}
+
+// GetSocketPath returns client dRPC socket file path.
+func (p *ClientPool) GetSocketPath() string {
+	return p.socketPath
+}
+
+// SetCallObserver sets an observer to be notified of the outcome of each
+// call sent via the pool.
+func (p *ClientPool) SetCallObserver(obs CallObserver) {
+	p.Lock()
+	defer p.Unlock()
+	p.observer = obs
+}
+
+// Stats returns a snapshot of the pool metrics.
+func (p *ClientPool) Stats() ClientPoolStats {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.stats
+	stats.Idle = len(p.idle)
+	return stats
+}
+
+// SendMsg sends a message to the dRPC server using a pooled connection and
+// returns the response to the caller.
+func (p *ClientPool) SendMsg(msg *Call) (*Response, error) {
+	return p.SendMsgContext(context.Background(), msg)
+}
+
+// acquire waits for a free slot in the pool and returns an idle connection,
+// or a new one if none are available.
+func (p *ClientPool) acquire(ctx context.Context) (net.Conn, error) {
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		start := time.Now()
+		var err error
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			err = errors.Wrap(ctx.Err(), "waiting for dRPC connection")
+		}
+		p.recordWait(time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.Lock()
+	p.stats.Calls++
+	p.stats.InFlight++
+	if n := len(p.idle); n > 0 {
+		conn := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.Unlock()
+		return conn, nil
+	}
+	p.Unlock()
+
+	conn, err := p.dialer.dial(p.socketPath)
+	if err != nil {
+		p.done()
+		return nil, errors.Wrap(err, "dRPC connect")
+	}
+	return conn, nil
+}
+
+// recordWait updates the metrics for a call that waited for a free slot.
+func (p *ClientPool) recordWait(waited time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.stats.Waits++
+	p.stats.WaitTime += waited
+	if waited > p.stats.MaxWaitTime {
+		p.stats.MaxWaitTime = waited
+	}
+}
+
+// done marks the end of a call and frees its slot.
+func (p *ClientPool) done() {
+	p.Lock()
+	p.stats.InFlight--
+	p.Unlock()
+	<-p.slots
+}
+
+// release returns a healthy connection to the pool.
+func (p *ClientPool) release(conn net.Conn) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.closed || len(p.idle) >= cap(p.slots) {
+		conn.Close()
+		return
+	}
+	p.idle = append(p.idle, conn)
+}
+
+// SendMsgContext sends a message to the dRPC server using a pooled connection
+// and returns the response to the caller. The context deadline is applied to
+// the socket, and the call is aborted if the context is canceled. Connections
+// on which a call fails are discarded rather than returned to the pool, so
+// that a late response cannot be read by a subsequent call.
+func (p *ClientPool) SendMsgContext(ctx context.Context, msg *Call) (resp *Response, err error) {
+	if msg == nil {
+		return nil, errors.Errorf("invalid dRPC call")
+	}
+
+	p.Lock()
+	obs := p.observer
+	p.Unlock()
+	startedAt := time.Now()
+	defer func() {
+		observeCall(obs, CallSideClient, msg, resp, err, startedAt)
+	}()
+
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.done()
+
+	resp, err = p.call(ctx, conn, msg)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), err.Error())
+		}
+		// The socket deadline is only set from the context, so a
+		// timeout means that the context deadline has been reached.
+		if ne, ok := errors.Cause(err).(net.Error); ok && ne.Timeout() {
+			return nil, errors.Wrap(context.DeadlineExceeded, err.Error())
+		}
+		return nil, err
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return resp, nil
+	}
+	p.release(conn)
+
+	return resp, nil
+}
+
+func (p *ClientPool) call(ctx context.Context, conn net.Conn, msg *Call) (*Response, error) {
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, errors.Wrap(err, "dRPC set deadline")
+	}
+
+	// Interrupt blocked socket operations if the context is canceled.
+	callDone := make(chan struct{})
+	defer close(callDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-callDone:
+		}
+	}()
+
+	// Sequence numbers are unique within the pool, always nonzero.
+	msg.Sequence = atomic.AddInt64(&p.sequence, 1)
+
+	callBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal dRPC request")
+	}
+	if _, err := conn.Write(callBytes); err != nil {
+		return nil, errors.Wrap(err, "dRPC send")
+	}
+
+	respBytes := make([]byte, MaxMsgSize)
+	numBytes, err := conn.Read(respBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "dRPC recv")
+	}
+
+	resp := &Response{}
+	if err := proto.Unmarshal(respBytes[:numBytes], resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal dRPC response")
+	}
+	if resp.Sequence != msg.Sequence {
+		return nil, errors.Errorf("dRPC response sequence %d does not match call sequence %d",
+			resp.Sequence, msg.Sequence)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,231 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+// pipeDialer is a domainSocketDialer which connects to an in-process dRPC
+// server that echoes the call body in its response after an optional delay.
+type pipeDialer struct {
+	sync.Mutex
+	delay     map[int32]time.Duration // per-method response delay
+	badSeq    bool                    // respond with the wrong sequence
+	dialErr   error
+	dialCount int
+}
+
+func (d *pipeDialer) dial(_ string) (net.Conn, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.dialErr != nil {
+		return nil, d.dialErr
+	}
+	d.dialCount++
+
+	client, server := net.Pipe()
+	go d.serve(server)
+	return client, nil
+}
+
+func (d *pipeDialer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	buf := make([]byte, MaxMsgSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+		call := new(Call)
+		if err := proto.Unmarshal(buf[:n], call); err != nil {
+			return
+		}
+
+		d.Lock()
+		delay := d.delay[call.Method]
+		seq := call.Sequence
+		if d.badSeq {
+			seq++
+		}
+		d.Unlock()
+		time.Sleep(delay)
+
+		respBytes, err := proto.Marshal(&Response{Sequence: seq, Body: call.Body})
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write(respBytes); err != nil {
+			return
+		}
+	}
+}
+
+func newTestClientPool(dialer *pipeDialer, size int) *ClientPool {
+	pool := NewClientPool(testSockPath, size)
+	pool.dialer = dialer
+	return pool
+}
+
+func TestClientPool_SendMsgContext(t *testing.T) {
+	for name, tc := range map[string]struct {
+		dialer  *pipeDialer
+		timeout time.Duration
+		call    *Call
+		expBody string
+		expErr  error
+	}{
+		"nil call": {
+			dialer: &pipeDialer{},
+			expErr: errors.New("invalid dRPC call"),
+		},
+		"dial fails": {
+			dialer: &pipeDialer{dialErr: errors.New("dial failed")},
+			call:   &Call{Body: []byte("foo")},
+			expErr: errors.New("dial failed"),
+		},
+		"success": {
+			dialer:  &pipeDialer{},
+			call:    &Call{Body: []byte("foo")},
+			expBody: "foo",
+		},
+		"sequence mismatch": {
+			dialer: &pipeDialer{badSeq: true},
+			call:   &Call{Body: []byte("foo")},
+			expErr: errors.New("does not match"),
+		},
+		"deadline exceeded": {
+			dialer:  &pipeDialer{delay: map[int32]time.Duration{1: time.Second}},
+			timeout: 10 * time.Millisecond,
+			call:    &Call{Method: 1, Body: []byte("foo")},
+			expErr:  context.DeadlineExceeded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pool := newTestClientPool(tc.dialer, 2)
+			defer pool.Close()
+
+			ctx := context.Background()
+			if tc.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			resp, gotErr := pool.SendMsgContext(ctx, tc.call)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				if tc.call != nil {
+					common.AssertEqual(t, 0, pool.Stats().Idle, "failed connection returned to pool")
+				}
+				return
+			}
+
+			common.AssertEqual(t, tc.expBody, string(resp.Body), "unexpected response body")
+			common.AssertEqual(t, tc.call.Sequence, resp.Sequence, "unexpected response sequence")
+			common.AssertEqual(t, 1, pool.Stats().Idle, "connection not returned to pool")
+		})
+	}
+}
+
+func TestClientPool_Concurrent(t *testing.T) {
+	dialer := &pipeDialer{
+		delay: map[int32]time.Duration{1: time.Second},
+	}
+	pool := newTestClientPool(dialer, 2)
+	defer pool.Close()
+
+	// A slow call must not block other calls.
+	slowDone := make(chan error)
+	go func() {
+		_, err := pool.SendMsgContext(context.Background(), &Call{Method: 1})
+		slowDone <- err
+	}()
+
+	for pool.Stats().InFlight == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := pool.SendMsgContext(context.Background(), &Call{Method: 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) >= time.Second {
+		t.Fatal("fast calls were blocked by slow call")
+	}
+
+	// With both connections in use, further calls wait for a free slot
+	// and give up when their context expires.
+	blocked := make(chan error)
+	go func() {
+		_, err := pool.SendMsgContext(context.Background(), &Call{Method: 1})
+		blocked <- err
+	}()
+	for pool.Stats().InFlight < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := pool.SendMsgContext(ctx, &Call{Method: 2})
+	common.CmpErr(t, errors.New("waiting for dRPC connection"), err)
+
+	for _, ch := range []chan error{slowDone, blocked} {
+		if err := <-ch; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := pool.Stats()
+	common.AssertEqual(t, 0, stats.InFlight, "unexpected in-flight calls")
+	common.AssertEqual(t, uint64(5), stats.Calls, "unexpected call count")
+	common.AssertEqual(t, uint64(1), stats.Waits, "unexpected wait count")
+	common.AssertTrue(t, stats.MaxWaitTime > 0, "expected wait time to be recorded")
+	common.AssertEqual(t, 2, dialer.dialCount, "connections not reused")
+}
+
+func TestClientPool_Close(t *testing.T) {
+	dialer := &pipeDialer{
+		delay: map[int32]time.Duration{1: 50 * time.Millisecond},
+	}
+	pool := newTestClientPool(dialer, 2)
+
+	if _, err := pool.SendMsgContext(context.Background(), &Call{Method: 2}); err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 1, pool.Stats().Idle, "connection not returned to pool")
+
+	// A call in flight when the pool is closed doesn't return its
+	// connection to the pool.
+	done := make(chan error)
+	go func() {
+		_, err := pool.SendMsgContext(context.Background(), &Call{Method: 1})
+		done <- err
+	}()
+	for pool.Stats().InFlight == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	common.CmpErr(t, nil, pool.Close())
+	common.AssertEqual(t, 0, pool.Stats().Idle, "idle connection not closed")
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 0, pool.Stats().Idle, "connection returned to closed pool")
+}
@@ -9,6 +9,7 @@ package drpc
 import (
 	"net"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -37,6 +38,7 @@ type ClientConnection struct {
 	dialer     domainSocketDialer // Interface to connect to the socket
 	conn       net.Conn           // Connection to socket
 	sequence   int64              // Increment each time we send
+	observer   CallObserver       // Notified of the outcome of each call
 }
 
 // IsConnected indicates whether the client connection is currently active
@@ -110,9 +112,15 @@ func (c *ClientConnection) recvResponse() (*Response, error) {
 	return resp, nil
 }
 
+// SetCallObserver sets an observer to be notified of the outcome of each
+// call sent via the connection.
+func (c *ClientConnection) SetCallObserver(obs CallObserver) {
+	c.observer = obs
+}
+
 // SendMsg sends a message to the connected dRPC server, and returns the
 // response to the caller.
-func (c *ClientConnection) SendMsg(msg *Call) (*Response, error) {
+func (c *ClientConnection) SendMsg(msg *Call) (resp *Response, err error) {
 	if !c.IsConnected() {
 		return nil, errors.Errorf("dRPC not connected")
 	}
@@ -121,7 +129,12 @@ func (c *ClientConnection) SendMsg(msg *Call) (*Response, error) {
 		return nil, errors.Errorf("invalid dRPC call")
 	}
 
-	err := c.sendCall(msg)
+	startedAt := time.Now()
+	defer func() {
+		observeCall(c.observer, CallSideClient, msg, resp, err, startedAt)
+	}()
+
+	err = c.sendCall(msg)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
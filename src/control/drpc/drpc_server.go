@@ -141,6 +141,12 @@ func NewDomainSocketServer(ctx context.Context, log logging.Logger, sock string)
 		sessions:  sessions}, nil
 }
 
+// SetCallObserver sets an observer to be notified of the outcome of each
+// call handled by the server.
+func (d *DomainSocketServer) SetCallObserver(obs CallObserver) {
+	d.service.SetCallObserver(obs)
+}
+
 // Session represents an individual client connection to the Domain Socket Server.
 type Session struct {
 	Conn net.Conn
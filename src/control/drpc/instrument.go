@@ -0,0 +1,156 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// CallSide identifies which end of a dRPC call is being observed.
+type CallSide string
+
+const (
+	// CallSideClient identifies calls sent by a dRPC client.
+	CallSideClient CallSide = "client"
+	// CallSideServer identifies calls handled by a dRPC server.
+	CallSideServer CallSide = "server"
+)
+
+// CallInfo describes the outcome of a single dRPC call.
+type CallInfo struct {
+	Side     CallSide
+	Module   ModuleID
+	Method   Method // nil if the method is not known
+	ReqSize  int
+	RespSize int
+	Duration time.Duration
+	// Status is the dRPC status of the response. For calls sent by a
+	// client it is not set if Err is set.
+	Status Status
+	// DaosStatus is the DAOS status reported in the response body, for
+	// methods whose response messages have a status field.
+	DaosStatus DaosStatus
+	// Err is set if the call could not be completed or, for calls handled
+	// by a server, if the module handler returned an error.
+	Err error
+}
+
+// CallObserver is notified of the outcome of dRPC calls.
+type CallObserver interface {
+	ObserveCall(info *CallInfo)
+}
+
+// daosStatusMethods are the methods whose response messages report a DAOS
+// status as an int32 in field 1. Other responses may use field 1 for other
+// values, so the status is only read from the responses of these methods.
+var daosStatusMethods = map[Method]struct{}{
+	MethodPrepShutdown:        {},
+	MethodPingRank:            {},
+	MethodSetRank:             {},
+	MethodSetUp:               {},
+	MethodSetLogMasks:         {},
+	MethodGroupUpdate:         {},
+	MethodJoin:                {},
+	MethodGetAttachInfo:       {},
+	MethodPoolCreate:          {},
+	MethodPoolDestroy:         {},
+	MethodPoolEvict:           {},
+	MethodPoolExclude:         {},
+	MethodPoolDrain:           {},
+	MethodPoolExtend:          {},
+	MethodPoolReintegrate:     {},
+	MethodPoolQuery:           {},
+	MethodPoolSetProp:         {},
+	MethodPoolGetProp:         {},
+	MethodPoolGetACL:          {},
+	MethodPoolOverwriteACL:    {},
+	MethodPoolUpdateACL:       {},
+	MethodPoolDeleteACL:       {},
+	MethodListPools:           {},
+	MethodListContainers:      {},
+	MethodContSetOwner:        {},
+	MethodSmdDevs:             {},
+	MethodSmdPools:            {},
+	MethodDevStateQuery:       {},
+	MethodSetFaultyState:      {},
+	MethodReplaceStorage:      {},
+	MethodIdentifyStorage:     {},
+	MethodGetPoolServiceRanks: {},
+	MethodPoolFindByLabel:     {},
+}
+
+// bodyDaosStatus returns the DAOS status from the response body of a call to
+// the given method. Responses of methods not in daosStatusMethods, and bodies
+// that cannot be parsed, are treated as successful.
+func bodyDaosStatus(method Method, body []byte) DaosStatus {
+	if method == nil {
+		return DaosSuccess
+	}
+	if _, found := daosStatusMethods[method]; !found {
+		return DaosSuccess
+	}
+
+	for len(body) > 0 {
+		num, typ, n := protowire.ConsumeTag(body)
+		if n < 0 {
+			return DaosSuccess
+		}
+		body = body[n:]
+
+		if num == 1 {
+			if typ != protowire.VarintType {
+				return DaosSuccess
+			}
+			val, n := protowire.ConsumeVarint(body)
+			if n < 0 {
+				return DaosSuccess
+			}
+			return DaosStatus(int32(val))
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, body)
+		if n < 0 {
+			return DaosSuccess
+		}
+		body = body[n:]
+	}
+
+	return DaosSuccess
+}
+
+// newCallInfo creates a CallInfo describing the outcome of a call.
+func newCallInfo(side CallSide, call *Call, resp *Response, err error, startedAt time.Time) *CallInfo {
+	info := &CallInfo{
+		Side:     side,
+		Module:   ModuleID(call.GetModule()),
+		ReqSize:  len(call.GetBody()),
+		Duration: time.Since(startedAt),
+		Err:      err,
+	}
+	if method, mErr := info.Module.GetMethod(call.GetMethod()); mErr == nil {
+		info.Method = method
+	}
+	if err == nil && resp != nil {
+		info.Status = resp.GetStatus()
+		info.RespSize = len(resp.GetBody())
+		if info.Status == Status_SUCCESS {
+			info.DaosStatus = bodyDaosStatus(info.Method, resp.GetBody())
+		}
+	}
+
+	return info
+}
+
+// observeCall notifies the observer, if set, of the outcome of a call.
+func observeCall(obs CallObserver, side CallSide, call *Call, resp *Response, err error, startedAt time.Time) {
+	if obs == nil || call == nil {
+		return
+	}
+	obs.ObserveCall(newCallInfo(side, call, resp, err, startedAt))
+}
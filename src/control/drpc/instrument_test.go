@@ -0,0 +1,240 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+type mockCallObserver struct {
+	sync.Mutex
+	calls []*CallInfo
+}
+
+func (o *mockCallObserver) ObserveCall(info *CallInfo) {
+	o.Lock()
+	defer o.Unlock()
+	o.calls = append(o.calls, info)
+}
+
+func cmpCallInfo(t *testing.T, expected *CallInfo, obs *mockCallObserver) {
+	t.Helper()
+
+	if len(obs.calls) != 1 {
+		t.Fatalf("expected 1 observed call, got %d", len(obs.calls))
+	}
+	if obs.calls[0].Duration <= 0 {
+		t.Fatal("expected call duration to be recorded")
+	}
+
+	common.CmpErr(t, expected.Err, obs.calls[0].Err)
+
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(CallInfo{}, "Duration", "Err"),
+	}
+	if diff := cmp.Diff(expected, obs.calls[0], cmpOpts...); diff != "" {
+		t.Fatalf("unexpected call info (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestDrpc_bodyDaosStatus(t *testing.T) {
+	statusBody := func(status int32) []byte {
+		b := protowire.AppendTag(nil, 1, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(status))
+	}
+
+	for name, tc := range map[string]struct {
+		method    Method
+		body      []byte
+		expStatus DaosStatus
+	}{
+		"empty": {
+			method:    MethodPoolCreate,
+			expStatus: DaosSuccess,
+		},
+		"unknown method": {
+			body:      statusBody(int32(DaosNonexistant)),
+			expStatus: DaosSuccess,
+		},
+		"response without status": {
+			method:    MethodClusterEvent,
+			body:      statusBody(int32(DaosNonexistant)),
+			expStatus: DaosSuccess,
+		},
+		"garbage": {
+			method:    MethodPoolCreate,
+			body:      []byte{0xff, 0xff, 0xff},
+			expStatus: DaosSuccess,
+		},
+		"status success": {
+			method:    MethodPoolCreate,
+			body:      statusBody(0),
+			expStatus: DaosSuccess,
+		},
+		"status error": {
+			method:    MethodPoolCreate,
+			body:      statusBody(int32(DaosNonexistant)),
+			expStatus: DaosNonexistant,
+		},
+		"status after other fields": {
+			method: MethodPoolCreate,
+			body: append(protowire.AppendString(
+				protowire.AppendTag(nil, 2, protowire.BytesType), "foo"),
+				statusBody(int32(DaosNoSpace))...),
+			expStatus: DaosNoSpace,
+		},
+		"field 1 not a varint": {
+			method: MethodPoolCreate,
+			body: protowire.AppendString(
+				protowire.AppendTag(nil, 1, protowire.BytesType), "foo"),
+			expStatus: DaosSuccess,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expStatus, bodyDaosStatus(tc.method, tc.body), "unexpected status")
+		})
+	}
+}
+
+func TestClient_SendMsg_Observer(t *testing.T) {
+	conn := newMockConn()
+	client := newTestClientConnection(newMockDialer(), conn)
+	obs := &mockCallObserver{}
+	client.SetCallObserver(obs)
+
+	call := newTestCall()
+	call.Body = []byte("foo")
+	conn.SetWriteOutputBytesForCall(t, call)
+	conn.SetReadOutputBytesToResponse(t, newTestResponse(call.Sequence))
+
+	if _, err := client.SendMsg(call); err != nil {
+		t.Fatal(err)
+	}
+
+	method, _ := ModuleID(call.Module).GetMethod(call.Method)
+	cmpCallInfo(t, &CallInfo{
+		Side:    CallSideClient,
+		Module:  ModuleID(call.Module),
+		Method:  method,
+		ReqSize: 3,
+		Status:  Status_SUCCESS,
+	}, obs)
+}
+
+func TestService_ProcessMessage_Observer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		handleCallErr  error
+		handleCallResp []byte
+		expInfo        *CallInfo
+	}{
+		"success": {
+			handleCallResp: []byte("succeeded"),
+			expInfo: &CallInfo{
+				Side:     CallSideServer,
+				Module:   defaultTestModID,
+				Method:   MethodPoolCreate,
+				RespSize: 9,
+			},
+		},
+		"handler error": {
+			handleCallErr: errors.New("HandleCall error"),
+			expInfo: &CallInfo{
+				Side:   CallSideServer,
+				Module: defaultTestModID,
+				Method: MethodPoolCreate,
+				Status: Status_FAILURE,
+				Err:    errors.New("HandleCall error"),
+			},
+		},
+		"handler DAOS error": {
+			handleCallErr: DaosNoSpace,
+			expInfo: &CallInfo{
+				Side:       CallSideServer,
+				Module:     defaultTestModID,
+				Method:     MethodPoolCreate,
+				Status:     Status_FAILURE,
+				DaosStatus: DaosNoSpace,
+				Err:        DaosNoSpace,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mockMod := newTestModule(defaultTestModID)
+			mockMod.HandleCallErr = tc.handleCallErr
+			mockMod.HandleCallResponse = tc.handleCallResp
+
+			obs := &mockCallObserver{}
+			service := NewModuleService(log)
+			service.RegisterModule(mockMod)
+			service.SetCallObserver(obs)
+
+			callBytes := getCallBytes(t, 1, int32(defaultTestModID), MethodPoolCreate)
+			if _, err := service.ProcessMessage(&Session{}, callBytes); err != nil {
+				t.Fatal(err)
+			}
+
+			cmpCallInfo(t, tc.expInfo, obs)
+		})
+	}
+}
+
+func TestClientPool_SendMsgContext_Observer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		dialer  *pipeDialer
+		expInfo *CallInfo
+	}{
+		"success": {
+			dialer: &pipeDialer{},
+			expInfo: &CallInfo{
+				Side:     CallSideClient,
+				Module:   ModuleMgmt,
+				Method:   MethodPoolCreate,
+				ReqSize:  3,
+				RespSize: 3,
+			},
+		},
+		"dial fails": {
+			dialer: &pipeDialer{dialErr: errors.New("dial failed")},
+			expInfo: &CallInfo{
+				Side:    CallSideClient,
+				Module:  ModuleMgmt,
+				Method:  MethodPoolCreate,
+				ReqSize: 3,
+				Err:     errors.New("dial failed"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pool := newTestClientPool(tc.dialer, 1)
+			defer pool.Close()
+
+			obs := &mockCallObserver{}
+			pool.SetCallObserver(obs)
+
+			pool.SendMsgContext(context.Background(), &Call{
+				Module: int32(ModuleMgmt),
+				Method: MethodPoolCreate.ID(),
+				Body:   []byte("foo"),
+			})
+
+			cmpCallInfo(t, tc.expInfo, obs)
+		})
+	}
+}
@@ -7,6 +7,8 @@
 package drpc
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
@@ -23,8 +25,9 @@ type Module interface {
 // ModuleService is the collection of Modules used by
 // DomainSocketServer to be used to process messages.
 type ModuleService struct {
-	log     logging.Logger
-	modules map[ModuleID]Module
+	log      logging.Logger
+	modules  map[ModuleID]Module
+	observer CallObserver
 }
 
 // NewModuleService creates an initialized ModuleService instance
@@ -49,6 +52,12 @@ func (r *ModuleService) RegisterModule(mod Module) {
 	r.modules[mod.ID()] = mod
 }
 
+// SetCallObserver sets an observer to be notified of the outcome of each
+// call handled by the registered modules.
+func (r *ModuleService) SetCallObserver(obs CallObserver) {
+	r.observer = obs
+}
+
 // GetModule fetches the module for the given ID. Returns true if found, false
 // otherwise.
 func (r *ModuleService) GetModule(id ModuleID) (Module, bool) {
@@ -109,11 +118,32 @@ func (r *ModuleService) ProcessMessage(session *Session, msgBytes []byte) ([]byt
 	if err != nil {
 		return marshalResponse(msg.GetSequence(), Status_UNKNOWN_METHOD, nil)
 	}
+
+	startedAt := time.Now()
 	respBody, err := module.HandleCall(session, method, msg.GetBody())
 	if err != nil {
 		r.log.Errorf("HandleCall for %s:%s failed: %s\n", module.ID().String(), method.String(), err)
+		r.observeHandled(msg, &Response{Status: ErrorToStatus(err)}, err, startedAt)
 		return marshalResponse(msg.GetSequence(), ErrorToStatus(err), nil)
 	}
+	r.observeHandled(msg, &Response{Body: respBody}, nil, startedAt)
 
 	return marshalResponse(msg.GetSequence(), Status_SUCCESS, respBody)
 }
+
+// observeHandled notifies the observer, if set, of the outcome of a call
+// handled by a module.
+func (r *ModuleService) observeHandled(call *Call, resp *Response, err error, startedAt time.Time) {
+	if r.observer == nil {
+		return
+	}
+
+	info := newCallInfo(CallSideServer, call, resp, nil, startedAt)
+	if err != nil {
+		info.Err = err
+		if ds, ok := errors.Cause(err).(DaosStatus); ok {
+			info.DaosStatus = ds
+		}
+	}
+	r.observer.ObserveCall(info)
+}
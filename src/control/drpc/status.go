@@ -26,6 +26,11 @@ func (ds DaosStatus) Error() string {
 	return fmt.Sprintf("%s(%d): %s", dErrStr, ds, dErrDesc)
 }
 
+// Name returns the symbolic name of the status, e.g. DER_BUSY.
+func (ds DaosStatus) Name() string {
+	return C.GoString(C.d_errstr(C.int(ds)))
+}
+
 const (
 	// DaosSuccess indicates no error
 	DaosSuccess DaosStatus = 0
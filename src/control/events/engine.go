@@ -74,6 +74,41 @@ func NewEngineDiedEvent(hostname string, instanceIdx uint32, rank uint32, exitEr
 	})
 }
 
+// NewEngineCrashLoopEvent creates a specific EngineCrashLoop event from given
+// inputs, raised when an engine's restart policy stops restarting it.
+func NewEngineCrashLoopEvent(hostname string, instanceIdx uint32, rank uint32, exitErr common.ExitStatus, restarts int) *RASEvent {
+	return fill(&RASEvent{
+		Msg: fmt.Sprintf("DAOS engine %d is crash looping after %d restarts, not restarting: %s",
+			instanceIdx, restarts, exitErr),
+		ID:       RASEngineCrashLoop,
+		Hostname: hostname,
+		Rank:     rank,
+		Type:     RASTypeInfoOnly,
+		Severity: RASSeverityError,
+		ExtendedInfo: &EngineStateInfo{
+			InstanceIdx: instanceIdx,
+			ExitErr:     exitErr,
+		},
+	})
+}
+
+// NewEngineStoppingEvent creates a specific EngineStopping event from given
+// inputs, raised when an engine is about to be stopped in a controlled manner
+// by its local control plane.
+func NewEngineStoppingEvent(hostname string, instanceIdx uint32, rank uint32, reason string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:      fmt.Sprintf("DAOS engine %d is stopping: %s", instanceIdx, reason),
+		ID:       RASEngineStopping,
+		Hostname: hostname,
+		Rank:     rank,
+		Type:     RASTypeStateChange,
+		Severity: RASSeverityNotice,
+		ExtendedInfo: &EngineStateInfo{
+			InstanceIdx: instanceIdx,
+		},
+	})
+}
+
 // NewEngineFormatRequiredEvent creates a EngineFormatRequired event from given inputs.
 func NewEngineFormatRequiredEvent(hostname string, instanceIdx uint32, formatType string) *RASEvent {
 	return fill(&RASEvent{
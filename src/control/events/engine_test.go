@@ -79,3 +79,39 @@ func TestEvents_ConvertEngineFormatRequired(t *testing.T) {
 		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
 	}
 }
+
+func TestEvents_ConvertEngineCrashLoop(t *testing.T) {
+	event := NewEngineCrashLoopEvent(tHost, tInstanceIdx, tRank, tExitErr, 5)
+
+	pbEvent, err := event.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returnedEvent := new(RASEvent)
+	if err := returnedEvent.FromProto(pbEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(event, returnedEvent, defEvtCmpOpts...); diff != "" {
+		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestEvents_ConvertEngineStopping(t *testing.T) {
+	event := NewEngineStoppingEvent(tHost, tInstanceIdx, tRank, "server shutdown")
+
+	pbEvent, err := event.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returnedEvent := new(RASEvent)
+	if err := returnedEvent.FromProto(pbEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(event, returnedEvent, defEvtCmpOpts...); diff != "" {
+		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+	}
+}
@@ -61,3 +61,17 @@ func NewGenericEvent(id RASID, sev RASSeverityID, msg, info string) *RASEvent {
 		ExtendedInfo: NewStrInfo(info),
 	})
 }
+
+// NewAuditRecordEvent returns an AuditRecord event carrying a JSON encoded
+// control-plane audit record, forwarded so that the MS leader can add records
+// made on other servers to the system database.
+func NewAuditRecordEvent(hostname, record string) *RASEvent {
+	return fill(&RASEvent{
+		ID:           RASAuditRecord,
+		Type:         RASTypeInfoOnly,
+		Msg:          "control-plane audit record",
+		Hostname:     hostname,
+		Rank:         math.MaxUint32,
+		ExtendedInfo: NewStrInfo(record),
+	})
+}
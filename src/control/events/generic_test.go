@@ -40,3 +40,21 @@ func TestEvents_ConvertGeneric(t *testing.T) {
 		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
 	}
 }
+
+func TestEvents_ConvertAuditRecord(t *testing.T) {
+	event := NewAuditRecordEvent("host1", `{"method":"/mgmt.MgmtSvc/PoolCreate"}`)
+
+	pbEvent, err := event.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returnedEvent := new(RASEvent)
+	if err := returnedEvent.FromProto(pbEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(event, returnedEvent, defEvtCmpOpts...); diff != "" {
+		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+	}
+}
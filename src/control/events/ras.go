@@ -53,6 +53,10 @@ const (
 	RASSwimRankDead         RASID = C.RAS_SWIM_RANK_DEAD         // info
 	RASSystemStartFailed    RASID = C.RAS_SYSTEM_START_FAILED    // error
 	RASSystemStopFailed     RASID = C.RAS_SYSTEM_STOP_FAILED     // error
+	RASCertExpiring         RASID = C.RAS_CERT_EXPIRING          // warning
+	RASEngineCrashLoop      RASID = C.RAS_ENGINE_CRASH_LOOP      // error
+	RASEngineStopping       RASID = C.RAS_ENGINE_STOPPING        // notice
+	RASAuditRecord          RASID = C.RAS_AUDIT_RECORD           // info
 )
 
 func (id RASID) String() string {
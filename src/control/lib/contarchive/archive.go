@@ -0,0 +1,399 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package contarchive implements a portable, streamable archive format for
+// DAOS containers.
+//
+// An archive starts with a magic string and a format version, followed by a
+// sequence of records and terminated by an end record. Each record is framed
+// as a one byte type, a four byte big-endian payload length, the payload and
+// a CRC32C of the preceding fields so that corruption is detected as the
+// archive is read. The end record holds a SHA-256 digest of everything that
+// precedes it, which detects truncated or reordered archives.
+//
+// The first record holds the container metadata as JSON. It is followed by
+// the data of each object: an object record, then for each dkey a dkey record
+// followed by an akey record and one or more value records for each akey.
+package contarchive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"hash"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Magic identifies a container archive.
+	Magic = "DAOSCTAR"
+	// Version is the archive format version written by this package.
+	Version = 1
+	// MaxPayloadSize is the largest record payload that may be written or
+	// read. Larger values must be split across several array records.
+	MaxPayloadSize = 64 << 20
+
+	recordHeaderSize = 5
+	recordCRCSize    = 4
+)
+
+// RecordType identifies the content of an archive record.
+type RecordType uint8
+
+// Record types.
+const (
+	RecordMetadata RecordType = iota + 1
+	RecordObject
+	RecordDKey
+	RecordAKey
+	RecordSingle
+	RecordArray
+	RecordEnd
+)
+
+func (rt RecordType) String() string {
+	switch rt {
+	case RecordMetadata:
+		return "metadata"
+	case RecordObject:
+		return "object"
+	case RecordDKey:
+		return "dkey"
+	case RecordAKey:
+		return "akey"
+	case RecordSingle:
+		return "single"
+	case RecordArray:
+		return "array"
+	case RecordEnd:
+		return "end"
+	default:
+		return "unknown"
+	}
+}
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type (
+	// ObjectID is a DAOS object ID.
+	ObjectID struct {
+		Hi uint64 `json:"hi"`
+		Lo uint64 `json:"lo"`
+	}
+
+	// Property is a container property in its raw form. String-valued
+	// properties set String, all others set Value.
+	Property struct {
+		Name   string `json:"name"`
+		Value  uint64 `json:"value,omitempty"`
+		String string `json:"string,omitempty"`
+	}
+
+	// Attribute is a container user attribute.
+	Attribute struct {
+		Name  string `json:"name"`
+		Value []byte `json:"value"`
+	}
+
+	// Snapshot describes a snapshot of the source container. Snapshot
+	// epochs are specific to the source system so they are informational.
+	Snapshot struct {
+		Epoch uint64 `json:"epoch"`
+		Name  string `json:"name,omitempty"`
+	}
+
+	// Metadata describes the archived container.
+	Metadata struct {
+		Created    time.Time    `json:"created"`
+		Pool       string       `json:"pool"`
+		Container  string       `json:"container"`
+		Label      string       `json:"label,omitempty"`
+		Layout     string       `json:"layout"`
+		Properties []*Property  `json:"properties"`
+		ACL        []string     `json:"acl,omitempty"`
+		Roots      []ObjectID   `json:"roots,omitempty"`
+		Attributes []*Attribute `json:"attributes,omitempty"`
+		Snapshots  []*Snapshot  `json:"snapshots,omitempty"`
+	}
+
+	// Record is a single record read from an archive.
+	Record struct {
+		Type    RecordType
+		Payload []byte
+	}
+)
+
+// Writer writes a container archive to an underlying io.Writer.
+type Writer struct {
+	out    io.Writer
+	digest hash.Hash
+	closed bool
+}
+
+// NewWriter returns a Writer after writing the archive preamble.
+func NewWriter(w io.Writer) (*Writer, error) {
+	aw := &Writer{
+		digest: sha256.New(),
+	}
+	aw.out = io.MultiWriter(w, aw.digest)
+
+	var preamble bytes.Buffer
+	preamble.WriteString(Magic)
+	if err := binary.Write(&preamble, binary.BigEndian, uint16(Version)); err != nil {
+		return nil, err
+	}
+	if _, err := aw.out.Write(preamble.Bytes()); err != nil {
+		return nil, errors.Wrap(err, "write archive preamble")
+	}
+
+	return aw, nil
+}
+
+func (w *Writer) writeRecord(rt RecordType, payload []byte) error {
+	if w.closed {
+		return errors.New("archive writer closed")
+	}
+	if len(payload) > MaxPayloadSize {
+		return errors.Errorf("%s record payload too large (%d > %d)",
+			rt, len(payload), MaxPayloadSize)
+	}
+
+	buf := make([]byte, recordHeaderSize+len(payload)+recordCRCSize)
+	buf[0] = byte(rt)
+	binary.BigEndian.PutUint32(buf[1:recordHeaderSize], uint32(len(payload)))
+	copy(buf[recordHeaderSize:], payload)
+	crcOff := len(buf) - recordCRCSize
+	binary.BigEndian.PutUint32(buf[crcOff:], crc32.Checksum(buf[:crcOff], crcTable))
+
+	if _, err := w.out.Write(buf); err != nil {
+		return errors.Wrapf(err, "write %s record", rt)
+	}
+
+	return nil
+}
+
+// WriteMetadata writes the container metadata record.
+func (w *Writer) WriteMetadata(md *Metadata) error {
+	if md == nil {
+		return errors.New("nil metadata")
+	}
+
+	payload, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+
+	return w.writeRecord(RecordMetadata, payload)
+}
+
+// WriteObject starts the data of a new object.
+func (w *Writer) WriteObject(oid ObjectID) error {
+	payload := make([]byte, 16)
+	binary.BigEndian.PutUint64(payload[0:8], oid.Hi)
+	binary.BigEndian.PutUint64(payload[8:16], oid.Lo)
+
+	return w.writeRecord(RecordObject, payload)
+}
+
+// WriteDKey starts a new dkey within the current object.
+func (w *Writer) WriteDKey(key []byte) error {
+	if len(key) == 0 {
+		return errors.New("empty dkey")
+	}
+	return w.writeRecord(RecordDKey, key)
+}
+
+// WriteAKey starts a new akey within the current dkey.
+func (w *Writer) WriteAKey(key []byte) error {
+	if len(key) == 0 {
+		return errors.New("empty akey")
+	}
+	return w.writeRecord(RecordAKey, key)
+}
+
+// WriteSingle writes the single value stored under the current akey.
+func (w *Writer) WriteSingle(value []byte) error {
+	return w.writeRecord(RecordSingle, value)
+}
+
+// WriteArray writes an extent of the array stored under the current akey,
+// starting at record index idx. The data must hold a whole number of records.
+func (w *Writer) WriteArray(recSize, idx uint64, data []byte) error {
+	if recSize == 0 || uint64(len(data))%recSize != 0 {
+		return errors.Errorf("array data size %d is not a multiple of record size %d",
+			len(data), recSize)
+	}
+
+	payload := make([]byte, 16+len(data))
+	binary.BigEndian.PutUint64(payload[0:8], recSize)
+	binary.BigEndian.PutUint64(payload[8:16], idx)
+	copy(payload[16:], data)
+
+	return w.writeRecord(RecordArray, payload)
+}
+
+// Close terminates the archive with the end record. It does not close the
+// underlying io.Writer.
+func (w *Writer) Close() error {
+	if err := w.writeRecord(RecordEnd, w.digest.Sum(nil)); err != nil {
+		return err
+	}
+	w.closed = true
+
+	return nil
+}
+
+// Reader reads a container archive from an underlying io.Reader, verifying
+// record checksums and the archive digest as it goes.
+type Reader struct {
+	in      io.Reader
+	digest  hash.Hash
+	version uint16
+	done    bool
+}
+
+// NewReader returns a Reader after validating the archive preamble.
+func NewReader(r io.Reader) (*Reader, error) {
+	ar := &Reader{
+		digest: sha256.New(),
+	}
+	ar.in = io.TeeReader(r, ar.digest)
+
+	preamble := make([]byte, len(Magic)+2)
+	if _, err := io.ReadFull(ar.in, preamble); err != nil {
+		return nil, errors.Wrap(err, "read archive preamble")
+	}
+	if string(preamble[:len(Magic)]) != Magic {
+		return nil, errors.New("not a container archive")
+	}
+	ar.version = binary.BigEndian.Uint16(preamble[len(Magic):])
+	if ar.version == 0 || ar.version > Version {
+		return nil, errors.Errorf("unsupported archive version %d (max %d)",
+			ar.version, Version)
+	}
+
+	return ar, nil
+}
+
+// Version returns the format version of the archive being read.
+func (r *Reader) Version() int {
+	return int(r.version)
+}
+
+// Next returns the next record in the archive. After the end record has been
+// read and the archive digest verified, io.EOF is returned.
+func (r *Reader) Next() (*Record, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	// the digest covers everything before the end record
+	expDigest := r.digest.Sum(nil)
+
+	hdr := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r.in, hdr); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, errors.Wrap(err, "archive truncated")
+	}
+	rec := &Record{Type: RecordType(hdr[0])}
+	size := binary.BigEndian.Uint32(hdr[1:])
+	if size > MaxPayloadSize {
+		return nil, errors.Errorf("%s record payload too large (%d > %d)",
+			rec.Type, size, MaxPayloadSize)
+	}
+
+	rest := make([]byte, int(size)+recordCRCSize)
+	if _, err := io.ReadFull(r.in, rest); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, errors.Wrap(err, "archive truncated")
+	}
+	rec.Payload = rest[:size]
+
+	crc := crc32.Update(crc32.Checksum(hdr, crcTable), crcTable, rec.Payload)
+	if crc != binary.BigEndian.Uint32(rest[size:]) {
+		return nil, errors.Errorf("%s record checksum mismatch", rec.Type)
+	}
+
+	switch rec.Type {
+	case RecordMetadata, RecordObject, RecordDKey, RecordAKey, RecordSingle, RecordArray:
+	case RecordEnd:
+		if !bytes.Equal(rec.Payload, expDigest) {
+			return nil, errors.New("archive digest mismatch")
+		}
+		r.done = true
+		return nil, io.EOF
+	default:
+		return nil, errors.Errorf("unknown record type %d", rec.Type)
+	}
+
+	return rec, nil
+}
+
+func (rec *Record) checkType(rt RecordType) error {
+	if rec.Type != rt {
+		return errors.Errorf("expected %s record, got %s", rt, rec.Type)
+	}
+	return nil
+}
+
+// Metadata decodes a metadata record.
+func (rec *Record) Metadata() (*Metadata, error) {
+	if err := rec.checkType(RecordMetadata); err != nil {
+		return nil, err
+	}
+
+	md := new(Metadata)
+	if err := json.Unmarshal(rec.Payload, md); err != nil {
+		return nil, errors.Wrap(err, "decode metadata")
+	}
+
+	return md, nil
+}
+
+// Object decodes an object record.
+func (rec *Record) Object() (ObjectID, error) {
+	if err := rec.checkType(RecordObject); err != nil {
+		return ObjectID{}, err
+	}
+	if len(rec.Payload) != 16 {
+		return ObjectID{}, errors.Errorf("invalid object record size %d", len(rec.Payload))
+	}
+
+	return ObjectID{
+		Hi: binary.BigEndian.Uint64(rec.Payload[0:8]),
+		Lo: binary.BigEndian.Uint64(rec.Payload[8:16]),
+	}, nil
+}
+
+// Array decodes an array record, returning the record size, the index of
+// the first record and the data.
+func (rec *Record) Array() (uint64, uint64, []byte, error) {
+	if err := rec.checkType(RecordArray); err != nil {
+		return 0, 0, nil, err
+	}
+	if len(rec.Payload) < 16 {
+		return 0, 0, nil, errors.Errorf("invalid array record size %d", len(rec.Payload))
+	}
+
+	recSize := binary.BigEndian.Uint64(rec.Payload[0:8])
+	idx := binary.BigEndian.Uint64(rec.Payload[8:16])
+	data := rec.Payload[16:]
+	if recSize == 0 || uint64(len(data))%recSize != 0 {
+		return 0, 0, nil, errors.Errorf("array data size %d is not a multiple of record size %d",
+			len(data), recSize)
+	}
+
+	return recSize, idx, data, nil
+}
@@ -0,0 +1,274 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package contarchive
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func testMetadata() *Metadata {
+	return &Metadata{
+		Created:   time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		Pool:      common.MockUUID(0),
+		Container: common.MockUUID(1),
+		Label:     "mycont",
+		Layout:    "POSIX",
+		Properties: []*Property{
+			{Name: "label", String: "mycont"},
+			{Name: "cksum", Value: 3},
+		},
+		ACL:   []string{"A::OWNER@:rwdtTaAo"},
+		Roots: []ObjectID{{Hi: 0, Lo: 1}, {Hi: 0, Lo: 2}},
+		Attributes: []*Attribute{
+			{Name: "binary", Value: []byte{0, 1, 2}},
+		},
+		Snapshots: []*Snapshot{
+			{Epoch: 262508437483290624, Name: "snap1"},
+		},
+	}
+}
+
+// writeTestArchive writes an archive with metadata and one object holding a
+// single value and an array value.
+func writeTestArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range []func() error{
+		func() error { return w.WriteMetadata(testMetadata()) },
+		func() error { return w.WriteObject(ObjectID{Hi: 1, Lo: 2}) },
+		func() error { return w.WriteDKey([]byte("dkey")) },
+		func() error { return w.WriteAKey([]byte("single")) },
+		func() error { return w.WriteSingle([]byte("hello")) },
+		func() error { return w.WriteAKey([]byte("array")) },
+		func() error { return w.WriteArray(4, 8, []byte("abcdefgh")) },
+		w.Close,
+	} {
+		if err := fn(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func readAll(data []byte) ([]*Record, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var recs []*Record
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return recs, nil
+		}
+		if err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func TestContArchive_RoundTrip(t *testing.T) {
+	recs, err := readAll(writeTestArchive(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expTypes := []RecordType{
+		RecordMetadata, RecordObject, RecordDKey, RecordAKey, RecordSingle,
+		RecordAKey, RecordArray,
+	}
+	var gotTypes []RecordType
+	for _, rec := range recs {
+		gotTypes = append(gotTypes, rec.Type)
+	}
+	if diff := cmp.Diff(expTypes, gotTypes); diff != "" {
+		t.Fatalf("unexpected record types (-want, +got):\n%s\n", diff)
+	}
+
+	md, err := recs[0].Metadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testMetadata(), md); diff != "" {
+		t.Fatalf("unexpected metadata (-want, +got):\n%s\n", diff)
+	}
+
+	oid, err := recs[1].Object()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, ObjectID{Hi: 1, Lo: 2}, oid, "unexpected oid")
+	common.AssertEqual(t, "dkey", string(recs[2].Payload), "unexpected dkey")
+	common.AssertEqual(t, "hello", string(recs[4].Payload), "unexpected single value")
+
+	recSize, idx, data, err := recs[6].Array()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, uint64(4), recSize, "unexpected record size")
+	common.AssertEqual(t, uint64(8), idx, "unexpected index")
+	common.AssertEqual(t, "abcdefgh", string(data), "unexpected array data")
+
+	if _, err := recs[1].Metadata(); err == nil {
+		t.Fatal("expected error decoding object record as metadata")
+	}
+}
+
+func TestContArchive_Reader(t *testing.T) {
+	archive := writeTestArchive(t)
+
+	for name, tc := range map[string]struct {
+		data   func() []byte
+		expErr error
+	}{
+		"empty": {
+			data:   func() []byte { return nil },
+			expErr: errors.New("read archive preamble"),
+		},
+		"bad magic": {
+			data: func() []byte {
+				data := append([]byte{}, archive...)
+				data[0] = 'X'
+				return data
+			},
+			expErr: errors.New("not a container archive"),
+		},
+		"unsupported version": {
+			data: func() []byte {
+				data := append([]byte{}, archive...)
+				data[len(Magic)+1] = Version + 1
+				return data
+			},
+			expErr: errors.New("unsupported archive version"),
+		},
+		"corrupt payload": {
+			data: func() []byte {
+				data := append([]byte{}, archive...)
+				data[len(data)/2] ^= 0xff
+				return data
+			},
+			expErr: errors.New("checksum mismatch"),
+		},
+		"truncated": {
+			data: func() []byte {
+				return archive[:len(archive)-10]
+			},
+			expErr: errors.New("archive truncated"),
+		},
+		"missing end": {
+			data: func() []byte {
+				return archive[:len(archive)-(recordHeaderSize+32+recordCRCSize)]
+			},
+			expErr: errors.New("archive truncated"),
+		},
+		"valid": {
+			data: func() []byte { return archive },
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, gotErr := readAll(tc.data())
+			common.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestContArchive_ReorderedRecords(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteDKey([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	first := buf.Len()
+	if err := w.WriteDKey([]byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	second := buf.Len()
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// swap the two (equally sized) dkey records, which remain valid
+	// individually but invalidate the archive digest
+	data := buf.Bytes()
+	reordered := append([]byte{}, data[:len(Magic)+2]...)
+	reordered = append(reordered, data[first:second]...)
+	reordered = append(reordered, data[len(Magic)+2:first]...)
+	reordered = append(reordered, data[second:]...)
+
+	_, gotErr := readAll(reordered)
+	common.CmpErr(t, errors.New("digest mismatch"), gotErr)
+}
+
+func TestContArchive_Writer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		write  func(*Writer) error
+		expErr error
+	}{
+		"nil metadata": {
+			write:  func(w *Writer) error { return w.WriteMetadata(nil) },
+			expErr: errors.New("nil metadata"),
+		},
+		"empty dkey": {
+			write:  func(w *Writer) error { return w.WriteDKey(nil) },
+			expErr: errors.New("empty dkey"),
+		},
+		"empty akey": {
+			write:  func(w *Writer) error { return w.WriteAKey(nil) },
+			expErr: errors.New("empty akey"),
+		},
+		"partial array record": {
+			write:  func(w *Writer) error { return w.WriteArray(4, 0, []byte("abc")) },
+			expErr: errors.New("not a multiple of record size"),
+		},
+		"zero record size": {
+			write:  func(w *Writer) error { return w.WriteArray(0, 0, nil) },
+			expErr: errors.New("not a multiple of record size"),
+		},
+		"payload too large": {
+			write: func(w *Writer) error {
+				return w.WriteSingle(make([]byte, MaxPayloadSize+1))
+			},
+			expErr: errors.New("payload too large"),
+		},
+		"write after close": {
+			write: func(w *Writer) error {
+				if err := w.Close(); err != nil {
+					return err
+				}
+				return w.WriteDKey([]byte("dkey"))
+			},
+			expErr: errors.New("writer closed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w, err := NewWriter(&bytes.Buffer{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.CmpErr(t, tc.expErr, tc.write(w))
+		})
+	}
+}
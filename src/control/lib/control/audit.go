@@ -0,0 +1,150 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+type (
+	// AuditRecord describes a single mutating control-plane operation.
+	AuditRecord struct {
+		Time     time.Time         `json:"time"`
+		Host     string            `json:"host"`
+		Caller   string            `json:"caller"`
+		Roles    []string          `json:"roles,omitempty"`
+		Source   string            `json:"source,omitempty"`
+		Method   string            `json:"method"`
+		Fields   map[string]string `json:"fields,omitempty"`
+		Outcome  string            `json:"outcome"`
+		Error    string            `json:"error,omitempty"`
+		Duration time.Duration     `json:"duration"`
+	}
+
+	// AuditQueryReq contains the parameters for an audit log query request.
+	// If SystemDB is set, the records are read from the system database on
+	// the management service leader, otherwise they are read from the audit
+	// log of each host in the request's hostlist.
+	AuditQueryReq struct {
+		unaryRequest
+		Since    time.Time
+		Method   string
+		Caller   string
+		Limit    uint32
+		SystemDB bool
+	}
+
+	// AuditQueryResp contains the results of an audit log query request.
+	AuditQueryResp struct {
+		HostErrorsResp
+		Records []*AuditRecord `json:"records"`
+	}
+)
+
+// isMSRequest implements part of the targetChooser interface, routing
+// requests for records in the system database to the MS leader.
+func (req *AuditQueryReq) isMSRequest() bool {
+	return req.SystemDB
+}
+
+func (aqr *AuditQueryResp) addRecords(pbResp *ctlpb.AuditQueryResp) {
+	for _, pbRec := range pbResp.GetRecords() {
+		rec := &AuditRecord{
+			Time:     time.Unix(0, pbRec.GetTime()),
+			Host:     pbRec.GetHost(),
+			Caller:   pbRec.GetCaller(),
+			Roles:    pbRec.GetRoles(),
+			Source:   pbRec.GetSource(),
+			Method:   pbRec.GetMethod(),
+			Outcome:  pbRec.GetOutcome(),
+			Error:    pbRec.GetError(),
+			Duration: time.Duration(pbRec.GetDuration()),
+		}
+		if len(pbRec.GetFields()) > 0 {
+			rec.Fields = make(map[string]string)
+			for _, field := range pbRec.GetFields() {
+				rec.Fields[field.GetKey()] = field.GetValue()
+			}
+		}
+		aqr.Records = append(aqr.Records, rec)
+	}
+}
+
+// AuditQuery requests records of mutating control-plane operations from the
+// audit log of all hosts supplied in the request's hostlist, or all
+// configured hosts if not explicitly specified, or from the system database
+// if requested. The results are sorted by time.
+func AuditQuery(ctx context.Context, rpcClient UnaryInvoker, req *AuditQueryReq) (*AuditQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	pbReq := &ctlpb.AuditQueryReq{
+		Method:   req.Method,
+		Caller:   req.Caller,
+		Limit:    req.Limit,
+		SystemDb: req.SystemDB,
+	}
+	if !req.Since.IsZero() {
+		pbReq.Since = req.Since.UnixNano()
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).AuditQuery(ctx, pbReq)
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(AuditQueryResp)
+	if req.SystemDB {
+		msResp, err := ur.getMSResponse()
+		if err != nil {
+			return nil, err
+		}
+		pbResp, ok := msResp.(*ctlpb.AuditQueryResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", msResp)
+		}
+		resp.addRecords(pbResp)
+
+		return resp, nil
+	}
+
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hostResp.Message.(*ctlpb.AuditQueryResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+		}
+		resp.addRecords(pbResp)
+	}
+
+	sort.SliceStable(resp.Records, func(i, j int) bool {
+		return resp.Records[i].Time.Before(resp.Records[j].Time)
+	})
+	if req.Limit > 0 && len(resp.Records) > int(req.Limit) {
+		resp.Records = resp.Records[len(resp.Records)-int(req.Limit):]
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_AuditQuery(t *testing.T) {
+	pbRecord := func(host string, ts int64, method string) *ctlpb.AuditRecord {
+		return &ctlpb.AuditRecord{
+			Time:     ts,
+			Host:     host,
+			Caller:   "admin",
+			Method:   method,
+			Fields:   []*ctlpb.AuditField{{Key: "id", Value: "pool1"}},
+			Outcome:  "success",
+			Duration: int64(time.Millisecond),
+		}
+	}
+	record := func(host string, ts int64, method string) *AuditRecord {
+		return &AuditRecord{
+			Time:     time.Unix(0, ts),
+			Host:     host,
+			Caller:   "admin",
+			Method:   method,
+			Fields:   map[string]string{"id": "pool1"},
+			Outcome:  "success",
+			Duration: time.Millisecond,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req     *AuditQueryReq
+		mic     *MockInvokerConfig
+		expResp *AuditQueryResp
+		expErr  error
+	}{
+		"local failure": {
+			req: &AuditQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &AuditQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+					},
+				},
+			},
+			expResp: &AuditQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"multiple hosts sorted and limited": {
+			req: &AuditQueryReq{Limit: 2},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host2",
+							Message: &ctlpb.AuditQueryResp{
+								Records: []*ctlpb.AuditRecord{
+									pbRecord("host2", 2, "/ctl.CtlSvc/StorageFormat"),
+								},
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.AuditQueryResp{
+								Records: []*ctlpb.AuditRecord{
+									pbRecord("host1", 1, "/ctl.CtlSvc/StorageFormat"),
+									pbRecord("host1", 3, "/mgmt.MgmtSvc/PoolDestroy"),
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &AuditQueryResp{
+				Records: []*AuditRecord{
+					record("host2", 2, "/ctl.CtlSvc/StorageFormat"),
+					record("host1", 3, "/mgmt.MgmtSvc/PoolDestroy"),
+				},
+			},
+		},
+		"system db": {
+			req: &AuditQueryReq{SystemDB: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, &ctlpb.AuditQueryResp{
+					Records: []*ctlpb.AuditRecord{
+						pbRecord("host2", 1, "/mgmt.MgmtSvc/PoolDestroy"),
+					},
+				}),
+			},
+			expResp: &AuditQueryResp{
+				Records: []*AuditRecord{
+					record("host2", 1, "/mgmt.MgmtSvc/PoolDestroy"),
+				},
+			},
+		},
+		"system db failure": {
+			req: &AuditQueryReq{SystemDB: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("not stored"), nil),
+			},
+			expErr: errors.New("not stored"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := AuditQuery(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -10,11 +10,13 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
 	"sort"
 
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	nd "github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
@@ -30,6 +32,9 @@ const (
 	defaultTargetCount    = 16
 	defaultEngineLogFile  = "/tmp/daos_engine"
 	defaultControlLogFile = "/tmp/daos_server.log"
+	defaultScmRamSize     = 16 // GiB
+	defaultBdevFileSize   = 16 // GiB
+	bdevFilePrefix        = "/tmp/daos-bdev"
 	// NetDevAny matches any netdetect network device class
 	NetDevAny    = math.MaxUint32
 	minDMABuffer = 1024
@@ -41,6 +46,10 @@ const (
 	errInvalNrEngines    = "unexpected number of engines requested, want %d got %d"
 	errInsufNrSSDs       = "insufficient number of ssds for numa %d, want %d got %d"
 	errInvalNrCores      = "invalid number of cores for numa %d"
+	errUnsupScmClass     = "unsupported scm class in request: %s"
+	errUnsupBdevClass    = "unsupported bdev class in request: %s"
+	errInsufNrKdevs      = "insufficient number of kdev devices, want %d got %d"
+	errInvalHelperRatio  = "helper ratio should be between 0 and 1, got %.2f"
 )
 
 type (
@@ -48,18 +57,51 @@ type (
 	ConfigGenerateReq struct {
 		unaryRequest
 		msRequest
-		NrEngines    int
-		MinNrSSDs    int
-		NetClass     uint32
+		NrEngines int
+		MinNrSSDs int
+		// HelperRatio sets the number of helper threads per target, if
+		// unset the helper count is derived from the available cores.
+		HelperRatio float64
+		NetClass    uint32
+		// ScmClass selects dcpm (default) or ram backed SCM, ScmSize
+		// sets the ramdisk size in GiB.
+		ScmClass storage.Class
+		ScmSize  int
+		// BdevClass selects nvme (default), kdev or file backed bdevs.
+		// BdevList holds the kdev devices to be shared between engines
+		// and BdevSize sets the file size in GiB.
+		BdevClass    storage.Class
+		BdevList     []string
+		BdevSize     int
+		ClassParams  []*ConfigGenerateClassParams
 		Client       UnaryInvoker
 		HostList     []string
 		AccessPoints []string
 		Log          logging.Logger
 	}
 
+	// ConfigGenerateClassParams overrides the request parameters for the
+	// hardware classes made up of hosts in the given host list. Unset
+	// values are inherited from the request.
+	ConfigGenerateClassParams struct {
+		HostList    string
+		NrEngines   *int
+		MinNrSSDs   *int
+		HelperRatio *float64
+	}
+
+	// ConfigGenerateClass contains the config generated for a set of hosts
+	// with identical hardware.
+	ConfigGenerateClass struct {
+		HostSet *hostlist.HostSet
+		Config  *config.Server
+	}
+
 	// ConfigGenerateResp contains the request response.
 	ConfigGenerateResp struct {
+		// ConfigOut is only set when all hosts share the same hardware.
 		ConfigOut *config.Server
+		Classes   []*ConfigGenerateClass
 	}
 
 	// ConfigGenerateError implements the error interface and
@@ -85,8 +127,9 @@ func IsConfigGenerateError(err error) bool {
 	return ok
 }
 
-// ConfigGenerate attempts to automatically detect hardware and generate a DAOS
-// server config file for a set of hosts with homogeneous hardware setup.
+// ConfigGenerate attempts to automatically detect hardware and generate DAOS
+// server config files for a set of hosts. Hosts are grouped into classes with
+// identical network and storage hardware and a config is generated for each.
 //
 // Returns API response or error.
 func ConfigGenerate(ctx context.Context, req ConfigGenerateReq) (*ConfigGenerateResp, error) {
@@ -100,35 +143,273 @@ func ConfigGenerate(ctx context.Context, req ConfigGenerateReq) (*ConfigGenerate
 		return nil, errors.New("no access points specified")
 	}
 
-	nd, err := getNetworkDetails(ctx, req)
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	netSets, err := getNetworkSets(ctx, req.Log, req.HostList, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	var storageSets HostStorageMap
+	if req.needStorageScan() {
+		storageSets, err = getStorageSets(ctx, req.Log, req.HostList, req.Client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	classes, err := getHardwareClasses(req.Log, netSets, storageSets)
 	if err != nil {
 		return nil, err
 	}
 
-	sd, err := getStorageDetails(ctx, req, nd.engineCount)
+	resp := new(ConfigGenerateResp)
+	for _, hc := range classes {
+		classReq, err := req.forClass(hc)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg, err := genClassConfig(classReq, hc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "hosts %s", hc.hostSet)
+		}
+
+		resp.Classes = append(resp.Classes, &ConfigGenerateClass{
+			HostSet: hc.hostSet,
+			Config:  cfg,
+		})
+	}
+	if len(resp.Classes) == 1 {
+		resp.ConfigOut = resp.Classes[0].Config
+	}
+
+	return resp, nil
+}
+
+// validate checks the storage classes and helper ratios in the request.
+func (req *ConfigGenerateReq) validate() error {
+	switch req.ScmClass {
+	case storage.ClassNone, storage.ClassDcpm, storage.ClassRam:
+	default:
+		return errors.Errorf(errUnsupScmClass, req.ScmClass)
+	}
+
+	switch req.BdevClass {
+	case storage.ClassNone, storage.ClassNvme, storage.ClassFile:
+	case storage.ClassKdev:
+		if len(req.BdevList) == 0 {
+			return errors.Errorf(errInsufNrKdevs, 1, 0)
+		}
+	default:
+		return errors.Errorf(errUnsupBdevClass, req.BdevClass)
+	}
+
+	if err := checkHelperRatio(req.HelperRatio); err != nil {
+		return err
+	}
+	for _, cp := range req.ClassParams {
+		if cp.HelperRatio == nil {
+			continue
+		}
+		if err := checkHelperRatio(*cp.HelperRatio); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkHelperRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return errors.Errorf(errInvalHelperRatio, ratio)
+	}
+
+	return nil
+}
+
+// needStorageScan returns false if neither PMem nor NVMe devices are required
+// on the hosts, e.g. when generating configs for emulated storage.
+func (req *ConfigGenerateReq) needStorageScan() bool {
+	if req.ScmClass != storage.ClassRam {
+		return true
+	}
+
+	switch req.BdevClass {
+	case storage.ClassNone, storage.ClassNvme:
+		return true
+	}
+
+	return false
+}
+
+// hostWithin returns true if the host address, with or without port, is in
+// the given host set.
+func hostWithin(hs *hostlist.HostSet, addr string) (bool, error) {
+	hosts := []string{addr}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		hosts = append(hosts, host)
+	}
+
+	for _, host := range hosts {
+		within, err := hs.Within(host)
+		if err != nil {
+			return false, err
+		}
+		if within {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// forClass returns a copy of the request with the parameters overridden by
+// any class parameters which match the hosts in the hardware class. Class
+// parameters must either cover all or none of the hosts in a class.
+func (req ConfigGenerateReq) forClass(hc *hardwareClass) (ConfigGenerateReq, error) {
+	var matched *ConfigGenerateClassParams
+	for _, cp := range req.ClassParams {
+		cpSet, err := hostlist.CreateSet(cp.HostList)
+		if err != nil {
+			return req, errors.Wrapf(err, "invalid class host list %q", cp.HostList)
+		}
+
+		var nrWithin int
+		for _, addr := range hc.hostSet.Slice() {
+			within, err := hostWithin(cpSet, addr)
+			if err != nil {
+				return req, err
+			}
+			if within {
+				nrWithin++
+			}
+		}
+
+		switch {
+		case nrWithin == 0:
+			continue
+		case nrWithin < hc.hostSet.Count():
+			return req, errors.Errorf("class hosts %s only partially cover hosts %s "+
+				"with identical hardware", cpSet, hc.hostSet)
+		case matched != nil:
+			return req, errors.Errorf("hosts %s match multiple class host lists (%s, %s)",
+				hc.hostSet, matched.HostList, cp.HostList)
+		}
+		matched = cp
+	}
+
+	if matched == nil {
+		return req, nil
+	}
+	req.Log.Debugf("class parameters for hosts %s applied to hosts %s", matched.HostList,
+		hc.hostSet)
+
+	if matched.NrEngines != nil {
+		req.NrEngines = *matched.NrEngines
+	}
+	if matched.MinNrSSDs != nil {
+		req.MinNrSSDs = *matched.MinNrSSDs
+	}
+	if matched.HelperRatio != nil {
+		req.HelperRatio = *matched.HelperRatio
+	}
+
+	return req, nil
+}
+
+// genClassConfig generates a server config for hosts in a hardware class.
+func genClassConfig(req ConfigGenerateReq, hc *hardwareClass) (*config.Server, error) {
+	nd, err := getNetworkDetails(req, hc.netSet)
 	if err != nil {
 		return nil, err
 	}
 
-	ccs, err := getCPUDetails(req.Log, sd.numaSSDs, nd.numaCoreCount)
+	sd, err := getStorageDetails(req, nd.engineCount, hc.storageSet)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg, err := genConfig(req.Log, req.AccessPoints, nd, sd, ccs)
+	ccs, err := getCPUDetails(req.Log, sd.nvmeSSDs(), nd.numaCoreCount)
 	if err != nil {
 		return nil, err
 	}
+	ccs.setHelperRatio(req.Log, req.HelperRatio, nd.numaCoreCount)
+
+	return genConfig(req.Log, req.AccessPoints, nd, sd, ccs)
+}
+
+// hardwareClass is a set of hosts with identical network and storage
+// hardware.
+type hardwareClass struct {
+	hostSet    *hostlist.HostSet
+	netSet     *HostFabricSet
+	storageSet *HostStorageSet
+}
+
+// getHardwareClasses groups hosts by the combination of network and storage
+// hardware sets they belong to. If storage sets are not supplied, hosts are
+// grouped by network hardware alone.
+func getHardwareClasses(log logging.Logger, netSets HostFabricMap, storageSets HostStorageMap) ([]*hardwareClass, error) {
+	var classes []*hardwareClass
+	for _, nk := range netSets.Keys() {
+		ns := netSets[nk]
+		if len(storageSets) == 0 {
+			classes = append(classes, &hardwareClass{hostSet: ns.HostSet, netSet: ns})
+			continue
+		}
+
+		for _, sk := range storageSets.Keys() {
+			ss := storageSets[sk]
+			hs, err := ns.HostSet.Intersects(ss.HostSet.String())
+			if err != nil {
+				return nil, err
+			}
+			if hs.Count() == 0 {
+				continue
+			}
+
+			classes = append(classes, &hardwareClass{
+				hostSet: hs,
+				netSet: &HostFabricSet{
+					HostFabric: ns.HostFabric,
+					HostSet:    hs,
+				},
+				storageSet: &HostStorageSet{
+					HostStorage: ss.HostStorage,
+					HostSet:     hs,
+				},
+			})
+		}
+	}
+
+	// order classes by descending host count
+	sort.Slice(classes, func(i, j int) bool {
+		ci, cj := classes[i].hostSet.Count(), classes[j].hostSet.Count()
+		if ci != cj {
+			return ci > cj
+		}
+		return classes[i].hostSet.String() < classes[j].hostSet.String()
+	})
+
+	if len(classes) > 1 {
+		log.Info("Heterogeneous hardware configurations detected, a config will " +
+			"be generated for each of the following sets of hosts:")
+		for _, hc := range classes {
+			log.Info(hc.hostSet.String())
+		}
+	}
 
-	return &ConfigGenerateResp{ConfigOut: cfg}, nil
+	return classes, nil
 }
 
-// getNetworkSet retrieves the result of network scan over host list and
-// verifies that there is only a single network set in response which indicates
-// that network hardware setup is homogeneous across all hosts.
+// getNetworkSets retrieves the result of network scan over host list. Hosts
+// with identical network hardware are grouped into a single set.
 //
-// Return host errors, network scan results for the host set or error.
-func getNetworkSet(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (*HostFabricSet, error) {
+// Return host errors, network scan results for each host set or error.
+func getNetworkSets(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (HostFabricMap, error) {
 	scanReq := new(NetworkScanReq)
 	scanReq.SetHostList(hostList)
 
@@ -141,28 +422,17 @@ func getNetworkSet(ctx context.Context, log logging.Logger, hostList []string, c
 		return nil, &ConfigGenerateError{HostErrorsResp: scanResp.HostErrorsResp}
 	}
 
-	// verify homogeneous network
-	switch len(scanResp.HostFabrics) {
-	case 0:
+	if len(scanResp.HostFabrics) == 0 {
 		return nil, errors.New("no host responses")
-	case 1: // success
-	default: // more than one means non-homogeneous hardware
-		log.Info("Heterogeneous network hardware configurations detected, " +
-			"cannot proceed. The following sets of hosts have different " +
-			"network hardware:")
-		for _, hns := range scanResp.HostFabrics {
-			log.Info(hns.HostSet.String())
-		}
-
-		return nil, errors.New("network hardware not consistent across hosts")
 	}
 
-	networkSet := scanResp.HostFabrics[scanResp.HostFabrics.Keys()[0]]
-
-	log.Debugf("Network hardware is consistent for hosts %s:\n\t%v",
-		networkSet.HostSet, networkSet.HostFabric.Interfaces)
+	for _, key := range scanResp.HostFabrics.Keys() {
+		hfs := scanResp.HostFabrics[key]
+		log.Debugf("Network hardware is consistent for hosts %s:\n\t%v",
+			hfs.HostSet, hfs.HostFabric.Interfaces)
+	}
 
-	return networkSet, nil
+	return scanResp.HostFabrics, nil
 }
 
 // numaNetIfaceMap is an alias for a map of NUMA node ID to optimal
@@ -272,16 +542,12 @@ type networkDetails struct {
 	numaCoreCount int
 }
 
-// getNetworkDetails retrieves recommended network interfaces.
+// getNetworkDetails retrieves recommended network interfaces for the hosts in
+// a network hardware set.
 //
 // Returns map of NUMA node ID to chosen fabric interfaces, number of engines to
-// provide mappings for, per-NUMA core count and any host errors.
-func getNetworkDetails(ctx context.Context, req ConfigGenerateReq) (*networkDetails, error) {
-	netSet, err := getNetworkSet(ctx, req.Log, req.HostList, req.Client)
-	if err != nil {
-		return nil, err
-	}
-
+// provide mappings for and per-NUMA core count.
+func getNetworkDetails(req ConfigGenerateReq, netSet *HostFabricSet) (*networkDetails, error) {
 	nd := &networkDetails{
 		engineCount:   req.NrEngines,
 		numaCoreCount: int(netSet.HostFabric.CoresPerNuma),
@@ -305,16 +571,15 @@ func getNetworkDetails(ctx context.Context, req ConfigGenerateReq) (*networkDeta
 	return nd, nil
 }
 
-// getStorageSet retrieves the result of storage scan over host list and
-// verifies that there is only a single storage set in response which indicates
-// that storage hardware setup is homogeneous across all hosts.
+// getStorageSets retrieves the result of storage scan over host list. Hosts
+// with identical storage hardware are grouped into a single set.
 //
 // Filter NVMe storage scan so only NUMA affinity and PCI address is taking into
 // account by supplying NvmeBasic flag in scan request. This enables
 // configuration to work with different combinations of SSD models.
 //
-// Return host errors, storage scan results for the host set or error.
-func getStorageSet(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (*HostStorageSet, error) {
+// Return host errors, storage scan results for each host set or error.
+func getStorageSets(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (HostStorageMap, error) {
 	scanReq := &StorageScanReq{NvmeBasic: true}
 	scanReq.SetHostList(hostList)
 
@@ -326,29 +591,18 @@ func getStorageSet(ctx context.Context, log logging.Logger, hostList []string, c
 		return nil, &ConfigGenerateError{HostErrorsResp: scanResp.HostErrorsResp}
 	}
 
-	// verify homogeneous storage
-	switch len(scanResp.HostStorage) {
-	case 0:
+	if len(scanResp.HostStorage) == 0 {
 		return nil, errors.New("no host responses")
-	case 1: // success
-	default: // more than one means non-homogeneous hardware
-		log.Info("Heterogeneous storage hardware configurations detected, " +
-			"cannot proceed. The following sets of hosts have different " +
-			"storage hardware:")
-		for _, hss := range scanResp.HostStorage {
-			log.Info(hss.HostSet.String())
-		}
-
-		return nil, errors.New("storage hardware not consistent across hosts")
 	}
 
-	storageSet := scanResp.HostStorage[scanResp.HostStorage.Keys()[0]]
-
-	log.Debugf("Storage hardware is consistent for hosts %s:\n\t%s\n\t%s",
-		storageSet.HostSet.String(), storageSet.HostStorage.ScmNamespaces.Summary(),
-		storageSet.HostStorage.NvmeDevices.Summary())
+	for _, key := range scanResp.HostStorage.Keys() {
+		hss := scanResp.HostStorage[key]
+		log.Debugf("Storage hardware is consistent for hosts %s:\n\t%s\n\t%s",
+			hss.HostSet.String(), hss.HostStorage.ScmNamespaces.Summary(),
+			hss.HostStorage.NvmeDevices.Summary())
+	}
 
-	return storageSet, nil
+	return scanResp.HostStorage, nil
 }
 
 // numaPMemsMap is an alias for a map of NUMA node ID to slice of string sorted
@@ -389,18 +643,73 @@ func mapSSDs(ssds storage.NvmeControllers) numaSSDsMap {
 	return nssds
 }
 
+// mapKdevs distributes kernel block devices across engines, the devices are
+// not bound to a NUMA node so are assigned to engines in turn.
+func mapKdevs(devices []string, engineCount int) numaSSDsMap {
+	nkdevs := make(numaSSDsMap)
+	for i, dev := range devices {
+		nn := i % engineCount
+		nkdevs[nn] = append(nkdevs[nn], dev)
+	}
+
+	return nkdevs
+}
+
+// mapFiles maps each engine to a single bdev backing file.
+func mapFiles(engineCount int) numaSSDsMap {
+	nfiles := make(numaSSDsMap)
+	for nn := 0; nn < engineCount; nn++ {
+		nfiles[nn] = []string{fmt.Sprintf("%s%d", bdevFilePrefix, nn)}
+	}
+
+	return nfiles
+}
+
 type storageDetails struct {
+	scmClass  storage.Class
+	scmSize   int
+	bdevClass storage.Class
+	bdevSize  int
 	numaPMems numaPMemsMap
 	numaSSDs  numaSSDsMap
 }
 
+// nvmeSSDs returns the NUMA to SSD mappings used when calculating target
+// counts, emulated bdevs are not taken into account.
+func (sd *storageDetails) nvmeSSDs() numaSSDsMap {
+	switch sd.bdevClass {
+	case storage.ClassNone, storage.ClassNvme:
+		return sd.numaSSDs
+	}
+
+	nssds := make(numaSSDsMap)
+	for nn := range sd.numaSSDs {
+		nssds[nn] = []string{}
+	}
+
+	return nssds
+}
+
 // validate checks sufficient PMem devices and SSD NUMA groups exist for the
 // required number of engines. Minimum thresholds for SSD group size is also
-// checked.
+// checked. PMem and SSD checks are skipped for emulated SCM and bdevs.
 func (sd *storageDetails) validate(log logging.Logger, engineCount int, minNrSSDs int) error {
-	log.Debugf("numa to pmem mappings: %v", sd.numaPMems)
-	if len(sd.numaPMems) < engineCount {
-		return errors.Errorf(errInsufNrPMemGroups, sd.numaPMems, engineCount, len(sd.numaPMems))
+	if sd.scmClass != storage.ClassRam {
+		log.Debugf("numa to pmem mappings: %v", sd.numaPMems)
+		if len(sd.numaPMems) < engineCount {
+			return errors.Errorf(errInsufNrPMemGroups, sd.numaPMems, engineCount,
+				len(sd.numaPMems))
+		}
+	}
+
+	switch sd.bdevClass {
+	case storage.ClassKdev:
+		if len(sd.numaSSDs) < engineCount {
+			return errors.Errorf(errInsufNrKdevs, engineCount, len(sd.numaSSDs))
+		}
+		return nil
+	case storage.ClassFile:
+		return nil
 	}
 
 	if minNrSSDs == 0 {
@@ -430,22 +739,47 @@ func (sd *storageDetails) validate(log logging.Logger, engineCount int, minNrSSD
 }
 
 // getStorageDetails retrieves mappings of NUMA node to PMem and NVMe SSD
-// devices.
+// devices for the hosts in a storage hardware set. The storage set may be nil
+// when neither PMem nor NVMe is required.
 //
-// Returns storage details struct or host error response and outer error.
-func getStorageDetails(ctx context.Context, req ConfigGenerateReq, engineCount int) (*storageDetails, error) {
+// Returns storage details struct or error.
+func getStorageDetails(req ConfigGenerateReq, engineCount int, storageSet *HostStorageSet) (*storageDetails, error) {
 	if engineCount < 1 {
 		return nil, errors.Errorf(errInvalNrEngines, 1, engineCount)
 	}
 
-	storageSet, err := getStorageSet(ctx, req.Log, req.HostList, req.Client)
-	if err != nil {
-		return nil, err
+	sd := &storageDetails{
+		scmClass:  req.ScmClass,
+		scmSize:   req.ScmSize,
+		bdevClass: req.BdevClass,
+		bdevSize:  req.BdevSize,
+		numaPMems: make(numaPMemsMap),
+		numaSSDs:  make(numaSSDsMap),
+	}
+	if sd.scmClass == storage.ClassNone {
+		sd.scmClass = storage.ClassDcpm
+	}
+	if sd.scmClass == storage.ClassRam && sd.scmSize == 0 {
+		sd.scmSize = defaultScmRamSize
+	}
+	if sd.bdevClass == storage.ClassNone {
+		sd.bdevClass = storage.ClassNvme
+	}
+	if storageSet != nil {
+		if sd.scmClass == storage.ClassDcpm {
+			sd.numaPMems = mapPMems(storageSet.HostStorage.ScmNamespaces)
+		}
+		sd.numaSSDs = mapSSDs(storageSet.HostStorage.NvmeDevices)
 	}
 
-	sd := &storageDetails{
-		numaPMems: mapPMems(storageSet.HostStorage.ScmNamespaces),
-		numaSSDs:  mapSSDs(storageSet.HostStorage.NvmeDevices),
+	switch sd.bdevClass {
+	case storage.ClassKdev:
+		sd.numaSSDs = mapKdevs(req.BdevList, engineCount)
+	case storage.ClassFile:
+		if sd.bdevSize == 0 {
+			sd.bdevSize = defaultBdevFileSize
+		}
+		sd.numaSSDs = mapFiles(engineCount)
 	}
 	if err := sd.validate(req.Log, engineCount, req.MinNrSSDs); err != nil {
 		return nil, err
@@ -547,6 +881,28 @@ func getCPUDetails(log logging.Logger, numaSSDs numaSSDsMap, coresPerNuma int) (
 	return numaCoreCounts, nil
 }
 
+// setHelperRatio overrides calculated helper thread counts with the given
+// ratio of helpers to targets, limited by the number of spare cores. A zero
+// ratio retains the calculated values.
+func (nccm numaCoreCountsMap) setHelperRatio(log logging.Logger, ratio float64, coresPerNuma int) {
+	if ratio == 0 {
+		return
+	}
+
+	for nn, cc := range nccm {
+		helpers := int(math.Round(ratio * float64(cc.nrTgts)))
+		if spare := coresPerNuma - cc.nrTgts - 1; helpers > spare {
+			log.Debugf("adjusting num helpers (%d) on numa %d to spare cores, new: %d",
+				helpers, nn, spare)
+			helpers = spare
+		}
+		if helpers < 0 {
+			helpers = 0
+		}
+		cc.nrHlprs = helpers
+	}
+}
+
 func defaultEngineCfg(idx int) *engine.Config {
 	return engine.NewConfig().
 		WithTargetCount(defaultTargetCount).
@@ -564,7 +920,7 @@ func genConfig(log logging.Logger, accessPoints []string, nd *networkDetails, sd
 		return nil, errors.Errorf(errInsufNrIfaces, "", nd.engineCount,
 			len(nd.numaIfaces), nd.numaIfaces)
 	}
-	if len(sd.numaPMems) < nd.engineCount {
+	if sd.scmClass != storage.ClassRam && len(sd.numaPMems) < nd.engineCount {
 		return nil, errors.Errorf(errInsufNrPMemGroups, sd.numaPMems, nd.engineCount,
 			len(sd.numaPMems))
 	}
@@ -580,7 +936,15 @@ func genConfig(log logging.Logger, accessPoints []string, nd *networkDetails, sd
 		engineCfg := defaultEngineCfg(nn).
 			WithTargetCount(ccs[nn].nrTgts).
 			WithHelperStreamCount(ccs[nn].nrHlprs)
-		if len(sd.numaPMems) > 0 {
+		switch {
+		case sd.scmClass == storage.ClassRam:
+			engineCfg.WithStorage(
+				storage.NewTierConfig().
+					WithScmClass(storage.ClassRam.String()).
+					WithScmMountPoint(fmt.Sprintf("%s%d", scmMountPrefix, nn)).
+					WithScmRamdiskSize(uint(sd.scmSize)),
+			)
+		case len(sd.numaPMems) > 0:
 			engineCfg.WithStorage(
 				storage.NewTierConfig().
 					WithScmClass(storage.ClassDcpm.String()).
@@ -589,11 +953,17 @@ func genConfig(log logging.Logger, accessPoints []string, nd *networkDetails, sd
 			)
 		}
 		if len(sd.numaSSDs) > 0 {
-			engineCfg.WithStorage(
-				storage.NewTierConfig().
-					WithBdevClass(storage.ClassNvme.String()).
-					WithBdevDeviceList(sd.numaSSDs[nn]...),
-			)
+			bdevClass := sd.bdevClass
+			if bdevClass == storage.ClassNone {
+				bdevClass = storage.ClassNvme
+			}
+			bdevCfg := storage.NewTierConfig().
+				WithBdevClass(bdevClass.String()).
+				WithBdevDeviceList(sd.numaSSDs[nn]...)
+			if bdevClass == storage.ClassFile {
+				bdevCfg.WithBdevFileSize(sd.bdevSize)
+			}
+			engineCfg.WithStorage(bdevCfg)
 		}
 
 		pnn := uint(nn)
@@ -119,7 +119,6 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 	fabIfs1wNuma := &ctlpb.NetworkScanResp{
 		Interfaces: []*ctlpb.FabricInterface{if1PB, if2PB}, Numacount: 2,
 	}
-	fabIfs2 := &ctlpb.NetworkScanResp{Interfaces: []*ctlpb.FabricInterface{if2PB}}
 	fabIfs3 := &ctlpb.NetworkScanResp{
 		Interfaces: []*ctlpb.FabricInterface{ib0PB, eth0PB}, Numacount: 2, Corespernuma: 24,
 	}
@@ -213,14 +212,6 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 			hostResponses: []*HostResponse{},
 			expErr:        errors.New("no host responses"),
 		},
-		"host network mismatch": {
-			hostResponses: dualHostResp(fabIfs1, fabIfs2),
-			expErr:        errors.New("network hardware not consistent across hosts"),
-		},
-		"engine count unset and zero numa on single host": {
-			hostResponses: dualHostResp(fabIfs1, fabIfs1wNuma),
-			expErr:        errors.New("network hardware not consistent across hosts"),
-		},
 		"engine count unset and zero numa": {
 			hostResponses: dualHostRespSame(fabIfs1),
 			expErr:        errors.New("zero numa nodes reported on hosts host[1-2]"),
@@ -323,7 +314,13 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 				Log:       log,
 			}
 
-			netDetails, gotErr := getNetworkDetails(context.TODO(), req)
+			var netDetails *networkDetails
+			netSets, gotErr := getNetworkSets(context.TODO(), log, nil, mi)
+			if gotErr == nil {
+				// details are retrieved for the first set of hosts
+				netSet := netSets[netSets.Keys()[0]]
+				netDetails, gotErr = getNetworkDetails(req, netSet)
+			}
 			common.CmpErr(t, tc.expErr, gotErr)
 			var gotHostErrs *HostErrorsResp
 			if cge, ok := gotErr.(*ConfigGenerateError); ok {
@@ -380,6 +377,9 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 		engineCount   int
 		minSSDs       int
 		disableNVMe   bool
+		scmClass      storage.Class
+		bdevClass     storage.Class
+		bdevList      []string
 		uErr          error
 		hostResponses []*HostResponse
 		expErr        error
@@ -421,11 +421,6 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			hostResponses: []*HostResponse{},
 			expErr:        errors.New("no host responses"),
 		},
-		"host storage mismatch": {
-			engineCount:   1,
-			hostResponses: hostRespOneWithScmNs,
-			expErr:        errors.New("storage hardware not consistent across hosts"),
-		},
 		"single engine zero pmems": {
 			engineCount:   1,
 			hostResponses: hostRespNoScmNs,
@@ -498,6 +493,43 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			},
 			expSSDs: [][]string{{}, {}},
 		},
+		"ram scm zero pmems": {
+			engineCount:   2,
+			scmClass:      storage.ClassRam,
+			hostResponses: hostRespWithSSDs,
+			expSSDs: [][]string{
+				engineCfgWithSSDs(t, 0).Storage.Tiers.BdevConfigs()[0].Bdev.DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.Tiers.BdevConfigs()[0].Bdev.DeviceList,
+			},
+		},
+		"file bdevs zero ssds": {
+			engineCount:   2,
+			bdevClass:     storage.ClassFile,
+			hostResponses: hostRespWithScmNss,
+			expPMems: [][]string{
+				engineCfgWithSSDs(t, 0).Storage.Tiers.ScmConfigs()[0].Scm.DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.Tiers.ScmConfigs()[0].Scm.DeviceList,
+			},
+			expSSDs: [][]string{{"/tmp/daos-bdev0"}, {"/tmp/daos-bdev1"}},
+		},
+		"kdev bdevs distributed across engines": {
+			engineCount:   2,
+			bdevClass:     storage.ClassKdev,
+			bdevList:      []string{"/dev/sda", "/dev/sdb", "/dev/sdc"},
+			hostResponses: hostRespWithScmNss,
+			expPMems: [][]string{
+				engineCfgWithSSDs(t, 0).Storage.Tiers.ScmConfigs()[0].Scm.DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.Tiers.ScmConfigs()[0].Scm.DeviceList,
+			},
+			expSSDs: [][]string{{"/dev/sda", "/dev/sdc"}, {"/dev/sdb"}},
+		},
+		"insufficient kdev bdevs": {
+			engineCount:   2,
+			bdevClass:     storage.ClassKdev,
+			bdevList:      []string{"/dev/sda"},
+			hostResponses: hostRespWithScmNss,
+			expErr:        errors.Errorf(errInsufNrKdevs, 2, 1),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -520,12 +552,26 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			req := ConfigGenerateReq{
 				NrEngines: tc.engineCount,
 				MinNrSSDs: tc.minSSDs,
+				ScmClass:  tc.scmClass,
+				BdevClass: tc.bdevClass,
+				BdevList:  tc.bdevList,
 				Client:    mi,
 				Log:       log,
 			}
 
-			storageDetails, gotErr := getStorageDetails(
-				context.TODO(), req, tc.engineCount)
+			var storageDetails *storageDetails
+			var gotErr error
+			if tc.engineCount < 1 {
+				_, gotErr = getStorageDetails(req, tc.engineCount, nil)
+			} else {
+				var storageSets HostStorageMap
+				storageSets, gotErr = getStorageSets(context.TODO(), log, nil, mi)
+				if gotErr == nil {
+					storageSet := storageSets[storageSets.Keys()[0]]
+					storageDetails, gotErr = getStorageDetails(req, tc.engineCount,
+						storageSet)
+				}
+			}
 			common.CmpErr(t, tc.expErr, gotErr)
 			var gotHostErrs *HostErrorsResp
 			if cge, ok := gotErr.(*ConfigGenerateError); ok {
@@ -558,6 +604,232 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 	}
 }
 
+func TestControl_AutoConfig_ConfigGenerate(t *testing.T) {
+	ib0PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib0, ib0PB); err != nil {
+		t.Fatal(err)
+	}
+	ib1PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib1, ib1PB); err != nil {
+		t.Fatal(err)
+	}
+	dualIbFabIfs := &ctlpb.NetworkScanResp{
+		Interfaces: []*ctlpb.FabricInterface{ib0PB, ib1PB}, Numacount: 2, Corespernuma: 24,
+	}
+	singleIbFabIfs := &ctlpb.NetworkScanResp{
+		Interfaces: []*ctlpb.FabricInterface{ib0PB}, Numacount: 1, Corespernuma: 24,
+	}
+	netResp := func(resps ...*ctlpb.NetworkScanResp) *UnaryResponse {
+		ur := new(UnaryResponse)
+		for i, r := range resps {
+			ur.Responses = append(ur.Responses, &HostResponse{
+				Addr: fmt.Sprintf("host%d", i+1), Message: r,
+			})
+		}
+		return ur
+	}
+	storageResp := func(variants ...string) *UnaryResponse {
+		ur := new(UnaryResponse)
+		for i, v := range variants {
+			ur.Responses = append(ur.Responses, &HostResponse{
+				Addr: fmt.Sprintf("host%d", i+1), Message: MockServerScanResp(t, v),
+			})
+		}
+		return ur
+	}
+	intPtr := func(i int) *int { return &i }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	type expClass struct {
+		hosts     string
+		nrSSDs    []int
+		nrTgts    []int
+		nrHlprs   []int
+		scmClass  storage.Class
+		bdevClass storage.Class
+	}
+
+	for name, tc := range map[string]struct {
+		helperRatio float64
+		scmClass    storage.Class
+		bdevClass   storage.Class
+		classParams []*ConfigGenerateClassParams
+		responses   []*UnaryResponse
+		expClasses  []expClass
+		expErr      error
+	}{
+		"homogeneous hosts": {
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs),
+				storageResp("withSpaceUsage", "withSpaceUsage"),
+			},
+			expClasses: []expClass{
+				{
+					hosts:   "host[1-2]",
+					nrSSDs:  []int{4, 4},
+					nrTgts:  []int{20, 20},
+					nrHlprs: []int{3, 3},
+				},
+			},
+		},
+		"heterogeneous storage without class params": {
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs, dualIbFabIfs),
+				storageResp("withSpaceUsage", "withSpaceUsage", "pmemA"),
+			},
+			expErr: errors.New("hosts host3: insufficient number of ssds"),
+		},
+		"heterogeneous hosts with class params": {
+			helperRatio: 0.1,
+			classParams: []*ConfigGenerateClassParams{
+				{
+					HostList:    "host3",
+					MinNrSSDs:   intPtr(0),
+					HelperRatio: floatPtr(0.25),
+				},
+				{
+					HostList:  "host4",
+					NrEngines: intPtr(1),
+				},
+			},
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs, dualIbFabIfs, singleIbFabIfs),
+				storageResp("withSpaceUsage", "withSpaceUsage", "pmemA", "withSpaceUsage"),
+			},
+			expClasses: []expClass{
+				{
+					hosts:   "host[1-2]",
+					nrSSDs:  []int{4, 4},
+					nrTgts:  []int{20, 20},
+					nrHlprs: []int{2, 2},
+				},
+				{
+					hosts:   "host3",
+					nrSSDs:  []int{0, 0},
+					nrTgts:  []int{16, 16},
+					nrHlprs: []int{4, 4},
+				},
+				{
+					hosts:   "host4",
+					nrSSDs:  []int{4},
+					nrTgts:  []int{20},
+					nrHlprs: []int{2},
+				},
+			},
+		},
+		"class params partially cover hardware class": {
+			classParams: []*ConfigGenerateClassParams{
+				{HostList: "host1", MinNrSSDs: intPtr(0)},
+			},
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs),
+				storageResp("withSpaceUsage", "withSpaceUsage"),
+			},
+			expErr: errors.New("only partially cover hosts host[1-2]"),
+		},
+		"class params overlap": {
+			classParams: []*ConfigGenerateClassParams{
+				{HostList: "host[1-2]", MinNrSSDs: intPtr(0)},
+				{HostList: "host[1-3]", MinNrSSDs: intPtr(2)},
+			},
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs),
+				storageResp("withSpaceUsage", "withSpaceUsage"),
+			},
+			expErr: errors.New("match multiple class host lists"),
+		},
+		"invalid helper ratio": {
+			helperRatio: 1.5,
+			expErr:      errors.Errorf(errInvalHelperRatio, 1.5),
+		},
+		"unsupported scm class": {
+			scmClass: storage.ClassNvme,
+			expErr:   errors.Errorf(errUnsupScmClass, storage.ClassNvme),
+		},
+		"emulated storage skips storage scan": {
+			scmClass:  storage.ClassRam,
+			bdevClass: storage.ClassFile,
+			responses: []*UnaryResponse{
+				netResp(dualIbFabIfs, dualIbFabIfs),
+			},
+			expClasses: []expClass{
+				{
+					hosts:     "host[1-2]",
+					nrSSDs:    []int{1, 1},
+					nrTgts:    []int{16, 16},
+					nrHlprs:   []int{7, 7},
+					scmClass:  storage.ClassRam,
+					bdevClass: storage.ClassFile,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.responses,
+			})
+
+			req := ConfigGenerateReq{
+				MinNrSSDs:    1,
+				HelperRatio:  tc.helperRatio,
+				NetClass:     NetDevAny,
+				ScmClass:     tc.scmClass,
+				BdevClass:    tc.bdevClass,
+				ClassParams:  tc.classParams,
+				Client:       mi,
+				HostList:     []string{"host1"},
+				AccessPoints: []string{"host1"},
+				Log:          log,
+			}
+
+			resp, gotErr := ConfigGenerate(context.TODO(), req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, len(tc.expClasses), len(resp.Classes),
+				"unexpected number of classes")
+			if len(resp.Classes) == 1 {
+				common.AssertEqual(t, resp.Classes[0].Config, resp.ConfigOut,
+					"expected config output for single class")
+			} else if resp.ConfigOut != nil {
+				t.Fatal("unexpected config output for multiple classes")
+			}
+
+			for i, exp := range tc.expClasses {
+				if exp.scmClass == storage.ClassNone {
+					exp.scmClass = storage.ClassDcpm
+				}
+				if exp.bdevClass == storage.ClassNone {
+					exp.bdevClass = storage.ClassNvme
+				}
+
+				got := resp.Classes[i]
+				common.AssertEqual(t, exp.hosts, got.HostSet.String(), "unexpected hosts")
+				common.AssertEqual(t, len(exp.nrTgts), len(got.Config.Engines),
+					"unexpected number of engines")
+				for j, ec := range got.Config.Engines {
+					common.AssertEqual(t, exp.nrTgts[j], ec.TargetCount,
+						"unexpected target count")
+					common.AssertEqual(t, exp.nrHlprs[j], ec.HelperStreamCount,
+						"unexpected helper count")
+					common.AssertEqual(t, exp.scmClass,
+						ec.Storage.Tiers.ScmConfigs()[0].Class, "unexpected scm class")
+					bdevCfg := ec.Storage.Tiers.BdevConfigs()[0]
+					common.AssertEqual(t, exp.bdevClass, bdevCfg.Class,
+						"unexpected bdev class")
+					common.AssertEqual(t, exp.nrSSDs[j], len(bdevCfg.Bdev.DeviceList),
+						"unexpected number of bdevs")
+				}
+			}
+		})
+	}
+}
+
 func TestControl_AutoConfig_getCPUDetails(t *testing.T) {
 	for name, tc := range map[string]struct {
 		numaCoreCount int   // physical cores per NUMA node
@@ -647,6 +919,8 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 		numaSSDs       numaSSDsMap       // numa to ssds mappings
 		numaIfaces     numaNetIfaceMap   // numa to network interface mappings
 		numaCoreCounts numaCoreCountsMap // numa to cpu mappings
+		scmClass       storage.Class     // emulated scm class
+		bdevClass      storage.Class     // emulated bdev class
 		expCfg         *config.Server    // expected config generated
 		expErr         error
 	}{
@@ -889,6 +1163,82 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 					WithTargetCount(6).
 					WithHelperStreamCount(0)),
 		},
+		"ram scm file bdevs": {
+			engineCount:    1,
+			accessPoints:   []string{"hostX"},
+			numaSSDs:       numaSSDsMap{0: []string{"/tmp/daos-bdev0"}},
+			numaIfaces:     numaNetIfaceMap{0: ib0},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			scmClass:       storage.ClassRam,
+			bdevClass:      storage.ClassFile,
+			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10001").WithNrHugePages(8192).WithEngines(
+				defaultEngineCfg(0).
+					WithFabricInterface("ib0").
+					WithFabricInterfacePort(defaultFiPort).
+					WithFabricProvider("ofi+psm2").
+					WithPinnedNumaNode(&numa0).
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass(storage.ClassRam.String()).
+							WithScmRamdiskSize(8).
+							WithScmMountPoint("/mnt/daos0"),
+						storage.NewTierConfig().
+							WithBdevClass(storage.ClassFile.String()).
+							WithBdevDeviceList("/tmp/daos-bdev0").
+							WithBdevFileSize(4),
+					).
+					WithStorageConfigOutputPath("/mnt/daos0/daos_nvme.conf").
+					WithStorageVosEnv("AIO").
+					WithHelperStreamCount(7)),
+		},
+		"dual engine ram scm kdev bdevs": {
+			engineCount:  2,
+			accessPoints: []string{"hostX"},
+			numaSSDs: numaSSDsMap{
+				0: []string{"/dev/sda"},
+				1: []string{"/dev/sdb"},
+			},
+			numaIfaces:     numaNetIfaceMap{0: ib0, 1: ib1},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 4}, 1: &coreCounts{16, 4}},
+			scmClass:       storage.ClassRam,
+			bdevClass:      storage.ClassKdev,
+			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10001").WithNrHugePages(8192).WithEngines(
+				defaultEngineCfg(0).
+					WithFabricInterface("ib0").
+					WithFabricInterfacePort(defaultFiPort).
+					WithFabricProvider("ofi+psm2").
+					WithPinnedNumaNode(&numa0).
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass(storage.ClassRam.String()).
+							WithScmRamdiskSize(8).
+							WithScmMountPoint("/mnt/daos0"),
+						storage.NewTierConfig().
+							WithBdevClass(storage.ClassKdev.String()).
+							WithBdevDeviceList("/dev/sda"),
+					).
+					WithStorageConfigOutputPath("/mnt/daos0/daos_nvme.conf").
+					WithStorageVosEnv("AIO").
+					WithHelperStreamCount(4),
+				defaultEngineCfg(1).
+					WithFabricInterface("ib1").
+					WithFabricInterfacePort(
+						int(defaultFiPort+defaultFiPortInterval)).
+					WithFabricProvider("ofi+psm2").
+					WithPinnedNumaNode(&numa1).
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass(storage.ClassRam.String()).
+							WithScmRamdiskSize(8).
+							WithScmMountPoint("/mnt/daos1"),
+						storage.NewTierConfig().
+							WithBdevClass(storage.ClassKdev.String()).
+							WithBdevDeviceList("/dev/sdb"),
+					).
+					WithStorageConfigOutputPath("/mnt/daos1/daos_nvme.conf").
+					WithStorageVosEnv("AIO").
+					WithHelperStreamCount(4)),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -899,6 +1249,10 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 				numaIfaces:  tc.numaIfaces,
 			}
 			sd := &storageDetails{
+				scmClass:  tc.scmClass,
+				scmSize:   8,
+				bdevClass: tc.bdevClass,
+				bdevSize:  4,
 				numaPMems: tc.numaPMems,
 				numaSSDs:  tc.numaSSDs,
 			}
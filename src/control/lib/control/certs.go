@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+type (
+	// CertInfo describes a certificate in use on a server.
+	CertInfo struct {
+		Name      string    `json:"name"`
+		Path      string    `json:"path"`
+		Subject   string    `json:"subject"`
+		NotBefore time.Time `json:"not_before"`
+		NotAfter  time.Time `json:"not_after"`
+		Error     string    `json:"error,omitempty"`
+	}
+
+	// HostCerts contains the certificates in use on a given host.
+	HostCerts struct {
+		Addr     string      `json:"addr"`
+		Insecure bool        `json:"insecure"`
+		Certs    []*CertInfo `json:"certs"`
+	}
+
+	// CertQueryReq contains the parameters for a certificate query request.
+	CertQueryReq struct {
+		unaryRequest
+	}
+
+	// CertQueryResp contains the results of a certificate query request.
+	CertQueryResp struct {
+		HostErrorsResp
+		HostCerts []*HostCerts `json:"host_certs"`
+	}
+)
+
+func (cqr *CertQueryResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.CertQueryResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	hc := &HostCerts{
+		Addr:     hr.Addr,
+		Insecure: pbResp.GetInsecure(),
+	}
+	for _, pbInfo := range pbResp.GetCerts() {
+		ci := &CertInfo{
+			Name:    pbInfo.GetName(),
+			Path:    pbInfo.GetPath(),
+			Subject: pbInfo.GetSubject(),
+			Error:   pbInfo.GetError(),
+		}
+		if ci.Error == "" {
+			ci.NotBefore = time.Unix(pbInfo.GetNotBefore(), 0)
+			ci.NotAfter = time.Unix(pbInfo.GetNotAfter(), 0)
+		}
+		hc.Certs = append(hc.Certs, ci)
+	}
+	cqr.HostCerts = append(cqr.HostCerts, hc)
+
+	return nil
+}
+
+// CertQuery concurrently requests details of the certificates in use on all
+// hosts supplied in the request's hostlist, or all configured hosts if not
+// explicitly specified. The results are sorted by host address.
+func CertQuery(ctx context.Context, rpcClient UnaryInvoker, req *CertQueryReq) (*CertQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).CertQuery(ctx, &ctlpb.CertQueryReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(CertQueryResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := resp.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(resp.HostCerts, func(i, j int) bool {
+		return resp.HostCerts[i].Addr < resp.HostCerts[j].Addr
+	})
+
+	return resp, nil
+}
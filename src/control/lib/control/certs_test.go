@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_CertQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		expResp *CertQueryResp
+		expErr  error
+	}{
+		"local failure": {
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+					},
+				},
+			},
+			expResp: &CertQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+						},
+					},
+				},
+			},
+			expErr: errors.New("unpack"),
+		},
+		"multiple hosts": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host2",
+							Message: &ctlpb.CertQueryResp{
+								Insecure: true,
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.CertQueryResp{
+								Certs: []*ctlpb.CertInfo{
+									{
+										Name:      "ca_cert",
+										Path:      "/etc/daos/certs/daosCA.crt",
+										Subject:   "CN=DAOS CA,O=DAOS",
+										NotBefore: 1,
+										NotAfter:  2,
+									},
+									{
+										Name:  "cert",
+										Path:  "/etc/daos/certs/server.crt",
+										Error: "no such file",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &CertQueryResp{
+				HostCerts: []*HostCerts{
+					{
+						Addr: "host1",
+						Certs: []*CertInfo{
+							{
+								Name:      "ca_cert",
+								Path:      "/etc/daos/certs/daosCA.crt",
+								Subject:   "CN=DAOS CA,O=DAOS",
+								NotBefore: time.Unix(1, 0),
+								NotAfter:  time.Unix(2, 0),
+							},
+							{
+								Name:  "cert",
+								Path:  "/etc/daos/certs/server.crt",
+								Error: "no such file",
+							},
+						},
+					},
+					{
+						Addr:     "host2",
+						Insecure: true,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := CertQuery(context.TODO(), mi, &CertQueryReq{})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+type (
+	// CrashArchive describes an archive of artifacts collected on a host
+	// after an engine exited abnormally.
+	CrashArchive struct {
+		Host string    `json:"host"`
+		Name string    `json:"name"`
+		Time time.Time `json:"time"`
+		Size uint64    `json:"size"`
+	}
+
+	// CrashListReq contains the parameters for a request to list the
+	// crash archives held on hosts.
+	CrashListReq struct {
+		unaryRequest
+	}
+
+	// CrashListResp contains the results of a crash archive list request.
+	CrashListResp struct {
+		HostErrorsResp
+		Archives []*CrashArchive `json:"archives"`
+	}
+
+	// CrashFetchReq contains the parameters for a request to fetch a
+	// crash archive from a single host. If Name is not set, the most
+	// recent archive is fetched.
+	CrashFetchReq struct {
+		unaryRequest
+		Name string
+	}
+)
+
+func crashArchiveFromPB(host string, pbArchive *ctlpb.CrashArchive) *CrashArchive {
+	return &CrashArchive{
+		Host: host,
+		Name: pbArchive.GetName(),
+		Time: time.Unix(0, pbArchive.GetTime()),
+		Size: pbArchive.GetSize(),
+	}
+}
+
+// CrashList lists the engine crash archives held on all hosts supplied in
+// the request's hostlist, or all configured hosts if not explicitly
+// specified. The results are sorted by time.
+func CrashList(ctx context.Context, rpcClient UnaryInvoker, req *CrashListReq) (*CrashListResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).CollectCrash(ctx, &ctlpb.CollectCrashReq{List: true})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(CrashListResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hostResp.Message.(*ctlpb.CollectCrashResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+		}
+		for _, pbArchive := range pbResp.GetArchives() {
+			resp.Archives = append(resp.Archives, crashArchiveFromPB(hostResp.Addr, pbArchive))
+		}
+	}
+
+	sort.SliceStable(resp.Archives, func(i, j int) bool {
+		return resp.Archives[i].Time.Before(resp.Archives[j].Time)
+	})
+
+	return resp, nil
+}
+
+// CrashFetch fetches an engine crash archive from the single host supplied in
+// the request's hostlist and writes its contents to the supplied writer. The
+// archive is transferred in chunks to avoid exceeding gRPC message size
+// limits.
+func CrashFetch(ctx context.Context, rpcClient UnaryInvoker, req *CrashFetchReq, out io.Writer) (*CrashArchive, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if out == nil {
+		return nil, errors.New("nil writer")
+	}
+	if len(req.getHostList()) != 1 {
+		return nil, errors.New("a single host must be specified")
+	}
+
+	pbReq := &ctlpb.CollectCrashReq{Name: req.Name}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).CollectCrash(ctx, pbReq)
+	})
+
+	var archive *CrashArchive
+	for {
+		ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(ur.Responses) != 1 {
+			return nil, errors.Errorf("expected 1 response, got %d", len(ur.Responses))
+		}
+		hostResp := ur.Responses[0]
+		if hostResp.Error != nil {
+			return nil, errors.Wrap(hostResp.Error, hostResp.Addr)
+		}
+
+		pbResp, ok := hostResp.Message.(*ctlpb.CollectCrashResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+		}
+		if len(pbResp.GetArchives()) != 1 {
+			return nil, errors.Errorf("expected 1 archive, got %d", len(pbResp.GetArchives()))
+		}
+
+		chunkArchive := crashArchiveFromPB(hostResp.Addr, pbResp.GetArchives()[0])
+		if archive == nil {
+			archive = chunkArchive
+			// Fetch the remaining chunks of the same archive even
+			// if a newer one is collected in the meantime.
+			pbReq.Name = archive.Name
+		} else if chunkArchive.Name != archive.Name || chunkArchive.Size != archive.Size {
+			return nil, errors.Errorf("crash archive %q changed during fetch", archive.Name)
+		}
+
+		if _, err := out.Write(pbResp.GetData()); err != nil {
+			return nil, err
+		}
+		pbReq.Offset += uint64(len(pbResp.GetData()))
+
+		if pbReq.Offset >= archive.Size {
+			return archive, nil
+		}
+		if len(pbResp.GetData()) == 0 {
+			return nil, errors.Errorf("short read of crash archive %q at offset %d",
+				archive.Name, pbReq.Offset)
+		}
+	}
+}
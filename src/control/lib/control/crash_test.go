@@ -0,0 +1,198 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_CrashList(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		expResp *CrashListResp
+		expErr  error
+	}{
+		"local failure": {
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &CrashListResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"multiple hosts sorted": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+							Message: &ctlpb.CollectCrashResp{
+								Archives: []*ctlpb.CrashArchive{
+									{Name: "b.tar.gz", Time: 20, Size: 2},
+								},
+							},
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.CollectCrashResp{
+								Archives: []*ctlpb.CrashArchive{
+									{Name: "a.tar.gz", Time: 10, Size: 1},
+									{Name: "c.tar.gz", Time: 30, Size: 3},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &CrashListResp{
+				Archives: []*CrashArchive{
+					{Host: "host2", Name: "a.tar.gz", Time: time.Unix(0, 10), Size: 1},
+					{Host: "host1", Name: "b.tar.gz", Time: time.Unix(0, 20), Size: 2},
+					{Host: "host2", Name: "c.tar.gz", Time: time.Unix(0, 30), Size: 3},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := CrashList(context.TODO(), mi, &CrashListReq{})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_CrashFetch(t *testing.T) {
+	chunkResp := func(name string, size uint64, data string) *UnaryResponse {
+		return &UnaryResponse{
+			Responses: []*HostResponse{
+				{
+					Addr: "host1",
+					Message: &ctlpb.CollectCrashResp{
+						Archives: []*ctlpb.CrashArchive{
+							{Name: name, Time: 10, Size: size},
+						},
+						Data: []byte(data),
+					},
+				},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		hosts      []string
+		mic        *MockInvokerConfig
+		expArchive *CrashArchive
+		expData    string
+		expErr     error
+	}{
+		"no host": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("single host"),
+		},
+		"multiple hosts": {
+			hosts:  []string{"host1", "host2"},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("single host"),
+		},
+		"remote failure": {
+			hosts: []string{"host1"},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("remote failed"),
+		},
+		"single chunk": {
+			hosts: []string{"host1"},
+			mic: &MockInvokerConfig{
+				UnaryResponse: chunkResp("a.tar.gz", 3, "abc"),
+			},
+			expArchive: &CrashArchive{Host: "host1", Name: "a.tar.gz", Time: time.Unix(0, 10), Size: 3},
+			expData:    "abc",
+		},
+		"multiple chunks": {
+			hosts: []string{"host1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp("a.tar.gz", 6, "abc"),
+					chunkResp("a.tar.gz", 6, "de"),
+					chunkResp("a.tar.gz", 6, "f"),
+				},
+			},
+			expArchive: &CrashArchive{Host: "host1", Name: "a.tar.gz", Time: time.Unix(0, 10), Size: 6},
+			expData:    "abcdef",
+		},
+		"archive changed": {
+			hosts: []string{"host1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp("a.tar.gz", 6, "abc"),
+					chunkResp("a.tar.gz", 9, "def"),
+				},
+			},
+			expErr: errors.New("changed during fetch"),
+		},
+		"short read": {
+			hosts: []string{"host1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp("a.tar.gz", 6, "abc"),
+					chunkResp("a.tar.gz", 6, ""),
+				},
+			},
+			expErr: errors.New("short read"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			req := &CrashFetchReq{}
+			req.SetHostList(tc.hosts)
+			var out bytes.Buffer
+
+			gotArchive, gotErr := CrashFetch(context.TODO(), mi, req, &out)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expArchive, gotArchive); diff != "" {
+				t.Fatalf("unexpected archive (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expData, out.String(), "unexpected data")
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"log"
 	"log/syslog"
+	"sync"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -66,18 +67,34 @@ func eventNotify(ctx context.Context, rpcClient UnaryInvoker, seq uint64, evt *e
 // EventForwarder implements the events.Handler interface, increments sequence
 // number for each event forwarded and distributes requests to MS access points.
 type EventForwarder struct {
+	sync.RWMutex
 	seq       <-chan uint64
 	client    UnaryInvoker
 	accessPts []string
 }
 
+// SetAccessPoints updates the MS access points that events are forwarded to.
+func (ef *EventForwarder) SetAccessPoints(accessPts []string) {
+	ef.Lock()
+	defer ef.Unlock()
+	ef.accessPts = accessPts
+}
+
+func (ef *EventForwarder) getAccessPoints() []string {
+	ef.RLock()
+	defer ef.RUnlock()
+	return ef.accessPts
+}
+
 // OnEvent implements the events.Handler interface.
 func (ef *EventForwarder) OnEvent(ctx context.Context, evt *events.RASEvent) {
+	accessPts := ef.getAccessPoints()
+
 	switch {
 	case evt == nil:
 		ef.client.Debug("skip event forwarding, nil event")
 		return
-	case len(ef.accessPts) == 0:
+	case len(accessPts) == 0:
 		ef.client.Debug("skip event forwarding, missing access points")
 		return
 	case !evt.ShouldForward():
@@ -85,7 +102,7 @@ func (ef *EventForwarder) OnEvent(ctx context.Context, evt *events.RASEvent) {
 		return
 	}
 
-	if err := eventNotify(ctx, ef.client, <-ef.seq, evt, ef.accessPts); err != nil {
+	if err := eventNotify(ctx, ef.client, <-ef.seq, evt, accessPts); err != nil {
 		ef.client.Debugf("failed to forward event to MS: %s", err)
 	}
 }
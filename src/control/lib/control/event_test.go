@@ -90,6 +90,7 @@ func TestControl_EventForwarder_OnEvent(t *testing.T) {
 
 	for name, tc := range map[string]struct {
 		aps            []string
+		updatedAps     []string
 		event          *events.RASEvent
 		nilClient      bool
 		expInvokeCount int
@@ -109,6 +110,16 @@ func TestControl_EventForwarder_OnEvent(t *testing.T) {
 			event: rasEventEngineDied,
 			aps:   []string{"192.168.1.1"},
 		},
+		"access points added": {
+			event:          rasEventEngineDiedFwdable,
+			updatedAps:     []string{"192.168.1.1"},
+			expInvokeCount: 1,
+		},
+		"access points removed": {
+			event:      rasEventEngineDiedFwdable,
+			aps:        []string{"192.168.1.1"},
+			updatedAps: []string{},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -127,6 +138,9 @@ func TestControl_EventForwarder_OnEvent(t *testing.T) {
 			}
 
 			ef := NewEventForwarder(mi, tc.aps)
+			if tc.updatedAps != nil {
+				ef.SetAccessPoints(tc.updatedAps)
+			}
 			for i := 0; i < callCount; i++ {
 				ef.OnEvent(context.TODO(), tc.event)
 			}
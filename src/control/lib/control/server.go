@@ -8,12 +8,16 @@ package control
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
 
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/engine"
 )
 
@@ -62,3 +66,188 @@ func SetEngineLogMasks(ctx context.Context, rpcClient UnaryInvoker, req *SetEngi
 
 	return resp, nil
 }
+
+type (
+	// ConfigChange describes a server config parameter which changed on
+	// reload and the action taken for it.
+	ConfigChange struct {
+		Field  string `json:"field"`
+		Action string `json:"action"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	// HostConfigChanges contains the config changes found on a given host.
+	HostConfigChanges struct {
+		Addr    string          `json:"addr"`
+		Path    string          `json:"path"`
+		Changes []*ConfigChange `json:"changes"`
+	}
+
+	// ReloadConfigReq contains the parameters for a config reload request.
+	ReloadConfigReq struct {
+		unaryRequest
+	}
+
+	// ReloadConfigResp contains the results of a config reload request.
+	ReloadConfigResp struct {
+		HostErrorsResp
+		HostChanges []*HostConfigChanges `json:"host_changes"`
+	}
+)
+
+func (rcr *ReloadConfigResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.ReloadConfigResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	hc := &HostConfigChanges{
+		Addr:    hr.Addr,
+		Path:    pbResp.GetPath(),
+		Changes: []*ConfigChange{},
+	}
+	for _, pbChange := range pbResp.GetChanges() {
+		hc.Changes = append(hc.Changes, &ConfigChange{
+			Field:  pbChange.GetField(),
+			Action: strings.ToLower(pbChange.GetAction().String()),
+			Error:  pbChange.GetError(),
+		})
+	}
+	rcr.HostChanges = append(rcr.HostChanges, hc)
+
+	return nil
+}
+
+// ReloadConfig concurrently requests that all hosts supplied in the request's
+// hostlist, or all configured hosts if not explicitly specified, re-read their
+// server config file. Changes which can be made at runtime are applied and the
+// action taken for each changed parameter is returned. The results are sorted
+// by host address.
+func ReloadConfig(ctx context.Context, rpcClient UnaryInvoker, req *ReloadConfigReq) (*ReloadConfigResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).ReloadConfig(ctx, &ctlpb.ReloadConfigReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(ReloadConfigResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := resp.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(resp.HostChanges, func(i, j int) bool {
+		return resp.HostChanges[i].Addr < resp.HostChanges[j].Addr
+	})
+
+	return resp, nil
+}
+
+type (
+	// ApplyConfigReq contains the parameters for a config apply request.
+	ApplyConfigReq struct {
+		unaryRequest
+		Config  []byte
+		DryRun  bool
+		Restart bool
+	}
+
+	// HostConfigApplyResult contains the outcome of applying a config on
+	// a given host.
+	HostConfigApplyResult struct {
+		Addr       string   `json:"addr"`
+		Path       string   `json:"path"`
+		BackupPath string   `json:"backup_path,omitempty"`
+		Changes    []string `json:"changes"`
+		Restarting bool     `json:"restarting"`
+	}
+
+	// ApplyConfigResp contains the results of a config apply request.
+	ApplyConfigResp struct {
+		HostErrorsResp
+		DryRun      bool                     `json:"dry_run"`
+		HostResults []*HostConfigApplyResult `json:"host_results"`
+	}
+)
+
+func (acr *ApplyConfigResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.ApplyConfigResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	acr.HostResults = append(acr.HostResults, &HostConfigApplyResult{
+		Addr:       hr.Addr,
+		Path:       pbResp.GetPath(),
+		BackupPath: pbResp.GetBackupPath(),
+		Changes:    append([]string{}, pbResp.GetChanges()...),
+		Restarting: pbResp.GetRestarting(),
+	})
+
+	return nil
+}
+
+// ApplyConfig concurrently requests that all hosts supplied in the request's
+// hostlist, or all configured hosts if not explicitly specified, validate the
+// candidate server config and write it in place of their config file. The
+// candidate is checked to be a parseable server config before being sent. The
+// results are sorted by host address.
+func ApplyConfig(ctx context.Context, rpcClient UnaryInvoker, req *ApplyConfigReq) (*ApplyConfigResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if len(req.Config) == 0 {
+		return nil, errors.New("empty config")
+	}
+	if err := yaml.UnmarshalStrict(req.Config, config.DefaultServer()); err != nil {
+		return nil, errors.Wrap(err, "parse config")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).ApplyConfig(ctx, &ctlpb.ApplyConfigReq{
+			Config:  req.Config,
+			DryRun:  req.DryRun,
+			Restart: req.Restart,
+		})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ApplyConfigResp{DryRun: req.DryRun}
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := resp.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(resp.HostResults, func(i, j int) bool {
+		return resp.HostResults[i].Addr < resp.HostResults[j].Addr
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_ReloadConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		expResp *ReloadConfigResp
+		expErr  error
+	}{
+		"local failure": {
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+					},
+				},
+			},
+			expResp: &ReloadConfigResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+						},
+					},
+				},
+			},
+			expErr: errors.New("unpack"),
+		},
+		"multiple hosts": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host2",
+							Message: &ctlpb.ReloadConfigResp{
+								Path: "/etc/daos/daos_server.yml",
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.ReloadConfigResp{
+								Path: "/etc/daos/daos_server.yml",
+								Changes: []*ctlpb.ConfigChange{
+									{
+										Field: "control_log_mask",
+									},
+									{
+										Field:  "engines[0].targets",
+										Action: ctlpb.ConfigChange_ENGINE_RESTART,
+									},
+									{
+										Field:  "port",
+										Action: ctlpb.ConfigChange_SERVER_RESTART,
+									},
+									{
+										Field:  "telemetry_port",
+										Action: ctlpb.ConfigChange_FAILED,
+										Error:  "address in use",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &ReloadConfigResp{
+				HostChanges: []*HostConfigChanges{
+					{
+						Addr: "host1",
+						Path: "/etc/daos/daos_server.yml",
+						Changes: []*ConfigChange{
+							{Field: "control_log_mask", Action: "applied"},
+							{Field: "engines[0].targets", Action: "engine_restart"},
+							{Field: "port", Action: "server_restart"},
+							{Field: "telemetry_port", Action: "failed", Error: "address in use"},
+						},
+					},
+					{
+						Addr:    "host2",
+						Path:    "/etc/daos/daos_server.yml",
+						Changes: []*ConfigChange{},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := ReloadConfig(context.TODO(), mi, &ReloadConfigReq{})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_ApplyConfig(t *testing.T) {
+	validCfg := []byte("name: daos_server\nport: 10001\n")
+
+	for name, tc := range map[string]struct {
+		config  []byte
+		mic     *MockInvokerConfig
+		expResp *ApplyConfigResp
+		expErr  error
+	}{
+		"empty config": {
+			expErr: errors.New("empty config"),
+		},
+		"unknown parameter": {
+			config: []byte("name: daos_server\nfoo: bar\n"),
+			expErr: errors.New("parse config"),
+		},
+		"local failure": {
+			config: validCfg,
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			config: validCfg,
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("candidate config: validation failed"),
+						},
+					},
+				},
+			},
+			expResp: &ApplyConfigResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{"host1", "candidate config: validation failed"}),
+			},
+		},
+		"multiple hosts": {
+			config: validCfg,
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host2",
+							Message: &ctlpb.ApplyConfigResp{
+								Path: "/etc/daos/daos_server.yml",
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.ApplyConfigResp{
+								Path:       "/etc/daos/daos_server.yml",
+								BackupPath: "/etc/daos/daos_server.yml.bak",
+								Changes:    []string{"port"},
+								Restarting: true,
+							},
+						},
+					},
+				},
+			},
+			expResp: &ApplyConfigResp{
+				HostResults: []*HostConfigApplyResult{
+					{
+						Addr:       "host1",
+						Path:       "/etc/daos/daos_server.yml",
+						BackupPath: "/etc/daos/daos_server.yml.bak",
+						Changes:    []string{"port"},
+						Restarting: true,
+					},
+					{
+						Addr:    "host2",
+						Path:    "/etc/daos/daos_server.yml",
+						Changes: []string{},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := ApplyConfig(context.TODO(), mi, &ApplyConfigReq{Config: tc.config})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+type (
+	// SupportBundle describes a support bundle collected from a host.
+	SupportBundle struct {
+		Host string `json:"host"`
+		Name string `json:"name"`
+		Size uint64 `json:"size"`
+	}
+
+	// SupportCollectLogReq contains the parameters for a request to
+	// collect support bundles from hosts.
+	SupportCollectLogReq struct {
+		unaryRequest
+	}
+
+	// SupportCollectLogResp contains the results of a support bundle
+	// collection request.
+	SupportCollectLogResp struct {
+		HostErrorsResp
+		Bundles []*SupportBundle `json:"bundles"`
+	}
+
+	// supportChunkReq is used to fetch the remaining chunks of a support
+	// bundle from a single host.
+	supportChunkReq struct {
+		unaryRequest
+	}
+)
+
+// SupportBundleFileName returns the name of the entry for a host's support
+// bundle within the collected archive.
+func SupportBundleFileName(host string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(host) + ".tar.gz"
+}
+
+func unpackCollectLogResp(hostResp *HostResponse) (*ctlpb.CollectLogResp, error) {
+	if hostResp.Error != nil {
+		return nil, hostResp.Error
+	}
+	pbResp, ok := hostResp.Message.(*ctlpb.CollectLogResp)
+	if !ok {
+		return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+	}
+	return pbResp, nil
+}
+
+// fetchSupportBundle writes the remaining chunks of a host's support bundle
+// to the supplied writer.
+func fetchSupportBundle(ctx context.Context, rpcClient UnaryInvoker, host string, first *ctlpb.CollectLogResp, out io.Writer) error {
+	if _, err := out.Write(first.GetData()); err != nil {
+		return err
+	}
+
+	pbReq := &ctlpb.CollectLogReq{
+		Bundle: first.GetBundle(),
+		Offset: uint64(len(first.GetData())),
+	}
+	req := new(supportChunkReq)
+	req.SetHostList([]string{host})
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).CollectLog(ctx, pbReq)
+	})
+
+	for pbReq.Offset < first.GetSize() {
+		ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+		if err != nil {
+			return err
+		}
+		if len(ur.Responses) != 1 {
+			return errors.Errorf("expected 1 response, got %d", len(ur.Responses))
+		}
+		pbResp, err := unpackCollectLogResp(ur.Responses[0])
+		if err != nil {
+			return err
+		}
+		if pbResp.GetBundle() != first.GetBundle() || pbResp.GetSize() != first.GetSize() {
+			return errors.Errorf("support bundle %q changed during fetch", first.GetBundle())
+		}
+		if len(pbResp.GetData()) == 0 {
+			return errors.Errorf("short read of support bundle %q at offset %d",
+				first.GetBundle(), pbReq.Offset)
+		}
+
+		if _, err := out.Write(pbResp.GetData()); err != nil {
+			return err
+		}
+		pbReq.Offset += uint64(len(pbResp.GetData()))
+	}
+
+	return nil
+}
+
+// SupportCollectLog assembles a support bundle of logs and diagnostics on all
+// hosts supplied in the request's hostlist, or all configured hosts if not
+// explicitly specified, and writes a tar archive containing each host's
+// bundle to the supplied writer. The bundles are transferred in chunks to
+// avoid exceeding gRPC message size limits. Each bundle is buffered in a
+// temporary file before it is added to the archive so that a failure to fetch
+// one host's bundle is reported as a host error and the remaining hosts are
+// still collected.
+func SupportCollectLog(ctx context.Context, rpcClient UnaryInvoker, req *SupportCollectLogReq, out io.Writer) (*SupportCollectLogResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if out == nil {
+		return nil, errors.New("nil writer")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).CollectLog(ctx, &ctlpb.CollectLogReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	hostResps := ur.Responses
+	sort.Slice(hostResps, func(i, j int) bool {
+		return hostResps[i].Addr < hostResps[j].Addr
+	})
+
+	buf, err := ioutil.TempFile("", "daos_support_bundle")
+	if err != nil {
+		return nil, errors.Wrap(err, "create support bundle buffer")
+	}
+	defer func() {
+		buf.Close()
+		os.Remove(buf.Name())
+	}()
+
+	resp := new(SupportCollectLogResp)
+	tw := tar.NewWriter(out)
+	for _, hostResp := range hostResps {
+		pbResp, err := unpackCollectLogResp(hostResp)
+		if err == nil {
+			if err = resetBuffer(buf); err != nil {
+				return nil, err
+			}
+			err = fetchSupportBundle(ctx, rpcClient, hostResp.Addr, pbResp, buf)
+		}
+		if err != nil {
+			if err := resp.addHostError(hostResp.Addr, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := addSupportBundle(tw, SupportBundleFileName(hostResp.Addr), buf); err != nil {
+			return nil, err
+		}
+
+		resp.Bundles = append(resp.Bundles, &SupportBundle{
+			Host: hostResp.Addr,
+			Name: SupportBundleFileName(hostResp.Addr),
+			Size: pbResp.GetSize(),
+		})
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// resetBuffer truncates the support bundle buffer for reuse.
+func resetBuffer(buf *os.File) error {
+	if err := buf.Truncate(0); err != nil {
+		return errors.Wrap(err, "truncate support bundle buffer")
+	}
+	_, err := buf.Seek(0, io.SeekStart)
+	return errors.Wrap(err, "seek support bundle buffer")
+}
+
+// addSupportBundle writes the contents of the buffer to the archive as an
+// entry with the supplied name.
+func addSupportBundle(tw *tar.Writer, name string, buf *os.File) error {
+	size, err := buf.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Wrap(err, "seek support bundle buffer")
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seek support bundle buffer")
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, buf, size)
+	return err
+}
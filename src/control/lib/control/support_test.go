@@ -0,0 +1,172 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_SupportCollectLog(t *testing.T) {
+	chunk := func(host, bundle string, size uint64, data string) *HostResponse {
+		return &HostResponse{
+			Addr: host,
+			Message: &ctlpb.CollectLogResp{
+				Bundle: bundle,
+				Size:   size,
+				Data:   []byte(data),
+			},
+		}
+	}
+	chunkResp := func(hostResps ...*HostResponse) *UnaryResponse {
+		return &UnaryResponse{Responses: hostResps}
+	}
+
+	for name, tc := range map[string]struct {
+		mic      *MockInvokerConfig
+		expResp  *SupportCollectLogResp
+		expFiles map[string]string
+		expErr   error
+	}{
+		"local failure": {
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &SupportCollectLogResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+			expFiles: map[string]string{},
+		},
+		"multiple hosts": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp(
+						chunk("host2:10001", "b", 2, "xy"),
+						chunk("host1:10001", "a", 6, "abc"),
+					),
+					chunkResp(chunk("host1:10001", "a", 6, "de")),
+					chunkResp(chunk("host1:10001", "a", 6, "f")),
+				},
+			},
+			expResp: &SupportCollectLogResp{
+				Bundles: []*SupportBundle{
+					{Host: "host1:10001", Name: "host1_10001.tar.gz", Size: 6},
+					{Host: "host2:10001", Name: "host2_10001.tar.gz", Size: 2},
+				},
+			},
+			expFiles: map[string]string{
+				"host1_10001.tar.gz": "abcdef",
+				"host2_10001.tar.gz": "xy",
+			},
+		},
+		"bundle changed": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp(chunk("host1", "a", 6, "abc")),
+					chunkResp(chunk("host1", "b", 6, "def")),
+				},
+			},
+			expResp: &SupportCollectLogResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{"host1", `support bundle "a" changed during fetch`}),
+			},
+			expFiles: map[string]string{},
+		},
+		"short read": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp(chunk("host1", "a", 6, "abc")),
+					chunkResp(chunk("host1", "a", 6, "")),
+				},
+			},
+			expResp: &SupportCollectLogResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{"host1", `short read of support bundle "a" at offset 3`}),
+			},
+			expFiles: map[string]string{},
+		},
+		"fetch fails on one host": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					chunkResp(
+						chunk("host1:10001", "a", 6, "abc"),
+						chunk("host2:10001", "b", 4, "wx"),
+						chunk("host3:10001", "c", 2, "yz"),
+					),
+					chunkResp(&HostResponse{
+						Addr:  "host1:10001",
+						Error: errors.New("connection lost"),
+					}),
+					chunkResp(chunk("host2:10001", "b", 4, "yz")),
+				},
+			},
+			expResp: &SupportCollectLogResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{"host1:10001", "connection lost"}),
+				Bundles: []*SupportBundle{
+					{Host: "host2:10001", Name: "host2_10001.tar.gz", Size: 4},
+					{Host: "host3:10001", Name: "host3_10001.tar.gz", Size: 2},
+				},
+			},
+			expFiles: map[string]string{
+				"host2_10001.tar.gz": "wxyz",
+				"host3_10001.tar.gz": "yz",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			var out bytes.Buffer
+			gotResp, gotErr := SupportCollectLog(context.TODO(), mi, &SupportCollectLogReq{}, &out)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+
+			gotFiles := make(map[string]string)
+			tr := tar.NewReader(&out)
+			for {
+				hdr, err := tr.Next()
+				if err != nil {
+					break
+				}
+				data, err := ioutil.ReadAll(tr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotFiles[hdr.Name] = string(data)
+			}
+			if diff := cmp.Diff(tc.expFiles, gotFiles); diff != "" {
+				t.Fatalf("unexpected archive contents (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -97,6 +97,9 @@ type SystemJoinReq struct {
 	FaultDomain *system.FaultDomain `json:"SrvFaultDomain"`
 	InstanceIdx uint32              `json:"Idx"`
 	Incarnation uint64              `json:"Incarnation"`
+	Restarts    uint32              `json:"Restarts"`
+	ConfigHash  string              `json:"ConfigHash"`
+	Config      string              `json:"Config"`
 }
 
 // MarshalJSON packs SystemJoinResp struct into a JSON message.
@@ -246,6 +249,66 @@ func SystemQuery(ctx context.Context, rpcClient UnaryInvoker, req *SystemQueryRe
 	return resp, convertMSResponse(ur, resp)
 }
 
+// SystemConfigCheckReq contains the inputs for the system config check
+// request.
+type SystemConfigCheckReq struct {
+	unaryRequest
+	msRequest
+}
+
+// ConfigFieldDiff describes a server config parameter which differs from the
+// reference config.
+type ConfigFieldDiff struct {
+	Field     string `json:"field"`
+	Value     string `json:"value"`
+	Reference string `json:"reference"`
+}
+
+// SystemConfigGroup describes the system members which joined with the same
+// normalized server config. An empty hash indicates that the members did not
+// supply a config when they joined.
+type SystemConfigGroup struct {
+	Hash   string             `json:"hash"`
+	Ranks  string             `json:"ranks"`
+	Hosts  []string           `json:"hosts"`
+	Config string             `json:"config,omitempty"`
+	Diffs  []*ConfigFieldDiff `json:"diffs,omitempty"`
+}
+
+// SystemConfigCheckResp contains the system members grouped by server config.
+// The first group is the reference against which the others are compared.
+type SystemConfigCheckResp struct {
+	Groups []*SystemConfigGroup `json:"groups"`
+}
+
+// SystemConfigCheck requests the server configs of the DAOS system members be
+// compared.
+//
+// Each server supplies a normalized config fingerprint with host-specific
+// parameters removed when it joins the system. The MS groups members by the
+// fingerprint and reports the parameters which differ between groups.
+func SystemConfigCheck(ctx context.Context, rpcClient UnaryInvoker, req *SystemConfigCheckReq) (*SystemConfigCheckResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := new(mgmtpb.SystemConfigCheckReq)
+	pbReq.Sys = req.getSystem(rpcClient)
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemConfigCheck(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system config check request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemConfigCheckResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
 func concatSysErrs(errSys, errRes error) error {
 	var errMsgs []string
 
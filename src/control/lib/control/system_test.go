@@ -660,6 +660,94 @@ func TestControl_SystemQuery(t *testing.T) {
 	}
 }
 
+func TestControl_SystemConfigCheck(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemConfigCheckReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemConfigCheckResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemConfigCheckReq request"),
+		},
+		"local failure": {
+			req:    new(SystemConfigCheckReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemConfigCheckReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"config groups": {
+			req: new(SystemConfigCheckReq),
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemConfigCheckResp{
+					Groups: []*mgmtpb.SystemConfigGroup{
+						{
+							Hash:   "abcd",
+							Ranks:  "0-1",
+							Hosts:  []string{"10.0.0.1:10001"},
+							Config: "name: daos_server\n",
+						},
+						{
+							Hash:   "ef01",
+							Ranks:  "2",
+							Hosts:  []string{"10.0.0.2:10001"},
+							Config: "name: other\n",
+							Diffs: []*mgmtpb.ConfigFieldDiff{
+								{Field: "name", Value: "other", Reference: "daos_server"},
+							},
+						},
+					},
+				},
+			),
+			expResp: &SystemConfigCheckResp{
+				Groups: []*SystemConfigGroup{
+					{
+						Hash:   "abcd",
+						Ranks:  "0-1",
+						Hosts:  []string{"10.0.0.1:10001"},
+						Config: "name: daos_server\n",
+					},
+					{
+						Hash:   "ef01",
+						Ranks:  "2",
+						Hosts:  []string{"10.0.0.2:10001"},
+						Config: "name: other\n",
+						Diffs: []*ConfigFieldDiff{
+							{Field: "name", Value: "other", Reference: "daos_server"},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemConfigCheck(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_SystemQueryRespErrors(t *testing.T) {
 	for name, tc := range map[string]struct {
 		absentHosts string
@@ -0,0 +1,640 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package fscopy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SkipMode selects how files that are already present at the destination
+// are detected as unchanged and skipped.
+type SkipMode int
+
+// Skip modes.
+const (
+	// SkipNone copies every file.
+	SkipNone SkipMode = iota
+	// SkipSizeMtime skips files with the same size and modification
+	// time (at one second granularity) as the source.
+	SkipSizeMtime
+	// SkipChecksum skips files with the same size and content as the
+	// source.
+	SkipChecksum
+)
+
+const (
+	// DefaultWorkers is the default number of concurrent copy workers.
+	DefaultWorkers = 4
+	// DefaultSplitSize is the default size of the parts that large files
+	// are split into so that they can be copied concurrently.
+	DefaultSplitSize = 64 << 20
+	// DefaultProgressInterval is the default interval between progress
+	// reports.
+	DefaultProgressInterval = time.Second
+
+	// bufSize is the size of the I/O buffer of each worker.
+	bufSize = 8 << 20
+)
+
+type (
+	// Endpoint is the source or destination of a copy.
+	Endpoint struct {
+		FS   FS
+		Path string
+		// Name identifies the endpoint in the journal, typically as
+		// specified by the user.
+		Name string
+	}
+
+	// Options controls the behavior of Copy.
+	Options struct {
+		Workers   int
+		SplitSize int64
+		Skip      SkipMode
+		// Verify compares the checksums of all source and destination
+		// files once the copy is complete.
+		Verify bool
+		// JournalPath enables recording completed files so that an
+		// interrupted copy can be resumed. The journal is removed once
+		// the copy completes.
+		JournalPath string
+		// Progress is called periodically with the progress of the
+		// copy, and once more when the copy is done.
+		Progress         func(*Progress)
+		ProgressInterval time.Duration
+	}
+
+	// Result summarizes a completed copy.
+	Result struct {
+		Progress
+		Source     string   `json:"source"`
+		Dest       string   `json:"dest"`
+		Mismatches []string `json:"mismatches,omitempty"`
+	}
+)
+
+type (
+	fileState struct {
+		rel       string
+		srcPath   string
+		dstPath   string
+		info      *FileInfo
+		remaining int32
+		once      sync.Once
+		skip      bool
+		err       error
+	}
+
+	dirState struct {
+		path string
+		info *FileInfo
+	}
+
+	copyJob struct {
+		file   *fileState
+		offset int64
+		length int64
+	}
+
+	copier struct {
+		src     Endpoint
+		dst     Endpoint
+		opts    Options
+		journal *journal
+		ctrs    *counters
+		cancel  context.CancelFunc
+
+		errMu    sync.Mutex
+		firstErr error
+
+		// only accessed by the walker until the workers are done
+		files []*fileState
+		dirs  []*dirState
+
+		mismatchMu sync.Mutex
+		mismatches []string
+	}
+)
+
+func (o *Options) setDefaults() {
+	if o.Workers <= 0 {
+		o.Workers = DefaultWorkers
+	}
+	if o.SplitSize <= 0 {
+		o.SplitSize = DefaultSplitSize
+	}
+	if o.ProgressInterval <= 0 {
+		o.ProgressInterval = DefaultProgressInterval
+	}
+}
+
+// resolveDest returns the destination path of the copy. If the destination
+// is an existing directory, the source is copied into it.
+func resolveDest(src Endpoint, srcInfo *FileInfo, dst Endpoint) (string, error) {
+	dstInfo, err := dst.FS.Stat(dst.Path)
+	switch {
+	case err == nil && dstInfo.Mode.IsDir():
+		return path.Join(dst.Path, path.Base(src.Path)), nil
+	case err == nil && srcInfo.Mode.IsDir():
+		return "", errors.Errorf("destination %s is not a directory", dst.Path)
+	case err != nil && !os.IsNotExist(err):
+		return "", errors.Wrapf(err, "stat %s", dst.Path)
+	}
+
+	return dst.Path, nil
+}
+
+// Copy copies the file or directory tree at the source endpoint to the
+// destination endpoint. If the destination is an existing directory, the
+// source is copied into it. Only regular files and directories are
+// supported.
+func Copy(parent context.Context, src, dst Endpoint, opts Options) (*Result, error) {
+	opts.setDefaults()
+
+	srcInfo, err := src.FS.Stat(src.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "stat %s", src.Path)
+	}
+	if !srcInfo.Mode.IsDir() && !srcInfo.Mode.IsRegular() {
+		return nil, errors.Errorf("%s: only files and directories are supported", src.Path)
+	}
+
+	target, err := resolveDest(src, srcInfo, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &copier{
+		src:  src,
+		dst:  dst,
+		opts: opts,
+		ctrs: newCounters(),
+	}
+	if opts.JournalPath != "" {
+		c.journal, err = openJournal(opts.JournalPath, src.Name, dst.Name, target)
+		if err != nil {
+			return nil, err
+		}
+		// a resumed copy uses the destination resolved by the first
+		// attempt, as that attempt may have created it
+		target = c.journal.target()
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	c.cancel = cancel
+
+	stopProgress := c.startProgress()
+	c.copyTree(ctx, srcInfo, target)
+	if c.err() == nil {
+		c.finishDirs()
+	}
+	if c.err() == nil {
+		// the copy is complete, so the journal is no longer needed
+		// even if the verification fails
+		if err := c.journal.remove(); err != nil {
+			c.fail(errors.Wrap(err, "remove journal"))
+		}
+		c.journal = nil
+	}
+	if c.err() == nil && opts.Verify {
+		c.verify(ctx)
+	}
+	c.ctrs.setPhase(PhaseDone)
+	stopProgress()
+
+	if err := c.journal.close(); err != nil {
+		c.fail(errors.Wrap(err, "close journal"))
+	}
+
+	sort.Strings(c.mismatches)
+	result := &Result{
+		Progress:   *c.ctrs.snapshot(),
+		Source:     src.Path,
+		Dest:       target,
+		Mismatches: c.mismatches,
+	}
+	if err := c.err(); err != nil {
+		return result, err
+	}
+	if len(result.Mismatches) > 0 {
+		return result, errors.Errorf("verification failed for %d file(s)", len(result.Mismatches))
+	}
+
+	return result, nil
+}
+
+func (c *copier) fail(err error) {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+
+	if c.firstErr == nil {
+		c.firstErr = err
+		c.cancel()
+	}
+}
+
+func (c *copier) err() error {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+
+	return c.firstErr
+}
+
+// startProgress periodically reports the progress of the copy until the
+// returned function is called, which makes a final report.
+func (c *copier) startProgress() func() {
+	if c.opts.Progress == nil {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(c.opts.ProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.opts.Progress(c.ctrs.snapshot())
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+		c.opts.Progress(c.ctrs.snapshot())
+	}
+}
+
+// copyTree walks the source tree, feeding the files found to the workers.
+func (c *copier) copyTree(ctx context.Context, srcInfo *FileInfo, target string) {
+	jobs := make(chan *copyJob, c.opts.Workers*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.copyWorker(ctx, jobs)
+		}()
+	}
+
+	atomic.StoreInt32(&c.ctrs.scanning, 1)
+	rel := ""
+	if !srcInfo.Mode.IsDir() {
+		rel = path.Base(c.src.Path)
+	}
+	if err := c.walk(ctx, jobs, c.src.Path, target, rel, srcInfo); err != nil {
+		c.fail(err)
+	}
+	atomic.StoreInt32(&c.ctrs.scanning, 0)
+
+	close(jobs)
+	wg.Wait()
+}
+
+func (c *copier) walk(ctx context.Context, jobs chan<- *copyJob, srcPath, dstPath, rel string, info *FileInfo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode.IsRegular():
+		return c.queueFile(ctx, jobs, &fileState{
+			rel:     rel,
+			srcPath: srcPath,
+			dstPath: dstPath,
+			info:    info,
+		})
+	case info.Mode.IsDir():
+	default:
+		return errors.Errorf("%s: only files and directories are supported", srcPath)
+	}
+
+	// the directory stays writable until its contents have been copied
+	if err := c.dst.FS.Mkdir(dstPath, 0700); err != nil {
+		if !os.IsExist(err) {
+			return errors.Wrapf(err, "mkdir %s", dstPath)
+		}
+		if err := c.dst.FS.Chmod(dstPath, info.Mode.Perm()|0700); err != nil {
+			return errors.Wrapf(err, "chmod %s", dstPath)
+		}
+	}
+	c.dirs = append(c.dirs, &dirState{path: dstPath, info: info})
+	atomic.AddUint64(&c.ctrs.dirs, 1)
+
+	names, err := c.src.FS.ReadDir(srcPath)
+	if err != nil {
+		return errors.Wrapf(err, "read directory %s", srcPath)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "." || name == ".." {
+			continue
+		}
+
+		childSrc := path.Join(srcPath, name)
+		childInfo, err := c.src.FS.Stat(childSrc)
+		if err != nil {
+			return errors.Wrapf(err, "stat %s", childSrc)
+		}
+		if err := c.walk(ctx, jobs, childSrc, path.Join(dstPath, name),
+			path.Join(rel, name), childInfo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queueFile splits a file into parts of at most SplitSize bytes and queues
+// a job for each of them.
+func (c *copier) queueFile(ctx context.Context, jobs chan<- *copyJob, f *fileState) error {
+	c.files = append(c.files, f)
+	atomic.AddUint64(&c.ctrs.filesTotal, 1)
+	atomic.AddUint64(&c.ctrs.bytesTotal, uint64(f.info.Size))
+
+	parts := (f.info.Size + c.opts.SplitSize - 1) / c.opts.SplitSize
+	if parts == 0 {
+		parts = 1
+	}
+	f.remaining = int32(parts)
+
+	for offset := int64(0); parts > 0; parts-- {
+		length := f.info.Size - offset
+		if length > c.opts.SplitSize {
+			length = c.opts.SplitSize
+		}
+
+		select {
+		case jobs <- &copyJob{file: f, offset: offset, length: length}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		offset += length
+	}
+
+	return nil
+}
+
+func (c *copier) copyWorker(ctx context.Context, jobs <-chan *copyJob) {
+	buf := make([]byte, bufSize)
+
+	for job := range jobs {
+		if ctx.Err() != nil {
+			continue
+		}
+		if err := c.copyPart(job, buf); err != nil {
+			c.fail(err)
+		}
+	}
+}
+
+func (c *copier) copyPart(job *copyJob, buf []byte) error {
+	f := job.file
+
+	// the first worker to reach a file decides whether it needs to be
+	// copied and prepares the destination, the others wait for it
+	f.once.Do(func() {
+		f.err = c.prepareFile(f)
+	})
+	if f.err != nil {
+		return f.err
+	}
+
+	if !f.skip && job.length > 0 {
+		if err := c.copyRange(f, job.offset, job.length, buf); err != nil {
+			return errors.Wrapf(err, "copy %s", f.srcPath)
+		}
+	}
+
+	if atomic.AddInt32(&f.remaining, -1) == 0 {
+		return c.finishFile(f)
+	}
+
+	return nil
+}
+
+func (c *copier) prepareFile(f *fileState) error {
+	if c.journal.completed(f.rel, f.info) {
+		f.skip = true
+		return nil
+	}
+
+	if c.opts.Skip != SkipNone {
+		same, err := c.unchanged(f)
+		if err != nil {
+			return err
+		}
+		if same {
+			f.skip = true
+			return c.journal.record(f.rel, f.info)
+		}
+	}
+
+	if err := c.dst.FS.Create(f.dstPath, 0600); err != nil {
+		return errors.Wrapf(err, "create %s", f.dstPath)
+	}
+
+	return nil
+}
+
+// unchanged indicates whether the destination file matches the source
+// according to the skip mode.
+func (c *copier) unchanged(f *fileState) (bool, error) {
+	dstInfo, err := c.dst.FS.Stat(f.dstPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "stat %s", f.dstPath)
+	}
+	if !dstInfo.Mode.IsRegular() || dstInfo.Size != f.info.Size {
+		return false, nil
+	}
+
+	if c.opts.Skip == SkipSizeMtime {
+		return dstInfo.ModTime.Unix() == f.info.ModTime.Unix(), nil
+	}
+
+	return c.sameContent(f, make([]byte, bufSize))
+}
+
+func (c *copier) copyRange(f *fileState, offset, length int64, buf []byte) (err error) {
+	in, err := c.src.FS.Open(f.srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := c.dst.FS.OpenWrite(f.dstPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := out.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	for done := int64(0); done < length; {
+		chunk := buf
+		if int64(len(chunk)) > length-done {
+			chunk = chunk[:length-done]
+		}
+
+		n, err := in.ReadAt(chunk, offset+done)
+		if n < len(chunk) {
+			if err == nil || err == io.EOF {
+				return errors.New("source file changed during copy")
+			}
+			return err
+		}
+		if _, err := out.WriteAt(chunk, offset+done); err != nil {
+			return err
+		}
+
+		done += int64(n)
+		atomic.AddUint64(&c.ctrs.bytesCopied, uint64(n))
+	}
+
+	return nil
+}
+
+func (c *copier) finishFile(f *fileState) error {
+	if f.skip {
+		atomic.AddUint64(&c.ctrs.filesSkipped, 1)
+		atomic.AddUint64(&c.ctrs.bytesSkipped, uint64(f.info.Size))
+		return nil
+	}
+
+	if err := c.dst.FS.Chmod(f.dstPath, f.info.Mode.Perm()); err != nil {
+		return errors.Wrapf(err, "chmod %s", f.dstPath)
+	}
+	if err := c.dst.FS.Chtimes(f.dstPath, f.info.ModTime); err != nil {
+		return errors.Wrapf(err, "set times of %s", f.dstPath)
+	}
+	if err := c.journal.record(f.rel, f.info); err != nil {
+		return errors.Wrap(err, "update journal")
+	}
+	atomic.AddUint64(&c.ctrs.filesCopied, 1)
+
+	return nil
+}
+
+// finishDirs applies the source permissions and modification times to the
+// destination directories, deepest first, now that their contents have been
+// copied.
+func (c *copier) finishDirs() {
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		dir := c.dirs[i]
+		if err := c.dst.FS.Chmod(dir.path, dir.info.Mode.Perm()); err != nil {
+			c.fail(errors.Wrapf(err, "chmod %s", dir.path))
+			return
+		}
+		if err := c.dst.FS.Chtimes(dir.path, dir.info.ModTime); err != nil {
+			c.fail(errors.Wrapf(err, "set times of %s", dir.path))
+			return
+		}
+	}
+}
+
+// verify compares the checksums of every source and destination file.
+func (c *copier) verify(ctx context.Context) {
+	c.ctrs.setPhase(PhaseVerify)
+
+	files := make(chan *fileState)
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buf := make([]byte, bufSize)
+			for f := range files {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				same, err := c.sameContent(f, buf)
+				if err != nil {
+					c.fail(errors.Wrapf(err, "verify %s", f.srcPath))
+					continue
+				}
+				if !same {
+					c.mismatchMu.Lock()
+					c.mismatches = append(c.mismatches, f.dstPath)
+					c.mismatchMu.Unlock()
+				}
+				atomic.AddUint64(&c.ctrs.filesVerified, 1)
+				atomic.AddUint64(&c.ctrs.bytesVerified, uint64(f.info.Size))
+			}
+		}()
+	}
+
+	for _, f := range c.files {
+		files <- f
+	}
+	close(files)
+	wg.Wait()
+}
+
+// sameContent compares the checksums of the source and destination files.
+func (c *copier) sameContent(f *fileState, buf []byte) (bool, error) {
+	srcSum, err := checksum(c.src.FS, f.srcPath, buf)
+	if err != nil {
+		return false, err
+	}
+	dstSum, err := checksum(c.dst.FS, f.dstPath, buf)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(srcSum, dstSum), nil
+}
+
+// checksum returns the SHA-256 digest of a file's content.
+func checksum(fs FS, filePath string, buf []byte) ([]byte, error) {
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	for offset := int64(0); ; {
+		n, err := file.ReadAt(buf, offset)
+		digest.Write(buf[:n])
+		offset += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return digest.Sum(nil), nil
+}
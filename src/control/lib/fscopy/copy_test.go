@@ -0,0 +1,396 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package fscopy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+var testMTime = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// testTree maps slash-separated relative paths to file contents, with
+// directories denoted by a trailing slash.
+type testTree map[string]string
+
+func writeTree(t *testing.T, root string, tree testTree) {
+	t.Helper()
+
+	for rel, content := range tree {
+		p := filepath.Join(root, rel)
+		if strings.HasSuffix(rel, "/") {
+			if err := os.MkdirAll(p, 0755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0640); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, testMTime, testMTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readTree(t *testing.T, root string) testTree {
+	t.Helper()
+
+	tree := make(testTree)
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || p == root {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			tree[filepath.ToSlash(rel)+"/"] = ""
+			return nil
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tree
+}
+
+// faultyFS wraps LocalFS, failing or corrupting accesses to some files.
+type faultyFS struct {
+	LocalFS
+	failCreate string
+	corrupt    string
+}
+
+func (fs *faultyFS) Create(path string, perm os.FileMode) error {
+	if fs.failCreate != "" && filepath.Base(path) == fs.failCreate {
+		return errors.New("injected failure")
+	}
+	return fs.LocalFS.Create(path, perm)
+}
+
+func (fs *faultyFS) Open(path string) (File, error) {
+	f, err := fs.LocalFS.Open(path)
+	if err != nil || fs.corrupt == "" || filepath.Base(path) != fs.corrupt {
+		return f, err
+	}
+	return &corruptFile{File: f}, nil
+}
+
+type corruptFile struct {
+	File
+}
+
+func (f *corruptFile) ReadAt(p []byte, off int64) (int, error) {
+	n, err := f.File.ReadAt(p, off)
+	if n > 0 {
+		p[0] ^= 0xff
+	}
+	return n, err
+}
+
+func TestFscopy_Copy(t *testing.T) {
+	srcTree := testTree{
+		"empty/":      "",
+		"a":           "alpha",
+		"b/":          "",
+		"b/c":         "charlie",
+		"b/d/":        "",
+		"b/d/e":       strings.Repeat("echo", 1000),
+		"b/d/f":       "",
+		"b/d/g/":      "",
+		"b/d/g/h/":    "",
+		"b/d/g/h/i/":  "",
+		"b/d/g/h/i/j": "juliet",
+	}
+
+	for name, tc := range map[string]struct {
+		dstExists bool
+		opts      Options
+	}{
+		"new destination": {},
+		"existing destination directory": {
+			dstExists: true,
+		},
+		"split files": {
+			opts: Options{Workers: 4, SplitSize: 7},
+		},
+		"single worker with verify": {
+			opts: Options{Workers: 1, Verify: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			srcDir := filepath.Join(tmpDir, "src")
+			writeTree(t, srcDir, srcTree)
+			if err := os.Chmod(filepath.Join(srcDir, "b"), 0750); err != nil {
+				t.Fatal(err)
+			}
+
+			dstDir := filepath.Join(tmpDir, "dst")
+			expDir := dstDir
+			if tc.dstExists {
+				if err := os.Mkdir(dstDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				expDir = filepath.Join(dstDir, "src")
+			}
+
+			var reports []*Progress
+			tc.opts.Progress = func(p *Progress) {
+				reports = append(reports, p)
+			}
+
+			result, err := Copy(context.Background(),
+				Endpoint{FS: LocalFS{}, Path: srcDir},
+				Endpoint{FS: LocalFS{}, Path: dstDir},
+				tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(srcTree, readTree(t, expDir)); diff != "" {
+				t.Fatalf("unexpected tree (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, expDir, result.Dest, "destination")
+			common.AssertEqual(t, uint64(7), result.Dirs, "directories")
+			common.AssertEqual(t, uint64(5), result.FilesCopied, "files copied")
+			common.AssertEqual(t, uint64(4018), result.BytesCopied, "bytes copied")
+			common.AssertEqual(t, PhaseDone, result.Phase, "phase")
+			if tc.opts.Verify {
+				common.AssertEqual(t, uint64(5), result.FilesVerified, "files verified")
+			}
+
+			if len(reports) == 0 {
+				t.Fatal("no progress reported")
+			}
+			common.AssertEqual(t, PhaseDone, reports[len(reports)-1].Phase, "last report")
+
+			fi, err := os.Stat(filepath.Join(expDir, "b"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, os.FileMode(0750), fi.Mode().Perm(), "dir mode")
+
+			fi, err = os.Stat(filepath.Join(expDir, "b/d/e"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, os.FileMode(0640), fi.Mode().Perm(), "file mode")
+			common.AssertTrue(t, fi.ModTime().Equal(testMTime), "file mtime")
+		})
+	}
+}
+
+func TestFscopy_Copy_Errors(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	writeTree(t, tmpDir, testTree{"src/a": "alpha", "file": "x"})
+	if err := os.Symlink("src/a", filepath.Join(tmpDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		src    string
+		dst    string
+		expErr error
+	}{
+		"missing source": {
+			src:    "missing",
+			dst:    "dst",
+			expErr: errors.New("no such file"),
+		},
+		"unsupported source": {
+			src:    "link",
+			dst:    "dst",
+			expErr: errors.New("only files and directories are supported"),
+		},
+		"directory onto file": {
+			src:    "src",
+			dst:    "file",
+			expErr: errors.New("is not a directory"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Copy(context.Background(),
+				Endpoint{FS: LocalFS{}, Path: filepath.Join(tmpDir, tc.src)},
+				Endpoint{FS: LocalFS{}, Path: filepath.Join(tmpDir, tc.dst)},
+				Options{})
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFscopy_Copy_SkipUnchanged(t *testing.T) {
+	for name, tc := range map[string]struct {
+		skip       SkipMode
+		modify     func(t *testing.T, dstDir string)
+		expCopied  uint64
+		expSkipped uint64
+	}{
+		"no skip": {
+			skip:      SkipNone,
+			expCopied: 3,
+		},
+		"size-mtime unchanged": {
+			skip:       SkipSizeMtime,
+			expSkipped: 3,
+		},
+		"size-mtime with modified destination": {
+			skip: SkipSizeMtime,
+			modify: func(t *testing.T, dstDir string) {
+				writeTree(t, dstDir, testTree{"a": "alphabet"})
+			},
+			expCopied:  1,
+			expSkipped: 2,
+		},
+		"size-mtime misses same size content change": {
+			skip: SkipSizeMtime,
+			modify: func(t *testing.T, dstDir string) {
+				writeTree(t, dstDir, testTree{"a": "ALPHA"})
+			},
+			expSkipped: 3,
+		},
+		"checksum catches same size content change": {
+			skip: SkipChecksum,
+			modify: func(t *testing.T, dstDir string) {
+				writeTree(t, dstDir, testTree{"a": "ALPHA"})
+			},
+			expCopied:  1,
+			expSkipped: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			srcDir := filepath.Join(tmpDir, "src")
+			dstDir := filepath.Join(tmpDir, "dst")
+			writeTree(t, srcDir, testTree{"a": "alpha", "b": "bravo", "c/d": "delta"})
+			if err := os.Mkdir(dstDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			src := Endpoint{FS: LocalFS{}, Path: srcDir}
+			dst := Endpoint{FS: LocalFS{}, Path: dstDir}
+			if _, err := Copy(context.Background(), src, dst, Options{}); err != nil {
+				t.Fatal(err)
+			}
+			if tc.modify != nil {
+				tc.modify(t, filepath.Join(dstDir, "src"))
+			}
+
+			result, err := Copy(context.Background(), src, dst, Options{Skip: tc.skip})
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expCopied, result.FilesCopied, "files copied")
+			common.AssertEqual(t, tc.expSkipped, result.FilesSkipped, "files skipped")
+		})
+	}
+}
+
+func TestFscopy_Copy_Resume(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+	journalPath := filepath.Join(tmpDir, "journal")
+	writeTree(t, srcDir, testTree{"a": "alpha", "b": "bravo", "z": "zulu"})
+
+	src := Endpoint{FS: LocalFS{}, Path: srcDir, Name: "src"}
+	dst := Endpoint{FS: &faultyFS{failCreate: "z"}, Path: dstDir, Name: "dst"}
+	opts := Options{Workers: 1, JournalPath: journalPath}
+
+	_, err := Copy(context.Background(), src, dst, opts)
+	common.CmpErr(t, errors.New("injected failure"), err)
+	if _, err := os.Stat(journalPath); err != nil {
+		t.Fatalf("journal not kept after failure: %s", err)
+	}
+
+	// a journal can't be reused for a different copy
+	_, err = Copy(context.Background(), src,
+		Endpoint{FS: LocalFS{}, Path: dstDir, Name: "other"}, opts)
+	common.CmpErr(t, errors.New("written for a copy from src to dst"), err)
+
+	// dst now exists, but the resumed copy must not copy into it
+	dst.FS = LocalFS{}
+	result, err := Copy(context.Background(), src, dst, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, dstDir, result.Dest, "destination")
+	common.AssertEqual(t, uint64(1), result.FilesCopied, "files copied")
+	common.AssertEqual(t, uint64(2), result.FilesSkipped, "files skipped")
+	if diff := cmp.Diff(readTree(t, srcDir), readTree(t, dstDir)); diff != "" {
+		t.Fatalf("unexpected tree (-want, +got):\n%s\n", diff)
+	}
+	if _, err := os.Stat(journalPath); !os.IsNotExist(err) {
+		t.Fatalf("journal not removed after completion: %v", err)
+	}
+}
+
+func TestFscopy_Copy_Verify(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+	writeTree(t, srcDir, testTree{"a": "alpha", "b": "bravo"})
+
+	result, err := Copy(context.Background(),
+		Endpoint{FS: LocalFS{}, Path: srcDir},
+		Endpoint{FS: &faultyFS{corrupt: "b"}, Path: dstDir},
+		Options{Verify: true})
+	common.CmpErr(t, errors.New("verification failed for 1 file(s)"), err)
+	if result == nil {
+		t.Fatal("no result returned")
+	}
+	common.AssertEqual(t, []string{filepath.Join(dstDir, "b")}, result.Mismatches, "mismatches")
+	common.AssertEqual(t, uint64(2), result.FilesVerified, "files verified")
+}
+
+func TestFscopy_ModeFromUnix(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mode    uint32
+		expMode os.FileMode
+	}{
+		"regular": {0100644, 0644},
+		"dir":     {0040755, os.ModeDir | 0755},
+		"symlink": {0120777, os.ModeSymlink | 0777},
+		"fifo":    {0010600, os.ModeIrregular | 0600},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expMode, ModeFromUnix(tc.mode), "mode")
+		})
+	}
+}
@@ -0,0 +1,145 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package fscopy implements a parallel, incremental and resumable copy of a
+// file or directory tree between two file systems, such as a POSIX file
+// system and a DAOS File System (DFS) container.
+package fscopy
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+type (
+	// FileInfo describes a file system entry.
+	FileInfo struct {
+		Mode    os.FileMode
+		Size    int64
+		ModTime time.Time
+	}
+
+	// File is an open file. Implementations must allow concurrent
+	// ReadAt/WriteAt calls at different offsets of the same file from
+	// separately opened handles.
+	File interface {
+		io.ReaderAt
+		io.WriterAt
+		io.Closer
+	}
+
+	// FS is the interface to a file system taking part in a copy. Paths
+	// are slash-separated. Errors should satisfy os.IsNotExist and
+	// os.IsExist where applicable.
+	FS interface {
+		// Stat describes the entry at path without following symlinks.
+		Stat(path string) (*FileInfo, error)
+		// ReadDir returns the names of the entries in a directory.
+		ReadDir(path string) ([]string, error)
+		// Mkdir creates a directory.
+		Mkdir(path string, perm os.FileMode) error
+		// Create creates or truncates a regular file.
+		Create(path string, perm os.FileMode) error
+		// Open opens a file for reading.
+		Open(path string) (File, error)
+		// OpenWrite opens an existing file for writing.
+		OpenWrite(path string) (File, error)
+		// Chmod sets the permissions of an entry.
+		Chmod(path string, perm os.FileMode) error
+		// Chtimes sets the modification time of an entry.
+		Chtimes(path string, mtime time.Time) error
+	}
+)
+
+// Unix file type bits, as found in the st_mode field of struct stat.
+const (
+	unixTypeMask = 0170000
+	unixDir      = 0040000
+	unixRegular  = 0100000
+	unixSymlink  = 0120000
+)
+
+// ModeFromUnix converts a Unix st_mode value into an os.FileMode holding the
+// file type and permission bits.
+func ModeFromUnix(mode uint32) os.FileMode {
+	m := os.FileMode(mode) & os.ModePerm
+
+	switch mode & unixTypeMask {
+	case unixRegular:
+	case unixDir:
+		m |= os.ModeDir
+	case unixSymlink:
+		m |= os.ModeSymlink
+	default:
+		m |= os.ModeIrregular
+	}
+
+	return m
+}
+
+// LocalFS implements FS on top of the local file system.
+type LocalFS struct{}
+
+// Stat implements FS.
+func (LocalFS) Stat(path string) (*FileInfo, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInfo{
+		Mode:    fi.Mode(),
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}, nil
+}
+
+// ReadDir implements FS.
+func (LocalFS) ReadDir(path string) ([]string, error) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	return dir.Readdirnames(-1)
+}
+
+// Mkdir implements FS.
+func (LocalFS) Mkdir(path string, perm os.FileMode) error {
+	return os.Mkdir(path, perm)
+}
+
+// Create implements FS.
+func (LocalFS) Create(path string, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// Open implements FS.
+func (LocalFS) Open(path string) (File, error) {
+	return os.Open(path)
+}
+
+// OpenWrite implements FS.
+func (LocalFS) OpenWrite(path string) (File, error) {
+	return os.OpenFile(path, os.O_WRONLY, 0)
+}
+
+// Chmod implements FS.
+func (LocalFS) Chmod(path string, perm os.FileMode) error {
+	return os.Chmod(path, perm)
+}
+
+// Chtimes implements FS.
+func (LocalFS) Chtimes(path string, mtime time.Time) error {
+	return os.Chtimes(path, mtime, mtime)
+}
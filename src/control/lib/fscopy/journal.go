@@ -0,0 +1,191 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package fscopy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const journalVersion = 1
+
+type (
+	// journalHeader is the first line of a journal and identifies the
+	// copy that it belongs to.
+	journalHeader struct {
+		Version int    `json:"version"`
+		Source  string `json:"source"`
+		Dest    string `json:"dest"`
+		// Target is the resolved destination path, which may differ
+		// from Dest once the first copy attempt has created it.
+		Target string `json:"target"`
+	}
+
+	// journalEntry records a file that has been completely copied.
+	journalEntry struct {
+		Path  string `json:"path"`
+		Size  int64  `json:"size"`
+		MTime int64  `json:"mtime"`
+	}
+
+	// journal records the files completed by a copy so that an
+	// interrupted copy can be resumed without copying them again. It is
+	// a file holding a header line followed by one JSON entry per line.
+	journal struct {
+		sync.Mutex
+		path   string
+		file   *os.File
+		header journalHeader
+		done   map[string]journalEntry
+	}
+)
+
+// openJournal opens the journal at path, loading the entries of a previous
+// attempt of the same copy, or creates it.
+func openJournal(path, source, dest, target string) (*journal, error) {
+	j := &journal{
+		path: path,
+		header: journalHeader{
+			Version: journalVersion,
+			Source:  source,
+			Dest:    dest,
+			Target:  target,
+		},
+		done: make(map[string]journalEntry),
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "open journal")
+	}
+	j.file = f
+
+	if err := j.load(); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "journal %s", path)
+	}
+
+	return j, nil
+}
+
+func (j *journal) load() error {
+	rd := bufio.NewReader(j.file)
+
+	line, err := rd.ReadBytes('\n')
+	if len(line) == 0 && err == io.EOF {
+		return j.writeLine(&j.header)
+	}
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	var hdr journalHeader
+	if err := json.Unmarshal(line, &hdr); err != nil {
+		return errors.New("invalid header")
+	}
+	if hdr.Version != journalVersion {
+		return errors.Errorf("unsupported version %d", hdr.Version)
+	}
+	if hdr.Source != j.header.Source || hdr.Dest != j.header.Dest {
+		return errors.Errorf("written for a copy from %s to %s", hdr.Source, hdr.Dest)
+	}
+	j.header = hdr
+
+	for err == nil {
+		line, err = rd.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(line) == 0 {
+			break
+		}
+
+		// an interrupted write leaves a partial last line, which
+		// must be terminated before new entries are appended
+		if !bytes.HasSuffix(line, []byte{'\n'}) {
+			if _, wErr := j.file.Write([]byte{'\n'}); wErr != nil {
+				return wErr
+			}
+		}
+
+		var entry journalEntry
+		if json.Unmarshal(line, &entry) != nil {
+			continue
+		}
+		j.done[entry.Path] = entry
+	}
+
+	return nil
+}
+
+func (j *journal) writeLine(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	_, err = j.file.Write(append(data, '\n'))
+
+	return err
+}
+
+// target returns the resolved destination path of the copy.
+func (j *journal) target() string {
+	return j.header.Target
+}
+
+// completed indicates whether the file at rel was copied by a previous
+// attempt and hasn't changed since.
+func (j *journal) completed(rel string, fi *FileInfo) bool {
+	if j == nil {
+		return false
+	}
+
+	entry, found := j.done[rel]
+	return found && entry.Size == fi.Size && entry.MTime == fi.ModTime.UnixNano()
+}
+
+// record adds a completed file to the journal.
+func (j *journal) record(rel string, fi *FileInfo) error {
+	if j == nil {
+		return nil
+	}
+
+	return j.writeLine(&journalEntry{
+		Path:  rel,
+		Size:  fi.Size,
+		MTime: fi.ModTime.UnixNano(),
+	})
+}
+
+func (j *journal) close() error {
+	if j == nil {
+		return nil
+	}
+
+	return j.file.Close()
+}
+
+// remove closes and deletes the journal once the copy has completed.
+func (j *journal) remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := j.close(); err != nil {
+		return err
+	}
+
+	return os.Remove(j.path)
+}
@@ -0,0 +1,152 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package fscopy
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Phase identifies the current phase of a copy.
+type Phase string
+
+// Copy phases.
+const (
+	PhaseCopy   Phase = "copy"
+	PhaseVerify Phase = "verify"
+	PhaseDone   Phase = "done"
+)
+
+// Progress is a snapshot of the progress of a copy.
+type Progress struct {
+	Phase         Phase   `json:"phase"`
+	Scanning      bool    `json:"scanning"`
+	Dirs          uint64  `json:"dirs"`
+	FilesTotal    uint64  `json:"files_total"`
+	FilesCopied   uint64  `json:"files_copied"`
+	FilesSkipped  uint64  `json:"files_skipped"`
+	BytesTotal    uint64  `json:"bytes_total"`
+	BytesCopied   uint64  `json:"bytes_copied"`
+	BytesSkipped  uint64  `json:"bytes_skipped"`
+	FilesVerified uint64  `json:"files_verified"`
+	BytesVerified uint64  `json:"bytes_verified"`
+	Rate          float64 `json:"bytes_per_second"`
+	Elapsed       float64 `json:"elapsed_seconds"`
+}
+
+// Percent returns the completion percentage of the current phase, based on
+// the number of bytes processed.
+func (p *Progress) Percent() float64 {
+	done := p.BytesCopied + p.BytesSkipped
+	if p.Phase == PhaseVerify {
+		done = p.BytesVerified
+	}
+
+	switch {
+	case p.BytesTotal > 0:
+		return 100 * float64(done) / float64(p.BytesTotal)
+	case p.Scanning:
+		return 0
+	default:
+		return 100
+	}
+}
+
+// Bar renders the progress as a single line with a progress bar of the
+// given width, suitable for updating in place on a terminal.
+func (p *Progress) Bar(width int) string {
+	pct := p.Percent()
+	if pct > 100 {
+		pct = 100
+	}
+
+	filled := int(float64(width) * pct / 100)
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+
+	files := p.FilesCopied + p.FilesSkipped
+	done := p.BytesCopied + p.BytesSkipped
+	if p.Phase == PhaseVerify {
+		files = p.FilesVerified
+		done = p.BytesVerified
+	}
+	total := humanize.IBytes(p.BytesTotal)
+	totalFiles := fmt.Sprintf("%d", p.FilesTotal)
+	if p.Scanning {
+		total += "+"
+		totalFiles += "+"
+	}
+
+	return fmt.Sprintf("%-6s [%s] %3.0f%% %s/%s %s/%s files %s/s",
+		p.Phase, bar, pct, humanize.IBytes(done), total, fmt.Sprint(files), totalFiles,
+		humanize.IBytes(uint64(p.Rate)))
+}
+
+// counters holds the progress counters shared between the copy workers.
+type counters struct {
+	dirs          uint64
+	filesTotal    uint64
+	filesCopied   uint64
+	filesSkipped  uint64
+	bytesTotal    uint64
+	bytesCopied   uint64
+	bytesSkipped  uint64
+	filesVerified uint64
+	bytesVerified uint64
+	scanning      int32
+	phase         atomic.Value
+	phaseStart    atomic.Value
+	start         time.Time
+}
+
+func newCounters() *counters {
+	c := &counters{
+		start: time.Now(),
+	}
+	c.setPhase(PhaseCopy)
+
+	return c
+}
+
+func (c *counters) setPhase(phase Phase) {
+	c.phase.Store(phase)
+	c.phaseStart.Store(time.Now())
+}
+
+func (c *counters) snapshot() *Progress {
+	p := &Progress{
+		Phase:         c.phase.Load().(Phase),
+		Scanning:      atomic.LoadInt32(&c.scanning) != 0,
+		Dirs:          atomic.LoadUint64(&c.dirs),
+		FilesTotal:    atomic.LoadUint64(&c.filesTotal),
+		FilesCopied:   atomic.LoadUint64(&c.filesCopied),
+		FilesSkipped:  atomic.LoadUint64(&c.filesSkipped),
+		BytesTotal:    atomic.LoadUint64(&c.bytesTotal),
+		BytesCopied:   atomic.LoadUint64(&c.bytesCopied),
+		BytesSkipped:  atomic.LoadUint64(&c.bytesSkipped),
+		FilesVerified: atomic.LoadUint64(&c.filesVerified),
+		BytesVerified: atomic.LoadUint64(&c.bytesVerified),
+		Elapsed:       time.Since(c.start).Seconds(),
+	}
+
+	// the rate covers the bytes processed by the current phase
+	processed := p.BytesCopied
+	if p.Phase == PhaseVerify {
+		processed = p.BytesVerified
+	}
+	if secs := time.Since(c.phaseStart.Load().(time.Time)).Seconds(); secs > 0 {
+		p.Rate = float64(processed) / secs
+	}
+
+	return p
+}
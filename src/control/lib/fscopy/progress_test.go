@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package fscopy
+
+import (
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestFscopy_Progress_Bar(t *testing.T) {
+	for name, tc := range map[string]struct {
+		progress Progress
+		expPct   float64
+		expBar   string
+	}{
+		"scanning empty": {
+			progress: Progress{Phase: PhaseCopy, Scanning: true},
+			expBar:   "copy   [>         ]   0% 0 B/0 B+ 0/0+ files 0 B/s",
+		},
+		"half copied": {
+			progress: Progress{
+				Phase:        PhaseCopy,
+				FilesTotal:   4,
+				FilesCopied:  1,
+				BytesTotal:   4096,
+				BytesCopied:  1024,
+				FilesSkipped: 1,
+				BytesSkipped: 1024,
+				Rate:         2048,
+			},
+			expPct: 50,
+			expBar: "copy   [=====>    ]  50% 2.0 KiB/4.0 KiB 2/4 files 2.0 KiB/s",
+		},
+		"verify": {
+			progress: Progress{
+				Phase:         PhaseVerify,
+				FilesTotal:    4,
+				FilesCopied:   4,
+				BytesTotal:    4096,
+				BytesCopied:   4096,
+				FilesVerified: 1,
+				BytesVerified: 1024,
+			},
+			expPct: 25,
+			expBar: "verify [==>       ]  25% 1.0 KiB/4.0 KiB 1/4 files 0 B/s",
+		},
+		"done without data": {
+			progress: Progress{Phase: PhaseDone},
+			expPct:   100,
+			expBar:   "done   [==========] 100% 0 B/0 B 0/0 files 0 B/s",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expPct, tc.progress.Percent(), "percent")
+			common.AssertEqual(t, tc.expBar, tc.progress.Bar(10), "bar")
+		})
+	}
+}
@@ -0,0 +1,265 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package snapsched implements snapshot schedules with retention policies,
+// such as "hourly, keep 24; daily, keep 7", and plans the snapshots to
+// create and prune in order to apply them to a container.
+package snapsched
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Attribute is the name of the container attribute holding the
+	// snapshot schedule.
+	Attribute = "daos.snapshot.schedule"
+
+	// namePrefix identifies the snapshots created by a schedule.
+	namePrefix = "sched-"
+	// timeFormat is the format of the period start in snapshot names.
+	timeFormat = "20060102T150405Z"
+)
+
+// Named periods.
+const (
+	Hourly  = "hourly"
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+)
+
+type (
+	// Policy creates a snapshot every period and keeps the most recent
+	// ones.
+	Policy struct {
+		// Period is either a named period (hourly, daily, weekly or
+		// monthly) or a duration such as 15m.
+		Period string `json:"period"`
+		Keep   int    `json:"keep"`
+		every  time.Duration
+	}
+
+	// Schedule is a set of policies applied to a container.
+	Schedule []*Policy
+
+	// Snapshot is an existing snapshot of a container.
+	Snapshot struct {
+		Epoch uint64 `json:"epoch"`
+		Name  string `json:"name"`
+	}
+
+	// PolicyStatus describes the state of a policy of a schedule.
+	PolicyStatus struct {
+		Policy    *Policy     `json:"policy"`
+		Snapshots []*Snapshot `json:"snapshots"`
+		Create    string      `json:"create,omitempty"`
+		Prune     []*Snapshot `json:"prune,omitempty"`
+		Next      time.Time   `json:"next"`
+	}
+
+	// Plan lists the snapshots to create and prune to apply a schedule.
+	Plan struct {
+		Policies []*PolicyStatus `json:"policies"`
+	}
+)
+
+// ParseSchedule parses a schedule in the form "period:keep[,period:keep...]",
+// for example "hourly:24,daily:7".
+func ParseSchedule(spec string) (Schedule, error) {
+	var sched Schedule
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		parts := strings.Split(field, ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid policy %q, expected period:keep", field)
+		}
+		p, err := NewPolicy(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		if seen[p.Period] {
+			return nil, errors.Errorf("duplicate period %q", p.Period)
+		}
+		seen[p.Period] = true
+		sched = append(sched, p)
+	}
+	if len(sched) == 0 {
+		return nil, errors.New("empty schedule")
+	}
+
+	return sched, nil
+}
+
+// NewPolicy validates a period and retention count.
+func NewPolicy(period, keep string) (*Policy, error) {
+	p := &Policy{Period: period}
+
+	switch period {
+	case Hourly:
+		p.every = time.Hour
+	case Daily:
+		p.every = 24 * time.Hour
+	case Weekly, Monthly:
+	default:
+		d, err := time.ParseDuration(period)
+		if err != nil {
+			return nil, errors.Errorf("invalid period %q, expected %s, %s, %s, %s or a duration",
+				period, Hourly, Daily, Weekly, Monthly)
+		}
+		if d < time.Minute || d%time.Minute != 0 {
+			return nil, errors.Errorf("invalid period %q, must be a whole number of minutes", period)
+		}
+		// a canonical form keeps snapshot names stable
+		p.Period = strings.TrimSuffix(d.String(), "0s")
+		if strings.HasSuffix(p.Period, "h0m") {
+			p.Period = strings.TrimSuffix(p.Period, "0m")
+		}
+		p.every = d
+	}
+
+	n, err := strconv.Atoi(keep)
+	if err != nil || n < 1 {
+		return nil, errors.Errorf("invalid retention count %q for period %s", keep, period)
+	}
+	p.Keep = n
+
+	return p, nil
+}
+
+func (p *Policy) String() string {
+	return fmt.Sprintf("%s:%d", p.Period, p.Keep)
+}
+
+func (s Schedule) String() string {
+	strs := make([]string, len(s))
+	for i, p := range s {
+		strs[i] = p.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// PeriodStart returns the start of the period containing t, in UTC. Weeks
+// start on Monday.
+func (p *Policy) PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+
+	switch p.Period {
+	case Daily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case Weekly:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Monthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Truncate(p.every)
+	}
+}
+
+// nextPeriod returns the start of the period following the one starting at
+// start.
+func (p *Policy) nextPeriod(start time.Time) time.Time {
+	switch p.Period {
+	case Daily:
+		return start.AddDate(0, 0, 1)
+	case Weekly:
+		return start.AddDate(0, 0, 7)
+	case Monthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.Add(p.every)
+	}
+}
+
+// SnapshotName returns the name of the snapshot of a policy for the period
+// starting at start.
+func (p *Policy) SnapshotName(start time.Time) string {
+	return fmt.Sprintf("%s%s-%s", namePrefix, p.Period, start.UTC().Format(timeFormat))
+}
+
+// parseName returns the period and period start of a scheduled snapshot, or
+// false if the name wasn't generated by a schedule.
+func parseName(name string) (string, time.Time, bool) {
+	if !strings.HasPrefix(name, namePrefix) {
+		return "", time.Time{}, false
+	}
+	name = strings.TrimPrefix(name, namePrefix)
+
+	i := strings.LastIndex(name, "-")
+	if i < 1 {
+		return "", time.Time{}, false
+	}
+	start, err := time.Parse(timeFormat, name[i+1:])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	return name[:i], start, true
+}
+
+type scheduled struct {
+	snap  *Snapshot
+	start time.Time
+}
+
+// Plan determines the snapshots to create and prune to apply the schedule at
+// time now. A policy creates a snapshot if there is none for the current
+// period, and prunes its oldest snapshots beyond the retention count. Only
+// the snapshots created by the schedule are considered.
+func (s Schedule) Plan(now time.Time, snaps []*Snapshot) *Plan {
+	byPeriod := make(map[string][]*scheduled)
+	for _, snap := range snaps {
+		period, start, ok := parseName(snap.Name)
+		if !ok {
+			continue
+		}
+		byPeriod[period] = append(byPeriod[period], &scheduled{snap: snap, start: start})
+	}
+
+	plan := new(Plan)
+	for _, p := range s {
+		own := byPeriod[p.Period]
+		sort.Slice(own, func(i, j int) bool {
+			return own[i].start.After(own[j].start)
+		})
+
+		start := p.PeriodStart(now)
+		status := &PolicyStatus{
+			Policy:    p,
+			Snapshots: []*Snapshot{},
+			Next:      p.nextPeriod(start),
+		}
+		keep := p.Keep
+		if len(own) == 0 || own[0].start.Before(start) {
+			status.Create = p.SnapshotName(start)
+			keep--
+		}
+
+		for i, sc := range own {
+			if i < keep {
+				status.Snapshots = append(status.Snapshots, sc.snap)
+				continue
+			}
+			status.Prune = append(status.Prune, sc.snap)
+		}
+		plan.Policies = append(plan.Policies, status)
+	}
+
+	return plan
+}
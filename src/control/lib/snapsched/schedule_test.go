@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package snapsched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestSnapsched_ParseSchedule(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec   string
+		expStr string
+		expErr error
+	}{
+		"empty": {
+			spec:   " , ",
+			expErr: errors.New("empty schedule"),
+		},
+		"named periods": {
+			spec:   "hourly:24, daily:7,weekly:4,monthly:12",
+			expStr: "hourly:24,daily:7,weekly:4,monthly:12",
+		},
+		"durations are canonical": {
+			spec:   "15m:4,90m:2,120m:3",
+			expStr: "15m:4,1h30m:2,2h:3",
+		},
+		"missing keep": {
+			spec:   "hourly",
+			expErr: errors.New("expected period:keep"),
+		},
+		"bad period": {
+			spec:   "yearly:1",
+			expErr: errors.New("invalid period \"yearly\""),
+		},
+		"sub-minute period": {
+			spec:   "90s:1",
+			expErr: errors.New("whole number of minutes"),
+		},
+		"bad keep": {
+			spec:   "daily:0",
+			expErr: errors.New("invalid retention count"),
+		},
+		"duplicate": {
+			spec:   "60m:1,1h:2",
+			expErr: errors.New("duplicate period"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sched, err := ParseSchedule(tc.spec)
+			common.CmpErr(t, tc.expErr, err)
+			if err != nil {
+				return
+			}
+			common.AssertEqual(t, tc.expStr, sched.String(), "schedule")
+		})
+	}
+}
+
+func TestSnapsched_PeriodStart(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2021, 6, 16, 13, 47, 12, 0, time.UTC)
+
+	for period, exp := range map[string]time.Time{
+		Hourly:  time.Date(2021, 6, 16, 13, 0, 0, 0, time.UTC),
+		Daily:   time.Date(2021, 6, 16, 0, 0, 0, 0, time.UTC),
+		Weekly:  time.Date(2021, 6, 14, 0, 0, 0, 0, time.UTC),
+		Monthly: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+		"15m":   time.Date(2021, 6, 16, 13, 45, 0, 0, time.UTC),
+	} {
+		t.Run(period, func(t *testing.T) {
+			p, err := NewPolicy(period, "1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, exp, p.PeriodStart(now), "period start")
+			common.AssertEqual(t, exp, p.PeriodStart(exp), "period start of start")
+		})
+	}
+}
+
+func TestSnapsched_parseName(t *testing.T) {
+	p, _ := NewPolicy("1h30m", "1")
+	start := time.Date(2021, 6, 16, 12, 0, 0, 0, time.UTC)
+	name := p.SnapshotName(start)
+	common.AssertEqual(t, "sched-1h30m-20210616T120000Z", name, "name")
+
+	period, got, ok := parseName(name)
+	common.AssertTrue(t, ok, "name not parsed")
+	common.AssertEqual(t, "1h30m", period, "period")
+	common.AssertEqual(t, start, got, "start")
+
+	for _, bad := range []string{"", "manual", "sched-", "sched-hourly", "sched-hourly-yesterday"} {
+		if _, _, ok := parseName(bad); ok {
+			t.Fatalf("expected %q not to be parsed", bad)
+		}
+	}
+}
+
+func TestSnapsched_Plan(t *testing.T) {
+	now := time.Date(2021, 6, 16, 13, 47, 0, 0, time.UTC)
+	hourly := func(hour int) *Snapshot {
+		return &Snapshot{
+			Epoch: uint64(hour),
+			Name:  "sched-hourly-" + time.Date(2021, 6, 16, hour, 0, 0, 0, time.UTC).Format(timeFormat),
+		}
+	}
+	daily := &Snapshot{Epoch: 1, Name: "sched-daily-20210616T000000Z"}
+	manual := &Snapshot{Epoch: 2, Name: "manual"}
+	unnamed := &Snapshot{Epoch: 3}
+
+	for name, tc := range map[string]struct {
+		spec    string
+		snaps   []*Snapshot
+		expPlan *Plan
+	}{
+		"nothing yet": {
+			spec:  "hourly:2",
+			snaps: []*Snapshot{manual, unnamed},
+			expPlan: &Plan{Policies: []*PolicyStatus{
+				{
+					Snapshots: []*Snapshot{},
+					Create:    "sched-hourly-20210616T130000Z",
+					Next:      time.Date(2021, 6, 16, 14, 0, 0, 0, time.UTC),
+				},
+			}},
+		},
+		"create and prune": {
+			spec:  "hourly:2,daily:1",
+			snaps: []*Snapshot{hourly(10), hourly(12), daily, hourly(11)},
+			expPlan: &Plan{Policies: []*PolicyStatus{
+				{
+					Snapshots: []*Snapshot{hourly(12)},
+					Create:    "sched-hourly-20210616T130000Z",
+					Prune:     []*Snapshot{hourly(11), hourly(10)},
+					Next:      time.Date(2021, 6, 16, 14, 0, 0, 0, time.UTC),
+				},
+				{
+					Snapshots: []*Snapshot{daily},
+					Next:      time.Date(2021, 6, 17, 0, 0, 0, 0, time.UTC),
+				},
+			}},
+		},
+		"up to date": {
+			spec:  "hourly:2",
+			snaps: []*Snapshot{hourly(12), hourly(13)},
+			expPlan: &Plan{Policies: []*PolicyStatus{
+				{
+					Snapshots: []*Snapshot{hourly(13), hourly(12)},
+					Next:      time.Date(2021, 6, 16, 14, 0, 0, 0, time.UTC),
+				},
+			}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sched, err := ParseSchedule(tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, status := range tc.expPlan.Policies {
+				status.Policy = sched[i]
+			}
+
+			plan := sched.Plan(now, tc.snaps)
+			if diff := cmp.Diff(tc.expPlan, plan, cmp.AllowUnexported(Policy{})); diff != "" {
+				t.Fatalf("unexpected plan (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package logging
+
+import (
+	"os"
+	"sync"
+)
+
+// LogFile is an io.Writer which appends to a log file that can be reopened,
+// e.g. after the file has been rotated or its path has changed. Writes are
+// discarded while no file is open.
+type LogFile struct {
+	sync.Mutex
+	path string
+	file *os.File
+}
+
+func openAppend(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0664)
+}
+
+// OpenLogFile opens the log file at the given path for appending. If the path
+// is empty, no file is opened.
+func OpenLogFile(path string) (*LogFile, error) {
+	lf := &LogFile{}
+	if err := lf.Reopen(path); err != nil {
+		return nil, err
+	}
+
+	return lf, nil
+}
+
+// Write appends the supplied data to the log file.
+func (lf *LogFile) Write(data []byte) (int, error) {
+	lf.Lock()
+	defer lf.Unlock()
+
+	if lf.file == nil {
+		return len(data), nil
+	}
+	return lf.file.Write(data)
+}
+
+// Path returns the path of the open log file.
+func (lf *LogFile) Path() string {
+	lf.Lock()
+	defer lf.Unlock()
+
+	return lf.path
+}
+
+// Reopen closes the current log file and opens the one at the given path. If
+// the path is empty, subsequent writes are discarded. The current file is
+// left open if the new one can't be opened.
+func (lf *LogFile) Reopen(path string) error {
+	var file *os.File
+	if path != "" {
+		var err error
+		file, err = openAppend(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	lf.Lock()
+	defer lf.Unlock()
+
+	oldFile := lf.file
+	lf.path, lf.file = path, file
+	if oldFile != nil {
+		return oldFile.Close()
+	}
+
+	return nil
+}
+
+// Close closes the log file. Subsequent writes are discarded.
+func (lf *LogFile) Close() error {
+	return lf.Reopen("")
+}
@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package logging_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestLogging_LogFile(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	readFile := func(path string) string {
+		t.Helper()
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	first := filepath.Join(testDir, "first.log")
+	second := filepath.Join(testDir, "second.log")
+
+	lf, err := logging.OpenLogFile(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(msg string) {
+		t.Helper()
+		if _, err := fmt.Fprintln(lf, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("one")
+	common.AssertEqual(t, first, lf.Path(), "unexpected path")
+
+	// reopening a rotated file creates a new one at the same path
+	if err := os.Rename(first, first+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lf.Reopen(first); err != nil {
+		t.Fatal(err)
+	}
+	write("two")
+
+	if err := lf.Reopen(filepath.Join(testDir, "missing", "bad.log")); err == nil {
+		t.Fatal("expected error opening file in missing directory")
+	}
+	write("three")
+
+	if err := lf.Reopen(second); err != nil {
+		t.Fatal(err)
+	}
+	write("four")
+
+	if err := lf.Close(); err != nil {
+		t.Fatal(err)
+	}
+	write("five")
+	common.AssertEqual(t, "", lf.Path(), "unexpected path")
+
+	for path, exp := range map[string]string{
+		first + ".1": "one\n",
+		first:        "two\nthree\n",
+		second:       "four\n",
+	} {
+		common.AssertEqual(t, exp, readFile(path), path)
+	}
+}
@@ -9,9 +9,11 @@ package auth
 import (
 	"bytes"
 	"crypto"
+	"crypto/rand"
 	"os"
 	"os/user"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -145,13 +147,28 @@ func sysNameToPrincipalName(name string) string {
 	return name + "@"
 }
 
+// nonceLen is the number of random bytes in an AuthSys token nonce.
+const nonceLen = 16
+
+func newNonce() ([]byte, error) {
+	nonce := make([]byte, nonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generating token nonce")
+	}
+	return nonce, nil
+}
+
 // AuthSysRequestFromCreds takes the domain info credentials gathered
 // during the dRPC request and creates an AuthSys security request to obtain
-// a handle from the management service.
-func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing crypto.PrivateKey) (*Credential, error) {
+// a handle from the management service. The resulting token is stamped with
+// the current time and expires after the supplied validity period.
+func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing crypto.PrivateKey, validity time.Duration) (*Credential, error) {
 	if creds == nil {
 		return nil, errors.New("No credentials supplied")
 	}
+	if validity <= 0 {
+		return nil, errors.Errorf("invalid credential validity %s", validity)
+	}
 
 	userInfo, err := ext.LookupUserID(creds.Uid())
 	if err != nil {
@@ -188,14 +205,22 @@ func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing cr
 		groupList = append(groupList, sysNameToPrincipalName(gInfo.Name))
 	}
 
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+	issued := timeNow()
+
 	// Craft AuthToken
 	sys := Sys{
-		Stamp:       0,
+		Stamp:       uint64(issued.Unix()),
 		Machinename: name,
 		User:        sysNameToPrincipalName(userInfo.Username()),
 		Group:       sysNameToPrincipalName(groupInfo.Name),
 		Groups:      groupList,
-		Secctx:      creds.Ctx()}
+		Secctx:      creds.Ctx(),
+		Expiry:      uint64(issued.Add(validity).Unix()),
+		Nonce:       nonce}
 
 	// Marshal our AuthSys token into a byte array
 	tokenBytes, err := proto.Marshal(&sys)
@@ -12,6 +12,7 @@ import (
 	"os/user"
 	"syscall"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -98,8 +99,10 @@ func TestAuthSysFromAuthToken_SucceedsWithGoodToken(t *testing.T) {
 
 // AuthSysRequestFromCreds tests
 
+const testValidity = time.Minute
+
 func TestAuthSysRequestFromCreds_failsIfDomainInfoNil(t *testing.T) {
-	result, err := AuthSysRequestFromCreds(&MockExt{}, nil, nil)
+	result, err := AuthSysRequestFromCreds(&MockExt{}, nil, nil, testValidity)
 
 	if result != nil {
 		t.Error("Expected a nil request")
@@ -143,7 +146,7 @@ func TestAuthSysRequestFromCreds_returnsAuthSys(t *testing.T) {
 			})
 	}
 
-	result, err := AuthSysRequestFromCreds(ext, creds, nil)
+	result, err := AuthSysRequestFromCreds(ext, creds, nil, testValidity)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -181,6 +184,25 @@ func TestAuthSysRequestFromCreds_returnsAuthSys(t *testing.T) {
 			t.Errorf("AuthSys had bad group in list (idx %v): %v", i, group)
 		}
 	}
+
+	if authsys.GetExpiry() != authsys.GetStamp()+uint64(testValidity.Seconds()) {
+		t.Errorf("AuthSys had bad expiry: stamp %d, expiry %d",
+			authsys.GetStamp(), authsys.GetExpiry())
+	}
+
+	if len(authsys.GetNonce()) != nonceLen {
+		t.Errorf("AuthSys had bad nonce: %v", authsys.GetNonce())
+	}
+}
+
+func TestAuthSysRequestFromCreds_BadValidity(t *testing.T) {
+	result, err := AuthSysRequestFromCreds(&MockExt{}, getTestCreds(1, 1), nil, 0)
+
+	if result != nil {
+		t.Error("Expected a nil request")
+	}
+
+	ExpectError(t, err, "invalid credential validity 0s", "")
 }
 
 func TestAuthSysRequestFromCreds_UidLookupFails(t *testing.T) {
@@ -192,7 +214,7 @@ func TestAuthSysRequestFromCreds_UidLookupFails(t *testing.T) {
 	expectedErr := fmt.Errorf("Failed to lookup uid %v: %v", uid,
 		ext.LookupUserIDErr)
 
-	result, err := AuthSysRequestFromCreds(ext, creds, nil)
+	result, err := AuthSysRequestFromCreds(ext, creds, nil, testValidity)
 
 	if result != nil {
 		t.Error("Expected a nil result")
@@ -221,7 +243,7 @@ func TestAuthSysRequestFromCreds_GidLookupFails(t *testing.T) {
 	expectedErr := fmt.Errorf("Failed to lookup gid %v: %v", gid,
 		ext.LookupGroupIDErr)
 
-	result, err := AuthSysRequestFromCreds(ext, creds, nil)
+	result, err := AuthSysRequestFromCreds(ext, creds, nil, testValidity)
 
 	if result != nil {
 		t.Error("Expected a nil result")
@@ -257,7 +279,7 @@ func TestAuthSysRequestFromCreds_GroupIDListFails(t *testing.T) {
 		testUser.username,
 		testUser.groupIDErr)
 
-	result, err := AuthSysRequestFromCreds(ext, creds, nil)
+	result, err := AuthSysRequestFromCreds(ext, creds, nil, testValidity)
 
 	if result != nil {
 		t.Error("Expected a nil result")
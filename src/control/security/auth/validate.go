@@ -0,0 +1,106 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// timeNow is the clock used when issuing and validating tokens.
+var timeNow = time.Now
+
+var (
+	// ErrTokenNoFreshness indicates that a token lacks an expiry or nonce.
+	ErrTokenNoFreshness = errors.New("token has no expiry or nonce")
+	// ErrTokenNotYetValid indicates that a token was issued in the future.
+	ErrTokenNotYetValid = errors.New("token is not yet valid")
+	// ErrTokenLifetime indicates that a token's lifetime exceeds the maximum.
+	ErrTokenLifetime = errors.New("token lifetime exceeds maximum")
+	// ErrTokenExpired indicates that a token has expired.
+	ErrTokenExpired = errors.New("token has expired")
+	// ErrTokenReplayed indicates that a token has already been presented.
+	ErrTokenReplayed = errors.New("token has already been used")
+)
+
+// TokenValidator checks the freshness of AuthSys tokens which have already
+// passed signature verification. Tokens are rejected if they are outside of
+// their validity window or if their nonce has been seen before the token
+// expired.
+type TokenValidator struct {
+	sync.Mutex
+	cfg    *security.CredentialConfig
+	now    func() time.Time
+	nonces map[string]time.Time
+}
+
+// NewTokenValidator returns a TokenValidator using the supplied config, or
+// the default credential config if none is supplied.
+func NewTokenValidator(cfg *security.CredentialConfig) *TokenValidator {
+	if cfg == nil {
+		cfg = security.DefaultCredentialConfig()
+	}
+	return &TokenValidator{
+		cfg:    cfg,
+		now:    timeNow,
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// pruneNonces forgets nonces belonging to tokens that can no longer be
+// accepted anyway. Must be called with the lock held.
+func (tv *TokenValidator) pruneNonces(now time.Time) {
+	for nonce, until := range tv.nonces {
+		if now.After(until) {
+			delete(tv.nonces, nonce)
+		}
+	}
+}
+
+// Validate checks that the AuthSys token is within its validity window,
+// allowing for the configured clock skew between agent and server, and that
+// it has not been presented before.
+func (tv *TokenValidator) Validate(sys *Sys) error {
+	if sys == nil {
+		return errors.New("nil AuthSys token")
+	}
+	if sys.GetExpiry() == 0 || len(sys.GetNonce()) == 0 {
+		return ErrTokenNoFreshness
+	}
+
+	now := tv.now()
+	skew := tv.cfg.ClockSkew
+	issued := time.Unix(int64(sys.GetStamp()), 0)
+	expiry := time.Unix(int64(sys.GetExpiry()), 0)
+
+	if issued.After(now.Add(skew)) {
+		return errors.Wrapf(ErrTokenNotYetValid, "issued at %s", issued)
+	}
+	if !expiry.After(issued) || expiry.Sub(issued) > tv.cfg.Validity {
+		return errors.Wrapf(ErrTokenLifetime, "issued at %s, expires at %s (max %s)",
+			issued, expiry, tv.cfg.Validity)
+	}
+	if now.After(expiry.Add(skew)) {
+		return errors.Wrapf(ErrTokenExpired, "expired at %s", expiry)
+	}
+
+	tv.Lock()
+	defer tv.Unlock()
+
+	tv.pruneNonces(now)
+	nonce := string(sys.GetNonce())
+	if _, seen := tv.nonces[nonce]; seen {
+		return ErrTokenReplayed
+	}
+	tv.nonces[nonce] = expiry.Add(skew)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func testSys(issued time.Time, lifetime time.Duration, nonce string) *Sys {
+	return &Sys{
+		Stamp:  uint64(issued.Unix()),
+		Expiry: uint64(issued.Add(lifetime).Unix()),
+		Nonce:  []byte(nonce),
+	}
+}
+
+func TestAuth_TokenValidator_Validate(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cfg := &security.CredentialConfig{
+		Validity:  5 * time.Minute,
+		ClockSkew: 30 * time.Second,
+	}
+
+	for name, tc := range map[string]struct {
+		sys    *Sys
+		expErr error
+	}{
+		"nil token": {
+			expErr: errors.New("nil AuthSys token"),
+		},
+		"no expiry": {
+			sys:    &Sys{Stamp: uint64(now.Unix()), Nonce: []byte("n")},
+			expErr: ErrTokenNoFreshness,
+		},
+		"no nonce": {
+			sys:    testSys(now, time.Minute, ""),
+			expErr: ErrTokenNoFreshness,
+		},
+		"fresh": {
+			sys: testSys(now, time.Minute, "n"),
+		},
+		"issued in future within skew": {
+			sys: testSys(now.Add(20*time.Second), time.Minute, "n"),
+		},
+		"issued in future beyond skew": {
+			sys:    testSys(now.Add(time.Minute), time.Minute, "n"),
+			expErr: ErrTokenNotYetValid,
+		},
+		"lifetime too long": {
+			sys:    testSys(now, 10*time.Minute, "n"),
+			expErr: ErrTokenLifetime,
+		},
+		"expiry before issue": {
+			sys:    testSys(now, -time.Minute, "n"),
+			expErr: ErrTokenLifetime,
+		},
+		"expired within skew": {
+			sys: testSys(now.Add(-80*time.Second), time.Minute, "n"),
+		},
+		"expired beyond skew": {
+			sys:    testSys(now.Add(-2*time.Minute), time.Minute, "n"),
+			expErr: ErrTokenExpired,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tv := NewTokenValidator(cfg)
+			tv.now = func() time.Time { return now }
+
+			common.CmpErr(t, tc.expErr, tv.Validate(tc.sys))
+		})
+	}
+}
+
+func TestAuth_TokenValidator_Replay(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tv := NewTokenValidator(nil)
+	tv.now = func() time.Time { return now }
+
+	first := testSys(now, time.Minute, "nonce1")
+	if err := tv.Validate(first); err != nil {
+		t.Fatal(err)
+	}
+	common.CmpErr(t, ErrTokenReplayed, tv.Validate(first))
+
+	// a different nonce is accepted
+	if err := tv.Validate(testSys(now, time.Minute, "nonce2")); err != nil {
+		t.Fatal(err)
+	}
+
+	// remembered nonces are forgotten once their tokens could no longer be
+	// accepted
+	now = now.Add(time.Minute + tv.cfg.ClockSkew + time.Second)
+	if err := tv.Validate(testSys(now, time.Minute, "nonce3")); err != nil {
+		t.Fatal(err)
+	}
+	if len(tv.nonces) != 1 {
+		t.Fatalf("expected expired nonces to be pruned, have %d", len(tv.nonces))
+	}
+}
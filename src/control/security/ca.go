@@ -0,0 +1,427 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CACertFileName is the name of the CA certificate file.
+	CACertFileName = "daosCA.crt"
+	// CAKeyFileName is the name of the CA private key file.
+	CAKeyFileName = "daosCA.key"
+	// CRLFileName is the name of the certificate revocation list file.
+	CRLFileName = "daosCA.crl"
+
+	// DefaultCAValidity is the default validity period of a new CA certificate.
+	DefaultCAValidity = 365 * 24 * time.Hour
+	// DefaultCertValidity is the default validity period of an issued certificate.
+	DefaultCertValidity = 365 * 24 * time.Hour
+
+	// crlValidity is the period after which a generated CRL should be updated.
+	crlValidity = 365 * 24 * time.Hour
+
+	caOrganization = "DAOS"
+	caCommonName   = "DAOS CA"
+	rsaKeyBits     = 3072
+
+	// Files are written with the permissions used by gen_certificates.sh,
+	// which are within the maximum permissions allowed when loading them.
+	newKeyPerm  os.FileMode = 0400
+	newCertPerm os.FileMode = 0644
+)
+
+// CertificateAuthority is a local certificate authority used to issue and
+// revoke the certificates used by DAOS components. The CA certificate, key
+// and revocation list are stored in a single directory.
+type CertificateAuthority struct {
+	Dir  string
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+// CertPath returns the path of the CA certificate.
+func (ca *CertificateAuthority) CertPath() string {
+	return filepath.Join(ca.Dir, CACertFileName)
+}
+
+// KeyPath returns the path of the CA private key.
+func (ca *CertificateAuthority) KeyPath() string {
+	return filepath.Join(ca.Dir, CAKeyFileName)
+}
+
+// CRLPath returns the path of the certificate revocation list.
+func (ca *CertificateAuthority) CRLPath() string {
+	return filepath.Join(ca.Dir, CRLFileName)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate serial number")
+	}
+	return serial, nil
+}
+
+func writePEMFile(path, blockType string, der []byte, perms os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms)
+	if err != nil {
+		return err
+	}
+
+	if err := pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+	return f.Close()
+}
+
+// replacePEMFile atomically replaces the file at the given path.
+func replacePEMFile(path, blockType string, der []byte, perms os.FileMode) error {
+	tmpPath := path + ".tmp"
+	os.Remove(tmpPath)
+	if err := writePEMFile(tmpPath, blockType, der, perms); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func writeKeyPair(certPath, keyPath string, certDER []byte, key *rsa.PrivateKey) error {
+	for _, path := range []string{certPath, keyPath} {
+		if _, err := os.Stat(path); err == nil {
+			return errors.Errorf("%s already exists", path)
+		}
+	}
+
+	if err := writePEMFile(keyPath, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), newKeyPerm); err != nil {
+		return err
+	}
+	if err := writePEMFile(certPath, "CERTIFICATE", certDER, newCertPerm); err != nil {
+		os.Remove(keyPath)
+		return err
+	}
+
+	return nil
+}
+
+// ensureCertDirectory creates the directory if necessary and then checks that
+// its permissions are safe for storing certificates and keys.
+func ensureCertDirectory(dir string) error {
+	if err := os.MkdirAll(dir, MaxDirPerm); err != nil {
+		return err
+	}
+	return ValidateCertDirectory(dir)
+}
+
+// InitCA creates a new certificate authority in the given directory, along
+// with an empty revocation list. The directory is created if it does not
+// exist, but an existing CA will not be overwritten.
+func InitCA(dir string, validity time.Duration) (*CertificateAuthority, error) {
+	if err := ensureCertDirectory(dir); err != nil {
+		return nil, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA key")
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{caOrganization},
+			CommonName:   caCommonName,
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &CertificateAuthority{Dir: dir, cert: cert, key: key}
+	if err := writeKeyPair(ca.CertPath(), ca.KeyPath(), der, key); err != nil {
+		return nil, err
+	}
+	if err := ca.writeCRL(nil); err != nil {
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+// LoadCA loads an existing certificate authority from the given directory.
+func LoadCA(dir string) (*CertificateAuthority, error) {
+	if err := ValidateCertDirectory(dir); err != nil {
+		return nil, err
+	}
+
+	ca := &CertificateAuthority{Dir: dir}
+
+	cert, err := LoadCertificate(ca.CertPath())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load CA certificate")
+	}
+	key, err := LoadPrivateKey(ca.KeyPath())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load CA key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("CA key is not an RSA private key")
+	}
+
+	ca.cert = cert
+	ca.key = rsaKey
+	return ca, nil
+}
+
+// Certificate returns the CA certificate.
+func (ca *CertificateAuthority) Certificate() *x509.Certificate {
+	return ca.cert
+}
+
+func componentExtKeyUsage(comp Component) ([]x509.ExtKeyUsage, error) {
+	switch comp {
+	case ComponentServer:
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, nil
+	case ComponentAgent, ComponentAdmin:
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, nil
+	default:
+		return nil, errors.Errorf("cannot issue certificate for component %q", comp)
+	}
+}
+
+// Issue generates a key and certificate for the given component and writes
+// them to the output directory as <component>.key and <component>.crt. The
+// certificate CommonName identifies the component as expected by
+// CommonNameToComponent. A copy of the CA certificate is also written to the
+// output directory if not already present. Admin certificates may be granted
+// roles which restrict the control-plane RPCs they are authorized to call.
+func (ca *CertificateAuthority) Issue(comp Component, outDir string, validity time.Duration, roles ...string) (*CertificateInfo, error) {
+	extKeyUsage, err := componentExtKeyUsage(comp)
+	if err != nil {
+		return nil, err
+	}
+	var extensions []pkix.Extension
+	if len(roles) > 0 {
+		if comp != ComponentAdmin {
+			return nil, errors.Errorf("roles may only be granted to %s certificates", ComponentAdmin)
+		}
+		for _, role := range roles {
+			if role == "" {
+				return nil, errors.New("role name must not be empty")
+			}
+		}
+		ext, err := roleExtension(roles)
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, ext)
+	}
+	if err := ensureCertDirectory(outDir); err != nil {
+		return nil, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{caOrganization},
+			CommonName:   comp.String(),
+		},
+		NotBefore:             now,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           extKeyUsage,
+		ExtraExtensions:       extensions,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	certPath := filepath.Join(outDir, comp.String()+".crt")
+	keyPath := filepath.Join(outDir, comp.String()+".key")
+	if err := writeKeyPair(certPath, keyPath, der, key); err != nil {
+		return nil, err
+	}
+
+	caCopyPath := filepath.Join(outDir, CACertFileName)
+	if _, err := os.Stat(caCopyPath); os.IsNotExist(err) {
+		if err := writePEMFile(caCopyPath, "CERTIFICATE", ca.cert.Raw, newCertPerm); err != nil {
+			return nil, err
+		}
+	}
+
+	return LoadCertificateInfo(CertNameCert, certPath)
+}
+
+func (ca *CertificateAuthority) writeCRL(revoked []pkix.RevokedCertificate) error {
+	now := time.Now()
+	der, err := ca.cert.CreateCRL(rand.Reader, ca.key, revoked, now, now.Add(crlValidity))
+	if err != nil {
+		return errors.Wrap(err, "failed to create CRL")
+	}
+	return replacePEMFile(ca.CRLPath(), "X509 CRL", der, newCertPerm)
+}
+
+// Revoke adds the certificate at the given path to the CA's revocation list.
+// The certificate must have been issued by the CA.
+func (ca *CertificateAuthority) Revoke(certPath string) (*CertificateInfo, error) {
+	cert, err := LoadCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := cert.CheckSignatureFrom(ca.cert); err != nil {
+		return nil, errors.Wrapf(err, "%s was not issued by this CA", certPath)
+	}
+
+	crl, err := loadCRL(ca.CRLPath(), ca.cert)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := crl.TBSCertList.RevokedCertificates
+	for _, rc := range revoked {
+		if rc.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return nil, errors.Errorf("%s is already revoked", certPath)
+		}
+	}
+	revoked = append(revoked, pkix.RevokedCertificate{
+		SerialNumber:   cert.SerialNumber,
+		RevocationTime: time.Now(),
+	})
+
+	if err := ca.writeCRL(revoked); err != nil {
+		return nil, err
+	}
+
+	return &CertificateInfo{
+		Name:      CertNameCert,
+		Path:      certPath,
+		Subject:   cert.Subject.String(),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}, nil
+}
+
+// UpdateCRL re-signs the CA's revocation list with the same revoked
+// certificates and a new update deadline, e.g. before the current list
+// expires. It returns the time by which the list must next be updated.
+func (ca *CertificateAuthority) UpdateCRL() (time.Time, error) {
+	crl, err := loadCRL(ca.CRLPath(), ca.cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := ca.writeCRL(crl.TBSCertList.RevokedCertificates); err != nil {
+		return time.Time{}, err
+	}
+
+	crl, err = loadCRL(ca.CRLPath(), ca.cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return crl.TBSCertList.NextUpdate, nil
+}
+
+// loadCRL loads the certificate revocation list at the given path and
+// verifies that it was signed by the supplied CA certificate.
+func loadCRL(crlPath string, caCert *x509.Certificate) (*pkix.CertificateList, error) {
+	crlData, err := LoadPEMData(crlPath, MaxCertPerm)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load CRL")
+	}
+
+	crl, err := x509.ParseCRL(crlData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse CRL %s", crlPath)
+	}
+	if err := caCert.CheckCRLSignature(crl); err != nil {
+		return nil, errors.Wrapf(err, "CRL %s was not signed by the CA", crlPath)
+	}
+
+	return crl, nil
+}
+
+// loadRevokedSerials returns the set of certificate serial numbers revoked by
+// the CRL at the given path, which must be signed by the CA at caRootPath,
+// along with the time by which the CRL must be updated. A CRL which has not
+// been updated in time is rejected.
+func loadRevokedSerials(crlPath, caRootPath string) (map[string]struct{}, time.Time, error) {
+	caCert, err := LoadCertificate(caRootPath)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "could not load caRoot")
+	}
+
+	crl, err := loadCRL(crlPath, caCert)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	nextUpdate := crl.TBSCertList.NextUpdate
+	if err := checkCRLUpdate(crlPath, nextUpdate); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	revoked := make(map[string]struct{})
+	for _, rc := range crl.TBSCertList.RevokedCertificates {
+		revoked[rc.SerialNumber.String()] = struct{}{}
+	}
+	return revoked, nextUpdate, nil
+}
+
+// checkCRLUpdate returns an error if the CRL was due to be updated before now.
+func checkCRLUpdate(crlPath string, nextUpdate time.Time) error {
+	if !nextUpdate.IsZero() && time.Now().After(nextUpdate) {
+		return errors.Errorf("CRL %s expired at %s; update it with dmg cert update-crl",
+			crlPath, nextUpdate.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func checkFilePerms(t *testing.T, path string, expPerms os.FileMode) {
+	t.Helper()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != expPerms {
+		t.Fatalf("%s: expected perms %#o, got %#o", path, expPerms, fi.Mode().Perm())
+	}
+}
+
+func TestSecurity_CertificateAuthority_Issue(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	caDir := filepath.Join(tmpDir, "ca")
+	ca, err := InitCA(caDir, DefaultCAValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFilePerms(t, caDir, MaxDirPerm)
+	checkFilePerms(t, ca.CertPath(), newCertPerm)
+	checkFilePerms(t, ca.KeyPath(), newKeyPerm)
+	checkFilePerms(t, ca.CRLPath(), newCertPerm)
+
+	if _, err := InitCA(caDir, DefaultCAValidity); err == nil {
+		t.Fatal("expected error when reinitializing CA")
+	}
+
+	ca, err = LoadCA(caDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := filepath.Join(tmpDir, "certs")
+	for name, tc := range map[string]struct {
+		comp        Component
+		expKeyUsage []x509.ExtKeyUsage
+		expErr      error
+	}{
+		"server": {
+			comp:        ComponentServer,
+			expKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+		"agent": {
+			comp:        ComponentAgent,
+			expKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+		"admin": {
+			comp:        ComponentAdmin,
+			expKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+		"undefined": {
+			comp:   ComponentUndefined,
+			expErr: errors.New("cannot issue certificate"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ci, err := ca.Issue(tc.comp, outDir, 24*time.Hour)
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			certPath := filepath.Join(outDir, tc.comp.String()+".crt")
+			keyPath := filepath.Join(outDir, tc.comp.String()+".key")
+			if ci.Path != certPath {
+				t.Fatalf("expected path %s, got %s", certPath, ci.Path)
+			}
+			checkFilePerms(t, certPath, newCertPerm)
+			checkFilePerms(t, keyPath, newKeyPerm)
+
+			cert, err := LoadCertificate(certPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPrivateKey(keyPath); err != nil {
+				t.Fatal(err)
+			}
+			if err := cert.CheckSignatureFrom(ca.Certificate()); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, CommonNameToComponent(cert.Subject.CommonName), tc.comp, "unexpected component")
+			common.AssertEqual(t, cert.ExtKeyUsage, tc.expKeyUsage, "unexpected key usage")
+
+			cfg := &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      filepath.Join(outDir, CACertFileName),
+					CertificatePath: certPath,
+					PrivateKeyPath:  keyPath,
+				},
+			}
+			if err := cfg.ReloadCertData(); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ca.Issue(tc.comp, outDir, 24*time.Hour)
+			common.CmpErr(t, errors.New("already exists"), err)
+		})
+	}
+
+	if err := ValidateCertDirectory(outDir); err != nil {
+		t.Fatal(err)
+	}
+
+	roleDir := filepath.Join(tmpDir, "roles")
+	_, err = ca.Issue(ComponentAgent, roleDir, 24*time.Hour, RoleReadOnly)
+	common.CmpErr(t, errors.New("roles may only be granted"), err)
+
+	ci, err := ca.Issue(ComponentAdmin, roleDir, 24*time.Hour, RoleReadOnly, RoleOperator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := LoadCertificate(ci.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roles, err := CertificateRoles(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, roles, []string{RoleReadOnly, RoleOperator}, "unexpected roles")
+}
+
+func TestSecurity_CertificateAuthority_Revoke(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	caDir := filepath.Join(tmpDir, "ca")
+	certDir := filepath.Join(tmpDir, "certs")
+	ca, err := InitCA(caDir, DefaultCAValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, comp := range []Component{ComponentServer, ComponentAgent} {
+		if _, err := ca.Issue(comp, certDir, DefaultCertValidity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	agentCertPath := filepath.Join(certDir, "agent.crt")
+	agentCert, err := LoadCertificate(agentCertPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverTC := &TransportConfig{
+		CertificateConfig: CertificateConfig{
+			CARootPath:      ca.CertPath(),
+			CertificatePath: filepath.Join(certDir, "server.crt"),
+			PrivateKeyPath:  filepath.Join(certDir, "server.key"),
+			CRLPath:         ca.CRLPath(),
+		},
+	}
+	agentTC := &TransportConfig{
+		CertificateConfig: CertificateConfig{
+			CARootPath:      ca.CertPath(),
+			CertificatePath: agentCertPath,
+			PrivateKeyPath:  filepath.Join(certDir, "agent.key"),
+		},
+	}
+	for _, tc := range []*TransportConfig{serverTC, agentTC} {
+		if err := tc.ReloadCertData(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := serverTC.checkRevoked(agentCert); err != nil {
+		t.Fatalf("unexpected error before revocation: %s", err)
+	}
+	if err := testHandshake(serverTC, agentTC); err != nil {
+		t.Fatalf("unexpected handshake error before revocation: %s", err)
+	}
+
+	if _, err := ca.Revoke(agentCertPath); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ca.Revoke(agentCertPath)
+	common.CmpErr(t, errors.New("already revoked"), err)
+
+	if err := serverTC.ReloadCertData(); err != nil {
+		t.Fatal(err)
+	}
+	common.CmpErr(t, errors.New("has been revoked"), serverTC.checkRevoked(agentCert))
+	if err := testHandshake(serverTC, agentTC); err == nil {
+		t.Fatal("expected handshake with revoked certificate to fail")
+	}
+
+	// A CRL signed by a different CA must be rejected.
+	otherCA, err := InitCA(filepath.Join(tmpDir, "other"), DefaultCAValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	badTC := *serverTC
+	badTC.CRLPath = otherCA.CRLPath()
+	badTC.tlsKeypair = nil
+	badTC.caPool = nil
+	common.CmpErr(t, errors.New("was not signed by the CA"), badTC.ReloadCertData())
+
+	// Certificates from another CA cannot be revoked.
+	_, err = otherCA.Revoke(agentCertPath)
+	common.CmpErr(t, errors.New("was not issued by this CA"), err)
+}
+
+func TestSecurity_CertificateAuthority_UpdateCRL(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	certDir := filepath.Join(tmpDir, "certs")
+	ca, err := InitCA(filepath.Join(tmpDir, "ca"), DefaultCAValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, comp := range []Component{ComponentServer, ComponentAgent} {
+		if _, err := ca.Issue(comp, certDir, DefaultCertValidity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	agentCertPath := filepath.Join(certDir, "agent.crt")
+	agentCert, err := LoadCertificate(agentCertPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ca.Revoke(agentCertPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the CRL with one which was due to be updated an hour ago
+	revoked, err := loadCRL(ca.CRLPath(), ca.cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thisUpdate := time.Now().Add(-2 * time.Hour)
+	der, err := ca.cert.CreateCRL(rand.Reader, ca.key, revoked.TBSCertList.RevokedCertificates,
+		thisUpdate, thisUpdate.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replacePEMFile(ca.CRLPath(), "X509 CRL", der, newCertPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	serverTC := &TransportConfig{
+		CertificateConfig: CertificateConfig{
+			CARootPath:      ca.CertPath(),
+			CertificatePath: filepath.Join(certDir, "server.crt"),
+			PrivateKeyPath:  filepath.Join(certDir, "server.key"),
+			CRLPath:         ca.CRLPath(),
+		},
+	}
+	common.CmpErr(t, errors.New("expired"), serverTC.ReloadCertData())
+
+	nextUpdate, err := ca.UpdateCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nextUpdate.After(time.Now().Add(crlValidity - time.Hour)) {
+		t.Fatalf("unexpected next update %s", nextUpdate)
+	}
+	if err := serverTC.ReloadCertData(); err != nil {
+		t.Fatal(err)
+	}
+	// revocations are retained
+	common.CmpErr(t, errors.New("has been revoked"), serverTC.checkRevoked(agentCert))
+
+	serverCert, err := LoadCertificate(serverTC.CertificatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := serverTC.checkRevoked(serverCert); err != nil {
+		t.Fatal(err)
+	}
+
+	// all peers are rejected once a loaded CRL expires
+	serverTC.crlNextUpdate = time.Now().Add(-time.Minute)
+	common.CmpErr(t, errors.New("expired"), serverTC.checkRevoked(serverCert))
+}
+
+// testHandshake performs a TLS handshake between a server and client using
+// the supplied transport configs.
+func testHandshake(serverCfg, clientCfg *TransportConfig) error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	srvErr := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			srvErr <- err
+			return
+		}
+		defer conn.Close()
+		srvErr <- tls.Server(conn, serverTLSConfig(serverCfg)).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	cliCfg := clientTLSConfig(clientCfg)
+	cliCfg.ServerName = ServerCommonName
+	cliErr := tls.Client(conn, cliCfg).Handshake()
+	if err := <-srvErr; err != nil {
+		return err
+	}
+	return cliErr
+}
@@ -0,0 +1,102 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Certificate names used to identify certificates in a TransportConfig.
+const (
+	CertNameCA   = "ca_cert"
+	CertNameCert = "cert"
+)
+
+// CertificateInfo describes a certificate file referenced by a TransportConfig.
+type CertificateInfo struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Subject   string    `json:"subject"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+}
+
+// ExpiresWithin returns true if the certificate will have expired by the
+// end of the supplied period starting at the given time.
+func (ci *CertificateInfo) ExpiresWithin(now time.Time, period time.Duration) bool {
+	return !ci.NotAfter.After(now.Add(period))
+}
+
+// LoadCertificateInfo reads the certificate at the given path and returns
+// details of its identity and validity period.
+func LoadCertificateInfo(name, certPath string) (*CertificateInfo, error) {
+	cert, err := LoadCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificateInfo{
+		Name:      name,
+		Path:      certPath,
+		Subject:   cert.Subject.String(),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}, nil
+}
+
+// CertificatePaths returns the paths of the certificates in use by the
+// TransportConfig, keyed by certificate name. No paths are returned if the
+// config is insecure.
+func (tc *TransportConfig) CertificatePaths() map[string]string {
+	if tc == nil || tc.AllowInsecure {
+		return nil
+	}
+
+	paths := make(map[string]string)
+	if tc.CARootPath != "" {
+		paths[CertNameCA] = tc.CARootPath
+	}
+	if tc.CertificatePath != "" {
+		paths[CertNameCert] = tc.CertificatePath
+	}
+	return paths
+}
+
+// ExpiringCertificates returns details of the certificates in use by the
+// TransportConfig which will have expired by the end of its expiry warning
+// period starting at the given time. Certificates which cannot be read are
+// reported in the returned error after the remaining certificates are checked.
+func (tc *TransportConfig) ExpiringCertificates(now time.Time) ([]*CertificateInfo, error) {
+	paths := tc.CertificatePaths()
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var expiring []*CertificateInfo
+	var errs []string
+	for _, name := range names {
+		ci, err := LoadCertificateInfo(name, paths[name])
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "%s certificate", name).Error())
+			continue
+		}
+		if ci.ExpiresWithin(now, tc.ExpiryWarningPeriod()) {
+			expiring = append(expiring, ci)
+		}
+	}
+
+	if len(errs) > 0 {
+		return expiring, errors.New(strings.Join(errs, "; "))
+	}
+	return expiring, nil
+}
@@ -0,0 +1,107 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecurity_LoadCertificateInfo(t *testing.T) {
+	serverTC := ServerTC()
+	SetupTCFilePerms(t, serverTC)
+
+	ci, err := LoadCertificateInfo(CertNameCert, serverTC.CertificatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ci.Name != CertNameCert || ci.Path != serverTC.CertificatePath {
+		t.Fatalf("unexpected name/path: %s/%s", ci.Name, ci.Path)
+	}
+	if ci.Subject != "CN=server,O=DAOS" {
+		t.Fatalf("unexpected subject: %s", ci.Subject)
+	}
+	expNotAfter := time.Date(2020, time.June, 4, 21, 50, 47, 0, time.UTC)
+	if !ci.NotAfter.Equal(expNotAfter) {
+		t.Fatalf("expected expiry %s, got %s", expNotAfter, ci.NotAfter)
+	}
+
+	if _, err := LoadCertificateInfo(CertNameCert, "testdata/certs/missing.crt"); err == nil {
+		t.Fatal("expected error for missing certificate")
+	}
+}
+
+func TestSecurity_TransportConfig_ExpiringCertificates(t *testing.T) {
+	agentTC := AgentTC()
+	SetupTCFilePerms(t, agentTC)
+
+	// CA certificate expires 2019-07-05, agent certificate 2029-07-15
+	for name, tc := range map[string]struct {
+		config   *TransportConfig
+		warnDays int
+		now      time.Time
+		expNames []string
+		expErr   string
+	}{
+		"insecure": {
+			config: InsecureTC(),
+			now:    time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"none expiring": {
+			config: agentTC,
+			now:    time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"ca within default warning period": {
+			config:   agentTC,
+			now:      time.Date(2019, time.June, 20, 0, 0, 0, 0, time.UTC),
+			expNames: []string{CertNameCA},
+		},
+		"ca outside configured warning period": {
+			config:   agentTC,
+			warnDays: 7,
+			now:      time.Date(2019, time.June, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"ca expired and cert expiring": {
+			config:   agentTC,
+			now:      time.Date(2029, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expNames: []string{CertNameCA, CertNameCert},
+		},
+		"unreadable cert": {
+			config: &TransportConfig{
+				CertificateConfig: CertificateConfig{
+					CARootPath:      agentTC.CARootPath,
+					CertificatePath: "testdata/certs/missing.crt",
+				},
+			},
+			now:      time.Date(2029, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expNames: []string{CertNameCA},
+			expErr:   "cert certificate",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.config.CertExpiryWarnDays = tc.warnDays
+
+			expiring, err := tc.config.ExpiringCertificates(tc.now)
+			if tc.expErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.expErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expErr)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expErr, err)
+			}
+
+			var gotNames []string
+			for _, ci := range expiring {
+				gotNames = append(gotNames, ci.Name)
+			}
+			if strings.Join(gotNames, ",") != strings.Join(tc.expNames, ",") {
+				t.Fatalf("expected expiring %v, got %v", tc.expNames, gotNames)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -27,13 +29,23 @@ const (
 	defaultClientCertDir = certDir + "clients"
 	defaultServer        = "server"
 	defaultInsecure      = false
+
+	defaultCredValidity  = 5 * time.Minute
+	defaultCredClockSkew = 30 * time.Second
+
+	defaultCertExpiryWarnDays = 30
 )
 
+// certDataLock serializes access to the loaded certificate data of all
+// TransportConfigs, allowing it to be replaced while in use by TLS handshakes.
+var certDataLock sync.RWMutex
+
 // TransportConfig contains all the information on whether or not to use
 // certificates and their location if their use is specified.
 type TransportConfig struct {
-	AllowInsecure     bool `yaml:"allow_insecure"`
-	CertificateConfig `yaml:",inline"`
+	AllowInsecure      bool `yaml:"allow_insecure"`
+	CertExpiryWarnDays int  `yaml:"cert_expiry_warn_days,omitempty"`
+	CertificateConfig  `yaml:",inline"`
 }
 
 func (tc *TransportConfig) String() string {
@@ -42,15 +54,20 @@ func (tc *TransportConfig) String() string {
 
 // CertificateConfig contains the specific certificate information for the daos
 // component. ServerName is only needed if the config is being used as a
-// transport credential for a gRPC tls client.
+// transport credential for a gRPC tls client. If CRLPath is set, peer
+// certificates revoked by the CRL are rejected, as are all peer certificates
+// once the CRL has expired.
 type CertificateConfig struct {
-	ServerName      string           `yaml:"server_name,omitempty"`
-	ClientCertDir   string           `yaml:"client_cert_dir,omitempty"`
-	CARootPath      string           `yaml:"ca_cert"`
-	CertificatePath string           `yaml:"cert"`
-	PrivateKeyPath  string           `yaml:"key"`
-	tlsKeypair      *tls.Certificate `yaml:"-"`
-	caPool          *x509.CertPool   `yaml:"-"`
+	ServerName      string              `yaml:"server_name,omitempty"`
+	ClientCertDir   string              `yaml:"client_cert_dir,omitempty"`
+	CARootPath      string              `yaml:"ca_cert"`
+	CertificatePath string              `yaml:"cert"`
+	PrivateKeyPath  string              `yaml:"key"`
+	CRLPath         string              `yaml:"crl,omitempty"`
+	tlsKeypair      *tls.Certificate    `yaml:"-"`
+	caPool          *x509.CertPool      `yaml:"-"`
+	revokedSerials  map[string]struct{} `yaml:"-"`
+	crlNextUpdate   time.Time           `yaml:"-"`
 }
 
 // DefaultAgentTransportConfig provides a default transport config disabling
@@ -114,34 +131,92 @@ func (tc *TransportConfig) PreLoadCertData() error {
 	if tc == nil {
 		return errors.New("nil TransportConfig")
 	}
-	if tc.tlsKeypair != nil && tc.caPool != nil || tc.AllowInsecure {
+	if tlsKeypair, caPool := tc.loadedCertData(); tlsKeypair != nil && caPool != nil || tc.AllowInsecure {
 		// In this case the data is already preloaded.
-		// In order to reload data use ReloadCertDatA
+		// In order to reload data use ReloadCertData
+		return nil
+	}
+
+	return tc.ReloadCertData()
+}
+
+// ReloadCertData reloads and stores the certificate data in the case when
+// certificate data has changed since initial loading. The previously loaded
+// data remains in use if the new data cannot be loaded.
+func (tc *TransportConfig) ReloadCertData() error {
+	if tc == nil {
+		return errors.New("nil TransportConfig")
+	}
+	if tc.AllowInsecure {
 		return nil
 	}
+
 	certificate, certPool, err := loadCertWithCustomCA(tc.CARootPath, tc.CertificatePath, tc.PrivateKeyPath)
 	if err != nil {
 		return err
 	}
 
+	// Pre-parse the Leaf Certificate
+	certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	var revoked map[string]struct{}
+	var nextUpdate time.Time
+	if tc.CRLPath != "" {
+		revoked, nextUpdate, err = loadRevokedSerials(tc.CRLPath, tc.CARootPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	certDataLock.Lock()
+	defer certDataLock.Unlock()
+
 	tc.tlsKeypair = certificate
 	tc.caPool = certPool
+	tc.revokedSerials = revoked
+	tc.crlNextUpdate = nextUpdate
 
-	// Pre-parse the Leaf Certificate
-	tc.tlsKeypair.Leaf, err = x509.ParseCertificate(tc.tlsKeypair.Certificate[0])
-	if err != nil {
+	return nil
+}
+
+// loadedCertData returns the currently loaded certificate data.
+func (tc *TransportConfig) loadedCertData() (*tls.Certificate, *x509.CertPool) {
+	certDataLock.RLock()
+	defer certDataLock.RUnlock()
+
+	return tc.tlsKeypair, tc.caPool
+}
+
+// checkRevoked returns an error if any of the supplied certificates has been
+// revoked by the loaded CRL, or if the CRL has expired since it was loaded.
+func (tc *TransportConfig) checkRevoked(certs ...*x509.Certificate) error {
+	certDataLock.RLock()
+	defer certDataLock.RUnlock()
+
+	if err := checkCRLUpdate(tc.CRLPath, tc.crlNextUpdate); err != nil {
 		return err
 	}
 
+	for _, cert := range certs {
+		if _, revoked := tc.revokedSerials[cert.SerialNumber.String()]; revoked {
+			return errors.Errorf("certificate %q (serial %s) has been revoked",
+				cert.Subject.CommonName, cert.SerialNumber)
+		}
+	}
 	return nil
 }
 
-// ReloadCertData reloads and stores the certificate data in the case when
-// certificate data has changed since initial loading.
-func (tc *TransportConfig) ReloadCertData() error {
-	tc.tlsKeypair = nil
-	tc.caPool = nil
-	return tc.PreLoadCertData()
+// ExpiryWarningPeriod returns the period before certificate expiry during
+// which warnings should be raised.
+func (tc *TransportConfig) ExpiryWarningPeriod() time.Duration {
+	days := tc.CertExpiryWarnDays
+	if days <= 0 {
+		days = defaultCertExpiryWarnDays
+	}
+	return time.Duration(days) * 24 * time.Hour
 }
 
 // PrivateKey returns the private key stored in the certificates loaded into the TransportConfig
@@ -150,13 +225,11 @@ func (tc *TransportConfig) PrivateKey() (crypto.PrivateKey, error) {
 		return nil, nil
 	}
 	// If we don't have our keys loaded attempt to load them.
-	if tc.tlsKeypair == nil || tc.caPool == nil {
-		err := tc.ReloadCertData()
-		if err != nil {
-			return nil, err
-		}
+	if err := tc.PreLoadCertData(); err != nil {
+		return nil, err
 	}
-	return tc.tlsKeypair.PrivateKey, nil
+	tlsKeypair, _ := tc.loadedCertData()
+	return tlsKeypair.PrivateKey, nil
 }
 
 // PublicKey returns the private key stored in the certificates loaded into the TransportConfig
@@ -165,11 +238,45 @@ func (tc *TransportConfig) PublicKey() (crypto.PublicKey, error) {
 		return nil, nil
 	}
 	// If we don't have our keys loaded attempt to load them.
-	if tc.tlsKeypair == nil || tc.caPool == nil {
-		err := tc.ReloadCertData()
-		if err != nil {
-			return nil, err
-		}
+	if err := tc.PreLoadCertData(); err != nil {
+		return nil, err
+	}
+	tlsKeypair, _ := tc.loadedCertData()
+	return tlsKeypair.Leaf.PublicKey, nil
+}
+
+// CredentialConfig contains the settings governing the lifetime of the
+// credentials issued by the agent to DAOS clients. The agent uses Validity to
+// set the expiry of each credential it signs; the server uses it as the
+// maximum lifetime it will accept, and tolerates clocks that differ by up to
+// ClockSkew when checking the issue and expiry times.
+type CredentialConfig struct {
+	Validity  time.Duration `yaml:"validity,omitempty"`
+	ClockSkew time.Duration `yaml:"clock_skew,omitempty"`
+}
+
+func (cc *CredentialConfig) String() string {
+	return fmt.Sprintf("validity: %s, clock skew: %s", cc.Validity, cc.ClockSkew)
+}
+
+// DefaultCredentialConfig provides a default credential config.
+func DefaultCredentialConfig() *CredentialConfig {
+	return &CredentialConfig{
+		Validity:  defaultCredValidity,
+		ClockSkew: defaultCredClockSkew,
+	}
+}
+
+// Validate checks that the credential config values are usable.
+func (cc *CredentialConfig) Validate() error {
+	if cc == nil {
+		return errors.New("nil CredentialConfig")
 	}
-	return tc.tlsKeypair.Leaf.PublicKey, nil
+	if cc.Validity <= 0 {
+		return errors.Errorf("credential validity must be positive (got %s)", cc.Validity)
+	}
+	if cc.ClockSkew < 0 {
+		return errors.Errorf("credential clock skew must not be negative (got %s)", cc.ClockSkew)
+	}
+	return nil
 }
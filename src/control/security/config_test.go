@@ -12,6 +12,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func InsecureTC() *TransportConfig {
@@ -159,6 +160,26 @@ func TestReloadCertData(t *testing.T) {
 	}
 }
 
+func TestReloadCertData_Failed(t *testing.T) {
+	testTC := ServerTC()
+	SetupTCFilePerms(t, testTC)
+
+	if err := testTC.PreLoadCertData(); err != nil {
+		t.Fatal(err)
+	}
+	beforeCert := testTC.tlsKeypair.Certificate[0]
+
+	testTC.CertificatePath = BadTC().CertificatePath
+	if err := testTC.ReloadCertData(); err == nil {
+		t.Fatal("expected reload of bad cert to fail")
+	}
+
+	// The previously loaded certificate should remain in use.
+	if !bytes.Equal(beforeCert, testTC.tlsKeypair.Certificate[0]) {
+		t.Fatal("cert changed after failed reload")
+	}
+}
+
 func ValidateInsecurePrivateKey(t *testing.T, key crypto.PrivateKey, err error) {
 	if err != nil {
 		t.Fatalf("Unable to Load PrivateKey from TransportConfig: %s", err)
@@ -240,3 +261,29 @@ func TestPublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *CredentialConfig
+		expErr string
+	}{
+		"nil":               {expErr: "nil CredentialConfig"},
+		"default":           {cfg: DefaultCredentialConfig()},
+		"zero validity":     {cfg: &CredentialConfig{}, expErr: "validity must be positive"},
+		"negative skew":     {cfg: &CredentialConfig{Validity: time.Minute, ClockSkew: -time.Second}, expErr: "clock skew must not be negative"},
+		"zero skew allowed": {cfg: &CredentialConfig{Validity: time.Minute}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
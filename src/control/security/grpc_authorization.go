@@ -34,6 +34,12 @@ var methodAuthorizations = map[string][]Component{
 	"/ctl.CtlSvc/PingRanks":                {ComponentServer},
 	"/ctl.CtlSvc/ResetFormatRanks":         {ComponentServer},
 	"/ctl.CtlSvc/StartRanks":               {ComponentServer},
+	"/ctl.CtlSvc/CertQuery":                {ComponentAdmin},
+	"/ctl.CtlSvc/AuditQuery":               {ComponentAdmin},
+	"/ctl.CtlSvc/CollectCrash":             {ComponentAdmin},
+	"/ctl.CtlSvc/CollectLog":               {ComponentAdmin},
+	"/ctl.CtlSvc/ReloadConfig":             {ComponentAdmin},
+	"/ctl.CtlSvc/ApplyConfig":              {ComponentAdmin},
 	"/mgmt.MgmtSvc/Join":                   {ComponentServer},
 	"/mgmt.MgmtSvc/ClusterEvent":           {ComponentServer},
 	"/mgmt.MgmtSvc/LeaderQuery":            {ComponentAdmin},
@@ -41,6 +47,7 @@ var methodAuthorizations = map[string][]Component{
 	"/mgmt.MgmtSvc/SystemErase":            {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemStart":            {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemStop":             {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemConfigCheck":      {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolCreate":             {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolDestroy":            {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolQuery":              {ComponentAdmin},
@@ -62,11 +62,18 @@ func TestSecurity_ComponentHasAccess(t *testing.T) {
 		"/ctl.CtlSvc/PingRanks":                {ComponentServer},
 		"/ctl.CtlSvc/ResetFormatRanks":         {ComponentServer},
 		"/ctl.CtlSvc/StartRanks":               {ComponentServer},
+		"/ctl.CtlSvc/CertQuery":                {ComponentAdmin},
+		"/ctl.CtlSvc/AuditQuery":               {ComponentAdmin},
+		"/ctl.CtlSvc/CollectCrash":             {ComponentAdmin},
+		"/ctl.CtlSvc/CollectLog":               {ComponentAdmin},
+		"/ctl.CtlSvc/ReloadConfig":             {ComponentAdmin},
+		"/ctl.CtlSvc/ApplyConfig":              {ComponentAdmin},
 		"/mgmt.MgmtSvc/Join":                   {ComponentServer},
 		"/mgmt.MgmtSvc/ClusterEvent":           {ComponentServer},
 		"/mgmt.MgmtSvc/LeaderQuery":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemQuery":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemStop":             {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemConfigCheck":      {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemErase":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/SystemStart":            {ComponentAdmin},
 		"/mgmt.MgmtSvc/PoolCreate":             {ComponentAdmin},
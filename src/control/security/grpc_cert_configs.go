@@ -28,10 +28,25 @@ import (
 // validate the certificate chain.
 
 func serverTLSConfig(cfg *TransportConfig) *tls.Config {
+	return &tls.Config{
+		// The certificate data is looked up for each new connection so
+		// that certificates reloaded at runtime take effect without
+		// restarting the server.
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			tlsKeypair, caPool := cfg.loadedCertData()
+			if tlsKeypair == nil || caPool == nil {
+				return nil, errors.New("certificate data not loaded")
+			}
+			return serverConnTLSConfig(cfg, tlsKeypair, caPool), nil
+		},
+	}
+}
+
+func serverConnTLSConfig(cfg *TransportConfig, tlsKeypair *tls.Certificate, caPool *x509.CertPool) *tls.Config {
 	return &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
-		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		ClientCAs:                cfg.caPool,
+		Certificates:             []tls.Certificate{*tlsKeypair},
+		ClientCAs:                caPool,
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -40,15 +55,17 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 		},
 		VerifyConnection: func(cs tls.ConnectionState) error {
 			opts := x509.VerifyOptions{
-				Roots:         cfg.caPool,
+				Roots:         caPool,
 				Intermediates: x509.NewCertPool(),
 				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 			}
 			for _, cert := range cs.PeerCertificates[1:] {
 				opts.Intermediates.AddCert(cert)
 			}
-			_, err := cs.PeerCertificates[0].Verify(opts)
-			return err
+			if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+				return err
+			}
+			return cfg.checkRevoked(cs.PeerCertificates...)
 		},
 	}
 }
@@ -57,8 +74,16 @@ const ServerCommonName = "server"
 
 func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 	return &tls.Config{
-		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		RootCAs:                  cfg.caPool,
+		// The client certificate is looked up for each new connection so
+		// that certificates reloaded at runtime take effect without
+		// restarting the client.
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			tlsKeypair, _ := cfg.loadedCertData()
+			if tlsKeypair == nil {
+				return nil, errors.New("certificate data not loaded")
+			}
+			return tlsKeypair, nil
+		},
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -76,10 +101,12 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 		// except it will not validate the hostname of the SubjectAlternativeName.
 		// After the certificate is verified it will ensure the CommonName
 		// of the received certificate is "server" to ensure we are
-		// communicating with a DAOS server.
+		// communicating with a DAOS server, and that the certificate has
+		// not been revoked.
 		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, caPool := cfg.loadedCertData()
 			opts := x509.VerifyOptions{
-				Roots:         cfg.caPool,
+				Roots:         caPool,
 				Intermediates: x509.NewCertPool(),
 			}
 			for _, cert := range cs.PeerCertificates[1:] {
@@ -92,7 +119,7 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 			if cs.PeerCertificates[0].Subject.CommonName != ServerCommonName {
 				return errors.New("Server certificate does not identify as Server")
 			}
-			return nil
+			return cfg.checkRevoked(cs.PeerCertificates...)
 		},
 	}
 }
@@ -8,13 +8,47 @@
 
 package security
 
-import "crypto/tls"
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+// revocationVerifier returns a function which rejects peer certificates that
+// have been revoked by the CRL loaded into the TransportConfig.
+func revocationVerifier(cfg *TransportConfig) func([][]byte, [][]*x509.Certificate) error {
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			if err := cfg.checkRevoked(chain...); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
 
 func serverTLSConfig(cfg *TransportConfig) *tls.Config {
+	return &tls.Config{
+		// The certificate data is looked up for each new connection so
+		// that certificates reloaded at runtime take effect without
+		// restarting the server.
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			tlsKeypair, caPool := cfg.loadedCertData()
+			if tlsKeypair == nil || caPool == nil {
+				return nil, errors.New("certificate data not loaded")
+			}
+			return serverConnTLSConfig(cfg, tlsKeypair, caPool), nil
+		},
+	}
+}
+
+func serverConnTLSConfig(cfg *TransportConfig, tlsKeypair *tls.Certificate, caPool *x509.CertPool) *tls.Config {
 	return &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
-		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		ClientCAs:                cfg.caPool,
+		Certificates:             []tls.Certificate{*tlsKeypair},
+		ClientCAs:                caPool,
+		VerifyPeerCertificate:    revocationVerifier(cfg),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
@@ -25,10 +59,22 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 }
 
 func clientTLSConfig(cfg *TransportConfig) *tls.Config {
+	_, caPool := cfg.loadedCertData()
 	return &tls.Config{
-		ServerName:               cfg.ServerName,
-		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
-		RootCAs:                  cfg.caPool,
+		ServerName: cfg.ServerName,
+		RootCAs:    caPool,
+		// The client certificate is looked up for each new connection so
+		// that certificates reloaded at runtime take effect without
+		// restarting the client. Changes to the CA certificate require
+		// a restart.
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			tlsKeypair, _ := cfg.loadedCertData()
+			if tlsKeypair == nil {
+				return nil, errors.New("certificate data not loaded")
+			}
+			return tlsKeypair, nil
+		},
+		VerifyPeerCertificate:    revocationVerifier(cfg),
 		MinVersion:               tls.VersionTLS12,
 		MaxVersion:               tls.VersionTLS12,
 		PreferServerCipherSuites: true,
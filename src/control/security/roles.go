@@ -0,0 +1,204 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Built-in roles which may be granted to admin certificates.
+const (
+	RoleReadOnly  = "read-only"
+	RoleOperator  = "operator"
+	RolePoolAdmin = "pool-admin"
+)
+
+// RoleExtensionOID identifies the certificate extension containing the list
+// of roles granted to an admin certificate.
+var RoleExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 343, 7001, 1}
+
+var readOnlyMethods = []string{
+	"StorageScan",
+	"NetworkScan",
+	"FirmwareQuery",
+	"SmdQuery",
+	"CertQuery",
+	"LeaderQuery",
+	"SystemQuery",
+	"SystemConfigCheck",
+	"PoolQuery",
+	"PoolGetProp",
+	"PoolGetACL",
+	"ListPools",
+	"ListContainers",
+}
+
+// builtinRoles maps the built-in role names to the methods they grant. Methods
+// are identified by name without the gRPC service prefix and may be glob
+// patterns.
+var builtinRoles = map[string][]string{
+	RoleReadOnly: readOnlyMethods,
+	RoleOperator: append([]string{
+		"SystemStart",
+		"SystemStop",
+		"SetEngineLogMasks",
+		"ReloadConfig",
+		"PoolExclude",
+		"PoolDrain",
+		"PoolReintegrate",
+		"PoolEvict",
+	}, readOnlyMethods...),
+	RolePoolAdmin: append([]string{
+		"Pool*",
+		"ContSetOwner",
+	}, readOnlyMethods...),
+}
+
+// AuthorizationConfig contains the role-based authorization settings applied
+// to control-plane RPCs made with admin certificates. Roles are granted to an
+// admin certificate by the extension identified by RoleExtensionOID. Admin
+// certificates without roles have unrestricted admin access unless
+// RequireRoles is set. Roles defined in Roles are added to the built-in
+// roles, replacing any built-in role with the same name.
+type AuthorizationConfig struct {
+	RequireRoles bool                `yaml:"require_roles,omitempty"`
+	Roles        map[string][]string `yaml:"roles,omitempty"`
+}
+
+func (ac *AuthorizationConfig) String() string {
+	return fmt.Sprintf("require roles: %t, roles: %s", ac.RequireRoles,
+		strings.Join(ac.RoleNames(), ","))
+}
+
+// Validate checks that the authorization config values are usable.
+func (ac *AuthorizationConfig) Validate() error {
+	if ac == nil {
+		return errors.New("nil AuthorizationConfig")
+	}
+	for role, methods := range ac.Roles {
+		if role == "" {
+			return errors.New("role name must not be empty")
+		}
+		if len(methods) == 0 {
+			return errors.Errorf("role %q grants no methods", role)
+		}
+		for _, method := range methods {
+			if _, err := path.Match(method, ""); err != nil {
+				return errors.Errorf("role %q: invalid method pattern %q", role, method)
+			}
+		}
+	}
+	return nil
+}
+
+// roleMethods returns the method patterns granted by the named role.
+func (ac *AuthorizationConfig) roleMethods(role string) ([]string, bool) {
+	if ac != nil {
+		if methods, found := ac.Roles[role]; found {
+			return methods, true
+		}
+	}
+	methods, found := builtinRoles[role]
+	return methods, found
+}
+
+// RoleNames returns the sorted names of all roles known to the config.
+func (ac *AuthorizationConfig) RoleNames() []string {
+	names := make([]string, 0, len(builtinRoles))
+	for name := range builtinRoles {
+		names = append(names, name)
+	}
+	if ac != nil {
+		for name := range ac.Roles {
+			if _, found := builtinRoles[name]; !found {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RoleHasAccess returns true if the named role grants access to the method
+// given in FullMethod.
+func (ac *AuthorizationConfig) RoleHasAccess(role, FullMethod string) bool {
+	methods, found := ac.roleMethods(role)
+	if !found {
+		return false
+	}
+
+	name := FullMethod[strings.LastIndex(FullMethod, "/")+1:]
+	for _, pattern := range methods {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CertHasAccess returns true if the peer identified by the certificate may
+// call the method given in FullMethod. The component identified by the
+// certificate must have access to the method and, for admin certificates,
+// one of the roles granted by the certificate must also allow the method.
+func (ac *AuthorizationConfig) CertHasAccess(cert *x509.Certificate, FullMethod string) (bool, error) {
+	comp := CommonNameToComponent(cert.Subject.CommonName)
+	if !comp.HasAccess(FullMethod) {
+		return false, nil
+	}
+	if comp != ComponentAdmin {
+		return true, nil
+	}
+
+	roles, err := CertificateRoles(cert)
+	if err != nil {
+		return false, err
+	}
+	if len(roles) == 0 {
+		return ac == nil || !ac.RequireRoles, nil
+	}
+
+	for _, role := range roles {
+		if ac.RoleHasAccess(role, FullMethod) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CertificateRoles returns the roles granted by the certificate's role
+// extension, if present.
+func CertificateRoles(cert *x509.Certificate) ([]string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(RoleExtensionOID) {
+			continue
+		}
+
+		var roles []string
+		if _, err := asn1.Unmarshal(ext.Value, &roles); err != nil {
+			return nil, errors.Wrap(err, "invalid role extension")
+		}
+		return roles, nil
+	}
+	return nil, nil
+}
+
+// roleExtension returns a certificate extension granting the supplied roles.
+func roleExtension(roles []string) (pkix.Extension, error) {
+	value, err := asn1.Marshal(roles)
+	if err != nil {
+		return pkix.Extension{}, errors.Wrap(err, "failed to encode roles")
+	}
+	return pkix.Extension{Id: RoleExtensionOID, Value: value}, nil
+}
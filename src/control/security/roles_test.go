@@ -0,0 +1,216 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func testRoleCert(t *testing.T, cn string, roles ...string) *x509.Certificate {
+	t.Helper()
+
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: cn},
+	}
+	if len(roles) > 0 {
+		ext, err := roleExtension(roles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert.Extensions = append(cert.Extensions, ext)
+	}
+	return cert
+}
+
+func TestSecurity_AuthorizationConfig_CertHasAccess(t *testing.T) {
+	badRoleCert := testRoleCert(t, "admin")
+	badRoleCert.Extensions = append(badRoleCert.Extensions, pkix.Extension{
+		Id:    RoleExtensionOID,
+		Value: []byte("garbage"),
+	})
+
+	for name, tc := range map[string]struct {
+		cfg       *AuthorizationConfig
+		cert      *x509.Certificate
+		method    string
+		expAccess bool
+		expErr    error
+	}{
+		"nil config; admin without roles": {
+			cert:      testRoleCert(t, "admin"),
+			method:    "/mgmt.MgmtSvc/SystemErase",
+			expAccess: true,
+		},
+		"admin without roles": {
+			cfg:       &AuthorizationConfig{},
+			cert:      testRoleCert(t, "admin"),
+			method:    "/mgmt.MgmtSvc/PoolDestroy",
+			expAccess: true,
+		},
+		"admin without roles; roles required": {
+			cfg:    &AuthorizationConfig{RequireRoles: true},
+			cert:   testRoleCert(t, "admin"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+		},
+		"agent unaffected by required roles": {
+			cfg:       &AuthorizationConfig{RequireRoles: true},
+			cert:      testRoleCert(t, "agent"),
+			method:    "/mgmt.MgmtSvc/GetAttachInfo",
+			expAccess: true,
+		},
+		"agent calling admin method": {
+			cert:   testRoleCert(t, "agent"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+		},
+		"read-only query": {
+			cert:      testRoleCert(t, "admin", RoleReadOnly),
+			method:    "/mgmt.MgmtSvc/PoolQuery",
+			expAccess: true,
+		},
+		"read-only destroy": {
+			cert:   testRoleCert(t, "admin", RoleReadOnly),
+			method: "/mgmt.MgmtSvc/PoolDestroy",
+		},
+		"operator system stop": {
+			cert:      testRoleCert(t, "admin", RoleOperator),
+			method:    "/mgmt.MgmtSvc/SystemStop",
+			expAccess: true,
+		},
+		"operator system erase": {
+			cert:   testRoleCert(t, "admin", RoleOperator),
+			method: "/mgmt.MgmtSvc/SystemErase",
+		},
+		"pool-admin pool destroy": {
+			cert:      testRoleCert(t, "admin", RolePoolAdmin),
+			method:    "/mgmt.MgmtSvc/PoolDestroy",
+			expAccess: true,
+		},
+		"pool-admin storage format": {
+			cert:   testRoleCert(t, "admin", RolePoolAdmin),
+			method: "/ctl.CtlSvc/StorageFormat",
+		},
+		"multiple roles": {
+			cert:      testRoleCert(t, "admin", RoleReadOnly, RolePoolAdmin),
+			method:    "/mgmt.MgmtSvc/PoolCreate",
+			expAccess: true,
+		},
+		"unknown role": {
+			cert:   testRoleCert(t, "admin", "superuser"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+		},
+		"custom role": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"helpdesk": {"SystemQuery"}},
+			},
+			cert:      testRoleCert(t, "admin", "helpdesk"),
+			method:    "/mgmt.MgmtSvc/SystemQuery",
+			expAccess: true,
+		},
+		"custom role replaces built-in role": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{RoleReadOnly: {"SystemQuery"}},
+			},
+			cert:   testRoleCert(t, "admin", RoleReadOnly),
+			method: "/mgmt.MgmtSvc/PoolQuery",
+		},
+		"role cannot grant method unavailable to admin": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"all": {"*"}},
+			},
+			cert:   testRoleCert(t, "admin", "all"),
+			method: "/mgmt.MgmtSvc/Join",
+		},
+		"invalid role extension": {
+			cert:   badRoleCert,
+			method: "/mgmt.MgmtSvc/SystemQuery",
+			expErr: errors.New("invalid role extension"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotAccess, gotErr := tc.cfg.CertHasAccess(tc.cert, tc.method)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, gotAccess, tc.expAccess, "unexpected access")
+		})
+	}
+}
+
+func TestSecurity_AuthorizationConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *AuthorizationConfig
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil AuthorizationConfig"),
+		},
+		"empty": {
+			cfg: &AuthorizationConfig{},
+		},
+		"valid roles": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"helpdesk": {"SystemQuery", "Pool*"}},
+			},
+		},
+		"empty role name": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"": {"SystemQuery"}},
+			},
+			expErr: errors.New("must not be empty"),
+		},
+		"role without methods": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"helpdesk": {}},
+			},
+			expErr: errors.New("grants no methods"),
+		},
+		"bad pattern": {
+			cfg: &AuthorizationConfig{
+				Roles: map[string][]string{"helpdesk": {"Pool["}},
+			},
+			expErr: errors.New("invalid method pattern"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestSecurity_CertificateRoles(t *testing.T) {
+	roles := []string{RoleReadOnly, "helpdesk"}
+	value, err := asn1.Marshal(roles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &x509.Certificate{
+		Extensions: []pkix.Extension{{Id: RoleExtensionOID, Value: value}},
+	}
+
+	gotRoles, err := CertificateRoles(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, gotRoles, roles, "unexpected roles")
+
+	gotRoles, err = CertificateRoles(&x509.Certificate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotRoles) != 0 {
+		t.Fatalf("expected no roles, got %v", gotRoles)
+	}
+}
@@ -0,0 +1,435 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+)
+
+// auditExemptMethods are the control-plane methods that do not change the
+// state of the system and are therefore not audited.
+var auditExemptMethods = map[string]struct{}{
+	"StorageScan":       {},
+	"NetworkScan":       {},
+	"FirmwareQuery":     {},
+	"SmdQuery":          {},
+	"CertQuery":         {},
+	"AuditQuery":        {},
+	"CollectCrash":      {},
+	"CollectLog":        {},
+	"LeaderQuery":       {},
+	"SystemQuery":       {},
+	"SystemConfigCheck": {},
+	"PoolQuery":         {},
+	"PoolGetProp":       {},
+	"PoolGetACL":        {},
+	"ListPools":         {},
+	"ListContainers":    {},
+	"GetAttachInfo":     {},
+}
+
+// auditFieldNames are the request fields recorded in audit records.
+var auditFieldNames = map[protoreflect.Name]struct{}{
+	"id":           {},
+	"uuid":         {},
+	"label":        {},
+	"rank":         {},
+	"ranks":        {},
+	"hosts":        {},
+	"force":        {},
+	"reformat":     {},
+	"kill":         {},
+	"user":         {},
+	"usergroup":    {},
+	"owneruser":    {},
+	"ownergroup":   {},
+	"masks":        {},
+	"targetidx":    {},
+	"tgt_ids":      {},
+	"dev_uuid":     {},
+	"old_dev_uuid": {},
+	"new_dev_uuid": {},
+	"path":         {},
+}
+
+// isAuditedMethod returns true if the gRPC method identified by FullMethod is
+// a mutating control-plane operation. Methods which only servers may call,
+// e.g. joins and the fan-out of system start and stop requests to the ranks,
+// are internal to the system and are not audited; the admin request which
+// caused them is.
+func isAuditedMethod(FullMethod string) bool {
+	if !strings.HasPrefix(FullMethod, "/ctl.CtlSvc/") && !strings.HasPrefix(FullMethod, "/mgmt.MgmtSvc/") {
+		return false
+	}
+	if !security.ComponentAdmin.HasAccess(FullMethod) && !security.ComponentAgent.HasAccess(FullMethod) {
+		return false
+	}
+	_, exempt := auditExemptMethods[FullMethod[strings.LastIndex(FullMethod, "/")+1:]]
+	return !exempt
+}
+
+// auditFields returns the key fields of the request, formatted as strings.
+func auditFields(req interface{}) map[string]string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if _, found := auditFieldNames[fd.Name()]; !found {
+			return true
+		}
+		if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.BytesKind || fd.IsMap() {
+			return true
+		}
+
+		if fd.IsList() {
+			list := v.List()
+			vals := make([]string, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				vals[i] = fmt.Sprint(list.Get(i).Interface())
+			}
+			fields[string(fd.Name())] = strings.Join(vals, ",")
+			return true
+		}
+		fields[string(fd.Name())] = fmt.Sprint(v.Interface())
+		return true
+	})
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+type (
+	// auditDatabase defines the system database methods used to
+	// replicate audit records.
+	auditDatabase interface {
+		IsLeader() bool
+		AddAuditRecord(*system.AuditRecord) error
+		AuditRecords() ([]*system.AuditRecord, error)
+	}
+
+	// auditLogger records mutating control-plane operations in a
+	// rotating JSON-lines file and optionally the system database.
+	// Records made on servers other than the MS leader are forwarded
+	// to the leader to be added to the system database.
+	auditLogger struct {
+		sync.Mutex
+		log       logging.Logger
+		cfg       *config.AuditConfig
+		hostname  string
+		sysdb     auditDatabase
+		forwarder events.Handler
+		file      *os.File
+		size      int64
+	}
+)
+
+// newAuditLogger returns an auditLogger writing to the file named in the
+// supplied config and forwarding records to the MS leader with the supplied
+// event handler.
+func newAuditLogger(log logging.Logger, cfg *config.AuditConfig, hostname string, sysdb auditDatabase, forwarder events.Handler) (*auditLogger, error) {
+	if cfg == nil {
+		return nil, errors.New("nil AuditConfig")
+	}
+
+	al := &auditLogger{
+		log:       log,
+		cfg:       cfg,
+		hostname:  hostname,
+		sysdb:     sysdb,
+		forwarder: forwarder,
+	}
+	if err := al.openFile(); err != nil {
+		return nil, err
+	}
+
+	return al, nil
+}
+
+func (al *auditLogger) openFile() error {
+	f, err := os.OpenFile(al.cfg.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log")
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to stat audit log")
+	}
+
+	al.file = f
+	al.size = fi.Size()
+	return nil
+}
+
+// Close closes the audit log file.
+func (al *auditLogger) Close() error {
+	al.Lock()
+	defer al.Unlock()
+
+	if al.file == nil {
+		return nil
+	}
+	err := al.file.Close()
+	al.file = nil
+	return err
+}
+
+// backupPath returns the path of the numbered rotated log file.
+func (al *auditLogger) backupPath(idx int) string {
+	return fmt.Sprintf("%s.%d", al.cfg.LogFile, idx)
+}
+
+// rotate moves the current log file to the first backup, shifting existing
+// backups and removing the oldest, then opens a new log file.
+func (al *auditLogger) rotate() error {
+	if err := al.file.Close(); err != nil {
+		return err
+	}
+	al.file = nil
+
+	for i := al.cfg.MaxBackups - 1; i > 0; i-- {
+		if err := os.Rename(al.backupPath(i), al.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if al.cfg.MaxBackups > 0 {
+		if err := os.Rename(al.cfg.LogFile, al.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(al.cfg.LogFile); err != nil {
+		return err
+	}
+
+	return al.openFile()
+}
+
+// write appends the record to the log file, rotating the file first if the
+// record would take it over the maximum size.
+func (al *auditLogger) write(rec *system.AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	al.Lock()
+	defer al.Unlock()
+
+	if al.file == nil {
+		return errors.New("audit log is closed")
+	}
+
+	maxSize := int64(al.cfg.MaxSize) * 1024 * 1024
+	if maxSize > 0 && al.size > 0 && al.size+int64(len(data)) > maxSize {
+		if err := al.rotate(); err != nil {
+			return errors.Wrap(err, "failed to rotate audit log")
+		}
+	}
+
+	n, err := al.file.Write(data)
+	al.size += int64(n)
+	return err
+}
+
+// record writes the audit record to the log file and, if configured, the
+// system database. Records are added to the system database directly on the
+// MS leader and are forwarded to the leader from other servers. Failures are
+// logged rather than returned so that they do not affect the operation.
+func (al *auditLogger) record(rec *system.AuditRecord) {
+	rec.Host = al.hostname
+
+	if err := al.write(rec); err != nil {
+		al.log.Errorf("audit log: %s", err)
+	}
+
+	if !al.cfg.SystemDB || al.sysdb == nil {
+		return
+	}
+	if al.sysdb.IsLeader() {
+		al.addToSystemDB(rec)
+		return
+	}
+	if al.forwarder == nil {
+		return
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		al.log.Errorf("audit log: failed to encode record: %s", err)
+		return
+	}
+	// forward in the background so that the operation isn't held up by a
+	// request to the MS leader
+	go al.forwarder.OnEvent(context.Background(), events.NewAuditRecordEvent(al.hostname, string(data)))
+}
+
+func (al *auditLogger) addToSystemDB(rec *system.AuditRecord) {
+	if err := al.sysdb.AddAuditRecord(rec); err != nil {
+		al.log.Errorf("audit log: failed to add record to system database: %s", err)
+	}
+}
+
+// OnEvent implements the events.Handler interface, adding audit records
+// forwarded to the MS leader by other servers to the system database.
+func (al *auditLogger) OnEvent(_ context.Context, evt *events.RASEvent) {
+	if evt == nil || evt.ID != events.RASAuditRecord || !al.cfg.SystemDB || al.sysdb == nil {
+		return
+	}
+
+	si := evt.GetStrInfo()
+	if si == nil {
+		al.log.Errorf("audit log: no record in event from %s", evt.Hostname)
+		return
+	}
+	rec := new(system.AuditRecord)
+	if err := json.Unmarshal([]byte(*si), rec); err != nil {
+		al.log.Errorf("audit log: invalid record in event from %s: %s", evt.Hostname, err)
+		return
+	}
+	al.addToSystemDB(rec)
+}
+
+// readAuditFile returns the records stored in the named log file.
+func readAuditFile(path string) ([]*system.AuditRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*system.AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		rec := new(system.AuditRecord)
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return nil, errors.Wrapf(err, "invalid audit record in %s", path)
+		}
+		records = append(records, rec)
+	}
+
+	return records, scanner.Err()
+}
+
+// fileRecords returns the records stored in the log file and its backups,
+// oldest first.
+func (al *auditLogger) fileRecords() ([]*system.AuditRecord, error) {
+	al.Lock()
+	defer al.Unlock()
+
+	var records []*system.AuditRecord
+	for i := al.cfg.MaxBackups; i >= 0; i-- {
+		path := al.cfg.LogFile
+		if i > 0 {
+			path = al.backupPath(i)
+		}
+
+		fileRecs, err := readAuditFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		records = append(records, fileRecs...)
+	}
+
+	return records, nil
+}
+
+// query returns the records matching the filters in the request, read from
+// the log file or the system database.
+func (al *auditLogger) query(req *ctlpb.AuditQueryReq) ([]*system.AuditRecord, error) {
+	var records []*system.AuditRecord
+	var err error
+	if req.GetSystemDb() {
+		if !al.cfg.SystemDB || al.sysdb == nil {
+			return nil, errors.New("audit records are not stored in the system database")
+		}
+		records, err = al.sysdb.AuditRecords()
+	} else {
+		records, err = al.fileRecords()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Unix(0, req.GetSince())
+	matched := make([]*system.AuditRecord, 0, len(records))
+	for _, rec := range records {
+		switch {
+		case req.GetSince() != 0 && !rec.Time.After(since):
+		case req.GetMethod() != "" && !strings.Contains(rec.Method, req.GetMethod()):
+		case req.GetCaller() != "" && rec.Caller != req.GetCaller():
+		default:
+			matched = append(matched, rec)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Time.Before(matched[j].Time)
+	})
+
+	if limit := int(req.GetLimit()); limit > 0 && len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	return matched, nil
+}
+
+// auditRecordToPB converts an audit record to its protobuf representation.
+func auditRecordToPB(rec *system.AuditRecord) *ctlpb.AuditRecord {
+	pbRec := &ctlpb.AuditRecord{
+		Time:     rec.Time.UnixNano(),
+		Host:     rec.Host,
+		Caller:   rec.Caller,
+		Roles:    rec.Roles,
+		Source:   rec.Source,
+		Method:   rec.Method,
+		Outcome:  rec.Outcome,
+		Error:    rec.Error,
+		Duration: int64(rec.Duration),
+	}
+
+	keys := make([]string, 0, len(rec.Fields))
+	for key := range rec.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pbRec.Fields = append(pbRec.Fields, &ctlpb.AuditField{Key: key, Value: rec.Fields[key]})
+	}
+
+	return pbRec
+}
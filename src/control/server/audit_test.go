@@ -0,0 +1,334 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_isAuditedMethod(t *testing.T) {
+	for method, expAudited := range map[string]bool{
+		"/mgmt.MgmtSvc/PoolCreate":     true,
+		"/mgmt.MgmtSvc/SystemStop":     true,
+		"/ctl.CtlSvc/StorageFormat":    true,
+		"/mgmt.MgmtSvc/PoolQuery":      false,
+		"/mgmt.MgmtSvc/SystemQuery":    false,
+		"/ctl.CtlSvc/StorageScan":      false,
+		"/ctl.CtlSvc/AuditQuery":       false,
+		"/mgmt.MgmtSvc/Join":           false,
+		"/mgmt.MgmtSvc/ClusterEvent":   false,
+		"/ctl.CtlSvc/StopRanks":        false,
+		"/ctl.CtlSvc/StartRanks":       false,
+		"/ctl.CtlSvc/ResetFormatRanks": false,
+		"/mgmt.MgmtSvc/PoolEvict":      true,
+		"/raft.RaftTransport/AppendEn": false,
+	} {
+		t.Run(method, func(t *testing.T) {
+			common.AssertEqual(t, isAuditedMethod(method), expAudited, "unexpected result")
+		})
+	}
+}
+
+func TestServer_auditFields(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       interface{}
+		expFields map[string]string
+	}{
+		"not a message": {
+			req: 42,
+		},
+		"pool destroy": {
+			req: &mgmtpb.PoolDestroyReq{
+				Sys:   "daos_server",
+				Id:    "pool1",
+				Force: true,
+			},
+			expFields: map[string]string{
+				"id":    "pool1",
+				"force": "true",
+			},
+		},
+		"system stop": {
+			req: &mgmtpb.SystemStopReq{
+				Ranks: "0-3",
+				Hosts: "host[1-2]",
+			},
+			expFields: map[string]string{
+				"ranks": "0-3",
+				"hosts": "host[1-2]",
+			},
+		},
+		"pool exclude": {
+			req: &mgmtpb.PoolExcludeReq{
+				Id:        "pool1",
+				Rank:      2,
+				Targetidx: []uint32{1, 3},
+			},
+			expFields: map[string]string{
+				"id":        "pool1",
+				"rank":      "2",
+				"targetidx": "1,3",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expFields, auditFields(tc.req)); diff != "" {
+				t.Fatalf("unexpected fields (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func testAuditRecord(method string, ts time.Time) *system.AuditRecord {
+	return &system.AuditRecord{
+		Time:     ts,
+		Caller:   "admin",
+		Method:   method,
+		Outcome:  auditOutcomeSuccess,
+		Duration: time.Millisecond,
+	}
+}
+
+func TestServer_auditLogger_rotate(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	cfg := &config.AuditConfig{
+		LogFile:    filepath.Join(testDir, "audit.log"),
+		MaxSize:    1,
+		MaxBackups: 2,
+	}
+	al, err := newAuditLogger(log, cfg, "host1", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer al.Close()
+
+	// Records of ~1KiB ensure that each file holds fewer than 1024.
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	numRecords := 4000
+	for i := 0; i < numRecords; i++ {
+		rec := testAuditRecord("/mgmt.MgmtSvc/PoolCreate", start.Add(time.Duration(i)*time.Second))
+		rec.Fields = map[string]string{"padding": string(make([]byte, 1000))}
+		al.record(rec)
+	}
+
+	for _, path := range []string{cfg.LogFile, cfg.LogFile + ".1", cfg.LogFile + ".2"} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() > 1024*1024 {
+			t.Fatalf("%s larger than max size (%d)", path, fi.Size())
+		}
+	}
+	if _, err := os.Stat(cfg.LogFile + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected oldest backup to be removed, got %v", err)
+	}
+
+	records, err := al.fileRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || len(records) >= numRecords {
+		t.Fatalf("unexpected number of records: %d", len(records))
+	}
+	last := records[len(records)-1]
+	common.AssertEqual(t, last.Time, start.Add(time.Duration(numRecords-1)*time.Second), "unexpected last record")
+	for i := 1; i < len(records); i++ {
+		if !records[i].Time.After(records[i-1].Time) {
+			t.Fatalf("records out of order at %d", i)
+		}
+	}
+}
+
+func TestServer_auditLogger_query(t *testing.T) {
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	testRecords := []*system.AuditRecord{
+		testAuditRecord("/mgmt.MgmtSvc/PoolCreate", start),
+		testAuditRecord("/mgmt.MgmtSvc/PoolDestroy", start.Add(time.Minute)),
+		testAuditRecord("/ctl.CtlSvc/StorageFormat", start.Add(2*time.Minute)),
+		testAuditRecord("/mgmt.MgmtSvc/PoolDestroy", start.Add(3*time.Minute)),
+	}
+	testRecords[2].Caller = "server"
+
+	for name, tc := range map[string]struct {
+		systemDB   bool
+		req        *ctlpb.AuditQueryReq
+		expMethods []string
+		expErr     error
+	}{
+		"all records": {
+			req: &ctlpb.AuditQueryReq{},
+			expMethods: []string{
+				"/mgmt.MgmtSvc/PoolCreate",
+				"/mgmt.MgmtSvc/PoolDestroy",
+				"/ctl.CtlSvc/StorageFormat",
+				"/mgmt.MgmtSvc/PoolDestroy",
+			},
+		},
+		"since": {
+			req: &ctlpb.AuditQueryReq{Since: start.Add(time.Minute).UnixNano()},
+			expMethods: []string{
+				"/ctl.CtlSvc/StorageFormat",
+				"/mgmt.MgmtSvc/PoolDestroy",
+			},
+		},
+		"method": {
+			req: &ctlpb.AuditQueryReq{Method: "Pool"},
+			expMethods: []string{
+				"/mgmt.MgmtSvc/PoolCreate",
+				"/mgmt.MgmtSvc/PoolDestroy",
+				"/mgmt.MgmtSvc/PoolDestroy",
+			},
+		},
+		"caller": {
+			req:        &ctlpb.AuditQueryReq{Caller: "server"},
+			expMethods: []string{"/ctl.CtlSvc/StorageFormat"},
+		},
+		"limit": {
+			req: &ctlpb.AuditQueryReq{Method: "PoolDestroy", Limit: 1},
+			expMethods: []string{
+				"/mgmt.MgmtSvc/PoolDestroy",
+			},
+		},
+		"system db not enabled": {
+			req:    &ctlpb.AuditQueryReq{SystemDb: true},
+			expErr: errors.New("not stored in the system database"),
+		},
+		"system db": {
+			systemDB: true,
+			req:      &ctlpb.AuditQueryReq{SystemDb: true, Method: "Destroy"},
+			expMethods: []string{
+				"/mgmt.MgmtSvc/PoolDestroy",
+				"/mgmt.MgmtSvc/PoolDestroy",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			cfg := &config.AuditConfig{
+				LogFile:  filepath.Join(testDir, "audit.log"),
+				SystemDB: tc.systemDB,
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			al, err := newAuditLogger(log, cfg, "host1", system.MockDatabase(t, log), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer al.Close()
+
+			for _, rec := range testRecords {
+				recCopy := *rec
+				al.record(&recCopy)
+			}
+
+			gotRecords, gotErr := al.query(tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotMethods := make([]string, len(gotRecords))
+			for i, rec := range gotRecords {
+				gotMethods[i] = rec.Method
+				common.AssertEqual(t, rec.Host, "host1", "unexpected host")
+			}
+			if diff := cmp.Diff(tc.expMethods, gotMethods); diff != "" {
+				t.Fatalf("unexpected records (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+type followerAuditDB struct {
+	auditDatabase
+}
+
+func (db *followerAuditDB) IsLeader() bool {
+	return false
+}
+
+func TestServer_auditLogger_forward(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	newLogger := func(name string, sysdb auditDatabase, forwarder events.Handler) *auditLogger {
+		al, err := newAuditLogger(log, &config.AuditConfig{
+			LogFile:  filepath.Join(testDir, name),
+			SystemDB: true,
+		}, name, sysdb, forwarder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return al
+	}
+
+	leaderDB := system.MockDatabase(t, log)
+	leader := newLogger("leader", leaderDB, nil)
+	defer leader.Close()
+
+	forwarded := make(chan *events.RASEvent, 1)
+	follower := newLogger("follower", &followerAuditDB{},
+		events.HandlerFunc(func(_ context.Context, evt *events.RASEvent) {
+			forwarded <- evt
+		}))
+	defer follower.Close()
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	rec := testAuditRecord("/mgmt.MgmtSvc/PoolCreate", start)
+	follower.record(rec)
+
+	var evt *events.RASEvent
+	select {
+	case evt = <-forwarded:
+	case <-time.After(time.Second):
+		t.Fatal("audit record not forwarded")
+	}
+	common.AssertEqual(t, events.RASAuditRecord, evt.ID, "unexpected event ID")
+
+	// events received by the leader are added to the system database
+	leader.OnEvent(context.Background(), events.NewGenericEvent(events.RASEngineDied,
+		events.RASSeverityError, "engine died", ""))
+	leader.OnEvent(context.Background(), evt.WithForwarded(true))
+
+	records, err := leaderDB.AuditRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*system.AuditRecord{rec}, records); diff != "" {
+		t.Fatalf("unexpected records (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,96 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// certCheckInterval is the period between checks for expiring certificates.
+const certCheckInterval = 12 * time.Hour
+
+func newCertExpiringEvent(hostname string, ci *security.CertificateInfo, now time.Time) *events.RASEvent {
+	var msg string
+	if ci.NotAfter.After(now) {
+		days := int(math.Ceil(ci.NotAfter.Sub(now).Hours() / 24))
+		msg = fmt.Sprintf("%s certificate %s expires in %d day(s) on %s",
+			ci.Name, ci.Path, days, ci.NotAfter.Format(time.RFC3339))
+	} else {
+		msg = fmt.Sprintf("%s certificate %s expired on %s",
+			ci.Name, ci.Path, ci.NotAfter.Format(time.RFC3339))
+	}
+
+	evt := events.NewGenericEvent(events.RASCertExpiring, events.RASSeverityWarning, msg,
+		fmt.Sprintf("subject: %s", ci.Subject))
+	evt.Hostname = hostname
+
+	return evt
+}
+
+// checkCertExpiry publishes an event for each certificate in the transport
+// config which will expire within the configured warning period.
+func checkCertExpiry(log logging.Logger, hostname string, tc *security.TransportConfig, publisher events.Publisher, now time.Time) {
+	expiring, err := tc.ExpiringCertificates(now)
+	if err != nil {
+		log.Errorf("failed to check certificate expiry: %s", err)
+	}
+
+	for _, ci := range expiring {
+		evt := newCertExpiringEvent(hostname, ci, now)
+		log.Info(evt.Msg)
+		publisher.Publish(evt)
+	}
+}
+
+// startCertMonitor periodically checks for certificates approaching expiry
+// until the context is canceled.
+func (srv *server) startCertMonitor(ctx context.Context) {
+	tc := srv.cfg.TransportConfig
+	if tc == nil || tc.AllowInsecure {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(certCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			checkCertExpiry(srv.log, srv.hostname, tc, srv.pubSub, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reloadCerts reloads the certificates referenced by the transport config
+// so that they are used for new connections, then checks their expiry.
+func (srv *server) reloadCerts() error {
+	tc := srv.cfg.TransportConfig
+	if tc == nil || tc.AllowInsecure {
+		return nil
+	}
+
+	if err := tc.ReloadCertData(); err != nil {
+		return err
+	}
+	srv.log.Info("reloaded certificates")
+
+	checkCertExpiry(srv.log, srv.hostname, tc, srv.pubSub, time.Now())
+
+	return nil
+}
@@ -0,0 +1,93 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const testCertDir = "../security/testdata/certs/"
+
+// testCertTransportConfig returns a transport config referencing test
+// certificates. The CA certificate expires 2019-07-05 and the agent
+// certificate 2029-07-15.
+func testCertTransportConfig() *security.TransportConfig {
+	return &security.TransportConfig{
+		CertificateConfig: security.CertificateConfig{
+			CARootPath:      testCertDir + "daosCA.crt",
+			CertificatePath: testCertDir + "agent.crt",
+			PrivateKeyPath:  testCertDir + "agent.key",
+		},
+	}
+}
+
+type mockPublisher struct {
+	published []*events.RASEvent
+}
+
+func (mp *mockPublisher) Publish(evt *events.RASEvent) {
+	mp.published = append(mp.published, evt)
+}
+
+func TestServer_checkCertExpiry(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tc     *security.TransportConfig
+		now    time.Time
+		expMsg []string
+	}{
+		"insecure": {
+			tc:  &security.TransportConfig{AllowInsecure: true},
+			now: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"none expiring": {
+			tc:  testCertTransportConfig(),
+			now: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"one expiring": {
+			tc:  testCertTransportConfig(),
+			now: time.Date(2019, time.June, 25, 0, 0, 0, 0, time.UTC),
+			expMsg: []string{
+				"ca_cert certificate " + testCertDir + "daosCA.crt expires in 11 day(s) on 2019-07-05T21:50:46Z",
+			},
+		},
+		"one expired one expiring": {
+			tc:  testCertTransportConfig(),
+			now: time.Date(2029, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expMsg: []string{
+				"ca_cert certificate " + testCertDir + "daosCA.crt expired on 2019-07-05T21:50:46Z",
+				"cert certificate " + testCertDir + "agent.crt expires in 15 day(s) on 2029-07-15T20:34:21Z",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mp := &mockPublisher{}
+			checkCertExpiry(log, "foo", tc.tc, mp, tc.now.Local())
+
+			var gotMsg []string
+			for _, evt := range mp.published {
+				common.AssertEqual(t, events.RASCertExpiring, evt.ID, "event ID")
+				common.AssertEqual(t, events.RASSeverityWarning, evt.Severity, "event severity")
+				common.AssertEqual(t, "foo", evt.Hostname, "event hostname")
+				gotMsg = append(gotMsg, evt.Msg)
+			}
+			if diff := cmp.Diff(tc.expMsg, gotMsg); diff != "" {
+				t.Fatalf("unexpected events (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
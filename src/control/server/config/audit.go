@@ -0,0 +1,44 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import "github.com/pkg/errors"
+
+const (
+	defaultAuditMaxSize    = 100 // MiB
+	defaultAuditMaxBackups = 5
+)
+
+// AuditConfig describes the audit log of mutating control-plane operations.
+type AuditConfig struct {
+	LogFile    string `yaml:"log_file"`
+	MaxSize    int    `yaml:"max_size,omitempty"`
+	MaxBackups int    `yaml:"max_backups,omitempty"`
+	SystemDB   bool   `yaml:"system_db,omitempty"`
+}
+
+// Validate checks the audit config values and sets defaults for any that are
+// unset.
+func (ac *AuditConfig) Validate() error {
+	if ac == nil {
+		return errors.New("nil AuditConfig")
+	}
+	if ac.LogFile == "" {
+		return errors.New("log_file must be set")
+	}
+	if ac.MaxSize < 0 || ac.MaxBackups < 0 {
+		return errors.New("max_size and max_backups must not be negative")
+	}
+
+	if ac.MaxSize == 0 {
+		ac.MaxSize = defaultAuditMaxSize
+	}
+	if ac.MaxBackups == 0 {
+		ac.MaxBackups = defaultAuditMaxBackups
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCrashLogTailLines = 1000
+	defaultCrashMaxArchives  = 5
+)
+
+// CrashCollectorConfig describes the collection of artifacts after an
+// abnormal engine exit.
+type CrashCollectorConfig struct {
+	Dir          string `yaml:"dir"`
+	CorePattern  string `yaml:"core_pattern,omitempty"`
+	LogTailLines int    `yaml:"log_tail_lines,omitempty"`
+	MaxArchives  int    `yaml:"max_archives,omitempty"`
+}
+
+// Validate checks the crash collector config values and sets defaults for
+// any that are unset.
+func (cc *CrashCollectorConfig) Validate() error {
+	if cc == nil {
+		return errors.New("nil CrashCollectorConfig")
+	}
+	if cc.Dir == "" {
+		return errors.New("dir must be set")
+	}
+	if cc.CorePattern != "" {
+		if _, err := filepath.Match(cc.CorePattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid core_pattern %q", cc.CorePattern)
+		}
+	}
+	if cc.LogTailLines < 0 || cc.MaxArchives < 0 {
+		return errors.New("log_tail_lines and max_archives must not be negative")
+	}
+
+	if cc.LogTailLines == 0 {
+		cc.LogTailLines = defaultCrashLogTailLines
+	}
+	if cc.MaxArchives == 0 {
+		cc.MaxArchives = defaultCrashMaxArchives
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Diff returns the config file paths of the parameters which differ between
+// the config and the supplied config, e.g. "control_log_mask" or
+// "engines[1].log_mask". Values which are not read from the config file are
+// ignored.
+func (cfg *Server) Diff(other *Server) []string {
+	var paths []string
+	for _, fd := range cfg.DiffFields(other) {
+		paths = append(paths, fd.Field)
+	}
+
+	return paths
+}
+
+// FieldDiff describes a config parameter which differs between two configs.
+type FieldDiff struct {
+	Field string // config file path of the parameter
+	Value string // value in the config
+	Other string // value in the supplied config
+}
+
+// DiffFields returns the parameters which differ between the config and the
+// supplied config along with their formatted values.
+func (cfg *Server) DiffFields(other *Server) []FieldDiff {
+	if cfg == nil || other == nil {
+		return nil
+	}
+
+	var diffs []FieldDiff
+	diffValues("", reflect.ValueOf(cfg).Elem(), reflect.ValueOf(other).Elem(), &diffs)
+
+	return diffs
+}
+
+// formatValue returns a printable representation of a config value.
+func formatValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Ptr, reflect.Struct:
+			return fmt.Sprintf("%d entries", v.Len())
+		}
+	case reflect.Struct:
+		return "set"
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// yamlFieldName returns the key used for the struct field in the config file,
+// or whether the field is inlined into its parent or not serialized.
+func yamlFieldName(field reflect.StructField) (name string, inline, skip bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			return "", true, false
+		}
+	}
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), false, false
+	}
+
+	return parts[0], false, false
+}
+
+func diffValues(path string, a, b reflect.Value, diffs *[]FieldDiff) {
+	addDiff := func() {
+		*diffs = append(*diffs, FieldDiff{
+			Field: path,
+			Value: formatValue(a),
+			Other: formatValue(b),
+		})
+	}
+
+	switch a.Kind() {
+	case reflect.Func, reflect.Chan:
+		return
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				addDiff()
+			}
+			return
+		}
+		diffValues(path, a.Elem(), b.Elem(), diffs)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" {
+				continue // unexported
+			}
+
+			name, inline, skip := yamlFieldName(field)
+			if skip {
+				continue
+			}
+			fieldPath := path
+			if !inline {
+				fieldPath = name
+				if path != "" {
+					fieldPath = path + "." + name
+				}
+			}
+			diffValues(fieldPath, a.Field(i), b.Field(i), diffs)
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Len() == 0 && b.Len() == 0 {
+			return // treat nil and empty as equal
+		}
+		elemKind := a.Type().Elem().Kind()
+		if a.Len() != b.Len() || (elemKind != reflect.Ptr && elemKind != reflect.Struct) {
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				addDiff()
+			}
+			return
+		}
+		for i := 0; i < a.Len(); i++ {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i), diffs)
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			addDiff()
+		}
+	}
+}
@@ -0,0 +1,101 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// hostEnvVars are the engine environment variables which name host devices,
+// e.g. the fabric domain added to the engine config at startup for verbs
+// providers.
+var hostEnvVars = map[string]bool{
+	"OFI_DOMAIN": true,
+}
+
+// Normalize returns a copy of the config with the parameters which are
+// expected to vary between hosts in a system removed, e.g. fabric interfaces
+// and domains, NUMA and core pinning, storage devices and mounts, log and
+// certificate paths. The remaining parameters should match on all hosts in
+// the system.
+func (cfg *Server) Normalize() (*Server, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal server config")
+	}
+	norm := new(Server)
+	if err := yaml.Unmarshal(data, norm); err != nil {
+		return nil, errors.Wrap(err, "unmarshal server config")
+	}
+
+	norm.Servers = nil
+	norm.BdevInclude = nil
+	norm.BdevExclude = nil
+	norm.NrHugepages = 0
+	norm.ControlLogFile = ""
+	norm.HelperLogFile = ""
+	norm.FWHelperLogFile = ""
+	norm.FaultPath = ""
+	if norm.TransportConfig != nil {
+		norm.TransportConfig.CertificateConfig = security.CertificateConfig{}
+	}
+	if norm.AuditConfig != nil {
+		norm.AuditConfig.LogFile = ""
+	}
+	if norm.CrashCollector != nil {
+		norm.CrashCollector.Dir = ""
+	}
+	norm.Fabric.Interface = ""
+	norm.Fabric.InterfacePort = 0
+	norm.Fabric.PinnedNumaNode = nil
+
+	for _, ec := range norm.Engines {
+		ec.Rank = nil
+		ec.ServiceThreadCore = 0
+		ec.LogFile = ""
+		ec.Fabric.Interface = ""
+		ec.Fabric.InterfacePort = 0
+		ec.Fabric.PinnedNumaNode = nil
+		var envVars []string
+		for _, ev := range ec.EnvVars {
+			if !hostEnvVars[strings.SplitN(ev, "=", 2)[0]] {
+				envVars = append(envVars, ev)
+			}
+		}
+		ec.EnvVars = envVars
+		for _, tier := range ec.Storage.Tiers {
+			tier.Scm.MountPoint = ""
+			tier.Scm.DeviceList = nil
+			tier.Bdev.DeviceList = nil
+		}
+	}
+
+	return norm, nil
+}
+
+// Fingerprint returns the normalized config serialized as YAML along with
+// its hash. Servers in a system with matching fingerprints are configured
+// consistently.
+func (cfg *Server) Fingerprint() (hash string, data []byte, err error) {
+	norm, err := cfg.Normalize()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err = yaml.Marshal(norm)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "marshal normalized config")
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(data)), data, nil
+}
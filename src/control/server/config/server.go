@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
@@ -33,6 +34,10 @@ const (
 	defaultConfigPath   = "../etc/daos_server.yml"
 	configOut           = ".daos_server.active.yml"
 	relConfExamplesPath = "../utils/config/examples/"
+
+	// DefaultShutdownDrainTimeout is the default time allowed for engines
+	// to be drained before they are stopped on server shutdown.
+	DefaultShutdownDrainTimeout = 30 * time.Second
 )
 
 type networkProviderValidation func(context.Context, string, string) error
@@ -43,8 +48,12 @@ type networkDeviceClass func(string) (uint32, error)
 // See utils/config/daos_server.yml for parameter descriptions.
 type Server struct {
 	// control-specific
-	ControlPort     int                       `yaml:"port"`
-	TransportConfig *security.TransportConfig `yaml:"transport_config"`
+	ControlPort         int                           `yaml:"port"`
+	TransportConfig     *security.TransportConfig     `yaml:"transport_config"`
+	CredentialConfig    *security.CredentialConfig    `yaml:"credential_config,omitempty"`
+	AuthorizationConfig *security.AuthorizationConfig `yaml:"authorization_config,omitempty"`
+	AuditConfig         *AuditConfig                  `yaml:"audit_config,omitempty"`
+	CrashCollector      *CrashCollectorConfig         `yaml:"crash_collector,omitempty"`
 	// Detect outdated "servers" config, to direct users to change their config file
 	Servers             []*engine.Config `yaml:"servers,omitempty"`
 	Engines             []*engine.Config `yaml:"engines"`
@@ -61,6 +70,10 @@ type Server struct {
 	RecreateSuperblocks bool             `yaml:"recreate_superblocks"`
 	FaultPath           string           `yaml:"fault_path"`
 	TelemetryPort       int              `yaml:"telemetry_port"`
+	// log dRPC calls that take longer than this (0 disables)
+	DrpcSlowCallThreshold time.Duration `yaml:"drpc_slow_call_threshold,omitempty"`
+	// time allowed to drain engines on shutdown (0 disables draining)
+	ShutdownDrainTimeout time.Duration `yaml:"shutdown_drain_timeout"`
 
 	// duplicated in engine.Config
 	SystemName string              `yaml:"name"`
@@ -213,6 +226,43 @@ func (cfg *Server) WithTransportConfig(cfgTransport *security.TransportConfig) *
 	return cfg
 }
 
+// WithCredentialConfig sets the client credential validation configuration.
+func (cfg *Server) WithCredentialConfig(cfgCred *security.CredentialConfig) *Server {
+	cfg.CredentialConfig = cfgCred
+	return cfg
+}
+
+// WithCertExpiryWarnDays sets the number of days before certificate expiry
+// that warnings will be raised.
+func (cfg *Server) WithCertExpiryWarnDays(days int) *Server {
+	cfg.TransportConfig.CertExpiryWarnDays = days
+	return cfg
+}
+
+// WithAuthorizationConfig sets the role-based authorization configuration.
+func (cfg *Server) WithAuthorizationConfig(cfgAuth *security.AuthorizationConfig) *Server {
+	cfg.AuthorizationConfig = cfgAuth
+	return cfg
+}
+
+// WithAuditConfig sets the audit log configuration.
+func (cfg *Server) WithAuditConfig(cfgAudit *AuditConfig) *Server {
+	cfg.AuditConfig = cfgAudit
+	return cfg
+}
+
+// WithCrashCollector sets the engine crash artifact collection configuration.
+func (cfg *Server) WithCrashCollector(cfgCrash *CrashCollectorConfig) *Server {
+	cfg.CrashCollector = cfgCrash
+	return cfg
+}
+
+// WithCRLPath sets the path of the certificate revocation list.
+func (cfg *Server) WithCRLPath(path string) *Server {
+	cfg.TransportConfig.CRLPath = path
+	return cfg
+}
+
 // WithFaultPath sets the fault path (identification string e.g. rack/shelf/node).
 func (cfg *Server) WithFaultPath(fp string) *Server {
 	cfg.FaultPath = fp
@@ -294,6 +344,20 @@ func (cfg *Server) WithFirmwareHelperLogFile(filePath string) *Server {
 	return cfg
 }
 
+// WithDrpcSlowCallThreshold sets the duration above which dRPC calls are
+// logged as slow.
+func (cfg *Server) WithDrpcSlowCallThreshold(threshold time.Duration) *Server {
+	cfg.DrpcSlowCallThreshold = threshold
+	return cfg
+}
+
+// WithShutdownDrainTimeout sets the time allowed for engines to be drained
+// before they are stopped on server shutdown.
+func (cfg *Server) WithShutdownDrainTimeout(timeout time.Duration) *Server {
+	cfg.ShutdownDrainTimeout = timeout
+	return cfg
+}
+
 // WithTelemetryPort sets the port for the telemetry exporter.
 func (cfg *Server) WithTelemetryPort(port int) *Server {
 	cfg.TelemetryPort = port
@@ -309,6 +373,7 @@ func DefaultServer() *Server {
 		AccessPoints:       []string{fmt.Sprintf("localhost:%d", build.DefaultControlPort)},
 		ControlPort:        build.DefaultControlPort,
 		TransportConfig:    security.DefaultServerTransportConfig(),
+		CredentialConfig:   security.DefaultCredentialConfig(),
 		Hyperthreads:       false,
 		Path:               defaultConfigPath,
 		ControlLogMask:     ControlLogLevel(logging.LogLevelInfo),
@@ -316,6 +381,8 @@ func DefaultServer() *Server {
 		validateNUMAFn:     netdetect.ValidateNUMAStub,
 		GetDeviceClassFn:   netdetect.GetDeviceClass,
 		EnableVMD:          false, // disabled by default
+
+		ShutdownDrainTimeout: DefaultShutdownDrainTimeout,
 	}
 }
 
@@ -492,6 +559,37 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 		return FaultConfigBadTelemetryPort
 	}
 
+	if cfg.DrpcSlowCallThreshold < 0 {
+		return errors.New("invalid drpc_slow_call_threshold: must not be negative")
+	}
+	if cfg.ShutdownDrainTimeout < 0 {
+		return errors.New("invalid shutdown_drain_timeout: must not be negative")
+	}
+
+	if cfg.CredentialConfig != nil {
+		if err := cfg.CredentialConfig.Validate(); err != nil {
+			return errors.Wrap(err, "invalid credential_config")
+		}
+	}
+
+	if cfg.AuthorizationConfig != nil {
+		if err := cfg.AuthorizationConfig.Validate(); err != nil {
+			return errors.Wrap(err, "invalid authorization_config")
+		}
+	}
+
+	if cfg.AuditConfig != nil {
+		if err := cfg.AuditConfig.Validate(); err != nil {
+			return errors.Wrap(err, "invalid audit_config")
+		}
+	}
+
+	if cfg.CrashCollector != nil {
+		if err := cfg.CrashCollector.Validate(); err != nil {
+			return errors.Wrap(err, "invalid crash_collector")
+		}
+	}
+
 	// Update access point addresses with control port if port is not
 	// supplied.
 	newAPs := make([]string, 0, len(cfg.AccessPoints))
@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -224,6 +225,8 @@ func TestServerConfig_Constructed(t *testing.T) {
 		WithControlLogFile("/tmp/daos_server.log").
 		WithHelperLogFile("/tmp/daos_admin.log").
 		WithFirmwareHelperLogFile("/tmp/daos_firmware.log").
+		WithDrpcSlowCallThreshold(time.Second).
+		WithShutdownDrainTimeout(time.Minute).
 		WithSystemName("daos_server").
 		WithSocketDir("./.daos/daos_server").
 		WithFabricProvider("ofi+verbs;ofi_rxm").
@@ -232,6 +235,30 @@ func TestServerConfig_Constructed(t *testing.T) {
 		WithAccessPoints("hostname1").
 		WithFaultCb("./.daos/fd_callback").
 		WithFaultPath("/vcdu0/rack1/hostname").
+		WithCredentialConfig(&security.CredentialConfig{
+			Validity:  10 * time.Minute,
+			ClockSkew: time.Minute,
+		}).
+		WithAuthorizationConfig(&security.AuthorizationConfig{
+			RequireRoles: true,
+			Roles: map[string][]string{
+				"helpdesk": {"SystemQuery", "PoolQuery"},
+			},
+		}).
+		WithAuditConfig(&AuditConfig{
+			LogFile:    "/var/log/daos/daos_audit.log",
+			MaxSize:    50,
+			MaxBackups: 10,
+			SystemDB:   true,
+		}).
+		WithCrashCollector(&CrashCollectorConfig{
+			Dir:          "/var/lib/daos/crash",
+			CorePattern:  "/var/crash/core.daos_engine.*",
+			LogTailLines: 500,
+			MaxArchives:  10,
+		}).
+		WithCertExpiryWarnDays(14).
+		WithCRLPath("/etc/daos/certs/daosCA.crl").
 		WithHyperthreads(true). // hyper-threads disabled by default
 		WithProviderValidator(netdetect.ValidateProviderStub).
 		WithNUMAValidator(netdetect.ValidateNUMAStub).
@@ -256,6 +283,13 @@ func TestServerConfig_Constructed(t *testing.T) {
 				WithPinnedNumaNode(&numaNode0).
 				WithBypassHealthChk(&bypass).
 				WithEnvVars("CRT_TIMEOUT=30").
+				WithRestartPolicy(&engine.RestartPolicy{
+					Policy:      engine.RestartPolicyOnFailure,
+					MaxAttempts: 5,
+					Backoff:     5 * time.Second,
+					MaxBackoff:  5 * time.Minute,
+					ResetWindow: 10 * time.Minute,
+				}).
 				WithLogFile("/tmp/daos_engine.0.log").
 				WithLogMask("WARN"),
 			engine.NewConfig().
@@ -427,6 +461,23 @@ func TestServerConfig_Validation(t *testing.T) {
 			},
 			expErr: FaultConfigBadTelemetryPort,
 		},
+		"bad dRPC slow call threshold (negative)": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDrpcSlowCallThreshold(-time.Second)
+			},
+			expErr: errors.New("drpc_slow_call_threshold"),
+		},
+		"shutdown draining disabled": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithShutdownDrainTimeout(0)
+			},
+		},
+		"bad shutdown drain timeout (negative)": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithShutdownDrainTimeout(-time.Second)
+			},
+			expErr: errors.New("shutdown_drain_timeout"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -1003,3 +1054,196 @@ func TestServerConfig_SaveActiveConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestServerConfig_Diff(t *testing.T) {
+	baseCfg := func() *Server {
+		return DefaultServer().
+			WithAccessPoints("hostA:10001").
+			WithControlLogMask(ControlLogLevelInfo).
+			WithFabricProvider("ofi+verbs").
+			WithEngines(
+				engine.NewConfig().
+					WithLogMask("ERR").
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass("ram").
+							WithScmMountPoint("/mnt/daos0"),
+					),
+				engine.NewConfig().
+					WithLogMask("ERR").
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass("ram").
+							WithScmMountPoint("/mnt/daos1"),
+					),
+			)
+	}
+
+	for name, tc := range map[string]struct {
+		modify   func(*Server)
+		expDiffs []string
+	}{
+		"identical": {
+			modify: func(*Server) {},
+		},
+		"top-level values": {
+			modify: func(cfg *Server) {
+				cfg.WithControlLogMask(ControlLogLevelDebug).
+					WithTelemetryPort(9191).
+					WithDrpcSlowCallThreshold(time.Second)
+			},
+			expDiffs: []string{"control_log_mask", "telemetry_port", "drpc_slow_call_threshold"},
+		},
+		"access points": {
+			modify: func(cfg *Server) {
+				cfg.WithAccessPoints("hostA:10001", "hostB:10001", "hostC:10001")
+			},
+			expDiffs: []string{"access_points"},
+		},
+		"inline fabric values": {
+			modify: func(cfg *Server) {
+				cfg.Fabric.Provider = "ofi+tcp"
+			},
+			expDiffs: []string{"provider"},
+		},
+		"nested pointer config": {
+			modify: func(cfg *Server) {
+				cfg.WithAuditConfig(&AuditConfig{LogFile: "/tmp/audit.log"})
+			},
+			expDiffs: []string{"audit_config"},
+		},
+		"engine values": {
+			modify: func(cfg *Server) {
+				cfg.Engines[1].WithLogMask("DEBUG")
+				cfg.Engines[0].Storage.Tiers[0].WithScmMountPoint("/mnt/other")
+			},
+			expDiffs: []string{"engines[0].storage[0].scm_mount", "engines[1].log_mask"},
+		},
+		"engine removed": {
+			modify: func(cfg *Server) {
+				cfg.Engines = cfg.Engines[:1]
+			},
+			expDiffs: []string{"engines"},
+		},
+		"values not from file ignored": {
+			modify: func(cfg *Server) {
+				cfg.WithGetNetworkDeviceClass(getDeviceClassStub)
+				cfg.Engines[0].Index = 5
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			newCfg := baseCfg()
+			tc.modify(newCfg)
+
+			gotDiffs := baseCfg().Diff(newCfg)
+			if diff := cmp.Diff(tc.expDiffs, gotDiffs, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("unexpected diffs (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServerConfig_DiffFields(t *testing.T) {
+	cfg := DefaultServer().
+		WithFabricProvider("ofi+verbs").
+		WithEngines(engine.NewConfig().WithTargetCount(8))
+	other := DefaultServer().
+		WithFabricProvider("ofi+tcp").
+		WithEngines(
+			engine.NewConfig().WithTargetCount(16),
+			engine.NewConfig().WithTargetCount(16),
+		)
+
+	expDiffs := []FieldDiff{
+		{Field: "engines", Value: "1 entries", Other: "2 entries"},
+		{Field: "provider", Value: "ofi+verbs", Other: "ofi+tcp"},
+	}
+	if diff := cmp.Diff(expDiffs, cfg.DiffFields(other)); diff != "" {
+		t.Fatalf("unexpected diffs (-want, +got):\n%s\n", diff)
+	}
+
+	other.Engines = other.Engines[:1]
+	expDiffs = []FieldDiff{
+		{Field: "engines[0].targets", Value: "8", Other: "16"},
+		{Field: "engines[0].provider", Value: "ofi+verbs", Other: "ofi+tcp"},
+		{Field: "provider", Value: "ofi+verbs", Other: "ofi+tcp"},
+	}
+	if diff := cmp.Diff(expDiffs, cfg.DiffFields(other)); diff != "" {
+		t.Fatalf("unexpected diffs (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServerConfig_Fingerprint(t *testing.T) {
+	numa0, numa1 := uint(0), uint(1)
+	hostCfg := func(iface, domain string, numa *uint, mount, bdev string) *Server {
+		return DefaultServer().
+			WithSystemName("daos_server").
+			WithFabricProvider("ofi+verbs").
+			WithAccessPoints("hostA:10001").
+			WithControlLogFile("/tmp/" + iface + ".log").
+			WithEngines(
+				engine.NewConfig().
+					WithTargetCount(8).
+					WithHelperStreamCount(2).
+					WithFabricInterface(iface).
+					WithFabricInterfacePort(31416).
+					WithPinnedNumaNode(numa).
+					WithServiceThreadCore(1).
+					WithEnvVars("FI_OFI_RXM_USE_SRX=1", "OFI_DOMAIN="+domain).
+					WithLogFile("/tmp/"+iface+"_engine.log").
+					WithStorage(
+						storage.NewTierConfig().
+							WithScmClass("dcpm").
+							WithScmMountPoint(mount).
+							WithScmDeviceList("/dev/"+mount),
+						storage.NewTierConfig().
+							WithBdevClass("nvme").
+							WithBdevDeviceList(bdev),
+					),
+			)
+	}
+
+	for name, tc := range map[string]struct {
+		cfg      *Server
+		expMatch bool
+	}{
+		"host-specific values differ": {
+			cfg:      hostCfg("ib1", "mlx5_1", &numa1, "pmem1", "0000:d8:00.0"),
+			expMatch: true,
+		},
+		"target count differs": {
+			cfg: func() *Server {
+				cfg := hostCfg("ib0", "mlx5_0", &numa0, "pmem0", "0000:81:00.0")
+				cfg.Engines[0].WithTargetCount(16)
+				return cfg
+			}(),
+		},
+		"env vars differ": {
+			cfg: func() *Server {
+				cfg := hostCfg("ib0", "mlx5_0", &numa0, "pmem0", "0000:81:00.0")
+				cfg.Engines[0].WithEnvVars("FI_OFI_RXM_USE_SRX=0")
+				return cfg
+			}(),
+		},
+		"system name differs": {
+			cfg: hostCfg("ib0", "mlx5_0", &numa0, "pmem0", "0000:81:00.0").
+				WithSystemName("other"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			refHash, refData, err := hostCfg("ib0", "mlx5_0", &numa0, "pmem0", "0000:81:00.0").Fingerprint()
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotHash, gotData, err := tc.cfg.Fingerprint()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			common.AssertEqual(t, tc.expMatch, refHash == gotHash, "unexpected hash match")
+			common.AssertEqual(t, tc.expMatch, string(refData) == string(gotData),
+				"unexpected normalized config match")
+		})
+	}
+}
@@ -0,0 +1,162 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const configBackupSuffix = ".bak"
+
+type checkHardwareFn func(context.Context, *config.Server) error
+
+// checkConfigHardware verifies that the fabric interfaces referenced by the
+// config exist on this host and support the configured provider and NUMA node.
+func checkConfigHardware(ctx context.Context, cfg *config.Server) error {
+	if len(cfg.Engines) == 0 {
+		return nil
+	}
+
+	lookupNetIF := func(name string) (netInterface, error) {
+		return net.InterfaceByName(name)
+	}
+	for _, ec := range cfg.Engines {
+		if err := checkFabricInterface(ec.Fabric.Interface, lookupNetIF); err != nil {
+			return err
+		}
+	}
+
+	ctx, err := netdetect.Init(ctx)
+	if err != nil {
+		return err
+	}
+	defer netdetect.CleanUp(ctx)
+
+	cfg.WithProviderValidator(netdetect.ValidateProviderConfig).
+		WithNUMAValidator(netdetect.ValidateNUMAConfig)
+	if _, err := cfg.CheckFabric(ctx); err != nil {
+		return errors.Wrap(err, "validate fabric config")
+	}
+
+	return nil
+}
+
+// writeFileSync writes data to the named file and flushes it to disk.
+func writeFileSync(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// setRestarter registers the function used to restart the server once a new
+// config has been applied.
+func (r *configReloader) setRestarter(fn func()) {
+	r.Lock()
+	defer r.Unlock()
+	r.restart = fn
+}
+
+// apply validates a candidate config file and, unless a dry run is requested,
+// replaces the server config file with it. The candidate is written alongside
+// the config file and renamed into place so the config file is never partially
+// written, the previous file is kept with a backup suffix. Changes take effect
+// when the server is restarted or the config is reloaded.
+func (r *configReloader) apply(ctx context.Context, req *ctlpb.ApplyConfigReq) (*ctlpb.ApplyConfigResp, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	path := r.cfg.Path
+	if path == "" {
+		return nil, errors.New("server config file path unknown")
+	}
+	if len(req.GetConfig()) == 0 {
+		return nil, errors.New("empty config")
+	}
+	if req.GetRestart() && !req.GetDryRun() && r.restart == nil {
+		return nil, errors.New("server restart not available")
+	}
+
+	perm := os.FileMode(0644)
+	if fi, err := os.Stat(path); err == nil {
+		perm = fi.Mode().Perm()
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return nil, errors.Wrap(err, "create candidate config file")
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := writeFileSync(tmpPath, req.GetConfig(), perm); err != nil {
+		return nil, errors.Wrap(err, "write candidate config file")
+	}
+
+	newCfg, err := r.loadConfig(r.log, tmpPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "candidate config")
+	}
+	if err := r.checkHardware(ctx, newCfg); err != nil {
+		return nil, errors.Wrap(err, "candidate config")
+	}
+	newCfg.Path = path // candidate will replace the config file
+
+	resp := &ctlpb.ApplyConfigResp{
+		Path:    path,
+		Changes: r.cfg.Diff(newCfg),
+	}
+	if req.GetDryRun() {
+		r.log.Infof("candidate config validated, %d parameters differ from %s",
+			len(resp.Changes), path)
+		return resp, nil
+	}
+
+	if data, err := ioutil.ReadFile(path); err == nil {
+		backupPath := path + configBackupSuffix
+		if err := writeFileSync(backupPath, data, perm); err != nil {
+			return nil, errors.Wrap(err, "back up config file")
+		}
+		resp.BackupPath = backupPath
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "read config file")
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, errors.Wrap(err, "replace config file")
+	}
+	r.log.Infof("config file %s replaced, %d parameters changed (previous config in %s)",
+		path, len(resp.Changes), resp.BackupPath)
+
+	if req.GetRestart() {
+		resp.Restarting = true
+		go r.restart()
+	}
+
+	return resp, nil
+}
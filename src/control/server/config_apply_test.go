@@ -0,0 +1,208 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_configReloader_apply(t *testing.T) {
+	const oldContent = "old config"
+
+	for name, tc := range map[string]struct {
+		noFile      bool
+		noRestarter bool
+		req         *ctlpb.ApplyConfigReq
+		loadErr     error
+		hwErr       error
+		modify      func(*config.Server)
+		expResp     func(path string) *ctlpb.ApplyConfigResp
+		expContent  string
+		expBackup   bool
+		expRestart  bool
+		expErr      error
+	}{
+		"empty config": {
+			req:        &ctlpb.ApplyConfigReq{},
+			expContent: oldContent,
+			expErr:     errors.New("empty config"),
+		},
+		"candidate fails to load": {
+			req:        &ctlpb.ApplyConfigReq{Config: []byte("new config")},
+			loadErr:    errors.New("validation failed"),
+			expContent: oldContent,
+			expErr:     errors.New("candidate config: validation failed"),
+		},
+		"candidate fails hardware check": {
+			req:        &ctlpb.ApplyConfigReq{Config: []byte("new config")},
+			hwErr:      errors.New("interface not found"),
+			expContent: oldContent,
+			expErr:     errors.New("candidate config: interface not found"),
+		},
+		"dry run": {
+			req: &ctlpb.ApplyConfigReq{Config: []byte("new config"), DryRun: true},
+			modify: func(cfg *config.Server) {
+				cfg.WithControlLogMask(config.ControlLogLevelDebug)
+			},
+			expResp: func(path string) *ctlpb.ApplyConfigResp {
+				return &ctlpb.ApplyConfigResp{
+					Path:    path,
+					Changes: []string{"control_log_mask"},
+				}
+			},
+			expContent: oldContent,
+		},
+		"applied": {
+			req: &ctlpb.ApplyConfigReq{Config: []byte("new config")},
+			modify: func(cfg *config.Server) {
+				cfg.WithControlPort(10002)
+			},
+			expResp: func(path string) *ctlpb.ApplyConfigResp {
+				return &ctlpb.ApplyConfigResp{
+					Path:       path,
+					BackupPath: path + configBackupSuffix,
+					Changes:    []string{"port"},
+				}
+			},
+			expContent: "new config",
+			expBackup:  true,
+		},
+		"applied without existing file": {
+			noFile: true,
+			req:    &ctlpb.ApplyConfigReq{Config: []byte("new config")},
+			expResp: func(path string) *ctlpb.ApplyConfigResp {
+				return &ctlpb.ApplyConfigResp{Path: path}
+			},
+			expContent: "new config",
+		},
+		"applied with restart": {
+			req: &ctlpb.ApplyConfigReq{Config: []byte("new config"), Restart: true},
+			expResp: func(path string) *ctlpb.ApplyConfigResp {
+				return &ctlpb.ApplyConfigResp{
+					Path:       path,
+					BackupPath: path + configBackupSuffix,
+					Restarting: true,
+				}
+			},
+			expContent: "new config",
+			expBackup:  true,
+			expRestart: true,
+		},
+		"restart not available": {
+			noRestarter: true,
+			req:         &ctlpb.ApplyConfigReq{Config: []byte("new config"), Restart: true},
+			expContent:  oldContent,
+			expErr:      errors.New("restart not available"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			cfgPath := filepath.Join(testDir, "daos_server.yml")
+			if !tc.noFile {
+				if err := ioutil.WriteFile(cfgPath, []byte(oldContent), 0600); err != nil {
+					t.Fatal(err)
+				}
+				if tc.expContent == "" {
+					tc.expContent = oldContent
+				}
+			}
+
+			cfg := reloadTestConfig()
+			cfg.Path = cfgPath
+			r := newConfigReloader(log, cfg, nil, nil, nil)
+			r.loadConfig = func(_ logging.Logger, path string) (*config.Server, error) {
+				data, err := ioutil.ReadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				common.AssertEqual(t, string(tc.req.Config), string(data),
+					"unexpected candidate config content")
+				if tc.loadErr != nil {
+					return nil, tc.loadErr
+				}
+
+				newCfg := reloadTestConfig()
+				newCfg.Path = path
+				if tc.modify != nil {
+					tc.modify(newCfg)
+				}
+				return newCfg, nil
+			}
+			r.checkHardware = func(context.Context, *config.Server) error {
+				return tc.hwErr
+			}
+			restarted := make(chan struct{}, 1)
+			if !tc.noRestarter {
+				r.setRestarter(func() { restarted <- struct{}{} })
+			}
+
+			gotResp, gotErr := r.apply(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+
+			if tc.expContent != "" {
+				data, err := ioutil.ReadFile(cfgPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				common.AssertEqual(t, tc.expContent, string(data), "unexpected config content")
+			}
+
+			// only the config file and its backup should remain
+			expFiles := 1
+			if tc.expBackup {
+				data, err := ioutil.ReadFile(cfgPath + configBackupSuffix)
+				if err != nil {
+					t.Fatal(err)
+				}
+				common.AssertEqual(t, oldContent, string(data), "unexpected backup content")
+				expFiles++
+			}
+			if tc.noFile && tc.expErr != nil {
+				expFiles = 0
+			}
+			files, err := ioutil.ReadDir(testDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, expFiles, len(files), "unexpected files in config dir")
+
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp(cfgPath), gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+
+			if tc.expRestart {
+				select {
+				case <-restarted:
+				case <-time.After(time.Second):
+					t.Fatal("expected restart")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,513 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// crashArchiveSuffix identifies crash archives in the collector
+	// directory.
+	crashArchiveSuffix = ".tar.gz"
+	// crashTimeFormat is used in crash archive names so that they sort
+	// in order of collection.
+	crashTimeFormat = "20060102-150405.000000"
+	// maxCrashEvents is the number of recent RAS events retained for
+	// inclusion in crash archives.
+	maxCrashEvents = 100
+	// crashChunkSize is the maximum amount of archive data returned in
+	// a single CollectCrash response.
+	crashChunkSize = 1024 * 1024
+)
+
+type (
+	// crashInfo contains the details of an abnormal engine exit that are
+	// captured before artifact collection begins.
+	crashInfo struct {
+		time       time.Time
+		instance   uint32
+		rank       system.Rank
+		pid        uint64
+		exitErr    error
+		startedAt  time.Time
+		logFile    string
+		args       []string
+		env        []string
+		superblock []byte
+	}
+
+	// crashFile is a generated file added to a crash archive.
+	crashFile struct {
+		name string
+		data []byte
+	}
+
+	// crashCollector gathers artifacts into a timestamped archive when an
+	// engine exits abnormally, and serves the archives to administrators.
+	crashCollector struct {
+		log      logging.Logger
+		cfg      *config.CrashCollectorConfig
+		hostname string
+
+		evtLock sync.Mutex
+		events  []*events.RASEvent
+
+		// collecting tracks in-progress collections so that they
+		// can be awaited.
+		collecting sync.WaitGroup
+	}
+)
+
+// newCrashCollector returns a crashCollector storing archives in the
+// directory named in the supplied config.
+func newCrashCollector(log logging.Logger, cfg *config.CrashCollectorConfig, hostname string) (*crashCollector, error) {
+	if cfg == nil {
+		return nil, errors.New("nil CrashCollectorConfig")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create crash archive directory")
+	}
+
+	return &crashCollector{
+		log:      log,
+		cfg:      cfg,
+		hostname: hostname,
+	}, nil
+}
+
+// OnEvent implements the events.Handler interface, retaining recent events
+// for inclusion in crash archives. Events forwarded to the MS leader from
+// other hosts are not retained.
+func (cc *crashCollector) OnEvent(_ context.Context, evt *events.RASEvent) {
+	if evt == nil || evt.IsForwarded() {
+		return
+	}
+
+	cc.evtLock.Lock()
+	defer cc.evtLock.Unlock()
+
+	cc.events = append(cc.events, evt)
+	if excess := len(cc.events) - maxCrashEvents; excess > 0 {
+		cc.events = append([]*events.RASEvent(nil), cc.events[excess:]...)
+	}
+}
+
+// rankEvents returns the retained events for the given rank, or all
+// retained events if the rank is unknown.
+func (cc *crashCollector) rankEvents(rank system.Rank) []*events.RASEvent {
+	cc.evtLock.Lock()
+	defer cc.evtLock.Unlock()
+
+	var evts []*events.RASEvent
+	for _, evt := range cc.events {
+		if rank.Equals(system.NilRank) || evt.Rank == rank.Uint32() {
+			evts = append(evts, evt)
+		}
+	}
+	return evts
+}
+
+// onInstanceExitFn returns onInstanceExitFn which will collect crash
+// artifacts for the given instance if it has exited abnormally. The details
+// of the instance are captured immediately and the artifacts are archived
+// in the background so that the exit is not delayed.
+func (cc *crashCollector) onInstanceExitFn(ei *EngineInstance) onInstanceExitFn {
+	return func(ctx context.Context, engineIdx uint32, rank system.Rank, exitErr error, exPid uint64) error {
+		// Exits during shutdown or after a stop request are expected.
+		if ctx.Err() != nil || ei.isStopRequested() {
+			return nil
+		}
+
+		info, err := ei.crashInfo(engineIdx, rank, exitErr, exPid)
+		if err != nil {
+			return errors.Wrap(err, "crash collection")
+		}
+
+		cc.collecting.Add(1)
+		go func() {
+			defer cc.collecting.Done()
+
+			path, err := cc.collect(info)
+			if err != nil {
+				cc.log.Errorf("instance %d: crash collection failed: %s", engineIdx, err)
+				return
+			}
+			cc.log.Infof("instance %d: crash artifacts collected in %s", engineIdx, path)
+		}()
+
+		return nil
+	}
+}
+
+// crashInfo captures the details of the instance required for crash
+// collection.
+func (ei *EngineInstance) crashInfo(engineIdx uint32, rank system.Rank, exitErr error, exPid uint64) (*crashInfo, error) {
+	info := &crashInfo{
+		time:     time.Now(),
+		instance: engineIdx,
+		rank:     rank,
+		pid:      exPid,
+		exitErr:  exitErr,
+	}
+
+	ei.RLock()
+	info.startedAt = ei._restart.startedAt
+	ei.RUnlock()
+
+	if cfg := ei.runner.GetConfig(); cfg != nil {
+		var err error
+		info.logFile = cfg.LogFile
+		if info.args, err = cfg.CmdLineArgs(); err != nil {
+			return nil, err
+		}
+		if info.env, err = cfg.CmdLineEnv(); err != nil {
+			return nil, err
+		}
+	}
+
+	if sb := ei.getSuperblock(); sb != nil {
+		data, err := sb.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		info.superblock = data
+	}
+
+	return info, nil
+}
+
+// tailFile returns up to the last n lines of the named file.
+func tailFile(path string, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunkSize = 64 * 1024
+	var data []byte
+	offset := fi.Size()
+	for offset > 0 {
+		readSize := int64(chunkSize)
+		if offset < readSize {
+			readSize = offset
+		}
+		offset -= readSize
+
+		chunk := make([]byte, readSize)
+		if _, err := f.ReadAt(chunk, offset); err != nil {
+			return nil, err
+		}
+		data = append(chunk, data...)
+
+		// Ignore the trailing newline of the last line.
+		if bytes.Count(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) >= n {
+			break
+		}
+	}
+
+	trimmed := bytes.TrimSuffix(data, []byte("\n"))
+	for i := len(trimmed) - 1; i >= 0; i-- {
+		if trimmed[i] != '\n' {
+			continue
+		}
+		if n--; n == 0 {
+			return data[i+1:], nil
+		}
+	}
+	return data, nil
+}
+
+// coreFiles returns the files matching the configured core pattern that were
+// modified after the given time.
+func (cc *crashCollector) coreFiles(since time.Time) ([]string, error) {
+	if cc.cfg.CorePattern == "" {
+		return nil, nil
+	}
+
+	matches, err := filepath.Glob(cc.cfg.CorePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var cores []string
+	for _, match := range matches {
+		fi, err := os.Stat(match)
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		if !since.IsZero() && fi.ModTime().Before(since) {
+			continue
+		}
+		cores = append(cores, match)
+	}
+	return cores, nil
+}
+
+func addTarData(tw *tar.Writer, name string, mtime time.Time, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: mtime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// addTarFile adds the contents of the file at path to the archive. If maxSize
+// is non-zero, only the last maxSize bytes of the file are added.
+func addTarFile(tw *tar.Writer, name, path string, maxSize int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := fi.Size()
+	if maxSize > 0 && size > maxSize {
+		if _, err := f.Seek(size-maxSize, io.SeekStart); err != nil {
+			return err
+		}
+		size = maxSize
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: fi.ModTime(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, f, size)
+	return err
+}
+
+// writeArchive writes the crash artifacts to a gzipped tarball.
+func (cc *crashCollector) writeArchive(w io.Writer, info *crashInfo) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "host: %s\n", cc.hostname)
+	fmt.Fprintf(&summary, "instance: %d\n", info.instance)
+	if !info.rank.Equals(system.NilRank) {
+		fmt.Fprintf(&summary, "rank: %d\n", info.rank)
+	}
+	fmt.Fprintf(&summary, "pid: %d\n", info.pid)
+	if !info.startedAt.IsZero() {
+		fmt.Fprintf(&summary, "started: %s\n", info.startedAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&summary, "exited: %s\n", info.time.Format(time.RFC3339))
+	fmt.Fprintf(&summary, "exit status: %s\n", common.GetExitStatus(info.exitErr))
+
+	files := []crashFile{
+		{"summary.txt", []byte(summary.String())},
+		{"cmdline.txt", []byte(strings.Join(info.args, " ") + "\n")},
+		{"environment.txt", []byte(strings.Join(info.env, "\n") + "\n")},
+	}
+	if info.superblock != nil {
+		files = append(files, crashFile{"superblock.yaml", info.superblock})
+	}
+
+	evtData, err := json.MarshalIndent(cc.rankEvents(info.rank), "", "  ")
+	if err != nil {
+		return err
+	}
+	files = append(files, crashFile{"ras_events.json", evtData})
+
+	if info.logFile != "" {
+		logData, err := tailFile(info.logFile, cc.cfg.LogTailLines)
+		switch {
+		case err == nil:
+			files = append(files, crashFile{"engine.log", logData})
+		case os.IsNotExist(err):
+		default:
+			cc.log.Errorf("crash collection: reading engine log: %s", err)
+		}
+	}
+
+	for _, f := range files {
+		if err := addTarData(tw, f.name, info.time, f.data); err != nil {
+			return err
+		}
+	}
+
+	cores, err := cc.coreFiles(info.startedAt)
+	if err != nil {
+		cc.log.Errorf("crash collection: finding core files: %s", err)
+	}
+	for _, core := range cores {
+		if err := addTarFile(tw, filepath.Join("cores", filepath.Base(core)), core, 0); err != nil {
+			return errors.Wrapf(err, "adding core file %s", core)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// collect writes a crash archive for the exited instance and applies the
+// retention limit, returning the path of the new archive.
+func (cc *crashCollector) collect(info *crashInfo) (string, error) {
+	name := fmt.Sprintf("daos_engine%d-crash-%s%s", info.instance,
+		info.time.UTC().Format(crashTimeFormat), crashArchiveSuffix)
+	path := filepath.Join(cc.cfg.Dir, name)
+
+	// Write to a temporary file so that incomplete archives are not
+	// listed.
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	if err := cc.writeArchive(f, info); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", err
+	}
+
+	if err := cc.prune(); err != nil {
+		cc.log.Errorf("crash collection: removing old archives: %s", err)
+	}
+
+	return path, nil
+}
+
+// archives returns the crash archives in the collector directory, oldest
+// first.
+func (cc *crashCollector) archives() ([]*ctlpb.CrashArchive, error) {
+	entries, err := ioutil.ReadDir(cc.cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var archives []*ctlpb.CrashArchive
+	for _, fi := range entries {
+		if !fi.Mode().IsRegular() || !strings.HasSuffix(fi.Name(), crashArchiveSuffix) {
+			continue
+		}
+		archives = append(archives, &ctlpb.CrashArchive{
+			Name: fi.Name(),
+			Time: fi.ModTime().UnixNano(),
+			Size: uint64(fi.Size()),
+		})
+	}
+	sort.SliceStable(archives, func(i, j int) bool {
+		if archives[i].Time == archives[j].Time {
+			return archives[i].Name < archives[j].Name
+		}
+		return archives[i].Time < archives[j].Time
+	})
+
+	return archives, nil
+}
+
+// prune removes the oldest archives in excess of the configured limit.
+func (cc *crashCollector) prune() error {
+	archives, err := cc.archives()
+	if err != nil {
+		return err
+	}
+
+	for len(archives) > cc.cfg.MaxArchives {
+		if err := os.Remove(filepath.Join(cc.cfg.Dir, archives[0].Name)); err != nil {
+			return err
+		}
+		archives = archives[1:]
+	}
+	return nil
+}
+
+// fetch returns the details of the named archive, or the most recent if no
+// name is supplied, and a chunk of its contents starting at offset.
+func (cc *crashCollector) fetch(name string, offset uint64) (*ctlpb.CrashArchive, []byte, error) {
+	archives, err := cc.archives()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(archives) == 0 {
+		return nil, nil, errors.New("no crash archives found")
+	}
+
+	archive := archives[len(archives)-1]
+	if name != "" {
+		archive = nil
+		for _, a := range archives {
+			if a.Name == name {
+				archive = a
+				break
+			}
+		}
+		if archive == nil {
+			return nil, nil, errors.Errorf("crash archive %q not found", name)
+		}
+	}
+	if offset > archive.Size {
+		return nil, nil, errors.Errorf("offset %d beyond end of crash archive %q", offset, archive.Name)
+	}
+
+	f, err := os.Open(filepath.Join(cc.cfg.Dir, archive.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	size := archive.Size - offset
+	if size > crashChunkSize {
+		size = crashChunkSize
+	}
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, int64(offset)); err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+
+	return archive, data, nil
+}
@@ -0,0 +1,429 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_tailFile(t *testing.T) {
+	for name, tc := range map[string]struct {
+		content string
+		lines   int
+		expData string
+	}{
+		"empty": {
+			lines: 2,
+		},
+		"fewer lines than requested": {
+			content: "a\nb\n",
+			lines:   5,
+			expData: "a\nb\n",
+		},
+		"tail": {
+			content: "a\nb\nc\nd\n",
+			lines:   2,
+			expData: "c\nd\n",
+		},
+		"no trailing newline": {
+			content: "a\nb\nc",
+			lines:   2,
+			expData: "b\nc",
+		},
+		"large file": {
+			content: strings.Repeat(strings.Repeat("x", 99)+"\n", 2000),
+			lines:   1500,
+			expData: strings.Repeat(strings.Repeat("x", 99)+"\n", 1500),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			path := common.CreateTestFile(t, testDir, tc.content)
+
+			data, err := tailFile(path, tc.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expData, string(data), "unexpected tail")
+		})
+	}
+}
+
+// readCrashArchive returns the contents of the files in the crash archive.
+func readCrashArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gzr)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(data)
+	}
+	return files
+}
+
+func newTestCrashCollector(t *testing.T, log logging.Logger, testDir string, maxArchives int) *crashCollector {
+	t.Helper()
+
+	cfg := &config.CrashCollectorConfig{
+		Dir:          filepath.Join(testDir, "crash"),
+		CorePattern:  filepath.Join(testDir, "core.*"),
+		LogTailLines: 2,
+		MaxArchives:  maxArchives,
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cc, err := newCrashCollector(log, cfg, "host1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cc
+}
+
+func TestServer_crashCollector_onInstanceExit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		stopRequested bool
+		shutdown      bool
+		expCollected  bool
+	}{
+		"abnormal exit": {
+			expCollected: true,
+		},
+		"stop requested": {
+			stopRequested: true,
+		},
+		"shutdown": {
+			shutdown: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			cc := newTestCrashCollector(t, log, testDir, 5)
+
+			logFile := filepath.Join(testDir, "daos_engine.log")
+			if err := ioutil.WriteFile(logFile, []byte("one\ntwo\nthree\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			startedAt := time.Now().Add(-time.Hour)
+			newCore := filepath.Join(testDir, "core.1234")
+			oldCore := filepath.Join(testDir, "core.42")
+			for _, core := range []string{newCore, oldCore} {
+				if err := ioutil.WriteFile(core, []byte(core), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			oldTime := startedAt.Add(-time.Hour)
+			if err := os.Chtimes(oldCore, oldTime, oldTime); err != nil {
+				t.Fatal(err)
+			}
+
+			runner := engine.NewTestRunner(nil, engine.NewConfig().
+				WithLogFile(logFile).
+				WithTargetCount(4).
+				WithEnvVars("FOO=BAR"))
+			ei := NewEngineInstance(log, nil, nil, runner)
+			ei.setIndex(1)
+			ei.setSuperblock(&Superblock{
+				Rank: system.NewRankPtr(2), ValidRank: true, UUID: common.MockUUID(),
+			})
+			ei._restart.startedAt = startedAt
+			ei._restart.stopRequested = tc.stopRequested
+
+			cc.OnEvent(context.Background(), events.NewEngineDiedEvent("host1", 1, 2, "killed", 1234))
+			cc.OnEvent(context.Background(), events.NewEngineDiedEvent("host1", 0, 3, "killed", 1235))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.shutdown {
+				cancel()
+			}
+
+			exitFn := cc.onInstanceExitFn(ei)
+			if err := exitFn(ctx, 1, system.Rank(2), errors.New("signal: killed"), 1234); err != nil {
+				t.Fatal(err)
+			}
+			cc.collecting.Wait()
+
+			archives, err := cc.archives()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.expCollected {
+				common.AssertEqual(t, 0, len(archives), "unexpected archives")
+				return
+			}
+			common.AssertEqual(t, 1, len(archives), "unexpected number of archives")
+			if !strings.HasPrefix(archives[0].Name, "daos_engine1-crash-") {
+				t.Fatalf("unexpected archive name %q", archives[0].Name)
+			}
+
+			files := readCrashArchive(t, filepath.Join(cc.cfg.Dir, archives[0].Name))
+			var names []string
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if diff := cmp.Diff([]string{
+				"cmdline.txt", "cores/core.1234", "engine.log", "environment.txt",
+				"ras_events.json", "summary.txt", "superblock.yaml",
+			}, names); diff != "" {
+				t.Fatalf("unexpected archive contents (-want, +got):\n%s\n", diff)
+			}
+
+			common.AssertEqual(t, "two\nthree\n", files["engine.log"], "unexpected log tail")
+			common.AssertTrue(t, strings.Contains(files["cmdline.txt"], "-t 4"), "expected target count in cmdline")
+			common.AssertTrue(t, strings.Contains(files["environment.txt"], "FOO=BAR"), "expected env var")
+			common.AssertTrue(t, strings.Contains(files["summary.txt"], "rank: 2"), "expected rank in summary")
+			common.AssertTrue(t, strings.Contains(files["superblock.yaml"], common.MockUUID()), "expected superblock UUID")
+
+			var evts []map[string]interface{}
+			if err := json.Unmarshal([]byte(files["ras_events.json"]), &evts); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, 1, len(evts), "unexpected number of events")
+			common.AssertEqual(t, float64(2), evts[0]["rank"], "unexpected event rank")
+		})
+	}
+}
+
+func TestServer_crashCollector_subscriptions(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sysdb := system.MockDatabase(t, log)
+	srv := &server{
+		log:          log,
+		sysdb:        sysdb,
+		membership:   system.NewMembership(log, sysdb),
+		pubSub:       events.NewPubSub(ctx, log),
+		evtForwarder: control.NewEventForwarder(nil, nil),
+		evtLogger:    control.NewEventLogger(log),
+		crash:        newTestCrashCollector(t, log, testDir, 1),
+	}
+	defer srv.pubSub.Close()
+
+	awaitRankEvents := func(rank system.Rank, exp int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for len(srv.crash.rankEvents(rank)) != exp {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d events for rank %d, got %d", exp, rank,
+					len(srv.crash.rankEvents(rank)))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	rankEvent := func(rank uint32) *events.RASEvent {
+		return events.NewGenericEvent(events.RASEngineDied, events.RASSeverityError,
+			"test event", "").WithRank(rank)
+	}
+
+	// subscriptions made before leadership is determined
+	srv.registerEvents()
+	srv.pubSub.Publish(rankEvent(2))
+	awaitRankEvents(system.Rank(2), 1)
+
+	registerLeaderSubscriptions(srv)
+	srv.pubSub.Publish(rankEvent(2))
+	srv.pubSub.Publish(rankEvent(3).WithForwarded(true))
+	awaitRankEvents(system.Rank(2), 2)
+	common.AssertEqual(t, 0, len(srv.crash.rankEvents(system.Rank(3))),
+		"forwarded events should not be retained")
+
+	registerFollowerSubscriptions(srv)
+	srv.pubSub.Publish(rankEvent(2))
+	awaitRankEvents(system.Rank(2), 3)
+}
+
+func TestServer_crashCollector_retention(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	cc := newTestCrashCollector(t, log, testDir, 2)
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := cc.collect(&crashInfo{
+			time:    start.Add(time.Duration(i) * time.Minute),
+			rank:    system.NilRank,
+			exitErr: errors.New("killed"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Ensure that the archives are ordered by modification time.
+		mtime := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	if err := cc.prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	archives, err := cc.archives()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotNames []string
+	for _, archive := range archives {
+		gotNames = append(gotNames, archive.Name)
+	}
+	if diff := cmp.Diff([]string{filepath.Base(paths[1]), filepath.Base(paths[2])}, gotNames); diff != "" {
+		t.Fatalf("unexpected archives (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_CtlSvc_CollectCrash(t *testing.T) {
+	for name, tc := range map[string]struct {
+		disabled    bool
+		noArchives  bool
+		req         *ctlpb.CollectCrashReq
+		expArchives []string
+		expData     string
+		expErr      error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"not enabled": {
+			disabled: true,
+			req:      &ctlpb.CollectCrashReq{List: true},
+			expErr:   errors.New("not enabled"),
+		},
+		"list": {
+			req:         &ctlpb.CollectCrashReq{List: true},
+			expArchives: []string{"a.tar.gz", "b.tar.gz"},
+		},
+		"fetch most recent": {
+			req:         &ctlpb.CollectCrashReq{},
+			expArchives: []string{"b.tar.gz"},
+			expData:     "bbbb",
+		},
+		"fetch named from offset": {
+			req:         &ctlpb.CollectCrashReq{Name: "a.tar.gz", Offset: 1},
+			expArchives: []string{"a.tar.gz"},
+			expData:     "aa",
+		},
+		"fetch unknown": {
+			req:    &ctlpb.CollectCrashReq{Name: "c.tar.gz"},
+			expErr: errors.New("not found"),
+		},
+		"fetch beyond end": {
+			req:    &ctlpb.CollectCrashReq{Name: "a.tar.gz", Offset: 10},
+			expErr: errors.New("beyond end"),
+		},
+		"fetch with no archives": {
+			noArchives: true,
+			req:        &ctlpb.CollectCrashReq{},
+			expErr:     errors.New("no crash archives"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			svc := mockControlService(t, log, nil, nil, nil, nil)
+			if !tc.disabled {
+				svc.crash = newTestCrashCollector(t, log, testDir, 5)
+			}
+			if !tc.disabled && !tc.noArchives {
+				for i, archive := range []struct{ name, data string }{
+					{"a.tar.gz", "aaa"},
+					{"b.tar.gz", "bbbb"},
+				} {
+					path := filepath.Join(svc.crash.cfg.Dir, archive.name)
+					if err := ioutil.WriteFile(path, []byte(archive.data), 0600); err != nil {
+						t.Fatal(err)
+					}
+					mtime := time.Now().Add(time.Duration(i-2) * time.Minute)
+					if err := os.Chtimes(path, mtime, mtime); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			resp, gotErr := svc.CollectCrash(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotArchives []string
+			for _, archive := range resp.GetArchives() {
+				gotArchives = append(gotArchives, archive.GetName())
+			}
+			if diff := cmp.Diff(tc.expArchives, gotArchives); diff != "" {
+				t.Fatalf("unexpected archives (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expData, string(resp.GetData()), "unexpected data")
+		})
+	}
+}
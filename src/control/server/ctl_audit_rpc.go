@@ -0,0 +1,40 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// AuditQuery returns the audit records matching the request filters, read
+// from the server's audit log or, if requested, the system database.
+func (svc *ControlService) AuditQuery(ctx context.Context, req *ctlpb.AuditQueryReq) (*ctlpb.AuditQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if svc.audit == nil {
+		return nil, errors.New("audit log is not enabled in the server configuration")
+	}
+
+	records, err := svc.audit.query(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ctlpb.AuditQueryResp{
+		Records: make([]*ctlpb.AuditRecord, len(records)),
+	}
+	for i, rec := range records {
+		resp.Records[i] = auditRecordToPB(rec)
+	}
+
+	return resp, nil
+}
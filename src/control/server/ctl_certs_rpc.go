@@ -0,0 +1,57 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+// CertQuery returns details of the certificates referenced by the server's
+// transport config, read from disk so that rotated certificates are reported.
+func (svc *ControlService) CertQuery(ctx context.Context, req *ctlpb.CertQueryReq) (*ctlpb.CertQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	tc := svc.srvCfg.TransportConfig
+	resp := &ctlpb.CertQueryResp{
+		Insecure: tc == nil || tc.AllowInsecure,
+	}
+
+	paths := tc.CertificatePaths()
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pbInfo := &ctlpb.CertInfo{
+			Name: name,
+			Path: paths[name],
+		}
+
+		ci, err := security.LoadCertificateInfo(name, paths[name])
+		if err != nil {
+			pbInfo.Error = err.Error()
+		} else {
+			pbInfo.Subject = ci.Subject
+			pbInfo.NotBefore = ci.NotBefore.Unix()
+			pbInfo.NotAfter = ci.NotAfter.Unix()
+		}
+
+		resp.Certs = append(resp.Certs, pbInfo)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_CtlSvc_CertQuery(t *testing.T) {
+	missingCertTC := testCertTransportConfig()
+	missingCertTC.CertificatePath = testCertDir + "missing.crt"
+
+	for name, tc := range map[string]struct {
+		req     *ctlpb.CertQueryReq
+		tc      *security.TransportConfig
+		expResp *ctlpb.CertQueryResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"insecure": {
+			req: &ctlpb.CertQueryReq{},
+			tc:  &security.TransportConfig{AllowInsecure: true},
+			expResp: &ctlpb.CertQueryResp{
+				Insecure: true,
+			},
+		},
+		"secure": {
+			req: &ctlpb.CertQueryReq{},
+			tc:  testCertTransportConfig(),
+			expResp: &ctlpb.CertQueryResp{
+				Certs: []*ctlpb.CertInfo{
+					{
+						Name:      security.CertNameCA,
+						Path:      testCertDir + "daosCA.crt",
+						Subject:   "CN=DAOS CA,O=DAOS",
+						NotBefore: 1559771446,
+						NotAfter:  1562363446,
+					},
+					{
+						Name:      security.CertNameCert,
+						Path:      testCertDir + "agent.crt",
+						Subject:   "CN=agent,O=DAOS",
+						NotBefore: 1563482061,
+						NotAfter:  1878842061,
+					},
+				},
+			},
+		},
+		"unreadable cert": {
+			req: &ctlpb.CertQueryReq{},
+			tc:  missingCertTC,
+			expResp: &ctlpb.CertQueryResp{
+				Certs: []*ctlpb.CertInfo{
+					{
+						Name:      security.CertNameCA,
+						Path:      testCertDir + "daosCA.crt",
+						Subject:   "CN=DAOS CA,O=DAOS",
+						NotBefore: 1559771446,
+						NotAfter:  1562363446,
+					},
+					{
+						Name:  security.CertNameCert,
+						Path:  testCertDir + "missing.crt",
+						Error: "stat " + testCertDir + "missing.crt: no such file or directory",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultServer()
+			cfg.TransportConfig = tc.tc
+			svc := &ControlService{srvCfg: cfg}
+
+			gotResp, gotErr := svc.CertQuery(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
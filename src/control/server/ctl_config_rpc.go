@@ -0,0 +1,49 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// ReloadConfig re-reads the server config file, applies changes to parameters
+// which can be updated at runtime and reports which changes require an engine
+// or server restart.
+func (svc *ControlService) ReloadConfig(ctx context.Context, req *ctlpb.ReloadConfigReq) (*ctlpb.ReloadConfigResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if svc.reloader == nil {
+		return nil, errors.New("config reload not available")
+	}
+
+	resp, err := svc.reloader.reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logConfigChanges(svc.log, resp)
+
+	return resp, nil
+}
+
+// ApplyConfig validates a candidate server config file and, unless a dry run
+// is requested, writes it in place of the server config file. A restart of the
+// server is triggered once the file is written if requested.
+func (svc *ControlService) ApplyConfig(ctx context.Context, req *ctlpb.ApplyConfigReq) (*ctlpb.ApplyConfigResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if svc.reloader == nil {
+		return nil, errors.New("config apply not available")
+	}
+
+	return svc.reloader.apply(ctx, req)
+}
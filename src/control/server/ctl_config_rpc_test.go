@@ -0,0 +1,120 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_CtlSvc_ReloadConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *ctlpb.ReloadConfigReq
+		noReloader  bool
+		newLogLevel config.ControlLogLevel
+		expResp     *ctlpb.ReloadConfigResp
+		expErr      error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no reloader": {
+			req:        &ctlpb.ReloadConfigReq{},
+			noReloader: true,
+			expErr:     errors.New("not available"),
+		},
+		"no changes": {
+			req:         &ctlpb.ReloadConfigReq{},
+			newLogLevel: config.ControlLogLevelInfo,
+			expResp: &ctlpb.ReloadConfigResp{
+				Path: "/etc/daos/daos_server.yml",
+			},
+		},
+		"log level changed": {
+			req:         &ctlpb.ReloadConfigReq{},
+			newLogLevel: config.ControlLogLevelError,
+			expResp: &ctlpb.ReloadConfigResp{
+				Path: "/etc/daos/daos_server.yml",
+				Changes: []*ctlpb.ConfigChange{
+					{Field: "control_log_mask"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := reloadTestConfig()
+			cfg.Path = "/etc/daos/daos_server.yml"
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			if !tc.noReloader {
+				svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
+				svc.reloader.loadConfig = func(_ logging.Logger, path string) (*config.Server, error) {
+					newCfg := reloadTestConfig().WithControlLogMask(tc.newLogLevel)
+					newCfg.Path = path
+					return newCfg, nil
+				}
+			}
+
+			gotResp, gotErr := svc.ReloadConfig(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_ApplyConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req        *ctlpb.ApplyConfigReq
+		noReloader bool
+		expErr     error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no reloader": {
+			req:        &ctlpb.ApplyConfigReq{Config: []byte("config")},
+			noReloader: true,
+			expErr:     errors.New("not available"),
+		},
+		"empty config": {
+			req:    &ctlpb.ApplyConfigReq{},
+			expErr: errors.New("empty config"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := reloadTestConfig()
+			cfg.Path = "/etc/daos/daos_server.yml"
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			if !tc.noReloader {
+				svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
+			}
+
+			_, gotErr := svc.ApplyConfig(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
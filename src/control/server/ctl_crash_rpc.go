@@ -0,0 +1,44 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// CollectCrash lists the engine crash archives held on the server, or returns
+// a chunk of the contents of the requested archive.
+func (svc *ControlService) CollectCrash(ctx context.Context, req *ctlpb.CollectCrashReq) (*ctlpb.CollectCrashResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if svc.crash == nil {
+		return nil, errors.New("crash collection is not enabled in the server configuration")
+	}
+
+	if req.GetList() {
+		archives, err := svc.crash.archives()
+		if err != nil {
+			return nil, err
+		}
+		return &ctlpb.CollectCrashResp{Archives: archives}, nil
+	}
+
+	archive, data, err := svc.crash.fetch(req.GetName(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctlpb.CollectCrashResp{
+		Archives: []*ctlpb.CrashArchive{archive},
+		Data:     data,
+	}, nil
+}
@@ -400,6 +400,25 @@ func (svc *ControlService) StartRanks(ctx context.Context, req *ctlpb.RanksReq)
 	return resp, nil
 }
 
+// setEngineLogMasks calls into the engine over dRPC to set its log masks.
+func setEngineLogMasks(ctx context.Context, ei Engine, masks string) error {
+	dresp, err := ei.CallDrpc(ctx, drpc.MethodSetLogMasks, &ctlpb.SetLogMasksReq{Masks: masks})
+	if err != nil {
+		return err
+	}
+
+	engineResp := new(ctlpb.SetLogMasksResp)
+	if err = proto.Unmarshal(dresp.Body, engineResp); err != nil {
+		return err
+	}
+
+	if engineResp.Status != 0 {
+		return drpc.DaosStatus(engineResp.Status)
+	}
+
+	return nil
+}
+
 // SetEngineLogMasks calls into each engine over dRPC to set loglevel at runtime.
 func (svc *ControlService) SetEngineLogMasks(ctx context.Context, req *ctlpb.SetLogMasksReq) (*ctlpb.SetLogMasksResp, error) {
 	if req == nil {
@@ -414,30 +433,18 @@ func (svc *ControlService) SetEngineLogMasks(ctx context.Context, req *ctlpb.Set
 			continue
 		}
 
-		if req.Masks == "" {
+		masks := req.Masks
+		if masks == "" {
 			// no need to validate here as config value already validated on start-up
-			req.Masks = svc.srvCfg.Engines[idx].LogMask
+			masks = svc.reloader.engineLogMask(idx)
 		}
-		if req.Masks == "" {
+		if masks == "" {
 			errs = append(errs, fmt.Sprintf("engine-%d: no log_mask set in engine config", ei.Index()))
 			continue
 		}
 
-		dresp, err := ei.CallDrpc(ctx, drpc.MethodSetLogMasks, req)
-		if err != nil {
-			errs = append(errs, fmt.Sprintf("engine-%d: %s", ei.Index(), err))
-			continue
-		}
-
-		engineResp := new(ctlpb.SetLogMasksResp)
-		if err = proto.Unmarshal(dresp.Body, engineResp); err != nil {
+		if err := setEngineLogMasks(ctx, ei, masks); err != nil {
 			errs = append(errs, fmt.Sprintf("engine-%d: %s", ei.Index(), err))
-			continue
-		}
-
-		if engineResp.Status != 0 {
-			errs = append(errs, fmt.Sprintf("engine-%d: %s", ei.Index(),
-				drpc.DaosStatus(engineResp.Status)))
 		}
 	}
 
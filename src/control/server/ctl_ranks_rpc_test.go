@@ -1000,6 +1000,7 @@ func TestServer_CtlSvc_SetEngineLogMasks(t *testing.T) {
 				engine.NewConfig().WithTargetCount(1).WithLogMask(tc.cfgLogMask),
 			)
 			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
 			for i, e := range svc.harness.instances {
 				srv := e.(*EngineInstance)
 
@@ -0,0 +1,47 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// CollectLog assembles a support bundle of logs and diagnostics on the server
+// and returns its first chunk, or returns a subsequent chunk of a previously
+// assembled bundle.
+func (svc *ControlService) CollectLog(ctx context.Context, req *ctlpb.CollectLogReq) (*ctlpb.CollectLogResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	name := req.GetBundle()
+	if name == "" {
+		if req.GetOffset() != 0 {
+			return nil, errors.New("offset requires a bundle name")
+		}
+
+		var err error
+		if _, name, err = svc.assembleSupportBundle(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle, data, err := svc.readSupportBundle(name, req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctlpb.CollectLogResp{
+		Bundle: name,
+		Size:   bundle.size,
+		Data:   data,
+	}, nil
+}
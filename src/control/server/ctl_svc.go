@@ -18,9 +18,13 @@ import (
 type ControlService struct {
 	ctlpb.UnimplementedCtlSvcServer
 	StorageControlService
-	harness *EngineHarness
-	srvCfg  *config.Server
-	events  *events.PubSub
+	harness  *EngineHarness
+	srvCfg   *config.Server
+	events   *events.PubSub
+	audit    *auditLogger
+	crash    *crashCollector
+	support  supportBundles
+	reloader *configReloader
 }
 
 // NewControlService returns ControlService to be used as gRPC control service
@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"syscall"
+	"time"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const drainReasonShutdown = "control plane shutting down"
+
+// drpcInFlight returns the number of dRPC calls in progress on the instance,
+// if its dRPC client is able to report them.
+func (ei *EngineInstance) drpcInFlight() int {
+	dc, err := ei.getDrpcClient()
+	if err != nil {
+		return 0
+	}
+	if sr, ok := dc.(interface{ Stats() drpc.ClientPoolStats }); ok {
+		return sr.Stats().InFlight
+	}
+
+	return 0
+}
+
+// drpcIdle indicates whether the instance has no dRPC calls in progress.
+func drpcIdle(e Engine) bool {
+	if ic, ok := e.(interface{ drpcInFlight() int }); ok {
+		return ic.drpcInFlight() == 0
+	}
+
+	return true
+}
+
+// drainEngines performs a controlled stop of the running engines so that their
+// loss is not treated as a failure by the management service.
+//
+// The MS is notified that the ranks are stopping, the engines are prepared for
+// shutdown and in-flight dRPC calls are given until the drain timeout to
+// complete before the engines are stopped. Engine exits are not published as
+// RASEngineDied events once draining has begun.
+func (svc *ControlService) drainEngines(ctx context.Context, hostname, reason string) {
+	timeout := svc.reloader.shutdownDrainTimeout()
+	if timeout <= 0 {
+		return
+	}
+
+	instances := make([]Engine, 0)
+	ranks := &system.RankSet{}
+	for _, ei := range svc.harness.Instances() {
+		if !ei.IsStarted() {
+			continue
+		}
+		instances = append(instances, ei)
+
+		if !ei.IsReady() {
+			continue
+		}
+		rank, err := ei.GetRank()
+		if err != nil {
+			svc.log.Debugf("instance %d: no rank (%s)", ei.Index(), err)
+			continue
+		}
+		ranks.Add(rank)
+		svc.events.Publish(events.NewEngineStoppingEvent(hostname, ei.Index(),
+			rank.Uint32(), reason))
+	}
+	if len(instances) == 0 {
+		return
+	}
+
+	svc.log.Infof("draining %d engine(s) (ranks %s) within %s", len(instances), ranks, timeout)
+	svc.events.DisableEventIDs(events.RASEngineDied)
+
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if ranks.Count() > 0 {
+		resp, err := svc.PrepShutdownRanks(drainCtx, &ctlpb.RanksReq{Ranks: ranks.String()})
+		if err != nil {
+			svc.log.Errorf("prep shutdown of ranks %s failed: %s", ranks, err)
+		}
+		for _, result := range resp.GetResults() {
+			if result.GetErrored() {
+				svc.log.Errorf("prep shutdown of rank %d failed: %s", result.GetRank(), result.GetMsg())
+			}
+		}
+	}
+
+	remaining := timeout
+	if deadline, ok := drainCtx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+	if idle, _ := pollInstanceState(drainCtx, instances, drpcIdle, remaining); !idle {
+		svc.log.Errorf("dRPC calls still in progress after %s, stopping engines", timeout)
+	}
+
+	for _, ei := range instances {
+		if err := ei.Stop(syscall.SIGINT); err != nil {
+			svc.log.Errorf("instance %d: sending %s: %s", ei.Index(), syscall.SIGINT, err)
+		}
+	}
+
+	stopCtx, stopCancel := context.WithCancel(ctx)
+	defer stopCancel()
+	if stopped, _ := pollInstanceState(stopCtx, instances,
+		func(e Engine) bool { return !e.IsStarted() },
+		svc.harness.rankReqTimeout); !stopped {
+
+		svc.log.Errorf("engines failed to stop within %s", svc.harness.rankReqTimeout)
+		return
+	}
+	svc.log.Infof("drained and stopped %d engine(s)", len(instances))
+}
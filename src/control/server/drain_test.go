@@ -0,0 +1,285 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// mockInFlightDrpcClient is a mock dRPC client which reports a number of
+// calls in progress.
+type mockInFlightDrpcClient struct {
+	*mockDrpcClient
+	inFlight int32
+}
+
+func (c *mockInFlightDrpcClient) Stats() drpc.ClientPoolStats {
+	return drpc.ClientPoolStats{InFlight: int(atomic.LoadInt32(&c.inFlight))}
+}
+
+// stoppableRunner is a test runner which stops running when signalled.
+type stoppableRunner struct {
+	*engine.TestRunner
+	running  atm.Bool
+	signalCb func(os.Signal)
+}
+
+func newStoppableRunner(signalCb func(os.Signal)) *stoppableRunner {
+	r := &stoppableRunner{
+		TestRunner: engine.NewTestRunner(nil, engine.NewConfig()),
+		signalCb:   signalCb,
+	}
+	r.running.SetTrue()
+	return r
+}
+
+func (r *stoppableRunner) IsRunning() bool {
+	return r.running.Load()
+}
+
+func (r *stoppableRunner) Signal(sig os.Signal) error {
+	r.running.SetFalse()
+	if r.signalCb != nil {
+		r.signalCb(sig)
+	}
+	return nil
+}
+
+// eventRecorder records the IDs and ranks of received events until the
+// sentinel event is received.
+type eventRecorder struct {
+	sync.Mutex
+	rx       []string
+	sentinel chan struct{}
+}
+
+func (r *eventRecorder) OnEvent(_ context.Context, evt *events.RASEvent) {
+	if evt.ID == events.RASUnknownEvent {
+		close(r.sentinel)
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.rx = append(r.rx, fmt.Sprintf("%s:%d", evt.ID, evt.Rank))
+}
+
+func (r *eventRecorder) received() []string {
+	r.Lock()
+	defer r.Unlock()
+
+	rx := make([]string, len(r.rx))
+	copy(rx, r.rx)
+	sort.Strings(rx)
+	return rx
+}
+
+func TestServer_CtlSvc_drainEngines(t *testing.T) {
+	for name, tc := range map[string]struct {
+		drainTimeout time.Duration
+		notReady     bool
+		inFlight     int32
+		clearAfter   time.Duration
+		expEvents    []string
+		expPrepCalls int
+		expSignals   int
+		expLog       string
+	}{
+		"draining disabled": {},
+		"engines drained": {
+			drainTimeout: 5 * time.Second,
+			expEvents: []string{
+				"engine_stopping:1",
+				"engine_stopping:2",
+			},
+			expPrepCalls: 2,
+			expSignals:   2,
+			expLog:       "drained and stopped 2 engine(s)",
+		},
+		"engines not ready": {
+			drainTimeout: 5 * time.Second,
+			notReady:     true,
+			expSignals:   2,
+			expLog:       "drained and stopped 2 engine(s)",
+		},
+		"in-flight calls complete": {
+			drainTimeout: 5 * time.Second,
+			inFlight:     1,
+			clearAfter:   50 * time.Millisecond,
+			expEvents: []string{
+				"engine_stopping:1",
+				"engine_stopping:2",
+			},
+			expPrepCalls: 2,
+			expSignals:   2,
+			expLog:       "drained and stopped 2 engine(s)",
+		},
+		"in-flight calls time out": {
+			drainTimeout: 100 * time.Millisecond,
+			inFlight:     1,
+			expEvents: []string{
+				"engine_stopping:1",
+				"engine_stopping:2",
+			},
+			expPrepCalls: 2,
+			expSignals:   2,
+			expLog:       "dRPC calls still in progress after 100ms",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer().
+				WithShutdownDrainTimeout(tc.drainTimeout).
+				WithEngines(
+					engine.NewConfig().WithTargetCount(1),
+					engine.NewConfig().WithTargetCount(1),
+				)
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc.harness.rankReqTimeout = time.Second
+			svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ps := events.NewPubSub(ctx, log)
+			defer ps.Close()
+			svc.events = ps
+
+			recorder := &eventRecorder{sentinel: make(chan struct{})}
+			ps.Subscribe(events.RASTypeAny, recorder)
+
+			var signals int32
+			respBytes, err := proto.Marshal(&mgmtpb.DaosResp{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			clients := make([]*mockInFlightDrpcClient, 0, len(svc.harness.instances))
+			for i, e := range svc.harness.instances {
+				ei := e.(*EngineInstance)
+				ei.ready.Store(!tc.notReady)
+				ei._superblock.Rank = system.NewRankPtr(uint32(i + 1))
+
+				ei.runner = newStoppableRunner(func(os.Signal) {
+					atomic.AddInt32(&signals, 1)
+					// simulate process exit which will call
+					// onInstanceExit handlers.
+					ei.exit(context.TODO(), common.NormalExit)
+				})
+				ei.OnInstanceExit(publishInstanceExitFn(ps.Publish, "foo"))
+
+				dcc := new(mockDrpcClientConfig)
+				dcc.setSendMsgResponse(drpc.Status_SUCCESS, respBytes, nil)
+				dc := &mockInFlightDrpcClient{
+					mockDrpcClient: newMockDrpcClient(dcc),
+					inFlight:       tc.inFlight,
+				}
+				ei.setDrpcClient(dc)
+				clients = append(clients, dc)
+			}
+
+			if tc.clearAfter > 0 {
+				go func() {
+					time.Sleep(tc.clearAfter)
+					for _, dc := range clients {
+						atomic.StoreInt32(&dc.inFlight, 0)
+					}
+				}()
+			}
+
+			svc.drainEngines(ctx, "foo", drainReasonShutdown)
+
+			// events are filtered in the order that they are published,
+			// so any engine died events will have been dispatched by the
+			// time that the sentinel is
+			ps.Publish(&events.RASEvent{
+				ID:   events.RASUnknownEvent,
+				Type: events.RASTypeInfoOnly,
+			})
+			select {
+			case <-recorder.sentinel:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for events")
+			}
+			deadline := time.Now().Add(time.Second)
+			for len(recorder.received()) < len(tc.expEvents) && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+
+			gotEvents := recorder.received()
+			if diff := cmp.Diff(tc.expEvents, gotEvents, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("unexpected events (-want, +got):\n%s\n", diff)
+			}
+
+			var gotPrepCalls int
+			for _, dc := range clients {
+				for _, m := range dc.CalledMethods() {
+					if m == drpc.MethodPrepShutdown {
+						gotPrepCalls++
+					}
+				}
+			}
+			common.AssertEqual(t, tc.expPrepCalls, gotPrepCalls, "unexpected prep shutdown calls")
+			common.AssertEqual(t, int32(tc.expSignals), atomic.LoadInt32(&signals),
+				"unexpected number of signals sent")
+			for _, e := range svc.harness.instances {
+				if tc.expSignals > 0 && e.IsStarted() {
+					t.Fatalf("instance %d not stopped", e.Index())
+				}
+			}
+
+			if tc.expLog != "" && !strings.Contains(buf.String(), tc.expLog) {
+				t.Fatalf("expected log to contain %q", tc.expLog)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_drainEngines_Signal(t *testing.T) {
+	// engines are stopped with SIGINT so that they shut down cleanly
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	cfg := config.DefaultServer().WithEngines(engine.NewConfig().WithTargetCount(1))
+	svc := mockControlService(t, log, cfg, nil, nil, nil)
+	svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
+
+	var gotSig os.Signal
+	ei := svc.harness.instances[0].(*EngineInstance)
+	ei.runner = newStoppableRunner(func(sig os.Signal) {
+		gotSig = sig
+	})
+	ei.ready.SetFalse()
+
+	svc.drainEngines(context.Background(), "foo", drainReasonShutdown)
+
+	common.AssertEqual(t, os.Signal(syscall.SIGINT), gotSig, "unexpected signal")
+}
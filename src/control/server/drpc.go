@@ -103,8 +103,10 @@ type drpcServerSetupReq struct {
 	sockDir string
 	engines []Engine
 	tc      *security.TransportConfig
+	cc      *security.CredentialConfig
 	sysdb   *system.Database
 	events  *events.PubSub
+	obs     drpc.CallObserver
 }
 
 // drpcServerSetup specifies socket path and starts drpc server.
@@ -120,8 +122,12 @@ func drpcServerSetup(ctx context.Context, req *drpcServerSetupReq) error {
 		return errors.Wrap(err, "unable to create socket server")
 	}
 
+	if req.obs != nil {
+		drpcServer.SetCallObserver(req.obs)
+	}
+
 	// Create and add our modules
-	drpcServer.RegisterRPCModule(NewSecurityModule(req.log, req.tc))
+	drpcServer.RegisterRPCModule(NewSecurityModule(req.log, req.tc, req.cc))
 	drpcServer.RegisterRPCModule(newMgmtModule())
 	drpcServer.RegisterRPCModule(newSrvModule(req.log, req.sysdb, req.engines, req.events))
 
@@ -192,8 +198,14 @@ func newDrpcCall(method drpc.Method, bodyMessage proto.Message) (*drpc.Call, err
 // drpc response is returned after basic checks.
 func makeDrpcCall(ctx context.Context, log logging.Logger, client drpc.DomainSocketClient, method drpc.Method, body proto.Message) (drpcResp *drpc.Response, err error) {
 	tryCall := func(msg proto.Message) (*drpc.Response, error) {
-		client.Lock()
-		defer client.Unlock()
+		// Pooled clients manage their own connections and may be used
+		// concurrently, so the client lock is only held for clients
+		// with a single connection.
+		cc, pooled := client.(drpc.ContextClient)
+		if !pooled {
+			client.Lock()
+			defer client.Unlock()
+		}
 
 		drpcCall, err := newDrpcCall(method, msg)
 		if err != nil {
@@ -209,9 +221,13 @@ func makeDrpcCall(ctx context.Context, log logging.Logger, client drpc.DomainSoc
 			}
 			return nil, errors.Wrap(err, "connect to client")
 		}
-		defer client.Close()
-
-		if drpcResp, err = client.SendMsg(drpcCall); err != nil {
+		if pooled {
+			drpcResp, err = cc.SendMsgContext(ctx, drpcCall)
+		} else {
+			defer client.Close()
+			drpcResp, err = client.SendMsg(drpcCall)
+		}
+		if err != nil {
 			return nil, errors.Wrapf(err, "failed to send %dB message", proto.Size(msg))
 		}
 
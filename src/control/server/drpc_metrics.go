@@ -0,0 +1,149 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const drpcMetricsNamespace = "daos_server_drpc"
+
+// drpcMetrics records the outcome and latency of dRPC calls made to and
+// handled on behalf of the engines, and exports them alongside the use of
+// each engine's dRPC client pool as Prometheus metrics.
+type drpcMetrics struct {
+	log           logging.Logger
+	slowThreshold int64 // time.Duration, updated atomically
+	harness       *EngineHarness
+	calls         *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	poolInFlight  *prometheus.Desc
+	poolIdle      *prometheus.Desc
+	poolWaits     *prometheus.Desc
+	poolWaitTime  *prometheus.Desc
+}
+
+func newDrpcMetrics(log logging.Logger, slowThreshold time.Duration, harness *EngineHarness) *drpcMetrics {
+	poolLabels := []string{"engine"}
+
+	return &drpcMetrics{
+		log:           log,
+		slowThreshold: int64(slowThreshold),
+		harness:       harness,
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: drpcMetricsNamespace,
+			Name:      "calls_total",
+			Help:      "Number of dRPC calls by module, method and status",
+		}, []string{"side", "module", "method", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: drpcMetricsNamespace,
+			Name:      "call_duration_seconds",
+			Help:      "Latency of dRPC calls by module and method",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"side", "module", "method"}),
+		poolInFlight: prometheus.NewDesc(drpcMetricsNamespace+"_pool_in_flight",
+			"Number of dRPC calls in progress on an engine's client pool", poolLabels, nil),
+		poolIdle: prometheus.NewDesc(drpcMetricsNamespace+"_pool_idle",
+			"Number of idle connections in an engine's client pool", poolLabels, nil),
+		poolWaits: prometheus.NewDesc(drpcMetricsNamespace+"_pool_waits_total",
+			"Number of dRPC calls that waited for a free connection", poolLabels, nil),
+		poolWaitTime: prometheus.NewDesc(drpcMetricsNamespace+"_pool_wait_seconds_total",
+			"Total time dRPC calls spent waiting for a free connection", poolLabels, nil),
+	}
+}
+
+// setSlowThreshold updates the duration above which calls are logged as slow.
+func (m *drpcMetrics) setSlowThreshold(threshold time.Duration) {
+	atomic.StoreInt64(&m.slowThreshold, int64(threshold))
+}
+
+func drpcMethodLabel(info *drpc.CallInfo) string {
+	if info.Method == nil {
+		return "unknown"
+	}
+	return info.Method.String()
+}
+
+func drpcStatusLabel(info *drpc.CallInfo) string {
+	switch {
+	case info.DaosStatus != drpc.DaosSuccess:
+		return info.DaosStatus.Name()
+	case info.Err != nil && info.Status == drpc.Status_SUCCESS:
+		return "error"
+	case info.Status != drpc.Status_SUCCESS:
+		return info.Status.String()
+	default:
+		return "success"
+	}
+}
+
+// ObserveCall records the outcome of a dRPC call and logs it if it took
+// longer than the slow call threshold.
+func (m *drpcMetrics) ObserveCall(info *drpc.CallInfo) {
+	side := string(info.Side)
+	module := info.Module.String()
+	method := drpcMethodLabel(info)
+	status := drpcStatusLabel(info)
+
+	m.calls.WithLabelValues(side, module, method, status).Inc()
+	m.latency.WithLabelValues(side, module, method).Observe(info.Duration.Seconds())
+
+	slowThreshold := time.Duration(atomic.LoadInt64(&m.slowThreshold))
+	if slowThreshold > 0 && info.Duration >= slowThreshold {
+		m.log.Infof("slow dRPC %s call %s/%s: %s (request %dB, response %dB, status %s)",
+			side, module, method, info.Duration, info.ReqSize, info.RespSize, status)
+	}
+}
+
+// Describe sends the descriptors of the dRPC metrics to the supplied channel.
+func (m *drpcMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.calls.Describe(ch)
+	m.latency.Describe(ch)
+	ch <- m.poolInFlight
+	ch <- m.poolIdle
+	ch <- m.poolWaits
+	ch <- m.poolWaitTime
+}
+
+// Collect sends the current values of the dRPC metrics to the supplied
+// channel.
+func (m *drpcMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.calls.Collect(ch)
+	m.latency.Collect(ch)
+
+	if m.harness == nil {
+		return
+	}
+	for _, e := range m.harness.Instances() {
+		ei, ok := e.(*EngineInstance)
+		if !ok {
+			continue
+		}
+		dc, err := ei.getDrpcClient()
+		if err != nil {
+			continue
+		}
+		pool, ok := dc.(*drpc.ClientPool)
+		if !ok {
+			continue
+		}
+
+		stats := pool.Stats()
+		idx := fmt.Sprintf("%d", ei.Index())
+		ch <- prometheus.MustNewConstMetric(m.poolInFlight, prometheus.GaugeValue, float64(stats.InFlight), idx)
+		ch <- prometheus.MustNewConstMetric(m.poolIdle, prometheus.GaugeValue, float64(stats.Idle), idx)
+		ch <- prometheus.MustNewConstMetric(m.poolWaits, prometheus.CounterValue, float64(stats.Waits), idx)
+		ch <- prometheus.MustNewConstMetric(m.poolWaitTime, prometheus.CounterValue, stats.WaitTime.Seconds(), idx)
+	}
+}
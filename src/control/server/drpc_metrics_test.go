@@ -0,0 +1,193 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// gatherDrpcMetrics returns the value of each dRPC counter and gauge, and the
+// sample count of each histogram, keyed by metric name and label values.
+func gatherDrpcMetrics(t *testing.T, m *drpcMetrics) map[string]float64 {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatal(err)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]float64)
+	for _, mf := range families {
+		for _, metric := range mf.GetMetric() {
+			key := []string{mf.GetName()}
+			for _, lp := range metric.GetLabel() {
+				key = append(key, lp.GetValue())
+			}
+
+			var val float64
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				val = metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				val = metric.GetGauge().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				val = float64(metric.GetHistogram().GetSampleCount())
+			}
+			values[strings.Join(key, ",")] = val
+		}
+	}
+
+	return values
+}
+
+func TestServer_drpcMetrics_ObserveCall(t *testing.T) {
+	for name, tc := range map[string]struct {
+		slowThreshold time.Duration
+		calls         []*drpc.CallInfo
+		expMetrics    map[string]float64
+		expLog        string
+	}{
+		"success and failures": {
+			calls: []*drpc.CallInfo{
+				{
+					Side:   drpc.CallSideClient,
+					Module: drpc.ModuleMgmt,
+					Method: drpc.MethodPoolCreate,
+				},
+				{
+					Side:   drpc.CallSideClient,
+					Module: drpc.ModuleMgmt,
+					Method: drpc.MethodPoolCreate,
+				},
+				{
+					Side:       drpc.CallSideClient,
+					Module:     drpc.ModuleMgmt,
+					Method:     drpc.MethodPoolCreate,
+					DaosStatus: drpc.DaosNoSpace,
+				},
+				{
+					Side:   drpc.CallSideClient,
+					Module: drpc.ModuleMgmt,
+					Method: drpc.MethodPoolCreate,
+					Err:    errors.New("dRPC send"),
+				},
+				{
+					Side:   drpc.CallSideServer,
+					Module: drpc.ModuleSrv,
+					Method: drpc.MethodNotifyReady,
+					Status: drpc.Status_FAILURE,
+					Err:    errors.New("handler failed"),
+				},
+				{
+					Side:   drpc.CallSideServer,
+					Module: drpc.ModuleSrv,
+				},
+			},
+			expMetrics: map[string]float64{
+				"daos_server_drpc_calls_total,PoolCreate,Management,client,success":                       2,
+				"daos_server_drpc_calls_total,PoolCreate,Management,client," + drpc.DaosNoSpace.Name():    1,
+				"daos_server_drpc_calls_total,PoolCreate,Management,client,error":                         1,
+				"daos_server_drpc_calls_total,notify ready,Server,server," + drpc.Status_FAILURE.String(): 1,
+				"daos_server_drpc_calls_total,unknown,Server,server,success":                              1,
+				"daos_server_drpc_call_duration_seconds,PoolCreate,Management,client":                     4,
+				"daos_server_drpc_call_duration_seconds,notify ready,Server,server":                       1,
+				"daos_server_drpc_call_duration_seconds,unknown,Server,server":                            1,
+			},
+		},
+		"slow call logged": {
+			slowThreshold: time.Second,
+			calls: []*drpc.CallInfo{
+				{
+					Side:     drpc.CallSideClient,
+					Module:   drpc.ModuleMgmt,
+					Method:   drpc.MethodPoolCreate,
+					ReqSize:  42,
+					RespSize: 7,
+					Duration: 2 * time.Second,
+				},
+				{
+					Side:     drpc.CallSideClient,
+					Module:   drpc.ModuleMgmt,
+					Method:   drpc.MethodPoolDestroy,
+					Duration: time.Millisecond,
+				},
+			},
+			expMetrics: map[string]float64{
+				"daos_server_drpc_calls_total,PoolCreate,Management,client,success":    1,
+				"daos_server_drpc_calls_total,PoolDestroy,Management,client,success":   1,
+				"daos_server_drpc_call_duration_seconds,PoolCreate,Management,client":  1,
+				"daos_server_drpc_call_duration_seconds,PoolDestroy,Management,client": 1,
+			},
+			expLog: "slow dRPC client call Management/PoolCreate: 2s (request 42B, response 7B, status success)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			m := newDrpcMetrics(log, tc.slowThreshold, nil)
+			for _, info := range tc.calls {
+				m.ObserveCall(info)
+			}
+
+			if diff := cmp.Diff(tc.expMetrics, gatherDrpcMetrics(t, m)); diff != "" {
+				t.Fatalf("unexpected metrics (-want, +got):\n%s\n", diff)
+			}
+
+			if tc.expLog == "" {
+				if strings.Contains(buf.String(), "slow dRPC") {
+					t.Fatalf("unexpected slow call log:\n%s", buf.String())
+				}
+				return
+			}
+			if !strings.Contains(buf.String(), tc.expLog) {
+				t.Fatalf("expected log to contain %q, got:\n%s", tc.expLog, buf.String())
+			}
+		})
+	}
+}
+
+func TestServer_drpcMetrics_Collect_Pool(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	harness := NewEngineHarness(log)
+	for i := 0; i < 2; i++ {
+		if err := harness.AddInstance(newTestEngine(log, false, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Only engines with a pooled client report pool metrics.
+	harness.instances[1].(*EngineInstance).setDrpcClient(drpc.NewClientPool("/dontcare", 2))
+
+	m := newDrpcMetrics(log, 0, harness)
+
+	expMetrics := map[string]float64{
+		"daos_server_drpc_pool_in_flight,1":          0,
+		"daos_server_drpc_pool_idle,1":               0,
+		"daos_server_drpc_pool_waits_total,1":        0,
+		"daos_server_drpc_pool_wait_seconds_total,1": 0,
+	}
+	if diff := cmp.Diff(expMetrics, gatherDrpcMetrics(t, m)); diff != "" {
+		t.Fatalf("unexpected metrics (-want, +got):\n%s\n", diff)
+	}
+}
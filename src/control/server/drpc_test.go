@@ -335,3 +335,30 @@ func TestServer_DrpcRetryCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_DrpcPooledClient(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	mc := newMockPooledDrpcClient(&mockDrpcClientConfig{
+		SendMsgResponse: &drpc.Response{},
+	})
+
+	// Pooled clients are used concurrently and must not be locked for
+	// the duration of a call.
+	mc.Lock()
+	defer mc.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := makeDrpcCall(ctx, log, mc, drpc.MethodPoolCreate, &mgmtpb.PoolCreateReq{}); err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 0, mc.CloseCallCount, "pooled client should not be closed after call")
+	common.AssertEqual(t, []drpc.Method{drpc.MethodPoolCreate}, mc.CalledMethods(), "unexpected methods")
+
+	cancel()
+	_, err := makeDrpcCall(ctx, log, mc, drpc.MethodPoolCreate, &mgmtpb.PoolCreateReq{})
+	common.CmpErr(t, context.Canceled, err)
+}
@@ -101,8 +101,10 @@ func cleanEnvVars(in, allowed []string) (out []string) {
 
 // mergeEnvVars merges and deduplicates two slices of environment
 // variables. Conflicts are resolved by taking the value from the
-// second list.
+// second list. Variables are kept in the order in which they first
+// appear so that merging the same lists always gives the same result.
 func mergeEnvVars(curVars []string, newVars []string) (merged []string) {
+	var keys []string
 	mergeMap := make(map[string]string)
 	for _, pair := range curVars {
 		kv := strings.SplitN(pair, "=", 2)
@@ -114,6 +116,7 @@ func mergeEnvVars(curVars []string, newVars []string) (merged []string) {
 		if _, found := mergeMap[kv[0]]; found {
 			continue
 		}
+		keys = append(keys, kv[0])
 		mergeMap[kv[0]] = kv[1]
 	}
 
@@ -128,12 +131,15 @@ func mergeEnvVars(curVars []string, newVars []string) (merged []string) {
 			continue
 		}
 		mergedKeys[kv[0]] = struct{}{}
+		if _, found := mergeMap[kv[0]]; !found {
+			keys = append(keys, kv[0])
+		}
 		mergeMap[kv[0]] = kv[1]
 	}
 
 	merged = make([]string, 0, len(mergeMap))
-	for key, val := range mergeMap {
-		merged = append(merged, strings.Join([]string{key, val}, "="))
+	for _, key := range keys {
+		merged = append(merged, strings.Join([]string{key, mergeMap[key]}, "="))
 	}
 
 	return
@@ -166,6 +172,7 @@ type Config struct {
 	Fabric            FabricConfig   `yaml:",inline"`
 	EnvVars           []string       `yaml:"env_vars,omitempty"`
 	EnvPassThrough    []string       `yaml:"env_pass_through,omitempty"`
+	RestartPolicy     *RestartPolicy `yaml:"restart_policy,omitempty"`
 	Index             uint32         `yaml:"-" cmdLongFlag:"--instance_idx" cmdShortFlag:"-I"`
 	MemSize           int            `yaml:"-" cmdLongFlag:"--mem_size" cmdShortFlag:"-r"`
 	HugePageSz        int            `yaml:"-" cmdLongFlag:"--hugepage_size" cmdShortFlag:"-H"`
@@ -188,6 +195,10 @@ func (c *Config) Validate() error {
 		return errors.Wrap(err, "storage config validation failed")
 	}
 
+	if err := c.RestartPolicy.Validate(); err != nil {
+		return errors.Wrap(err, "restart policy validation failed")
+	}
+
 	if c.LogMask != "" {
 		return ValidateLogMasks(c.LogMask)
 	}
@@ -346,6 +357,13 @@ func (c *Config) WithBypassHealthChk(bypass *bool) *Config {
 	return c
 }
 
+// WithRestartPolicy sets the policy for restarting this instance after an
+// unexpected exit.
+func (c *Config) WithRestartPolicy(policy *RestartPolicy) *Config {
+	c.RestartPolicy = policy
+	return c
+}
+
 // WithCrtCtxShareAddr defines the CRT_CTX_SHARE_ADDR for this instance
 func (c *Config) WithCrtCtxShareAddr(addr uint32) *Config {
 	c.Fabric.CrtCtxShareAddr = addr
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -153,7 +154,14 @@ func TestConstructedConfig(t *testing.T) {
 		WithTargetCount(12).
 		WithHelperStreamCount(1).
 		WithPinnedNumaNode(&numaNode).
-		WithBypassHealthChk(nil)
+		WithBypassHealthChk(nil).
+		WithRestartPolicy(&RestartPolicy{
+			Policy:      RestartPolicyOnFailure,
+			MaxAttempts: 3,
+			Backoff:     10 * time.Second,
+			MaxBackoff:  time.Minute,
+			ResetWindow: time.Hour,
+		})
 
 	if *update {
 		outFile, err := os.Create(goldenPath)
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sync"
 	"syscall"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ const engineBin = "daos_engine"
 
 // Runner starts and manages an instance of a DAOS I/O Engine
 type Runner struct {
+	sync.RWMutex
 	Config  *Config
 	log     logging.Logger
 	running atm.Bool
@@ -38,7 +40,7 @@ func NewRunner(log logging.Logger, config *Config) *Runner {
 	}
 }
 
-func (r *Runner) run(ctx context.Context, args, env []string, errOut chan<- error) error {
+func (r *Runner) run(ctx context.Context, cfg *Config, args, env []string, errOut chan<- error) error {
 	binPath, err := common.FindBinary(engineBin)
 	if err != nil {
 		return errors.Wrapf(err, "can't start %s", engineBin)
@@ -47,11 +49,11 @@ func (r *Runner) run(ctx context.Context, args, env []string, errOut chan<- erro
 	cmd := exec.CommandContext(ctx, binPath, args...)
 	cmd.Stdout = &cmdLogger{
 		logFn:  r.log.Info,
-		prefix: fmt.Sprintf("%s:%d", engineBin, r.Config.Index),
+		prefix: fmt.Sprintf("%s:%d", engineBin, cfg.Index),
 	}
 	cmd.Stderr = &cmdLogger{
 		logFn:  r.log.Error,
-		prefix: fmt.Sprintf("%s:%d", engineBin, r.Config.Index),
+		prefix: fmt.Sprintf("%s:%d", engineBin, cfg.Index),
 	}
 	cmd.Env = env
 
@@ -66,13 +68,13 @@ func (r *Runner) run(ctx context.Context, args, env []string, errOut chan<- erro
 		},
 	}
 
-	r.log.Debugf("%s:%d args: %s", engineBin, r.Config.Index, args)
-	r.log.Debugf("%s:%d env: %s", engineBin, r.Config.Index, cmd.Env)
-	r.log.Infof("Starting I/O Engine instance %d: %s", r.Config.Index, binPath)
+	r.log.Debugf("%s:%d args: %s", engineBin, cfg.Index, args)
+	r.log.Debugf("%s:%d env: %s", engineBin, cfg.Index, cmd.Env)
+	r.log.Infof("Starting I/O Engine instance %d: %s", cfg.Index, binPath)
 
 	if err := cmd.Start(); err != nil {
 		return errors.Wrapf(common.GetExitStatus(err),
-			"%s (instance %d) failed to start", binPath, r.Config.Index)
+			"%s (instance %d) failed to start", binPath, cfg.Index)
 	}
 	r.cmd = cmd
 
@@ -88,17 +90,18 @@ func (r *Runner) run(ctx context.Context, args, env []string, errOut chan<- erro
 
 // Start asynchronously starts the Engine instance.
 func (r *Runner) Start(ctx context.Context, errOut chan<- error) error {
-	args, err := r.Config.CmdLineArgs()
+	cfg := r.GetConfig()
+	args, err := cfg.CmdLineArgs()
 	if err != nil {
 		return err
 	}
-	env, err := r.Config.CmdLineEnv()
+	env, err := cfg.CmdLineEnv()
 	if err != nil {
 		return err
 	}
-	env = mergeEnvVars(cleanEnvVars(os.Environ(), r.Config.EnvPassThrough), env)
+	env = mergeEnvVars(cleanEnvVars(os.Environ(), cfg.EnvPassThrough), env)
 
-	return r.run(ctx, args, env, errOut)
+	return r.run(ctx, cfg, args, env, errOut)
 }
 
 // IsRunning indicates whether the Runner process is running or not.
@@ -112,7 +115,7 @@ func (r *Runner) Signal(signal os.Signal) error {
 		return nil
 	}
 
-	r.log.Debugf("Signalling I/O Engine instance %d (%s)", r.Config.Index, signal)
+	r.log.Debugf("Signalling I/O Engine instance %d (%s)", r.GetConfig().Index, signal)
 
 	return r.cmd.Process.Signal(signal)
 }
@@ -129,5 +132,17 @@ func (r *Runner) GetLastPid() uint64 {
 
 // GetConfig returns the runner's configuration
 func (r *Runner) GetConfig() *Config {
+	r.RLock()
+	defer r.RUnlock()
+
 	return r.Config
 }
+
+// SetConfig replaces the runner's configuration, the new config is used
+// the next time the engine is started.
+func (r *Runner) SetConfig(cfg *Config) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Config = cfg
+}
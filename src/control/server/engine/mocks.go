@@ -86,3 +86,7 @@ func (tr *TestRunner) GetLastPid() uint64 {
 func (tr *TestRunner) GetConfig() *Config {
 	return tr.serverCfg
 }
+
+func (tr *TestRunner) SetConfig(cfg *Config) {
+	tr.serverCfg = cfg
+}
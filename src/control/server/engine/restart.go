@@ -0,0 +1,108 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RestartPolicyNever disables automatic restart of an engine that has
+	// exited unexpectedly.
+	RestartPolicyNever = "never"
+	// RestartPolicyOnFailure restarts an engine that has exited
+	// unexpectedly, backing off exponentially between attempts.
+	RestartPolicyOnFailure = "on-failure"
+
+	defaultRestartMaxAttempts = 5
+	defaultRestartBackoff     = 5 * time.Second
+	defaultRestartMaxBackoff  = 5 * time.Minute
+	defaultRestartResetWindow = 10 * time.Minute
+)
+
+// RestartPolicy describes whether and how an engine that has exited
+// unexpectedly is restarted by the harness.
+//
+// When the policy is on-failure, up to MaxAttempts consecutive restarts are
+// made, waiting Backoff before the first and doubling the wait (up to
+// MaxBackoff) before each subsequent attempt. If the engine runs for longer
+// than ResetWindow, the count of consecutive restarts is reset. Once
+// MaxAttempts has been reached, the engine is considered to be in a crash
+// loop and no further restarts are attempted.
+type RestartPolicy struct {
+	Policy      string        `yaml:"policy"`
+	MaxAttempts int           `yaml:"max_attempts,omitempty"`
+	Backoff     time.Duration `yaml:"backoff,omitempty"`
+	MaxBackoff  time.Duration `yaml:"max_backoff,omitempty"`
+	ResetWindow time.Duration `yaml:"reset_window,omitempty"`
+}
+
+// Validate ensures that the restart policy is valid, setting defaults for
+// any values that have not been supplied.
+func (rp *RestartPolicy) Validate() error {
+	if rp == nil {
+		return nil
+	}
+
+	switch rp.Policy {
+	case "":
+		rp.Policy = RestartPolicyNever
+	case RestartPolicyNever, RestartPolicyOnFailure:
+	default:
+		return errors.Errorf("unknown restart policy %q (valid: %s, %s)",
+			rp.Policy, RestartPolicyNever, RestartPolicyOnFailure)
+	}
+
+	if rp.MaxAttempts < 0 || rp.Backoff < 0 || rp.MaxBackoff < 0 || rp.ResetWindow < 0 {
+		return errors.New("restart policy values must not be negative")
+	}
+	if rp.MaxAttempts == 0 {
+		rp.MaxAttempts = defaultRestartMaxAttempts
+	}
+	if rp.Backoff == 0 {
+		rp.Backoff = defaultRestartBackoff
+	}
+	if rp.MaxBackoff == 0 {
+		rp.MaxBackoff = defaultRestartMaxBackoff
+	}
+	if rp.ResetWindow == 0 {
+		rp.ResetWindow = defaultRestartResetWindow
+	}
+	if rp.MaxBackoff < rp.Backoff {
+		return errors.Errorf("restart policy max_backoff (%s) is less than backoff (%s)",
+			rp.MaxBackoff, rp.Backoff)
+	}
+
+	return nil
+}
+
+// Enabled returns true if engines should be restarted automatically.
+func (rp *RestartPolicy) Enabled() bool {
+	return rp != nil && rp.Policy == RestartPolicyOnFailure
+}
+
+// BackoffDelay returns the time to wait before making the given restart
+// attempt, starting from 1.
+func (rp *RestartPolicy) BackoffDelay(attempt int) time.Duration {
+	if rp == nil || attempt < 1 {
+		return 0
+	}
+
+	delay := rp.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= rp.MaxBackoff {
+			return rp.MaxBackoff
+		}
+	}
+	if delay > rp.MaxBackoff {
+		return rp.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,104 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestEngine_RestartPolicy_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy    *RestartPolicy
+		expPolicy *RestartPolicy
+		expErr    error
+	}{
+		"nil": {},
+		"empty policy defaults to never": {
+			policy: &RestartPolicy{},
+			expPolicy: &RestartPolicy{
+				Policy:      RestartPolicyNever,
+				MaxAttempts: defaultRestartMaxAttempts,
+				Backoff:     defaultRestartBackoff,
+				MaxBackoff:  defaultRestartMaxBackoff,
+				ResetWindow: defaultRestartResetWindow,
+			},
+		},
+		"on-failure with overrides": {
+			policy: &RestartPolicy{
+				Policy:      RestartPolicyOnFailure,
+				MaxAttempts: 2,
+				Backoff:     time.Second,
+			},
+			expPolicy: &RestartPolicy{
+				Policy:      RestartPolicyOnFailure,
+				MaxAttempts: 2,
+				Backoff:     time.Second,
+				MaxBackoff:  defaultRestartMaxBackoff,
+				ResetWindow: defaultRestartResetWindow,
+			},
+		},
+		"unknown policy": {
+			policy: &RestartPolicy{Policy: "always"},
+			expErr: errors.New("unknown restart policy"),
+		},
+		"negative attempts": {
+			policy: &RestartPolicy{Policy: RestartPolicyOnFailure, MaxAttempts: -1},
+			expErr: errors.New("must not be negative"),
+		},
+		"max backoff less than backoff": {
+			policy: &RestartPolicy{
+				Policy:     RestartPolicyOnFailure,
+				Backoff:    time.Minute,
+				MaxBackoff: time.Second,
+			},
+			expErr: errors.New("less than backoff"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.policy.Validate()
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expPolicy, tc.policy); diff != "" {
+				t.Fatalf("unexpected policy (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestEngine_RestartPolicy_BackoffDelay(t *testing.T) {
+	policy := &RestartPolicy{
+		Policy:     RestartPolicyOnFailure,
+		Backoff:    5 * time.Second,
+		MaxBackoff: time.Minute,
+	}
+
+	for attempt, expDelay := range map[int]time.Duration{
+		0:  0,
+		1:  5 * time.Second,
+		2:  10 * time.Second,
+		3:  20 * time.Second,
+		4:  40 * time.Second,
+		5:  time.Minute,
+		64: time.Minute,
+	} {
+		common.AssertEqual(t, policy.BackoffDelay(attempt), expDelay, "unexpected delay")
+	}
+	common.AssertTrue(t, policy.Enabled(), "expected policy to be enabled")
+
+	var nilPolicy *RestartPolicy
+	common.AssertFalse(t, nilPolicy.Enabled(), "expected nil policy to be disabled")
+	common.AssertEqual(t, nilPolicy.BackoffDelay(1), time.Duration(0), "unexpected delay")
+}
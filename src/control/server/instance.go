@@ -21,6 +21,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/atm"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -57,6 +58,8 @@ type EngineInstance struct {
 	onStorageReady  []onStorageReadyFn
 	onReady         []onReadyFn
 	onInstanceExit  []onInstanceExitFn
+	onCrashLoop     []onCrashLoopFn
+	drpcObserver    drpc.CallObserver
 
 	sync.RWMutex
 	// these must be protected by a mutex in order to
@@ -65,6 +68,7 @@ type EngineInstance struct {
 	_drpcClient drpc.DomainSocketClient
 	_superblock *Superblock
 	_lastErr    error // populated when harness receives signal
+	_restart    restartState
 }
 
 // NewEngineInstance returns an *EngineInstance initialized with
@@ -196,6 +200,7 @@ func (ei *EngineInstance) determineRank(ctx context.Context, ready *srvpb.Notify
 		FaultDomain: ei.hostFaultDomain,
 		InstanceIdx: ei.Index(),
 		Incarnation: ready.GetIncarnation(),
+		Restarts:    ei.RestartCount(),
 	})
 	if err != nil {
 		ei.log.Errorf("join failed: %s", err)
@@ -335,6 +340,21 @@ func (ei *EngineInstance) GetTargetCount() int {
 	return ei.runner.GetConfig().TargetCount
 }
 
+// updateConfig applies update to a copy of the engine config and swaps the
+// copy in, so that concurrent readers never see a partially updated config.
+// The new config takes effect the next time the engine is started.
+func (ei *EngineInstance) updateConfig(update func(*engine.Config)) *engine.Config {
+	ei.Lock()
+	defer ei.Unlock()
+
+	cfg := new(engine.Config)
+	*cfg = *ei.runner.GetConfig()
+	update(cfg)
+	ei.runner.SetConfig(cfg)
+
+	return cfg
+}
+
 func (ei *EngineInstance) callSetUp(ctx context.Context) error {
 	dresp, err := ei.CallDrpc(ctx, drpc.MethodSetUp, nil)
 	if err != nil {
@@ -29,10 +29,20 @@ var (
 	errInstanceNotReady = errors.New("instance not ready yet")
 )
 
+// setDrpcClient sets the client used to make dRPC calls to the engine,
+// closing the connections of the client it replaces.
 func (ei *EngineInstance) setDrpcClient(c drpc.DomainSocketClient) {
 	ei.Lock()
-	defer ei.Unlock()
+	prev := ei._drpcClient
 	ei._drpcClient = c
+	ei.Unlock()
+
+	if prev == nil || prev == c {
+		return
+	}
+	if err := prev.Close(); err != nil {
+		ei.log.Errorf("instance %d: closing dRPC client: %s", ei.Index(), err)
+	}
 }
 
 func (ei *EngineInstance) getDrpcClient() (drpc.DomainSocketClient, error) {
@@ -44,13 +54,23 @@ func (ei *EngineInstance) getDrpcClient() (drpc.DomainSocketClient, error) {
 	return ei._drpcClient, nil
 }
 
+// setDrpcObserver sets an observer to be notified of the outcome of each dRPC
+// call made to the engine. Must be called before the engine is started.
+func (ei *EngineInstance) setDrpcObserver(obs drpc.CallObserver) {
+	ei.drpcObserver = obs
+}
+
 // NotifyDrpcReady receives a ready message from the running Engine
 // instance.
 func (ei *EngineInstance) NotifyDrpcReady(msg *srvpb.NotifyReadyReq) {
 	ei.log.Debugf("%s instance %d drpc ready: %v", build.DataPlaneName, ei.Index(), msg)
 
 	// activate the dRPC client connection to this engine
-	ei.setDrpcClient(drpc.NewClientConnection(msg.DrpcListenerSock))
+	pool := drpc.NewClientPool(msg.DrpcListenerSock, drpc.DefaultClientPoolSize)
+	if ei.drpcObserver != nil {
+		pool.SetCallObserver(ei.drpcObserver)
+	}
+	ei.setDrpcClient(pool)
 
 	go func() {
 		ei.drpcReady <- msg
@@ -76,9 +96,15 @@ func (ei *EngineInstance) CallDrpc(ctx context.Context, method drpc.Method, body
 		rankMsg = fmt.Sprintf(" (rank %s)", sb.Rank)
 	}
 
+	poolMsg := ""
+	if cp, ok := dc.(*drpc.ClientPool); ok {
+		stats := cp.Stats()
+		poolMsg = fmt.Sprintf(" (%d in flight, %d waited/%s)", stats.InFlight, stats.Waits, stats.WaitTime)
+	}
+
 	startedAt := time.Now()
 	defer func() {
-		ei.log.Debugf("dRPC to index %d%s: %s/%dB/%s", ei.Index(), rankMsg, method, proto.Size(body), time.Since(startedAt))
+		ei.log.Debugf("dRPC to index %d%s: %s/%dB/%s%s", ei.Index(), rankMsg, method, proto.Size(body), time.Since(startedAt), poolMsg)
 	}()
 
 	return makeDrpcCall(ctx, ei.log, dc, method, body)
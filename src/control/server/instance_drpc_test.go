@@ -58,6 +58,30 @@ func TestEngineInstance_NotifyDrpcReady(t *testing.T) {
 	waitForEngineReady(t, instance)
 }
 
+func TestEngineInstance_setDrpcClient(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	instance := getTestEngineInstance(log)
+	first := newMockDrpcClient(&mockDrpcClientConfig{})
+	second := newMockDrpcClient(&mockDrpcClientConfig{})
+
+	instance.setDrpcClient(first)
+	instance.setDrpcClient(first)
+	common.AssertEqual(t, 0, first.CloseCallCount, "client closed when set again")
+
+	// the client replaced on engine restart is closed
+	instance.setDrpcClient(second)
+	common.AssertEqual(t, 1, first.CloseCallCount, "replaced client not closed")
+
+	// and when it is cleared on engine exit
+	instance.setDrpcClient(nil)
+	common.AssertEqual(t, 1, second.CloseCallCount, "client not closed on exit")
+	if _, err := instance.getDrpcClient(); err == nil {
+		t.Fatal("expected no dRPC client after exit")
+	}
+}
+
 func TestEngineInstance_CallDrpc(t *testing.T) {
 	for name, tc := range map[string]struct {
 		notReady bool
@@ -29,6 +29,7 @@ type EngineRunner interface {
 	GetLastPid() uint64
 	Signal(os.Signal) error
 	GetConfig() *engine.Config
+	SetConfig(*engine.Config)
 }
 
 func (ei *EngineInstance) format(ctx context.Context, recreateSBs bool) error {
@@ -162,6 +163,9 @@ func (ei *EngineInstance) exit(ctx context.Context, exitErr error) {
 	if err := ei.removeSocket(); err != nil {
 		ei.log.Errorf("removing socket file: %s", err)
 	}
+
+	// the engine's dRPC server is gone, release the connections to it
+	ei.setDrpcClient(nil)
 }
 
 // run performs setup of and starts process runner for I/O Engine instance and
@@ -188,6 +192,7 @@ func (ei *EngineInstance) run(parent context.Context, recreateSBs bool) error {
 	if err := ei.start(parent, errChan); err != nil {
 		return err
 	}
+	ei.setStarted()
 	ei.waitDrpc.SetTrue()
 
 	if err := ei.waitReady(ctx, errChan); err != nil {
@@ -202,8 +207,11 @@ func (ei *EngineInstance) run(parent context.Context, recreateSBs bool) error {
 }
 
 // requestStart makes a request to (re-)start the engine, and blocks
-// until the request is received.
+// until the request is received. Any automatic restart that is pending
+// is canceled and the restart policy state is reset.
 func (ei *EngineInstance) requestStart(ctx context.Context) {
+	ei.resetRestartState()
+
 	select {
 	case <-ctx.Done():
 	case ei.startRequested <- true:
@@ -224,7 +232,9 @@ func (ei *EngineInstance) Run(ctx context.Context, recreateSBs bool) {
 					return
 				}
 
-				ei.exit(ctx, ei.run(ctx, recreateSBs))
+				exitErr := ei.run(ctx, recreateSBs)
+				ei.exit(ctx, exitErr)
+				ei.handleRestart(ctx, exitErr)
 			}
 		}
 	}()
@@ -235,6 +245,8 @@ func (ei *EngineInstance) Run(ctx context.Context, recreateSBs bool) {
 
 // Stop sends signal to stop EngineInstance runner (nonblocking).
 func (ei *EngineInstance) Stop(signal os.Signal) error {
+	ei.requestStop()
+
 	ei.RLock()
 	if ei._cancelCtx != nil {
 		ei._cancelCtx()
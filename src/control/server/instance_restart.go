@@ -0,0 +1,235 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type (
+	onCrashLoopFn func(context.Context, uint32, system.Rank, error, int) error
+
+	// pendingRestart tracks an automatic restart that is waiting for its
+	// backoff delay to expire.
+	pendingRestart struct {
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+
+	// restartState tracks automatic restarts of the instance performed
+	// according to its restart policy.
+	restartState struct {
+		startedAt     time.Time
+		attempts      int
+		restarts      uint32
+		crashLoop     bool
+		stopRequested bool
+		pending       *pendingRestart
+	}
+)
+
+// OnCrashLoop adds a list of callbacks to invoke when the instance restart
+// policy gives up restarting the instance.
+func (ei *EngineInstance) OnCrashLoop(fns ...onCrashLoopFn) {
+	ei.onCrashLoop = append(ei.onCrashLoop, fns...)
+}
+
+// publishCrashLoopFn returns onCrashLoopFn which will publish a crash loop
+// event using the provided publish function.
+func publishCrashLoopFn(publishFn func(*events.RASEvent), hostname string) onCrashLoopFn {
+	return func(_ context.Context, engineIdx uint32, rank system.Rank, exitErr error, restarts int) error {
+		if exitErr == nil {
+			return errors.New("expected non-nil exit error")
+		}
+
+		evt := events.NewEngineCrashLoopEvent(hostname, engineIdx, rank.Uint32(),
+			common.ExitStatus(exitErr.Error()), restarts)
+
+		publishFn(evt.WithForwardable(!rank.Equals(system.NilRank)))
+
+		return nil
+	}
+}
+
+func (ei *EngineInstance) restartPolicy() *engine.RestartPolicy {
+	if cfg := ei.runner.GetConfig(); cfg != nil {
+		return cfg.RestartPolicy
+	}
+	return nil
+}
+
+// RestartCount returns the number of times the instance has been restarted
+// automatically by its restart policy.
+func (ei *EngineInstance) RestartCount() uint32 {
+	ei.RLock()
+	defer ei.RUnlock()
+
+	return ei._restart.restarts
+}
+
+// IsCrashLooping indicates whether the restart policy has stopped restarting
+// the instance after too many consecutive failures.
+func (ei *EngineInstance) IsCrashLooping() bool {
+	ei.RLock()
+	defer ei.RUnlock()
+
+	return ei._restart.crashLoop
+}
+
+// setStarted records the time at which the instance was last started.
+func (ei *EngineInstance) setStarted() {
+	ei.Lock()
+	defer ei.Unlock()
+
+	ei._restart.startedAt = time.Now()
+}
+
+// cancelPendingRestart cancels any automatic restart that is waiting for its
+// backoff delay to expire, and waits for it to finish.
+func (ei *EngineInstance) cancelPendingRestart() {
+	ei.Lock()
+	pending := ei._restart.pending
+	ei._restart.pending = nil
+	ei.Unlock()
+
+	if pending == nil {
+		return
+	}
+	pending.cancel()
+	<-pending.done
+}
+
+// resetRestartState is called when a start is requested by an administrator
+// or the harness, clearing any crash loop so that the restart policy applies
+// afresh.
+func (ei *EngineInstance) resetRestartState() {
+	ei.cancelPendingRestart()
+
+	ei.Lock()
+	defer ei.Unlock()
+
+	ei._restart.attempts = 0
+	ei._restart.crashLoop = false
+	ei._restart.stopRequested = false
+}
+
+// requestStop records that the instance is being stopped deliberately, so
+// that its exit does not trigger an automatic restart.
+func (ei *EngineInstance) requestStop() {
+	ei.cancelPendingRestart()
+
+	ei.Lock()
+	defer ei.Unlock()
+
+	ei._restart.stopRequested = true
+}
+
+// handleRestart applies the instance restart policy after the instance has
+// exited. If the exit was unexpected and the policy allows, a restart is
+// scheduled after the backoff delay. If the maximum number of consecutive
+// restarts has been reached, the instance is considered to be crash looping
+// and the onCrashLoop callbacks are invoked instead.
+func (ei *EngineInstance) handleRestart(ctx context.Context, exitErr error) {
+	policy := ei.restartPolicy()
+	if !policy.Enabled() || exitErr == nil || ctx.Err() != nil {
+		return
+	}
+
+	engineIdx := ei.Index()
+
+	ei.Lock()
+	if ei._restart.stopRequested || ei._restart.pending != nil {
+		ei.Unlock()
+		return
+	}
+	if !ei._restart.startedAt.IsZero() && time.Since(ei._restart.startedAt) > policy.ResetWindow {
+		ei._restart.attempts = 0
+	}
+	if ei._restart.attempts >= policy.MaxAttempts {
+		ei._restart.crashLoop = true
+		restarts := ei._restart.attempts
+		ei.Unlock()
+
+		ei.log.Errorf("instance %d: crash loop detected after %d restarts, not restarting",
+			engineIdx, restarts)
+
+		rank, err := ei.GetRank()
+		if err != nil {
+			ei.log.Debugf("instance %d: no rank (%s)", engineIdx, err)
+		}
+		for _, fn := range ei.onCrashLoop {
+			if err := fn(ctx, engineIdx, rank, exitErr, restarts); err != nil {
+				ei.log.Errorf("onCrashLoop: %s", err)
+			}
+		}
+		return
+	}
+
+	ei._restart.attempts++
+	delay := policy.BackoffDelay(ei._restart.attempts)
+	rctx, cancel := context.WithCancel(ctx)
+	pending := &pendingRestart{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	ei._restart.pending = pending
+	attempt := ei._restart.attempts
+	ei.Unlock()
+
+	ei.log.Infof("instance %d: restarting in %s (attempt %d of %d)",
+		engineIdx, delay, attempt, policy.MaxAttempts)
+
+	go func() {
+		defer close(pending.done)
+		defer cancel()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-rctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Count the restart before it is requested so that the
+		// count is reported when the restarted instance joins.
+		ei.Lock()
+		ei._restart.restarts++
+		ei.Unlock()
+
+		select {
+		case <-rctx.Done():
+			ei.Lock()
+			ei._restart.restarts--
+			ei.Unlock()
+		case ei.startRequested <- true:
+			ei.Lock()
+			if ei._restart.pending == pending {
+				ei._restart.pending = nil
+			}
+			ei.Unlock()
+		}
+	}()
+}
+
+// isStopRequested indicates whether the instance is being stopped
+// deliberately.
+func (ei *EngineInstance) isStopRequested() bool {
+	ei.RLock()
+	defer ei.RUnlock()
+
+	return ei._restart.stopRequested
+}
@@ -0,0 +1,159 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestIOEngineInstance_handleRestart(t *testing.T) {
+	exitErr := errors.New("killed")
+	onFailure := func() *engine.RestartPolicy {
+		return &engine.RestartPolicy{
+			Policy:      engine.RestartPolicyOnFailure,
+			MaxAttempts: 2,
+			Backoff:     time.Millisecond,
+			MaxBackoff:  time.Millisecond,
+			ResetWindow: time.Hour,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		policy        *engine.RestartPolicy
+		exitErr       error
+		stopRequested bool
+		prevAttempts  int
+		startedAt     time.Time
+		expRestart    bool
+		expCrashLoop  bool
+	}{
+		"no policy": {
+			exitErr: exitErr,
+		},
+		"never": {
+			policy:  &engine.RestartPolicy{Policy: engine.RestartPolicyNever},
+			exitErr: exitErr,
+		},
+		"on-failure": {
+			policy:     onFailure(),
+			exitErr:    exitErr,
+			expRestart: true,
+		},
+		"on-failure; clean exit": {
+			policy: onFailure(),
+		},
+		"on-failure; stop requested": {
+			policy:        onFailure(),
+			exitErr:       exitErr,
+			stopRequested: true,
+		},
+		"on-failure; max attempts reached": {
+			policy:       onFailure(),
+			exitErr:      exitErr,
+			prevAttempts: 2,
+			expCrashLoop: true,
+		},
+		"on-failure; max attempts reached outside reset window": {
+			policy:       onFailure(),
+			exitErr:      exitErr,
+			prevAttempts: 2,
+			startedAt:    time.Now().Add(-2 * time.Hour),
+			expRestart:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runner := engine.NewTestRunner(nil, engine.NewConfig().WithRestartPolicy(tc.policy))
+			ei := NewEngineInstance(log, nil, nil, runner)
+			ei.setSuperblock(&Superblock{
+				Rank: system.NewRankPtr(1), ValidRank: true,
+			})
+			ei._restart.attempts = tc.prevAttempts
+			ei._restart.startedAt = tc.startedAt
+			ei._restart.stopRequested = tc.stopRequested
+
+			var rxEvts []*events.RASEvent
+			ei.OnCrashLoop(publishCrashLoopFn(func(evt *events.RASEvent) {
+				rxEvts = append(rxEvts, evt)
+			}, "foo"))
+
+			ei.handleRestart(ctx, tc.exitErr)
+
+			var restarted bool
+			select {
+			case restarted = <-ei.startRequested:
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			common.AssertEqual(t, tc.expRestart, restarted, "unexpected restart")
+			common.AssertEqual(t, tc.expCrashLoop, ei.IsCrashLooping(), "unexpected crash loop state")
+			if tc.expRestart {
+				common.AssertEqual(t, uint32(1), ei.RestartCount(), "unexpected restart count")
+			} else {
+				common.AssertEqual(t, uint32(0), ei.RestartCount(), "unexpected restart count")
+			}
+
+			if !tc.expCrashLoop {
+				common.AssertEqual(t, 0, len(rxEvts), "unexpected events published")
+				return
+			}
+			common.AssertEqual(t, 1, len(rxEvts), "unexpected number of events published")
+			common.AssertEqual(t, events.RASEngineCrashLoop.String(), rxEvts[0].ID.String(), "unexpected event")
+			common.AssertTrue(t, rxEvts[0].ShouldForward(), "expected event to be forwarded")
+
+			// A manual start clears the crash loop.
+			ei.resetRestartState()
+			common.AssertFalse(t, ei.IsCrashLooping(), "expected crash loop to be cleared")
+		})
+	}
+}
+
+func TestIOEngineInstance_cancelPendingRestart(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	runner := engine.NewTestRunner(nil, engine.NewConfig().WithRestartPolicy(&engine.RestartPolicy{
+		Policy:      engine.RestartPolicyOnFailure,
+		MaxAttempts: 1,
+		Backoff:     time.Hour,
+		MaxBackoff:  time.Hour,
+		ResetWindow: time.Hour,
+	}))
+	ei := NewEngineInstance(log, nil, nil, runner)
+
+	ei.handleRestart(context.Background(), errors.New("killed"))
+	ei.RLock()
+	pending := ei._restart.pending
+	ei.RUnlock()
+	if pending == nil {
+		t.Fatal("expected restart to be pending")
+	}
+
+	ei.requestStop()
+
+	select {
+	case <-pending.done:
+	default:
+		t.Fatal("expected pending restart to be canceled")
+	}
+	common.AssertEqual(t, uint32(0), ei.RestartCount(), "unexpected restart count")
+}
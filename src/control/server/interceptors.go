@@ -7,7 +7,10 @@
 package server
 
 import (
+	"crypto/x509"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -20,26 +23,34 @@ import (
 	"github.com/daos-stack/daos/src/control/common/proto"
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
-func checkAccess(ctx context.Context, FullMethod string) error {
-	component, err := componentFromContext(ctx)
-
+func checkAccess(ctx context.Context, FullMethod string, authCfg *security.AuthorizationConfig) error {
+	peerCert, err := certFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	hasAccess := component.HasAccess(FullMethod)
+	hasAccess, err := authCfg.CertHasAccess(peerCert, FullMethod)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
 
 	if !hasAccess {
+		component := security.CommonNameToComponent(peerCert.Subject.CommonName)
 		errMsg := fmt.Sprintf("%s does not have permission to call %s", component, FullMethod)
+		if roles, _ := security.CertificateRoles(peerCert); len(roles) > 0 {
+			errMsg = fmt.Sprintf("%s with roles %s does not have permission to call %s",
+				component, strings.Join(roles, ","), FullMethod)
+		}
 		return status.Error(codes.PermissionDenied, errMsg)
 	}
 
 	return nil
 }
 
-func componentFromContext(ctx context.Context) (comp *security.Component, err error) {
+func certFromContext(ctx context.Context) (*x509.Certificate, error) {
 	clientPeer, ok := peer.FromContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "no peer information found")
@@ -56,36 +67,35 @@ func componentFromContext(ctx context.Context) (comp *security.Component, err er
 		return nil, status.Error(codes.Unauthenticated, "unable to verify client certificates")
 	}
 
-	peerCert := certs[0][0]
-
-	component := security.CommonNameToComponent(peerCert.Subject.CommonName)
-
-	return &component, nil
+	return certs[0][0], nil
 }
 
-func unaryAccessInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+func unaryAccessInterceptor(authCfg *security.AuthorizationConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := checkAccess(ctx, info.FullMethod, authCfg)
 
-	err := checkAccess(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, err
+		return handler(ctx, req)
 	}
-
-	return handler(ctx, req)
 }
 
-func streamAccessInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	ctx := ss.Context()
-	err := checkAccess(ctx, info.FullMethod)
+func streamAccessInterceptor(authCfg *security.AuthorizationConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		err := checkAccess(ctx, info.FullMethod, authCfg)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return handler(srv, ss)
+		return handler(srv, ss)
+	}
 }
 
-func unaryInterceptorForTransportConfig(cfg *security.TransportConfig) (grpc.UnaryServerInterceptor, error) {
+func unaryInterceptorForTransportConfig(cfg *security.TransportConfig, authCfg *security.AuthorizationConfig) (grpc.UnaryServerInterceptor, error) {
 	if cfg == nil {
 		return nil, errors.New("nil TransportConfig")
 	}
@@ -94,10 +104,10 @@ func unaryInterceptorForTransportConfig(cfg *security.TransportConfig) (grpc.Una
 		return nil, nil
 	}
 
-	return unaryAccessInterceptor, nil
+	return unaryAccessInterceptor(authCfg), nil
 }
 
-func streamInterceptorForTransportConfig(cfg *security.TransportConfig) (grpc.StreamServerInterceptor, error) {
+func streamInterceptorForTransportConfig(cfg *security.TransportConfig, authCfg *security.AuthorizationConfig) (grpc.StreamServerInterceptor, error) {
 	if cfg == nil {
 		return nil, errors.New("nil TransportConfig")
 	}
@@ -106,7 +116,75 @@ func streamInterceptorForTransportConfig(cfg *security.TransportConfig) (grpc.St
 		return nil, nil
 	}
 
-	return streamAccessInterceptor, nil
+	return streamAccessInterceptor(authCfg), nil
+}
+
+// auditCaller returns the identity, roles and network address of the caller.
+func auditCaller(ctx context.Context) (caller string, roles []string, source string) {
+	caller = "unauthenticated"
+	if clientPeer, ok := peer.FromContext(ctx); ok && clientPeer.Addr != nil {
+		source = clientPeer.Addr.String()
+	}
+
+	peerCert, err := certFromContext(ctx)
+	if err != nil {
+		return
+	}
+	caller = security.CommonNameToComponent(peerCert.Subject.CommonName).String()
+	roles, _ = security.CertificateRoles(peerCert)
+	return
+}
+
+// newAuditRecord returns an audit record for a completed request.
+func newAuditRecord(ctx context.Context, method string, req interface{}, start time.Time, err error) *system.AuditRecord {
+	rec := &system.AuditRecord{
+		Time:     start,
+		Method:   method,
+		Fields:   auditFields(req),
+		Outcome:  auditOutcomeSuccess,
+		Duration: time.Since(start),
+	}
+	rec.Caller, rec.Roles, rec.Source = auditCaller(ctx)
+	if err != nil {
+		rec.Outcome = auditOutcomeFailure
+		rec.Error = err.Error()
+	}
+
+	return rec
+}
+
+// unaryAuditInterceptor records mutating requests in the audit log. It must
+// be the outermost interceptor so that requests denied by the access
+// interceptor are also recorded.
+func unaryAuditInterceptor(al *auditLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !isAuditedMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		res, err := handler(ctx, req)
+		al.record(newAuditRecord(ctx, info.FullMethod, req, start, err))
+
+		return res, err
+	}
+}
+
+// streamAuditInterceptor records mutating streaming requests in the audit
+// log. Stream messages are not inspected, so no request fields are recorded.
+// As with unaryAuditInterceptor, it must be the outermost interceptor.
+func streamAuditInterceptor(al *auditLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !isAuditedMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		start := time.Now()
+		err := handler(srv, ss)
+		al.record(newAuditRecord(ss.Context(), info.FullMethod, nil, start, err))
+
+		return err
+	}
 }
 
 func unaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
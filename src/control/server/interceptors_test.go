@@ -8,13 +8,30 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 type testStatus struct {
@@ -64,3 +81,240 @@ func TestServer_unaryStatusInterceptor(t *testing.T) {
 		})
 	}
 }
+
+func peerContext(t *testing.T, cn string, roles ...string) context.Context {
+	t.Helper()
+
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: cn},
+	}
+	if len(roles) > 0 {
+		value, err := asn1.Marshal(roles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert.Extensions = []pkix.Extension{{Id: security.RoleExtensionOID, Value: value}}
+	}
+
+	return peer.NewContext(context.TODO(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			},
+		},
+	})
+}
+
+func TestServer_unaryAccessInterceptor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ctx     context.Context
+		authCfg *security.AuthorizationConfig
+		method  string
+		expCode codes.Code
+		expMsg  string
+	}{
+		"no peer": {
+			ctx:     context.TODO(),
+			method:  "/mgmt.MgmtSvc/SystemQuery",
+			expCode: codes.Unauthenticated,
+		},
+		"admin without roles": {
+			ctx:     peerContext(t, "admin"),
+			method:  "/mgmt.MgmtSvc/SystemErase",
+			expCode: codes.OK,
+		},
+		"admin without roles; roles required": {
+			ctx:     peerContext(t, "admin"),
+			authCfg: &security.AuthorizationConfig{RequireRoles: true},
+			method:  "/mgmt.MgmtSvc/SystemQuery",
+			expCode: codes.PermissionDenied,
+			expMsg:  "admin does not have permission to call /mgmt.MgmtSvc/SystemQuery",
+		},
+		"read-only query": {
+			ctx:     peerContext(t, "admin", security.RoleReadOnly),
+			method:  "/mgmt.MgmtSvc/SystemQuery",
+			expCode: codes.OK,
+		},
+		"read-only erase": {
+			ctx:     peerContext(t, "admin", security.RoleReadOnly),
+			method:  "/mgmt.MgmtSvc/SystemErase",
+			expCode: codes.PermissionDenied,
+			expMsg:  "admin with roles read-only does not have permission to call /mgmt.MgmtSvc/SystemErase",
+		},
+		"agent calling admin method": {
+			ctx:     peerContext(t, "agent"),
+			method:  "/mgmt.MgmtSvc/SystemQuery",
+			expCode: codes.PermissionDenied,
+			expMsg:  "agent does not have permission to call /mgmt.MgmtSvc/SystemQuery",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handlerCalled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCalled = true
+				return nil, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+
+			_, gotErr := unaryAccessInterceptor(tc.authCfg)(tc.ctx, nil, info, handler)
+			st := status.Convert(gotErr)
+			common.AssertEqual(t, st.Code(), tc.expCode, "unexpected status code")
+			common.AssertEqual(t, handlerCalled, tc.expCode == codes.OK, "unexpected handler call")
+			if tc.expMsg != "" {
+				common.AssertEqual(t, st.Message(), tc.expMsg, "unexpected error message")
+			}
+		})
+	}
+}
+
+func TestServer_unaryAuditInterceptor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ctx        context.Context
+		method     string
+		req        interface{}
+		handlerErr error
+		expRecord  *system.AuditRecord
+	}{
+		"query not audited": {
+			ctx:    peerContext(t, "admin"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+			req:    &mgmtpb.SystemQueryReq{},
+		},
+		"successful request": {
+			ctx:    peerContext(t, "admin", security.RoleOperator),
+			method: "/mgmt.MgmtSvc/SystemStop",
+			req:    &mgmtpb.SystemStopReq{Ranks: "0-1"},
+			expRecord: &system.AuditRecord{
+				Host:    "host1",
+				Caller:  "admin",
+				Roles:   []string{security.RoleOperator},
+				Method:  "/mgmt.MgmtSvc/SystemStop",
+				Fields:  map[string]string{"ranks": "0-1"},
+				Outcome: auditOutcomeSuccess,
+			},
+		},
+		"failed request": {
+			ctx:        peerContext(t, "admin"),
+			method:     "/mgmt.MgmtSvc/PoolDestroy",
+			req:        &mgmtpb.PoolDestroyReq{Id: "pool1"},
+			handlerErr: errors.New("permission denied"),
+			expRecord: &system.AuditRecord{
+				Host:    "host1",
+				Caller:  "admin",
+				Method:  "/mgmt.MgmtSvc/PoolDestroy",
+				Fields:  map[string]string{"id": "pool1"},
+				Outcome: auditOutcomeFailure,
+				Error:   "permission denied",
+			},
+		},
+		"unauthenticated caller": {
+			ctx:    context.TODO(),
+			method: "/ctl.CtlSvc/StorageFormat",
+			req:    &ctlpb.StorageFormatReq{Reformat: true},
+			expRecord: &system.AuditRecord{
+				Host:    "host1",
+				Caller:  "unauthenticated",
+				Method:  "/ctl.CtlSvc/StorageFormat",
+				Fields:  map[string]string{"reformat": "true"},
+				Outcome: auditOutcomeSuccess,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			al, err := newAuditLogger(log, &config.AuditConfig{
+				LogFile: filepath.Join(testDir, "audit.log"),
+			}, "host1", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer al.Close()
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tc.handlerErr
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+
+			_, gotErr := unaryAuditInterceptor(al)(tc.ctx, tc.req, info, handler)
+			common.CmpErr(t, tc.handlerErr, gotErr)
+
+			records, err := al.fileRecords()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.expRecord == nil {
+				common.AssertEqual(t, len(records), 0, "unexpected audit records")
+				return
+			}
+			common.AssertEqual(t, len(records), 1, "unexpected number of audit records")
+
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreFields(system.AuditRecord{}, "Time", "Duration"),
+			}
+			if diff := cmp.Diff(tc.expRecord, records[0], cmpOpts...); diff != "" {
+				t.Fatalf("unexpected audit record (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *testServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+func TestServer_streamAuditInterceptor(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	al, err := newAuditLogger(log, &config.AuditConfig{
+		LogFile: filepath.Join(testDir, "audit.log"),
+	}, "host1", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer al.Close()
+
+	handlerErr := errors.New("stream closed")
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		return handlerErr
+	}
+	ss := &testServerStream{ctx: peerContext(t, "admin")}
+	for _, method := range []string{"/ctl.CtlSvc/StorageScan", "/ctl.CtlSvc/StorageFormat"} {
+		info := &grpc.StreamServerInfo{FullMethod: method}
+		gotErr := streamAuditInterceptor(al)(nil, ss, info, handler)
+		common.CmpErr(t, handlerErr, gotErr)
+	}
+
+	records, err := al.fileRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expRecords := []*system.AuditRecord{
+		{
+			Host:    "host1",
+			Caller:  "admin",
+			Method:  "/ctl.CtlSvc/StorageFormat",
+			Outcome: auditOutcomeFailure,
+			Error:   handlerErr.Error(),
+		},
+	}
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(system.AuditRecord{}, "Time", "Duration"),
+	}
+	if diff := cmp.Diff(expRecords, records, cmpOpts...); diff != "" {
+		t.Fatalf("unexpected audit records (-want, +got):\n%s\n", diff)
+	}
+}
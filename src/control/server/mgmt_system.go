@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/protobuf/proto"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
@@ -29,6 +31,7 @@ import (
 	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -266,6 +269,9 @@ func (svc *mgmtSvc) join(ctx context.Context, req *batchJoinRequest) *batchJoinR
 		FabricContexts: req.GetNctxs(),
 		FaultDomain:    fd,
 		Incarnation:    req.GetIncarnation(),
+		Restarts:       req.GetRestarts(),
+		ConfigHash:     req.GetConfigHash(),
+		Config:         req.GetConfig(),
 	})
 	if err != nil {
 		return &batchJoinResponse{joinErr: err}
@@ -598,6 +604,81 @@ func (svc *mgmtSvc) SystemQuery(ctx context.Context, req *mgmtpb.SystemQueryReq)
 	return resp, nil
 }
 
+// SystemConfigCheck groups system members by the hash of the normalized
+// server config supplied when they joined. The group with the most members is
+// used as the reference and the parameters which differ from it are reported
+// for each of the other groups. Members which joined without a config hash
+// are grouped together last.
+func (svc *mgmtSvc) SystemConfigCheck(ctx context.Context, req *mgmtpb.SystemConfigCheckReq) (*mgmtpb.SystemConfigCheckResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemConfigCheck RPC: %+v", req)
+
+	groups := make(map[string]*mgmtpb.SystemConfigGroup)
+	groupRanks := make(map[string]system.RankList)
+	groupHosts := make(map[string]map[string]struct{})
+	for _, m := range svc.membership.Members(nil) {
+		if _, exists := groups[m.ConfigHash]; !exists {
+			groups[m.ConfigHash] = &mgmtpb.SystemConfigGroup{
+				Hash:   m.ConfigHash,
+				Config: m.Config,
+			}
+			groupHosts[m.ConfigHash] = make(map[string]struct{})
+		}
+		groupRanks[m.ConfigHash] = append(groupRanks[m.ConfigHash], m.Rank)
+		groupHosts[m.ConfigHash][m.Addr.String()] = struct{}{}
+	}
+
+	resp := new(mgmtpb.SystemConfigCheckResp)
+	for hash, group := range groups {
+		group.Ranks = system.RankSetFromRanks(groupRanks[hash]).String()
+		for host := range groupHosts[hash] {
+			group.Hosts = append(group.Hosts, host)
+		}
+		sort.Strings(group.Hosts)
+		resp.Groups = append(resp.Groups, group)
+	}
+	sort.Slice(resp.Groups, func(i, j int) bool {
+		gi, gj := resp.Groups[i], resp.Groups[j]
+		if (gi.Hash == "") != (gj.Hash == "") {
+			return gj.Hash == ""
+		}
+		ni, nj := len(groupRanks[gi.Hash]), len(groupRanks[gj.Hash])
+		if ni != nj {
+			return ni > nj
+		}
+		return gi.Hash < gj.Hash
+	})
+
+	if len(resp.Groups) > 1 && resp.Groups[0].Hash != "" {
+		refCfg := new(config.Server)
+		if err := yaml.Unmarshal([]byte(resp.Groups[0].Config), refCfg); err != nil {
+			return nil, errors.Wrapf(err, "parse config with hash %s", resp.Groups[0].Hash)
+		}
+		for _, group := range resp.Groups[1:] {
+			if group.Hash == "" {
+				continue
+			}
+			groupCfg := new(config.Server)
+			if err := yaml.Unmarshal([]byte(group.Config), groupCfg); err != nil {
+				return nil, errors.Wrapf(err, "parse config with hash %s", group.Hash)
+			}
+			for _, fd := range refCfg.DiffFields(groupCfg) {
+				group.Diffs = append(group.Diffs, &mgmtpb.ConfigFieldDiff{
+					Field:     fd.Field,
+					Value:     fd.Other,
+					Reference: fd.Value,
+				})
+			}
+		}
+	}
+
+	svc.log.Debugf("Responding to SystemConfigCheck RPC: %s", mgmtpb.Debug(resp))
+
+	return resp, nil
+}
+
 func fanout2pbStopResp(act string, fr *fanoutResponse) (*mgmtpb.SystemStopResp, error) {
 	sr := &mgmtpb.SystemStopResp{}
 	sr.Absentranks = fr.AbsentRanks.String()
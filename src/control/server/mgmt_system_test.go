@@ -28,6 +28,8 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -1045,6 +1047,128 @@ func TestServer_MgmtSvc_SystemQuery(t *testing.T) {
 	}
 }
 
+func TestServer_MgmtSvc_SystemConfigCheck(t *testing.T) {
+	fingerprint := func(targets int, provider string) (string, string) {
+		hash, data, err := config.DefaultServer().
+			WithFabricProvider(provider).
+			WithEngines(engine.NewConfig().WithTargetCount(targets)).
+			Fingerprint()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash, string(data)
+	}
+	refHash, refCfg := fingerprint(8, "ofi+verbs")
+	tgtHash, tgtCfg := fingerprint(16, "ofi+verbs")
+	provHash, provCfg := fingerprint(8, "ofi+tcp")
+
+	withConfig := func(m *system.Member, hash, cfg string) *system.Member {
+		m.ConfigHash = hash
+		m.Config = cfg
+		return m
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq    bool
+		members   system.Members
+		expGroups []*mgmtpb.SystemConfigGroup
+		expErrMsg string
+	}{
+		"nil req": {
+			nilReq:    true,
+			expErrMsg: "nil request",
+		},
+		"no members": {},
+		"consistent configs": {
+			members: system.Members{
+				withConfig(mockMember(t, 0, 1, "joined"), refHash, refCfg),
+				withConfig(mockMember(t, 1, 1, "joined"), refHash, refCfg),
+				withConfig(mockMember(t, 2, 2, "joined"), refHash, refCfg),
+			},
+			expGroups: []*mgmtpb.SystemConfigGroup{
+				{
+					Hash:   refHash,
+					Ranks:  "0-2",
+					Hosts:  []string{common.MockHostAddr(1).String(), common.MockHostAddr(2).String()},
+					Config: refCfg,
+				},
+			},
+		},
+		"config drift": {
+			members: system.Members{
+				withConfig(mockMember(t, 0, 1, "joined"), refHash, refCfg),
+				withConfig(mockMember(t, 1, 1, "joined"), refHash, refCfg),
+				withConfig(mockMember(t, 2, 2, "joined"), tgtHash, tgtCfg),
+				withConfig(mockMember(t, 3, 3, "joined"), provHash, provCfg),
+				withConfig(mockMember(t, 4, 3, "joined"), provHash, provCfg),
+				withConfig(mockMember(t, 5, 4, "joined"), refHash, refCfg),
+				mockMember(t, 6, 5, "joined"),
+			},
+			expGroups: []*mgmtpb.SystemConfigGroup{
+				{
+					Hash:  refHash,
+					Ranks: "0-1,5",
+					Hosts: []string{
+						common.MockHostAddr(1).String(),
+						common.MockHostAddr(4).String(),
+					},
+					Config: refCfg,
+				},
+				{
+					Hash:   provHash,
+					Ranks:  "3-4",
+					Hosts:  []string{common.MockHostAddr(3).String()},
+					Config: provCfg,
+					Diffs: []*mgmtpb.ConfigFieldDiff{
+						{Field: "engines[0].provider", Value: "ofi+tcp", Reference: "ofi+verbs"},
+						{Field: "provider", Value: "ofi+tcp", Reference: "ofi+verbs"},
+					},
+				},
+				{
+					Hash:   tgtHash,
+					Ranks:  "2",
+					Hosts:  []string{common.MockHostAddr(2).String()},
+					Config: tgtCfg,
+					Diffs: []*mgmtpb.ConfigFieldDiff{
+						{Field: "engines[0].targets", Value: "16", Reference: "8"},
+					},
+				},
+				{
+					Ranks: "6",
+					Hosts: []string{common.MockHostAddr(5).String()},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			for _, m := range tc.members {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := &mgmtpb.SystemConfigCheckReq{Sys: build.DefaultSystemName}
+			if tc.nilReq {
+				req = nil
+			}
+
+			gotResp, gotErr := svc.SystemConfigCheck(context.TODO(), req)
+			common.ExpectError(t, gotErr, tc.expErrMsg, name)
+			if tc.expErrMsg != "" {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expGroups, gotResp.Groups, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected groups (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestServer_MgmtSvc_SystemStart(t *testing.T) {
 	hr := func(a int32, rrs ...*sharedpb.RankResult) *control.HostResponse {
 		return &control.HostResponse{
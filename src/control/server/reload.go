@@ -0,0 +1,391 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/pbin"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+// engineFieldRegexp matches the config file path of an engine parameter,
+// capturing the engine index and the parameter name.
+var engineFieldRegexp = regexp.MustCompile(`^engines\[(\d+)\]\.([^.\[]+)`)
+
+type (
+	loadConfigFn   func(logging.Logger, string) (*config.Server, error)
+	updateEnvarsFn func(context.Context, *config.Server) error
+)
+
+// loadConfig reads and validates the server config file at the given path.
+func loadConfig(log logging.Logger, path string) (*config.Server, error) {
+	cfg := config.DefaultServer()
+	cfg.Path = path
+	if err := cfg.Load(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(log); err != nil {
+		return nil, errors.Wrapf(err, "%s: validation failed", path)
+	}
+
+	return cfg, nil
+}
+
+// configReloader re-reads the server config file and applies changes to the
+// running configuration where this can be done without a restart.
+//
+// Control plane log level and log file, privileged helper log files, dRPC slow
+// call threshold, shutdown drain timeout, MS access points used for event
+// forwarding and joins, telemetry port and engine log masks are applied
+// immediately. Other engine parameters are applied to the engine config and
+// take effect when the engine is next started. Remaining parameters, including
+// engine storage, the number of engines and JSON log formatting, require
+// daos_server to be restarted. The control log file is reopened on each
+// reload so that rotated files are picked up. Privileged helpers are started
+// per request, so a new helper log file is used by the next helper started.
+// Engine log files are engine command line arguments and take effect on
+// engine restart. Events are forwarded to the access points,
+// so the event sink is updated when they are. Note that the MS replica set is
+// fixed when daos_server starts.
+type configReloader struct {
+	sync.Mutex
+	log           *logging.LeveledLogger
+	cfg           *config.Server
+	harness       *EngineHarness
+	forwarder     *control.EventForwarder
+	drpcMetrics   *drpcMetrics
+	exporter      *promExporter
+	logFile       *logging.LogFile
+	loadConfig    loadConfigFn
+	checkHardware checkHardwareFn
+	updateEnvars  updateEnvarsFn
+	restart       func()
+}
+
+func newConfigReloader(log *logging.LeveledLogger, cfg *config.Server, harness *EngineHarness, forwarder *control.EventForwarder, metrics *drpcMetrics) *configReloader {
+	return &configReloader{
+		log:           log,
+		cfg:           cfg,
+		harness:       harness,
+		forwarder:     forwarder,
+		drpcMetrics:   metrics,
+		loadConfig:    loadConfig,
+		checkHardware: checkConfigHardware,
+		updateEnvars:  updateEnginesFabricEnvars,
+	}
+}
+
+// setExporter registers the telemetry exporter once it has been started.
+func (r *configReloader) setExporter(pe *promExporter) {
+	r.Lock()
+	defer r.Unlock()
+	r.exporter = pe
+}
+
+// setLogFile registers the control log file so that it can be reopened.
+func (r *configReloader) setLogFile(lf *logging.LogFile) {
+	r.Lock()
+	defer r.Unlock()
+	r.logFile = lf
+}
+
+// telemetryPort returns the port on which telemetry should be served.
+func (r *configReloader) telemetryPort() int {
+	r.Lock()
+	defer r.Unlock()
+	return r.cfg.TelemetryPort
+}
+
+// config returns a copy of the running config. Engine configs are replaced
+// rather than updated when reloaded, so they are not copied.
+func (r *configReloader) config() *config.Server {
+	r.Lock()
+	defer r.Unlock()
+	cfg := *r.cfg
+	cfg.Engines = append([]*engine.Config{}, r.cfg.Engines...)
+	return &cfg
+}
+
+// accessPoints returns the addresses of the MS replicas.
+func (r *configReloader) accessPoints() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string{}, r.cfg.AccessPoints...)
+}
+
+// shutdownDrainTimeout returns how long engines are given to drain before
+// they are stopped.
+func (r *configReloader) shutdownDrainTimeout() time.Duration {
+	r.Lock()
+	defer r.Unlock()
+	return r.cfg.ShutdownDrainTimeout
+}
+
+// engineLogMask returns the log mask configured for the engine at the given
+// index.
+func (r *configReloader) engineLogMask(idx int) string {
+	r.Lock()
+	defer r.Unlock()
+	if idx < 0 || idx >= len(r.cfg.Engines) {
+		return ""
+	}
+	return r.cfg.Engines[idx].LogMask
+}
+
+// fingerprint returns the normalized running config and its hash, to be
+// compared with the configs of other servers in the system.
+func (r *configReloader) fingerprint() (string, []byte, error) {
+	r.Lock()
+	defer r.Unlock()
+	return r.cfg.Fingerprint()
+}
+
+// reload re-reads the config file, applies any changes which can be made to
+// the running server and returns the action taken for each changed parameter.
+func (r *configReloader) reload(ctx context.Context) (*ctlpb.ReloadConfigResp, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	newCfg, err := r.loadConfig(r.log, r.cfg.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reload config")
+	}
+	// Env vars added to the engine configs at startup aren't in the file,
+	// add them so that they are retained and not reported as changed.
+	if err := r.updateEnvars(ctx, newCfg); err != nil {
+		return nil, errors.Wrap(err, "reload config")
+	}
+
+	resp := &ctlpb.ReloadConfigResp{Path: r.cfg.Path}
+	if newCfg.ControlLogFile == r.cfg.ControlLogFile {
+		r.reopenControlLogFile()
+	}
+
+	updatedEngines := make(map[int]bool)
+	for _, field := range r.cfg.Diff(newCfg) {
+		change := &ctlpb.ConfigChange{
+			Field:  field,
+			Action: ctlpb.ConfigChange_SERVER_RESTART,
+		}
+
+		var applyErr error
+		switch field {
+		case "control_log_mask":
+			r.log.SetLevel(logging.LogLevel(newCfg.ControlLogMask))
+			r.cfg.ControlLogMask = newCfg.ControlLogMask
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "control_log_file":
+			change.Action, applyErr = r.applyControlLogFile(newCfg.ControlLogFile)
+		case "helper_log_file":
+			applyErr = setHelperLogFile(pbin.DaosAdminLogFileEnvVar, newCfg.HelperLogFile)
+			r.cfg.HelperLogFile = newCfg.HelperLogFile
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "firmware_helper_log_file":
+			applyErr = setHelperLogFile(pbin.DaosFWLogFileEnvVar, newCfg.FWHelperLogFile)
+			r.cfg.FWHelperLogFile = newCfg.FWHelperLogFile
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "drpc_slow_call_threshold":
+			r.drpcMetrics.setSlowThreshold(newCfg.DrpcSlowCallThreshold)
+			r.cfg.DrpcSlowCallThreshold = newCfg.DrpcSlowCallThreshold
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "shutdown_drain_timeout":
+			r.cfg.ShutdownDrainTimeout = newCfg.ShutdownDrainTimeout
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "access_points":
+			r.forwarder.SetAccessPoints(newCfg.AccessPoints)
+			r.cfg.AccessPoints = newCfg.AccessPoints
+			change.Action = ctlpb.ConfigChange_APPLIED
+		case "telemetry_port":
+			change.Action, applyErr = r.applyTelemetryPort(newCfg.TelemetryPort)
+		default:
+			matches := engineFieldRegexp.FindStringSubmatch(field)
+			if matches == nil {
+				break
+			}
+			idx, err := strconv.Atoi(matches[1])
+			if err != nil {
+				break
+			}
+
+			switch matches[2] {
+			case "log_mask":
+				change.Action, applyErr = r.applyEngineLogMask(ctx, idx, newCfg.Engines[idx].LogMask)
+			case "storage":
+				// storage is prepared when daos_server starts
+			default:
+				change.Action = ctlpb.ConfigChange_ENGINE_RESTART
+				if !updatedEngines[idx] {
+					reloaded := newCfg.Engines[idx]
+					r.swapEngineConfig(idx, func(cfg *engine.Config) {
+						updateEngineConfig(cfg, reloaded)
+					})
+					updatedEngines[idx] = true
+				}
+			}
+		}
+
+		if applyErr != nil {
+			change.Action = ctlpb.ConfigChange_FAILED
+			change.Error = applyErr.Error()
+			r.log.Errorf("config reload: %s: %s", field, applyErr)
+		} else {
+			r.log.Debugf("config reload: %s: %s", field, change.Action)
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+
+	return resp, nil
+}
+
+// logConfigChanges logs the outcome of a config reload.
+func logConfigChanges(log logging.Logger, resp *ctlpb.ReloadConfigResp) {
+	if len(resp.GetChanges()) == 0 {
+		log.Infof("config reloaded from %s: no changes", resp.GetPath())
+		return
+	}
+
+	for _, change := range resp.GetChanges() {
+		switch change.GetAction() {
+		case ctlpb.ConfigChange_APPLIED:
+			log.Infof("config reloaded from %s: %s applied", resp.GetPath(), change.GetField())
+		case ctlpb.ConfigChange_FAILED:
+			log.Errorf("config reloaded from %s: %s not applied: %s", resp.GetPath(),
+				change.GetField(), change.GetError())
+		default:
+			log.Infof("config reloaded from %s: %s changed, requires %s", resp.GetPath(),
+				change.GetField(), restartTarget(change.GetAction()))
+		}
+	}
+}
+
+func restartTarget(action ctlpb.ConfigChange_Action) string {
+	if action == ctlpb.ConfigChange_ENGINE_RESTART {
+		return "engine restart"
+	}
+	return build.ControlPlaneName + " restart"
+}
+
+// reopenControlLogFile reopens the control log file at its current path, e.g.
+// after it has been rotated.
+func (r *configReloader) reopenControlLogFile() {
+	if r.logFile == nil || r.cfg.ControlLogFile == "" {
+		return
+	}
+
+	if err := r.logFile.Reopen(r.cfg.ControlLogFile); err != nil {
+		r.log.Errorf("config reload: reopen control log file: %s", err)
+	}
+}
+
+// applyControlLogFile switches control plane logging to a new file. If the
+// new file can't be opened, logging to the current file continues.
+func (r *configReloader) applyControlLogFile(path string) (ctlpb.ConfigChange_Action, error) {
+	if r.logFile == nil {
+		return ctlpb.ConfigChange_SERVER_RESTART, nil
+	}
+
+	if err := r.logFile.Reopen(path); err != nil {
+		return ctlpb.ConfigChange_FAILED, err
+	}
+	r.cfg.ControlLogFile = path
+
+	return ctlpb.ConfigChange_APPLIED, nil
+}
+
+// setHelperLogFile sets the environment variable read by a privileged helper
+// for its log file. Helpers inherit the server environment when started.
+func setHelperLogFile(envVar, path string) error {
+	if path == "" {
+		return os.Unsetenv(envVar)
+	}
+
+	return os.Setenv(envVar, path)
+}
+
+// applyTelemetryPort moves the telemetry exporter to a new port. If telemetry
+// was disabled when the server started, it cannot be enabled without a
+// restart.
+func (r *configReloader) applyTelemetryPort(port int) (ctlpb.ConfigChange_Action, error) {
+	switch {
+	case r.exporter != nil:
+		if err := r.exporter.setPort(port); err != nil {
+			return ctlpb.ConfigChange_FAILED, err
+		}
+	case r.cfg.TelemetryPort == 0:
+		return ctlpb.ConfigChange_SERVER_RESTART, nil
+	}
+
+	// If the exporter has not started yet, it will pick up the new port.
+	r.cfg.TelemetryPort = port
+	return ctlpb.ConfigChange_APPLIED, nil
+}
+
+// applyEngineLogMask updates the log masks of a running engine and records
+// the new value in its config so that it is used on engine restart.
+func (r *configReloader) applyEngineLogMask(ctx context.Context, idx int, masks string) (ctlpb.ConfigChange_Action, error) {
+	r.swapEngineConfig(idx, func(cfg *engine.Config) {
+		cfg.LogMask = masks
+	})
+
+	// an unset mask reverts to the engine default on restart
+	if masks == "" {
+		return ctlpb.ConfigChange_ENGINE_RESTART, nil
+	}
+
+	instances := r.harness.Instances()
+	if idx >= len(instances) || !instances[idx].IsReady() {
+		return ctlpb.ConfigChange_APPLIED, nil
+	}
+	if err := setEngineLogMasks(ctx, instances[idx], masks); err != nil {
+		return ctlpb.ConfigChange_FAILED, err
+	}
+
+	return ctlpb.ConfigChange_APPLIED, nil
+}
+
+// swapEngineConfig replaces the running config of an engine with an updated
+// copy. The config is shared with the engine's runner, which reads it without
+// holding the reloader lock, so it is never modified in place.
+func (r *configReloader) swapEngineConfig(idx int, update func(*engine.Config)) {
+	instances := r.harness.Instances()
+	if idx < len(instances) {
+		if ei, ok := instances[idx].(*EngineInstance); ok {
+			r.cfg.Engines[idx] = ei.updateConfig(update)
+			return
+		}
+	}
+
+	cfg := *r.cfg.Engines[idx]
+	update(&cfg)
+	r.cfg.Engines[idx] = &cfg
+}
+
+// updateEngineConfig updates a copy of the running engine config with the
+// reloaded values, retaining the storage config and parameters set at runtime.
+func updateEngineConfig(cur, reloaded *engine.Config) {
+	storageCfg := cur.Storage
+	idx, memSize, hugePageSz := cur.Index, cur.MemSize, cur.HugePageSz
+	logMask := cur.LogMask
+
+	*cur = *reloaded
+	cur.Storage = storageCfg
+	cur.Index, cur.MemSize, cur.HugePageSz = idx, memSize, hugePageSz
+	cur.LogMask = logMask // updated separately
+}
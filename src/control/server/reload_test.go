@@ -0,0 +1,480 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/pbin"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func reloadTestConfig() *config.Server {
+	return config.DefaultServer().
+		WithAccessPoints("hostA:10001").
+		WithControlLogMask(config.ControlLogLevelInfo).
+		WithEngines(
+			engine.NewConfig().
+				WithTargetCount(1).
+				WithLogMask("ERR").
+				WithStorage(
+					storage.NewTierConfig().
+						WithScmClass("ram").
+						WithScmMountPoint("/mnt/daos0"),
+				),
+			engine.NewConfig().
+				WithTargetCount(1).
+				WithLogMask("ERR").
+				WithStorage(
+					storage.NewTierConfig().
+						WithScmClass("ram").
+						WithScmMountPoint("/mnt/daos1"),
+				),
+		)
+}
+
+func TestServer_configReloader_reload(t *testing.T) {
+	// mimic the OFI_DOMAIN env var set on verbs engines at startup
+	updateEnvars := func(_ context.Context, cfg *config.Server) error {
+		for _, ec := range cfg.Engines {
+			if ec.Fabric.Provider == "ofi+verbs" && !ec.HasEnvVar("OFI_DOMAIN") {
+				ec.WithEnvVars("OFI_DOMAIN=mlx5_0")
+			}
+		}
+		return nil
+	}
+	testConfig := func(telemetryPort int, verbs bool) *config.Server {
+		cfg := reloadTestConfig().WithTelemetryPort(telemetryPort)
+		if verbs {
+			cfg.WithFabricProvider("ofi+verbs")
+			_ = updateEnvars(context.Background(), cfg)
+		}
+		return cfg
+	}
+
+	for name, tc := range map[string]struct {
+		telemetryPort int
+		verbs         bool
+		notReady      bool
+		drpcStatus    int32
+		loadErr       error
+		modify        func(*config.Server)
+		expChanges    []*ctlpb.ConfigChange
+		expCfg        func(*config.Server)
+		expLogMaskSet int
+		expErr        error
+	}{
+		"load fails": {
+			loadErr: errors.New("parse failed"),
+			expErr:  errors.New("parse failed"),
+		},
+		"no changes": {
+			modify: func(*config.Server) {},
+		},
+		"dynamic server values": {
+			modify: func(cfg *config.Server) {
+				cfg.WithControlLogMask(config.ControlLogLevelDebug).
+					WithDrpcSlowCallThreshold(time.Second).
+					WithShutdownDrainTimeout(time.Minute).
+					WithAccessPoints("hostA:10001", "hostB:10001", "hostC:10001")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "control_log_mask"},
+				{Field: "drpc_slow_call_threshold"},
+				{Field: "shutdown_drain_timeout"},
+				{Field: "access_points"},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.WithControlLogMask(config.ControlLogLevelDebug).
+					WithDrpcSlowCallThreshold(time.Second).
+					WithShutdownDrainTimeout(time.Minute).
+					WithAccessPoints("hostA:10001", "hostB:10001", "hostC:10001")
+			},
+		},
+		"server restart required": {
+			modify: func(cfg *config.Server) {
+				cfg.WithControlPort(10002).WithTelemetryPort(9191)
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "port", Action: ctlpb.ConfigChange_SERVER_RESTART},
+				{Field: "telemetry_port", Action: ctlpb.ConfigChange_SERVER_RESTART},
+			},
+		},
+		"telemetry port changed before exporter started": {
+			telemetryPort: 9191,
+			modify: func(cfg *config.Server) {
+				cfg.WithTelemetryPort(9192)
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "telemetry_port"},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.WithTelemetryPort(9192)
+			},
+		},
+		"engine log mask set": {
+			modify: func(cfg *config.Server) {
+				cfg.Engines[1].WithLogMask("DEBUG")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines[1].log_mask"},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[1].WithLogMask("DEBUG")
+			},
+			expLogMaskSet: 1,
+		},
+		"engine log mask set on engine not ready": {
+			notReady: true,
+			modify: func(cfg *config.Server) {
+				cfg.Engines[1].WithLogMask("DEBUG")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines[1].log_mask"},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[1].WithLogMask("DEBUG")
+			},
+		},
+		"engine log mask set fails": {
+			drpcStatus: int32(drpc.DaosInvalidInput),
+			modify: func(cfg *config.Server) {
+				cfg.Engines[0].WithLogMask("DEBUG")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{
+					Field:  "engines[0].log_mask",
+					Action: ctlpb.ConfigChange_FAILED,
+					Error:  drpc.DaosInvalidInput.Error(),
+				},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[0].WithLogMask("DEBUG")
+			},
+			expLogMaskSet: 1,
+		},
+		"engine log mask unset": {
+			modify: func(cfg *config.Server) {
+				cfg.Engines[0].WithLogMask("")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines[0].log_mask", Action: ctlpb.ConfigChange_ENGINE_RESTART},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[0].WithLogMask("")
+			},
+		},
+		"engine restart required": {
+			modify: func(cfg *config.Server) {
+				cfg.Engines[0].WithTargetCount(4).WithLogFile("/tmp/engine0.log")
+				cfg.Engines[1].Storage.Tiers[0].WithScmMountPoint("/mnt/other")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines[0].targets", Action: ctlpb.ConfigChange_ENGINE_RESTART},
+				{Field: "engines[0].log_file", Action: ctlpb.ConfigChange_ENGINE_RESTART},
+				{Field: "engines[1].storage[0].scm_mount", Action: ctlpb.ConfigChange_SERVER_RESTART},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[0].WithTargetCount(4).WithLogFile("/tmp/engine0.log")
+			},
+		},
+		"runtime env vars unchanged": {
+			verbs:  true,
+			modify: func(*config.Server) {},
+		},
+		"env vars changed with runtime env vars": {
+			verbs: true,
+			modify: func(cfg *config.Server) {
+				cfg.Engines[0].WithEnvVars("FOO=bar")
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines[0].env_vars", Action: ctlpb.ConfigChange_ENGINE_RESTART},
+			},
+			expCfg: func(cfg *config.Server) {
+				cfg.Engines[0].EnvVars = []string{"FOO=bar", "OFI_DOMAIN=mlx5_0"}
+			},
+		},
+		"engine added": {
+			modify: func(cfg *config.Server) {
+				cfg.Engines = append(cfg.Engines, engine.NewConfig().WithTargetCount(1))
+			},
+			expChanges: []*ctlpb.ConfigChange{
+				{Field: "engines", Action: ctlpb.ConfigChange_SERVER_RESTART},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := testConfig(tc.telemetryPort, tc.verbs)
+			cfg.Path = "/etc/daos/daos_server.yml"
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			log.SetLevel(logging.LogLevel(cfg.ControlLogMask))
+
+			respBytes, err := proto.Marshal(&ctlpb.SetLogMasksResp{Status: tc.drpcStatus})
+			if err != nil {
+				t.Fatal(err)
+			}
+			clients := make([]*mockDrpcClient, 0, len(svc.harness.instances))
+			for _, e := range svc.harness.instances {
+				ei := e.(*EngineInstance)
+				ei.ready.Store(!tc.notReady)
+
+				dcc := new(mockDrpcClientConfig)
+				dcc.setSendMsgResponse(drpc.Status_SUCCESS, respBytes, nil)
+				dc := newMockDrpcClient(dcc)
+				ei.setDrpcClient(dc)
+				clients = append(clients, dc)
+			}
+
+			prevEngines := make([]*engine.Config, len(cfg.Engines))
+			prevValues := make([]engine.Config, len(cfg.Engines))
+			for i, ec := range cfg.Engines {
+				prevEngines[i], prevValues[i] = ec, *ec
+			}
+
+			metrics := newDrpcMetrics(log, 0, svc.harness)
+			forwarder := control.NewEventForwarder(nil, cfg.AccessPoints)
+			r := newConfigReloader(log, cfg, svc.harness, forwarder, metrics)
+			r.loadConfig = func(_ logging.Logger, path string) (*config.Server, error) {
+				if tc.loadErr != nil {
+					return nil, tc.loadErr
+				}
+				newCfg := reloadTestConfig().WithTelemetryPort(tc.telemetryPort)
+				if tc.verbs {
+					newCfg.WithFabricProvider("ofi+verbs")
+				}
+				newCfg.Path = path
+				tc.modify(newCfg)
+				return newCfg, nil
+			}
+			r.updateEnvars = updateEnvars
+
+			gotResp, gotErr := r.reload(context.Background())
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			expResp := &ctlpb.ReloadConfigResp{
+				Path:    cfg.Path,
+				Changes: tc.expChanges,
+			}
+			if diff := cmp.Diff(expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+
+			expCfg := testConfig(tc.telemetryPort, tc.verbs)
+			expCfg.Path = cfg.Path
+			if tc.expCfg != nil {
+				tc.expCfg(expCfg)
+			}
+			if diff := cmp.Diff(expCfg.Diff(cfg), []string{}, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("unexpected running config (-want, +got):\n%s\n", diff)
+			}
+
+			common.AssertEqual(t, logging.LogLevel(expCfg.ControlLogMask), log.Level(),
+				"unexpected control log level")
+			common.AssertEqual(t, int64(expCfg.DrpcSlowCallThreshold), metrics.slowThreshold,
+				"unexpected slow call threshold")
+
+			var gotLogMaskSet int
+			for _, dc := range clients {
+				for _, m := range dc.CalledMethods() {
+					if m == drpc.MethodSetLogMasks {
+						gotLogMaskSet++
+					}
+				}
+			}
+			common.AssertEqual(t, tc.expLogMaskSet, gotLogMaskSet, "unexpected set log masks calls")
+
+			// engine configs are swapped rather than updated in place
+			for i, e := range svc.harness.instances {
+				runnerCfg := e.(*EngineInstance).runner.GetConfig()
+				if runnerCfg != cfg.Engines[i] {
+					t.Fatalf("engine %d: runner config not swapped", i)
+				}
+				if diff := cmp.Diff(prevValues[i], *prevEngines[i]); diff != "" {
+					t.Fatalf("engine %d: previous config modified (-want, +got):\n%s\n", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_configReloader_reload_logFiles(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	for _, envVar := range []string{pbin.DaosAdminLogFileEnvVar, pbin.DaosFWLogFileEnvVar} {
+		if prev, set := os.LookupEnv(envVar); set {
+			defer os.Setenv(envVar, prev)
+		} else {
+			defer os.Unsetenv(envVar)
+		}
+	}
+
+	firstLog := filepath.Join(testDir, "first.log")
+	secondLog := filepath.Join(testDir, "second.log")
+	adminLog := filepath.Join(testDir, "daos_admin.log")
+
+	cfg := reloadTestConfig().
+		WithControlLogFile(firstLog).
+		WithFirmwareHelperLogFile(filepath.Join(testDir, "daos_firmware.log"))
+	if err := os.Setenv(pbin.DaosFWLogFileEnvVar, cfg.FWHelperLogFile); err != nil {
+		t.Fatal(err)
+	}
+
+	lf, err := logging.OpenLogFile(firstLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lf.Close()
+
+	var newCfg *config.Server
+	svc := mockControlService(t, log, cfg, nil, nil, nil)
+	r := newConfigReloader(log, cfg, svc.harness, nil, nil)
+	r.setLogFile(lf)
+	r.loadConfig = func(_ logging.Logger, path string) (*config.Server, error) {
+		return newCfg, nil
+	}
+	r.updateEnvars = func(context.Context, *config.Server) error { return nil }
+
+	reload := func(expChanges ...*ctlpb.ConfigChange) {
+		t.Helper()
+
+		gotResp, err := r.reload(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		expResp := &ctlpb.ReloadConfigResp{Path: cfg.Path, Changes: expChanges}
+		if diff := cmp.Diff(expResp, gotResp, protocmp.Transform()); diff != "" {
+			t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+		}
+	}
+	write := func(msg string) {
+		t.Helper()
+		if _, err := fmt.Fprintln(lf, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readFile := func(path string) string {
+		t.Helper()
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// unchanged control log file is reopened, e.g. after rotation
+	if err := os.Rename(firstLog, firstLog+".1"); err != nil {
+		t.Fatal(err)
+	}
+	newCfg = reloadTestConfig().
+		WithControlLogFile(firstLog).
+		WithFirmwareHelperLogFile(cfg.FWHelperLogFile)
+	reload()
+	write("first")
+
+	newCfg = reloadTestConfig().
+		WithControlLogFile(secondLog).
+		WithHelperLogFile(adminLog)
+	reload(
+		&ctlpb.ConfigChange{Field: "control_log_file"},
+		&ctlpb.ConfigChange{Field: "helper_log_file"},
+		&ctlpb.ConfigChange{Field: "firmware_helper_log_file"},
+	)
+	write("second")
+
+	common.AssertEqual(t, "first\n", readFile(firstLog), "unexpected rotated log content")
+	common.AssertEqual(t, "second\n", readFile(secondLog), "unexpected new log content")
+	common.AssertEqual(t, secondLog, cfg.ControlLogFile, "running config not updated")
+	common.AssertEqual(t, adminLog, os.Getenv(pbin.DaosAdminLogFileEnvVar), "unexpected helper log env")
+	if _, set := os.LookupEnv(pbin.DaosFWLogFileEnvVar); set {
+		t.Fatal("firmware helper log env not unset")
+	}
+
+	// control log file is retained if the new one can't be opened
+	newCfg = reloadTestConfig().
+		WithControlLogFile(filepath.Join(testDir, "missing", "bad.log")).
+		WithHelperLogFile(adminLog)
+	gotResp, err := r.reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, ctlpb.ConfigChange_FAILED, gotResp.GetChanges()[0].GetAction(),
+		"unexpected action")
+	common.AssertEqual(t, secondLog, lf.Path(), "unexpected log file path")
+	common.AssertEqual(t, secondLog, cfg.ControlLogFile, "running config updated")
+}
+
+func TestServer_updateEngineConfig(t *testing.T) {
+	cur := engine.NewConfig().
+		WithTargetCount(1).
+		WithLogMask("ERR").
+		WithStorage(
+			storage.NewTierConfig().
+				WithScmClass("ram").
+				WithScmMountPoint("/mnt/daos0"),
+		)
+	cur.Index = 1
+	cur.MemSize = 1024
+	cur.HugePageSz = 2
+
+	reloaded := engine.NewConfig().
+		WithTargetCount(8).
+		WithLogMask("DEBUG").
+		WithEnvVars("FOO=bar").
+		WithStorage(
+			storage.NewTierConfig().
+				WithScmClass("ram").
+				WithScmMountPoint("/mnt/other"),
+		)
+
+	updateEngineConfig(cur, reloaded)
+
+	expCfg := engine.NewConfig().
+		WithTargetCount(8).
+		WithLogMask("ERR").
+		WithEnvVars("FOO=bar").
+		WithStorage(
+			storage.NewTierConfig().
+				WithScmClass("ram").
+				WithScmMountPoint("/mnt/daos0"),
+		)
+	expCfg.Index = 1
+	expCfg.MemSize = 1024
+	expCfg.HugePageSz = 2
+
+	if diff := cmp.Diff(expCfg, cur); diff != "" {
+		t.Fatalf("unexpected engine config (-want, +got):\n%s\n", diff)
+	}
+}
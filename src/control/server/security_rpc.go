@@ -21,15 +21,18 @@ import (
 
 // SecurityModule is the security drpc module struct
 type SecurityModule struct {
-	log    logging.Logger
-	config *security.TransportConfig
+	log       logging.Logger
+	config    *security.TransportConfig
+	validator *auth.TokenValidator
 }
 
 // NewSecurityModule creates a new security module with a transport config
-func NewSecurityModule(log logging.Logger, tc *security.TransportConfig) *SecurityModule {
+// and a credential config governing the accepted token lifetime.
+func NewSecurityModule(log logging.Logger, tc *security.TransportConfig, cc *security.CredentialConfig) *SecurityModule {
 	return &SecurityModule{
-		log:    log,
-		config: tc,
+		log:       log,
+		config:    tc,
+		validator: auth.NewTokenValidator(cc),
 	}
 }
 
@@ -67,6 +70,17 @@ func (m *SecurityModule) processValidateCredentials(body []byte) ([]byte, error)
 		return m.validateRespWithStatus(drpc.DaosNoPermission)
 	}
 
+	// Check that the token is fresh and has not been seen before
+	sys, err := auth.AuthSysFromAuthToken(cred.GetToken())
+	if err != nil {
+		m.log.Errorf("cred token invalid: %v", err)
+		return m.validateRespWithStatus(drpc.DaosInvalidInput)
+	}
+	if err := m.validator.Validate(sys); err != nil {
+		m.log.Errorf("cred from %q rejected: %v", sys.GetMachinename(), err)
+		return m.validateRespWithStatus(drpc.DaosNoPermission)
+	}
+
 	resp := &auth.ValidateCredResp{Token: cred.Token}
 	responseBytes, err := proto.Marshal(resp)
 	if err != nil {
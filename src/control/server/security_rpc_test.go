@@ -33,7 +33,7 @@ func TestSrvSecurityModule_ID(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, nil)
+	mod := NewSecurityModule(log, nil, nil)
 
 	common.AssertEqual(t, mod.ID(), drpc.ModuleSecurity, "wrong drpc module")
 }
@@ -54,7 +54,7 @@ func TestSrvSecurityModule_BadMethod(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 	method, err := mod.ID().GetMethod(-1)
 	if method != nil {
 		t.Errorf("Expected no method to be returned, got %+v", method)
@@ -71,7 +71,7 @@ func TestSrvSecurityModule_ValidateCred_InvalidReq(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 	// Put garbage in the body
 	resp, err := callValidateCreds(mod, []byte{byte(123), byte(90), byte(255)})
 
@@ -122,7 +122,7 @@ func TestSrvSecurityModule_ValidateCred_NoCred(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 	reqBytes := marshal(t, &auth.ValidateCredReq{})
 
 	resp, err := callValidateCreds(mod, reqBytes)
@@ -140,7 +140,7 @@ func TestSrvSecurityModule_ValidateCred_NoToken(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 	reqBytes := getMarshaledValidateCredReq(t, nil, &auth.Token{
 		Flavor: auth.Flavor_AUTH_NONE,
 		Data:   []byte{byte(1), byte(2)},
@@ -161,7 +161,7 @@ func TestSrvSecurityModule_ValidateCred_NoVerifier(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 	reqBytes := getMarshaledValidateCredReq(t, &auth.Token{
 		Flavor: auth.Flavor_AUTH_NONE,
 		Data:   []byte{byte(1), byte(2)},
@@ -178,11 +178,13 @@ func TestSrvSecurityModule_ValidateCred_NoVerifier(t *testing.T) {
 	})
 }
 
-func getValidToken(t *testing.T) *auth.Token {
+func getTokenIssuedAt(t *testing.T, issued time.Time) *auth.Token {
 	tokenData := &auth.Sys{
-		Stamp: uint64(time.Now().Unix()),
-		User:  "gooduser@",
-		Group: "goodgroup@",
+		Stamp:  uint64(issued.Unix()),
+		Expiry: uint64(issued.Add(time.Minute).Unix()),
+		Nonce:  []byte(t.Name()),
+		User:   "gooduser@",
+		Group:  "goodgroup@",
 	}
 	return &auth.Token{
 		Flavor: auth.Flavor_AUTH_SYS,
@@ -190,6 +192,10 @@ func getValidToken(t *testing.T) *auth.Token {
 	}
 }
 
+func getValidToken(t *testing.T) *auth.Token {
+	return getTokenIssuedAt(t, time.Now())
+}
+
 func getVerifierForToken(t *testing.T, token *auth.Token, key crypto.PublicKey) *auth.Token {
 	verifier, err := auth.VerifierFromToken(key, token)
 	if err != nil {
@@ -206,7 +212,7 @@ func TestSrvSecurityModule_ValidateCred_Insecure_OK(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 
 	token := getValidToken(t)
 	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
@@ -226,7 +232,7 @@ func TestSrvSecurityModule_ValidateCred_Insecure_BadVerifier(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, insecureTransportConfig())
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
 
 	token := getValidToken(t)
 	reqBytes := getMarshaledValidateCredReq(t, token, &auth.Token{Data: []byte{0x1}}) // junk verifier
@@ -242,6 +248,52 @@ func TestSrvSecurityModule_ValidateCred_Insecure_BadVerifier(t *testing.T) {
 	})
 }
 
+func TestSrvSecurityModule_ValidateCred_Expired(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
+
+	token := getTokenIssuedAt(t, time.Now().Add(-time.Hour))
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	resp, err := callValidateCreds(mod, reqBytes)
+
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Status: int32(drpc.DaosNoPermission),
+	})
+}
+
+func TestSrvSecurityModule_ValidateCred_Replayed(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, insecureTransportConfig(), nil)
+
+	token := getValidToken(t)
+	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
+
+	resp, err := callValidateCreds(mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Token: token,
+	})
+
+	resp, err = callValidateCreds(mod, reqBytes)
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	expectValidateResp(t, resp, &auth.ValidateCredResp{
+		Status: int32(drpc.DaosNoPermission),
+	})
+}
+
 func generateTestCert(t *testing.T, dir string) crypto.PrivateKey {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -283,7 +335,7 @@ func TestSrvSecurityModule_ValidateCred_Secure_OK(t *testing.T) {
 
 	key := generateTestCert(t, tmpDir)
 
-	mod := NewSecurityModule(log, secureTransportConfig(tmpDir))
+	mod := NewSecurityModule(log, secureTransportConfig(tmpDir), nil)
 	token := getValidToken(t)
 
 	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, key))
@@ -303,7 +355,7 @@ func TestSrvSecurityModule_ValidateCred_Secure_LoadingCertFailed(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
 
-	mod := NewSecurityModule(log, secureTransportConfig("some/fake/path"))
+	mod := NewSecurityModule(log, secureTransportConfig("some/fake/path"), nil)
 	token := getValidToken(t)
 
 	reqBytes := getMarshaledValidateCredReq(t, token, getVerifierForToken(t, token, nil))
@@ -328,7 +380,7 @@ func TestSrvSecurityModule_ValidateCred_Secure_BadVerifier(t *testing.T) {
 
 	_ = generateTestCert(t, tmpDir)
 
-	mod := NewSecurityModule(log, secureTransportConfig(tmpDir))
+	mod := NewSecurityModule(log, secureTransportConfig(tmpDir), nil)
 	token := getValidToken(t)
 
 	// unsigned hash instead of signed by cert
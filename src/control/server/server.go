@@ -24,6 +24,7 @@ import (
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/atm"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -67,6 +68,7 @@ func processConfig(log *logging.LeveledLogger, cfg *config.Server) (*system.Faul
 // server struct contains state and components of DAOS Server.
 type server struct {
 	log         *logging.LeveledLogger
+	logFile     *logging.LogFile
 	cfg         *config.Server
 	hostname    string
 	runningUser string
@@ -84,6 +86,12 @@ type server struct {
 	ctlSvc       *ControlService
 	mgmtSvc      *mgmtSvc
 	grpcServer   *grpc.Server
+	audit        *auditLogger
+	crash        *crashCollector
+	drpcMetrics  *drpcMetrics
+	reloader     *configReloader
+	restartReq   chan struct{}
+	restarting   atm.Bool
 
 	cbLock           sync.Mutex
 	onEnginesStarted []func(context.Context) error
@@ -110,6 +118,7 @@ func newServer(ctx context.Context, log *logging.LeveledLogger, cfg *config.Serv
 		runningUser: cu.Username,
 		faultDomain: faultDomain,
 		harness:     harness,
+		restartReq:  make(chan struct{}, 1),
 	}, nil
 }
 
@@ -154,7 +163,35 @@ func (srv *server) createServices(ctx context.Context) error {
 	srv.evtForwarder = control.NewEventForwarder(rpcClient, srv.cfg.AccessPoints)
 	srv.evtLogger = control.NewEventLogger(srv.log)
 
+	if srv.cfg.AuditConfig != nil {
+		srv.audit, err = newAuditLogger(srv.log, srv.cfg.AuditConfig, srv.hostname, sysdb, srv.evtForwarder)
+		if err != nil {
+			return errors.Wrap(err, "create audit logger")
+		}
+		srv.OnShutdown(func() {
+			if err := srv.audit.Close(); err != nil {
+				srv.log.Errorf("closing audit log: %s", err)
+			}
+		})
+	}
+
+	if srv.cfg.CrashCollector != nil {
+		srv.crash, err = newCrashCollector(srv.log, srv.cfg.CrashCollector, srv.hostname)
+		if err != nil {
+			return errors.Wrap(err, "create crash collector")
+		}
+		srv.OnShutdown(srv.crash.collecting.Wait)
+	}
+
+	srv.drpcMetrics = newDrpcMetrics(srv.log, srv.cfg.DrpcSlowCallThreshold, srv.harness)
+	srv.reloader = newConfigReloader(srv.log, srv.cfg, srv.harness, srv.evtForwarder, srv.drpcMetrics)
+	srv.reloader.setRestarter(srv.requestRestart)
+	srv.reloader.setLogFile(srv.logFile)
+
 	srv.ctlSvc = NewControlService(srv.log, srv.harness, srv.cfg, srv.pubSub)
+	srv.ctlSvc.audit = srv.audit
+	srv.ctlSvc.crash = srv.crash
+	srv.ctlSvc.reloader = srv.reloader
 	srv.mgmtSvc = newMgmtSvc(srv.harness, srv.membership, sysdb, rpcClient, srv.pubSub)
 
 	return nil
@@ -184,6 +221,17 @@ func (srv *server) shutdown() {
 	}
 }
 
+// requestRestart triggers a shutdown of the server, draining engines first,
+// after which Start returns ErrRestartRequested so that the server can be
+// started again with an updated config.
+func (srv *server) requestRestart() {
+	srv.restarting.SetTrue()
+	select {
+	case srv.restartReq <- struct{}{}:
+	default: // restart already requested
+	}
+}
+
 // initNetwork resolves local address and starts TCP listener then calls
 // netInit to process network configuration.
 func (srv *server) initNetwork(ctx context.Context) error {
@@ -220,10 +268,17 @@ func (srv *server) initStorage() error {
 func (srv *server) createEngine(ctx context.Context, idx int, cfg *engine.Config) (*EngineInstance, error) {
 	// Closure to join an engine instance to a system using control API.
 	joinFn := func(ctxIn context.Context, req *control.SystemJoinReq) (*control.SystemJoinResp, error) {
-		req.SetHostList(srv.cfg.AccessPoints)
+		req.SetHostList(srv.reloader.accessPoints())
 		req.SetSystem(srv.cfg.SystemName)
 		req.ControlAddr = srv.ctlAddr
 
+		hash, data, err := srv.reloader.fingerprint()
+		if err != nil {
+			srv.log.Errorf("failed to generate config fingerprint: %s", err)
+		} else {
+			req.ConfigHash, req.Config = hash, string(data)
+		}
+
 		return control.SystemJoin(ctxIn, srv.mgmtSvc.rpcClient, req)
 	}
 
@@ -263,6 +318,10 @@ func (srv *server) addEngines(ctx context.Context) error {
 		engine.storage.SetBdevCache(*nvmeScanResp)
 
 		registerEngineEventCallbacks(engine, srv.hostname, srv.pubSub, &allStarted)
+		engine.setDrpcObserver(srv.drpcMetrics)
+		if srv.crash != nil {
+			engine.OnInstanceExit(srv.crash.onInstanceExitFn(engine))
+		}
 
 		if err := srv.harness.AddInstance(engine); err != nil {
 			return err
@@ -291,7 +350,7 @@ func (srv *server) addEngines(ctx context.Context) error {
 
 // setupGrpc creates a new grpc server and registers services.
 func (srv *server) setupGrpc() error {
-	srvOpts, err := getGrpcOpts(srv.cfg.TransportConfig)
+	srvOpts, err := getGrpcOpts(srv.cfg.TransportConfig, srv.cfg.AuthorizationConfig, srv.audit)
 	if err != nil {
 		return err
 	}
@@ -355,22 +414,62 @@ func (srv *server) start(ctx context.Context, shutdown context.CancelFunc) error
 	srv.log.Infof("%s v%s (pid %d) listening on %s", build.ControlPlaneName,
 		build.DaosVersion, os.Getpid(), srv.ctlAddr)
 
-	sigChan := make(chan os.Signal)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
 	go func() {
-		sig := <-sigChan
-		srv.log.Debugf("Caught signal: %s", sig)
+		var draining bool
+		for {
+			var sig os.Signal
+			select {
+			case <-ctx.Done():
+				return
+			case sig = <-sigChan:
+				srv.log.Debugf("Caught signal: %s", sig)
+			case <-srv.restartReq:
+				srv.log.Infof("%s restart requested", build.ControlPlaneName)
+				sig = syscall.SIGTERM
+			}
 
-		shutdown()
+			if sig == syscall.SIGHUP {
+				if err := srv.reloadCerts(); err != nil {
+					srv.log.Errorf("failed to reload certificates: %s", err)
+				}
+				resp, err := srv.reloader.reload(ctx)
+				if err != nil {
+					srv.log.Errorf("failed to reload config: %s", err)
+					continue
+				}
+				logConfigChanges(srv.log, resp)
+				continue
+			}
+
+			// Drain engines before shutting down unless asked to
+			// quit or already draining.
+			if sig != syscall.SIGQUIT && !draining {
+				draining = true
+				go func() {
+					srv.ctlSvc.drainEngines(ctx, srv.hostname, drainReasonShutdown)
+					shutdown()
+				}()
+				continue
+			}
+
+			shutdown()
+			return
+		}
 	}()
 
+	srv.startCertMonitor(ctx)
+
 	drpcSetupReq := &drpcServerSetupReq{
 		log:     srv.log,
 		sockDir: srv.cfg.SocketDir,
 		engines: srv.harness.Instances(),
 		tc:      srv.cfg.TransportConfig,
+		cc:      srv.cfg.CredentialConfig,
 		sysdb:   srv.sysdb,
 		events:  srv.pubSub,
+		obs:     srv.drpcMetrics,
 	}
 	// Single daos_server dRPC server to handle all engine requests
 	if err := drpcServerSetup(ctx, drpcSetupReq); err != nil {
@@ -382,12 +481,21 @@ func (srv *server) start(ctx context.Context, shutdown context.CancelFunc) error
 		}
 	}()
 
-	return errors.Wrapf(srv.harness.Start(ctx, srv.sysdb, srv.cfg),
-		"%s harness exited", build.ControlPlaneName)
+	err := srv.harness.Start(ctx, srv.sysdb, srv.cfg)
+	if srv.restarting.IsTrue() {
+		return ErrRestartRequested
+	}
+
+	return errors.Wrapf(err, "%s harness exited", build.ControlPlaneName)
 }
 
-// Start is the entry point for a daos_server instance.
-func Start(log *logging.LeveledLogger, cfg *config.Server) error {
+// ErrRestartRequested is returned by Start when the server has shut down in
+// order to be restarted, e.g. after a new config has been applied.
+var ErrRestartRequested = errors.New("server restart requested")
+
+// Start is the entry point for a daos_server instance. The control log file,
+// if set, is reopened when the config is reloaded.
+func Start(log *logging.LeveledLogger, logFile *logging.LogFile, cfg *config.Server) error {
 	faultDomain, err := processConfig(log, cfg)
 	if err != nil {
 		return err
@@ -402,6 +510,7 @@ func Start(log *logging.LeveledLogger, cfg *config.Server) error {
 	if err != nil {
 		return err
 	}
+	srv.logFile = logFile
 	defer srv.shutdown()
 
 	if err := srv.createServices(ctx); err != nil {
@@ -123,6 +123,35 @@ func updateFabricEnvars(ctx context.Context, cfg *engine.Config) error {
 	return nil
 }
 
+// updateEnginesFabricEnvars adjusts the fabric configuration of the engines in
+// a config read after startup, as netInit does for the running config, so that
+// the two can be compared.
+func updateEnginesFabricEnvars(ctx context.Context, cfg *config.Server) error {
+	var needAlias bool
+	for _, ec := range cfg.Engines {
+		if strings.HasPrefix(ec.Fabric.Provider, "ofi+verbs") && !ec.HasEnvVar("OFI_DOMAIN") {
+			needAlias = true
+		}
+	}
+	if !needAlias {
+		return nil
+	}
+
+	ctx, err := netdetect.Init(ctx)
+	if err != nil {
+		return err
+	}
+	defer netdetect.CleanUp(ctx)
+
+	for _, ec := range cfg.Engines {
+		if err := updateFabricEnvars(ctx, ec); err != nil {
+			return errors.Wrap(err, "update engine fabric envars")
+		}
+	}
+
+	return nil
+}
+
 // netInit performs all network detection tasks in one place starting with
 // netdetect library init and cleaning up on exit. Warn if configured number
 // of engines is less than NUMA node count and update-in-place engine configs.
@@ -260,6 +289,9 @@ func registerEngineEventCallbacks(engine *EngineInstance, hostname string, pubSu
 	// Register callback to publish engine process exit events.
 	engine.OnInstanceExit(publishInstanceExitFn(pubSub.Publish, hostname))
 
+	// Register callback to publish engine crash loop events.
+	engine.OnCrashLoop(publishCrashLoopFn(pubSub.Publish, hostname))
+
 	// Register callback to publish engine format requested events.
 	engine.OnAwaitFormat(publishFormatRequiredFn(pubSub.Publish, hostname))
 
@@ -318,18 +350,24 @@ func configureFirstEngine(ctx context.Context, engine *EngineInstance, sysdb *sy
 // registerTelemetryCallbacks sets telemetry related callbacks to
 // be triggered when all engines have been started.
 func registerTelemetryCallbacks(ctx context.Context, srv *server) {
-	telemPort := srv.cfg.TelemetryPort
-	if telemPort == 0 {
+	if srv.cfg.TelemetryPort == 0 {
 		return
 	}
 
 	srv.OnEnginesStarted(func(ctxIn context.Context) error {
+		// port may have been changed or unset on config reload
+		telemPort := srv.reloader.telemetryPort()
+		if telemPort == 0 {
+			return nil
+		}
+
 		srv.log.Debug("starting Prometheus exporter")
-		cleanup, err := startPrometheusExporter(ctxIn, srv.log, telemPort, srv.harness.Instances())
+		exporter, err := startPrometheusExporter(ctxIn, srv.log, telemPort, srv.harness.Instances(), srv.drpcMetrics)
 		if err != nil {
 			return err
 		}
-		srv.OnShutdown(cleanup)
+		srv.reloader.setExporter(exporter)
+		srv.OnShutdown(exporter.Close)
 		return nil
 	})
 }
@@ -341,6 +379,9 @@ func registerTelemetryCallbacks(ctx context.Context, srv *server) {
 func registerFollowerSubscriptions(srv *server) {
 	srv.pubSub.Reset()
 	srv.pubSub.Subscribe(events.RASTypeAny, srv.evtLogger)
+	if srv.crash != nil {
+		srv.pubSub.Subscribe(events.RASTypeAny, srv.crash)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.evtForwarder)
 }
 
@@ -349,8 +390,14 @@ func registerFollowerSubscriptions(srv *server) {
 func registerLeaderSubscriptions(srv *server) {
 	srv.pubSub.Reset()
 	srv.pubSub.Subscribe(events.RASTypeAny, srv.evtLogger)
+	if srv.crash != nil {
+		srv.pubSub.Subscribe(events.RASTypeAny, srv.crash)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.membership)
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.sysdb)
+	if srv.audit != nil {
+		srv.pubSub.Subscribe(events.RASTypeInfoOnly, srv.audit)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange,
 		events.HandlerFunc(func(ctx context.Context, evt *events.RASEvent) {
 			switch evt.ID {
@@ -370,28 +417,32 @@ func registerLeaderSubscriptions(srv *server) {
 		}))
 }
 
-func getGrpcOpts(cfgTransport *security.TransportConfig) ([]grpc.ServerOption, error) {
-	unaryInterceptors := []grpc.UnaryServerInterceptor{
+func getGrpcOpts(cfgTransport *security.TransportConfig, cfgAuth *security.AuthorizationConfig, audit *auditLogger) ([]grpc.ServerOption, error) {
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if audit != nil {
+		unaryInterceptors = append(unaryInterceptors, unaryAuditInterceptor(audit))
+		streamInterceptors = append(streamInterceptors, streamAuditInterceptor(audit))
+	}
+	unaryInterceptors = append(unaryInterceptors,
 		unaryErrorInterceptor,
 		unaryStatusInterceptor,
-	}
-	streamInterceptors := []grpc.StreamServerInterceptor{
-		streamErrorInterceptor,
-	}
+	)
+	streamInterceptors = append(streamInterceptors, streamErrorInterceptor)
 	tcOpt, err := security.ServerOptionForTransportConfig(cfgTransport)
 	if err != nil {
 		return nil, err
 	}
 	srvOpts := []grpc.ServerOption{tcOpt}
 
-	uintOpt, err := unaryInterceptorForTransportConfig(cfgTransport)
+	uintOpt, err := unaryInterceptorForTransportConfig(cfgTransport, cfgAuth)
 	if err != nil {
 		return nil, err
 	}
 	if uintOpt != nil {
 		unaryInterceptors = append(unaryInterceptors, uintOpt)
 	}
-	sintOpt, err := streamInterceptorForTransportConfig(cfgTransport)
+	sintOpt, err := streamInterceptorForTransportConfig(cfgTransport, cfgAuth)
 	if err != nil {
 		return nil, err
 	}
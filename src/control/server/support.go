@@ -0,0 +1,324 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+const (
+	// supportBundlePattern is used to create support bundles in the
+	// system temporary directory.
+	supportBundlePattern = "daos_support-*.tar.gz"
+	// supportChunkSize is the maximum amount of bundle data returned in
+	// a single CollectLog response.
+	supportChunkSize = 1 << 20
+	// supportBundleExpiry is the time after which a bundle that has not
+	// been completely fetched is removed.
+	supportBundleExpiry = 30 * time.Minute
+	// supportLogMaxSize limits the amount of each log file included in a
+	// bundle; larger logs are truncated to their most recent content.
+	supportLogMaxSize = 64 << 20
+	// supportRedacted replaces secrets in the bundled server config.
+	supportRedacted = "[REDACTED]"
+)
+
+type (
+	// supportSources provides the diagnostics included in a support
+	// bundle.
+	supportSources struct {
+		storageScan  func(context.Context) (*ctlpb.StorageScanResp, error)
+		networkScan  func(context.Context) (*ctlpb.NetworkScanResp, error)
+		hugePageInfo func() (interface{}, error)
+	}
+
+	supportBundle struct {
+		path    string
+		size    uint64
+		created time.Time
+	}
+
+	// supportBundles tracks the support bundles assembled on the server
+	// that have not yet been completely fetched. The zero value is ready
+	// for use.
+	supportBundles struct {
+		sync.Mutex
+		bundles map[string]*supportBundle
+		sources *supportSources
+	}
+)
+
+// supportSources returns the sources used to collect bundle diagnostics.
+func (svc *ControlService) supportSources() *supportSources {
+	if svc.support.sources != nil {
+		return svc.support.sources
+	}
+
+	return &supportSources{
+		storageScan: func(ctx context.Context) (*ctlpb.StorageScanResp, error) {
+			return svc.StorageScan(ctx, &ctlpb.StorageScanReq{})
+		},
+		networkScan: func(ctx context.Context) (*ctlpb.NetworkScanResp, error) {
+			return svc.NetworkScan(ctx, &ctlpb.NetworkScanReq{})
+		},
+		hugePageInfo: func() (interface{}, error) {
+			return common.GetHugePageInfo()
+		},
+	}
+}
+
+// redactedConfig returns the server configuration in YAML format with the
+// values of the private key path and engine environment variables replaced.
+func redactedConfig(cfg *config.Server) ([]byte, error) {
+	redacted := *cfg
+	if cfg.TransportConfig != nil {
+		tc := *cfg.TransportConfig
+		if tc.PrivateKeyPath != "" {
+			tc.PrivateKeyPath = supportRedacted
+		}
+		redacted.TransportConfig = &tc
+	}
+
+	redactEngines := func(in []*engine.Config) []*engine.Config {
+		out := make([]*engine.Config, 0, len(in))
+		for _, ec := range in {
+			rec := *ec
+			rec.EnvVars = make([]string, 0, len(ec.EnvVars))
+			for _, ev := range ec.EnvVars {
+				name := strings.SplitN(ev, "=", 2)[0]
+				rec.EnvVars = append(rec.EnvVars, name+"="+supportRedacted)
+			}
+			out = append(out, &rec)
+		}
+		return out
+	}
+	redacted.Engines = redactEngines(cfg.Engines)
+	if len(cfg.Servers) > 0 {
+		redacted.Servers = redactEngines(cfg.Servers)
+	}
+
+	return yaml.Marshal(&redacted)
+}
+
+// addTarJSON adds the JSON representation of the supplied value to the
+// archive or, if the value could not be retrieved, the error text.
+func addTarJSON(tw *tar.Writer, name string, mtime time.Time, val interface{}, valErr error) error {
+	if valErr != nil {
+		return addTarData(tw, name+".err", mtime, []byte(valErr.Error()+"\n"))
+	}
+
+	data, err := json.MarshalIndent(val, "", "  ")
+	if err != nil {
+		return err
+	}
+	return addTarData(tw, name+".json", mtime, data)
+}
+
+// addTarLog adds a log file to the archive, ignoring files that do not exist.
+func addTarLog(log logging.Logger, tw *tar.Writer, name, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	err := addTarFile(tw, name, path, supportLogMaxSize)
+	if os.IsNotExist(errors.Cause(err)) {
+		log.Debugf("support bundle: skipping missing file %s", path)
+		return nil
+	}
+	return err
+}
+
+// writeSupportBundle writes the support bundle contents to a gzipped tarball.
+func (svc *ControlService) writeSupportBundle(ctx context.Context, w io.Writer, now time.Time) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	src := svc.supportSources()
+	cfg := svc.reloader.config()
+
+	cfgData, err := redactedConfig(cfg)
+	if err != nil {
+		return errors.Wrap(err, "redact server config")
+	}
+	if err := addTarData(tw, "daos_server.yml", now, cfgData); err != nil {
+		return err
+	}
+
+	for _, lf := range []struct{ name, path string }{
+		{"daos_server.log", cfg.ControlLogFile},
+		{"daos_admin.log", cfg.HelperLogFile},
+		{"daos_firmware.log", cfg.FWHelperLogFile},
+	} {
+		if err := addTarLog(svc.log, tw, lf.name, lf.path); err != nil {
+			return err
+		}
+	}
+
+	for idx, ec := range cfg.Engines {
+		dir := fmt.Sprintf("engine%d/", idx)
+		if err := addTarLog(svc.log, tw, dir+"engine.log", ec.LogFile); err != nil {
+			return err
+		}
+		if err := addTarLog(svc.log, tw, dir+"daos_nvme.conf", ec.Storage.ConfigOutputPath); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range svc.harness.Instances() {
+		ei, ok := e.(*EngineInstance)
+		if !ok {
+			continue
+		}
+		sb := ei.getSuperblock()
+		if sb == nil {
+			continue
+		}
+		data, err := sb.Marshal()
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("engine%d/superblock.yaml", ei.Index())
+		if err := addTarData(tw, name, now, data); err != nil {
+			return err
+		}
+	}
+
+	hpi, hpiErr := src.hugePageInfo()
+	if err := addTarJSON(tw, "hugepages", now, hpi, hpiErr); err != nil {
+		return err
+	}
+	scanResp, scanErr := src.storageScan(ctx)
+	if err := addTarJSON(tw, "storage_scan", now, scanResp, scanErr); err != nil {
+		return err
+	}
+	netResp, netErr := src.networkScan(ctx)
+	if err := addTarJSON(tw, "network_scan", now, netResp, netErr); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// pruneSupportBundles removes bundles that have not been completely fetched
+// within the expiry period. The caller must hold the lock.
+func (svc *ControlService) pruneSupportBundles(now time.Time) {
+	for name, b := range svc.support.bundles {
+		if now.Sub(b.created) < supportBundleExpiry {
+			continue
+		}
+		svc.log.Debugf("removing expired support bundle %s", b.path)
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			svc.log.Errorf("failed to remove support bundle: %s", err)
+		}
+		delete(svc.support.bundles, name)
+	}
+}
+
+// assembleSupportBundle creates a new support bundle and starts tracking it.
+func (svc *ControlService) assembleSupportBundle(ctx context.Context) (*supportBundle, string, error) {
+	f, err := ioutil.TempFile("", supportBundlePattern)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "create support bundle")
+	}
+	path := f.Name()
+
+	now := time.Now()
+	if err := svc.writeSupportBundle(ctx, f, now); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, "", errors.Wrap(err, "write support bundle")
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, "", err
+	}
+
+	bundle := &supportBundle{
+		path:    path,
+		size:    uint64(fi.Size()),
+		created: now,
+	}
+	name := filepath.Base(path)
+
+	svc.support.Lock()
+	defer svc.support.Unlock()
+	svc.pruneSupportBundles(now)
+	if svc.support.bundles == nil {
+		svc.support.bundles = make(map[string]*supportBundle)
+	}
+	svc.support.bundles[name] = bundle
+	svc.log.Debugf("assembled support bundle %s (%d bytes)", path, bundle.size)
+
+	return bundle, name, nil
+}
+
+// readSupportBundle returns a chunk of the named bundle starting at offset.
+// The bundle is removed once its final chunk has been read.
+func (svc *ControlService) readSupportBundle(name string, offset uint64) (*supportBundle, []byte, error) {
+	svc.support.Lock()
+	defer svc.support.Unlock()
+
+	bundle, found := svc.support.bundles[name]
+	if !found {
+		return nil, nil, errors.Errorf("support bundle %q not found", name)
+	}
+	if offset > bundle.size {
+		return nil, nil, errors.Errorf("offset %d beyond end of support bundle %q", offset, name)
+	}
+
+	f, err := os.Open(bundle.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	size := bundle.size - offset
+	if size > supportChunkSize {
+		size = supportChunkSize
+	}
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, int64(offset)); err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+
+	if offset+size >= bundle.size {
+		if err := os.Remove(bundle.path); err != nil {
+			svc.log.Errorf("failed to remove support bundle: %s", err)
+		}
+		delete(svc.support.bundles, name)
+	}
+
+	return bundle, data, nil
+}
@@ -0,0 +1,188 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_redactedConfig(t *testing.T) {
+	cfg := config.DefaultServer().
+		WithTransportConfig(&security.TransportConfig{
+			CertificateConfig: security.CertificateConfig{
+				CertificatePath: "/etc/daos/certs/server.crt",
+				PrivateKeyPath:  "/etc/daos/certs/server.key",
+			},
+		}).
+		WithEngines(engine.NewConfig().WithEnvVars("FOO=BAR", "SECRET=hunter2"))
+
+	data, err := redactedConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := string(data)
+	for _, secret := range []string{"server.key", "BAR", "hunter2"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("secret %q not redacted:\n%s", secret, out)
+		}
+	}
+	for _, expected := range []string{"server.crt", "FOO=" + supportRedacted, "SECRET=" + supportRedacted} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected %q in redacted config:\n%s", expected, out)
+		}
+	}
+
+	// The original configuration must not be modified.
+	common.AssertEqual(t, "/etc/daos/certs/server.key", cfg.TransportConfig.PrivateKeyPath, "key path modified")
+	common.AssertTrue(t, common.Includes(cfg.Engines[0].EnvVars, "SECRET=hunter2"), "env var modified")
+}
+
+func TestServer_CtlSvc_CollectLog(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req      *ctlpb.CollectLogReq
+		expFiles []string
+		expErr   error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"offset without bundle": {
+			req:    &ctlpb.CollectLogReq{Offset: 10},
+			expErr: errors.New("requires a bundle"),
+		},
+		"unknown bundle": {
+			req:    &ctlpb.CollectLogReq{Bundle: "foo.tar.gz"},
+			expErr: errors.New("not found"),
+		},
+		"assemble": {
+			req: &ctlpb.CollectLogReq{},
+			expFiles: []string{
+				"daos_server.log", "daos_server.yml", "engine0/engine.log",
+				"engine0/superblock.yaml", "hugepages.err", "network_scan.err",
+				"storage_scan.json",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			ctlLog := common.CreateTestFile(t, testDir, "control log\n")
+			engineLog := filepath.Join(testDir, "engine.log")
+			if err := ioutil.WriteFile(engineLog, []byte("engine log\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.DefaultServer().
+				WithControlLogFile(ctlLog).
+				WithHelperLogFile(filepath.Join(testDir, "missing.log")).
+				WithEngines(engine.NewConfig().WithTargetCount(1).WithLogFile(engineLog))
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc.reloader = newConfigReloader(log, cfg, svc.harness, nil, nil)
+			svc.support.sources = &supportSources{
+				storageScan: func(context.Context) (*ctlpb.StorageScanResp, error) {
+					return &ctlpb.StorageScanResp{}, nil
+				},
+				networkScan: func(context.Context) (*ctlpb.NetworkScanResp, error) {
+					return nil, errors.New("no network")
+				},
+				hugePageInfo: func() (interface{}, error) {
+					return nil, errors.New("no hugepages")
+				},
+			}
+
+			resp, gotErr := svc.CollectLog(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, resp.GetSize(), uint64(len(resp.GetData())), "expected single chunk")
+
+			// The bundle is removed once completely fetched.
+			if _, err := os.Stat(filepath.Join(os.TempDir(), resp.GetBundle())); !os.IsNotExist(err) {
+				t.Fatalf("expected bundle to be removed, got %v", err)
+			}
+			common.AssertEqual(t, 0, len(svc.support.bundles), "expected no tracked bundles")
+
+			bundlePath := filepath.Join(testDir, "bundle.tar.gz")
+			if err := ioutil.WriteFile(bundlePath, resp.GetData(), 0600); err != nil {
+				t.Fatal(err)
+			}
+			files := readCrashArchive(t, bundlePath)
+			var names []string
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if diff := cmp.Diff(tc.expFiles, names); diff != "" {
+				t.Fatalf("unexpected bundle contents (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, "control log\n", files["daos_server.log"], "unexpected control log")
+			common.AssertEqual(t, "no network\n", files["network_scan.err"], "unexpected scan error")
+		})
+	}
+}
+
+func TestServer_CtlSvc_CollectLog_chunks(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	svc := mockControlService(t, log, nil, nil, nil, nil)
+
+	path := filepath.Join(testDir, "bundle.tar.gz")
+	data := strings.Repeat("x", supportChunkSize+10)
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	svc.support.bundles = map[string]*supportBundle{
+		"bundle.tar.gz": {path: path, size: uint64(len(data))},
+	}
+
+	var got []byte
+	for len(got) < len(data) {
+		resp, err := svc.CollectLog(context.TODO(), &ctlpb.CollectLogReq{
+			Bundle: "bundle.tar.gz",
+			Offset: uint64(len(got)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, resp.GetData()...)
+	}
+	common.AssertEqual(t, data, string(got), "unexpected bundle data")
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected bundle to be removed, got %v", err)
+	}
+	if _, err := svc.CollectLog(context.TODO(), &ctlpb.CollectLogReq{Bundle: "bundle.tar.gz"}); err == nil {
+		t.Fatal("expected error fetching removed bundle")
+	}
+}
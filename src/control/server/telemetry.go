@@ -9,7 +9,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -77,19 +79,21 @@ func regPromEngineSources(ctx context.Context, log logging.Logger, engines []Eng
 	return cleanupFns, nil
 }
 
-func startPrometheusExporter(ctx context.Context, log logging.Logger, port int, engines []Engine) (func(), error) {
-	cleanupFns, err := regPromEngineSources(ctx, log, engines)
-	if err != nil {
-		return nil, err
-	}
-
-	listenAddress := fmt.Sprintf("0.0.0.0:%d", port)
+// promExporter serves the registered Prometheus metrics over HTTP.
+type promExporter struct {
+	sync.Mutex
+	log        logging.Logger
+	port       int
+	httpSrv    *http.Server
+	cleanupFns []func()
+}
 
-	srv := http.Server{Addr: listenAddress}
-	http.Handle("/metrics", promhttp.HandlerFor(
+func (pe *promExporter) newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
 		prometheus.DefaultGatherer, promhttp.HandlerOpts{},
 	))
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		num, err := w.Write([]byte(`<html>
 				<head><title>DAOS Exporter</title></head>
 				<body>
@@ -98,30 +102,106 @@ func startPrometheusExporter(ctx context.Context, log logging.Logger, port int,
 				</body>
 				</html>`))
 		if err != nil {
-			log.Errorf("%d: %s", num, err)
+			pe.log.Errorf("%d: %s", num, err)
 		}
 	})
 
+	return mux
+}
+
+// listen starts the HTTP listener on the given port, caller must hold lock.
+func (pe *promExporter) listen(port int) error {
+	listenAddress := fmt.Sprintf("0.0.0.0:%d", port)
+	lis, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return errors.Wrap(err, "unable to listen for telemetry requests")
+	}
+
+	srv := &http.Server{Addr: listenAddress, Handler: pe.newHandler()}
 	// http listener is a blocking call
 	go func() {
-		log.Infof("Listening on %s", listenAddress)
-		err := srv.ListenAndServe()
-		log.Infof("Prometheus web exporter stopped: %s", err.Error())
+		pe.log.Infof("Listening on %s", listenAddress)
+		err := srv.Serve(lis)
+		pe.log.Infof("Prometheus web exporter stopped: %s", err.Error())
 	}()
+	pe.httpSrv = srv
+	pe.port = port
 
-	return func() {
-		log.Debug("Shutting down Prometheus web exporter")
+	return nil
+}
 
-		// When this cleanup function is called, the original context
-		// will probably have already been canceled.
-		timedCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-		defer cancel()
-		if err := srv.Shutdown(timedCtx); err != nil {
-			log.Infof("HTTP server didn't shut down within timeout: %s", err.Error())
-		}
+// stopListener shuts down the HTTP listener, caller must hold lock.
+func (pe *promExporter) stopListener() {
+	if pe.httpSrv == nil {
+		return
+	}
+
+	// When this is called on shutdown, the original context will probably
+	// have already been canceled.
+	timedCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	if err := pe.httpSrv.Shutdown(timedCtx); err != nil {
+		pe.log.Infof("HTTP server didn't shut down within timeout: %s", err.Error())
+	}
+	pe.httpSrv = nil
+	pe.port = 0
+}
+
+// setPort moves the HTTP listener to the given port, a port of zero stops
+// the listener.
+func (pe *promExporter) setPort(port int) error {
+	pe.Lock()
+	defer pe.Unlock()
+
+	if port == pe.port {
+		return nil
+	}
+	pe.stopListener()
+	if port == 0 {
+		return nil
+	}
+
+	return pe.listen(port)
+}
+
+// Close stops the HTTP listener and unregisters the metrics sources.
+func (pe *promExporter) Close() {
+	pe.Lock()
+	defer pe.Unlock()
 
-		for _, cleanup := range cleanupFns {
-			cleanup()
+	pe.log.Debug("Shutting down Prometheus web exporter")
+	pe.stopListener()
+	for _, cleanup := range pe.cleanupFns {
+		cleanup()
+	}
+	pe.cleanupFns = nil
+}
+
+func startPrometheusExporter(ctx context.Context, log logging.Logger, port int, engines []Engine, collectors ...prometheus.Collector) (*promExporter, error) {
+	cleanupFns, err := regPromEngineSources(ctx, log, engines)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range collectors {
+		if err := prometheus.Register(c); err != nil {
+			for _, cleanup := range cleanupFns {
+				cleanup()
+			}
+			return nil, errors.Wrap(err, "register metrics collector")
 		}
-	}, nil
+		cleanupFns = append(cleanupFns, func(c prometheus.Collector) func() {
+			return func() { prometheus.Unregister(c) }
+		}(c))
+	}
+
+	pe := &promExporter{
+		log:        log,
+		cleanupFns: cleanupFns,
+	}
+	if err := pe.setPort(port); err != nil {
+		pe.Close()
+		return nil, err
+	}
+
+	return pe, nil
 }
@@ -132,6 +132,23 @@ func (c *mockDrpcClient) GetSocketPath() string {
 	return c.cfg.SocketPath
 }
 
+// mockPooledDrpcClient is a mock of a dRPC client which also satisfies
+// the drpc.ContextClient interface.
+type mockPooledDrpcClient struct {
+	*mockDrpcClient
+}
+
+func (c *mockPooledDrpcClient) SendMsgContext(ctx context.Context, call *drpc.Call) (*drpc.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.SendMsg(call)
+}
+
+func newMockPooledDrpcClient(cfg *mockDrpcClientConfig) *mockPooledDrpcClient {
+	return &mockPooledDrpcClient{newMockDrpcClient(cfg)}
+}
+
 func newMockDrpcClient(cfg *mockDrpcClientConfig) *mockDrpcClient {
 	if cfg == nil {
 		cfg = &mockDrpcClientConfig{}
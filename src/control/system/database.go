@@ -66,6 +66,7 @@ type (
 		MapVersion    uint32
 		Members       *MemberDatabase
 		Pools         *PoolDatabase
+		Audit         *AuditDatabase
 		SchemaVersion uint
 	}
 
@@ -203,6 +204,7 @@ func NewDatabase(log logging.Logger, cfg *DatabaseConfig) (*Database, error) {
 				Uuids:  make(PoolUuidMap),
 				Labels: make(PoolLabelMap),
 			},
+			Audit:         &AuditDatabase{},
 			SchemaVersion: CurrentSchemaVersion,
 		},
 	}
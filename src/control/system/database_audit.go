@@ -0,0 +1,110 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxAuditRecords is the number of audit records retained in the system
+// database. The oldest records are discarded once the limit is reached.
+const MaxAuditRecords = 10000
+
+type (
+	// AuditRecord describes a single mutating control-plane operation.
+	AuditRecord struct {
+		Time     time.Time         `json:"time"`
+		Host     string            `json:"host"`
+		Caller   string            `json:"caller"`
+		Roles    []string          `json:"roles,omitempty"`
+		Source   string            `json:"source,omitempty"`
+		Method   string            `json:"method"`
+		Fields   map[string]string `json:"fields,omitempty"`
+		Outcome  string            `json:"outcome"`
+		Error    string            `json:"error,omitempty"`
+		Duration time.Duration     `json:"duration"`
+	}
+
+	// AuditDatabase contains the audit records replicated in the
+	// system database. Once the database has reached its capacity the
+	// records are stored as a ring, with the oldest at index Oldest.
+	AuditDatabase struct {
+		Records []*AuditRecord
+		Oldest  int
+	}
+)
+
+// addRecord appends the record, replacing the oldest record if the database
+// has reached its capacity.
+func (adb *AuditDatabase) addRecord(rec *AuditRecord) {
+	if len(adb.Records) < MaxAuditRecords {
+		adb.Records = append(adb.Records, rec)
+		return
+	}
+
+	adb.Records[adb.Oldest] = rec
+	adb.Oldest = (adb.Oldest + 1) % len(adb.Records)
+}
+
+// ordered calls the supplied function for each record, oldest first.
+func (adb *AuditDatabase) ordered(fn func(*AuditRecord)) {
+	for i := range adb.Records {
+		fn(adb.Records[(adb.Oldest+i)%len(adb.Records)])
+	}
+}
+
+// AddAuditRecord submits the audit record for replication to the
+// system database.
+func (db *Database) AddAuditRecord(rec *AuditRecord) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	if rec == nil {
+		return errors.New("nil audit record")
+	}
+
+	data, err := createRaftUpdate(raftOpAddAuditRecord, rec)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// AuditRecords returns a copy of the audit records stored in the system
+// database, oldest first.
+func (db *Database) AuditRecords() ([]*AuditRecord, error) {
+	if err := db.CheckLeader(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	records := make([]*AuditRecord, 0, len(db.data.Audit.Records))
+	db.data.Audit.ordered(func(rec *AuditRecord) {
+		recCopy := *rec
+		records = append(records, &recCopy)
+	})
+	return records, nil
+}
+
+// applyAuditRecord is responsible for adding an audit record to the
+// database.
+func (d *dbData) applyAuditRecord(data []byte, panicFn func(error)) {
+	rec := new(AuditRecord)
+	if err := json.Unmarshal(data, rec); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode audit record"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.Audit.addRecord(rec)
+}
@@ -0,0 +1,60 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestSystem_Database_AuditRecords(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	var expRecords []*AuditRecord
+	for i := 0; i < MaxAuditRecords+2; i++ {
+		rec := &AuditRecord{
+			Time:     start.Add(time.Duration(i) * time.Second),
+			Host:     "host1",
+			Caller:   "admin",
+			Method:   "/mgmt.MgmtSvc/PoolDestroy",
+			Fields:   map[string]string{"id": "pool1"},
+			Outcome:  "success",
+			Duration: time.Millisecond,
+		}
+		if err := db.AddAuditRecord(rec); err != nil {
+			t.Fatal(err)
+		}
+		if i >= 2 {
+			expRecords = append(expRecords, rec)
+		}
+	}
+
+	gotRecords, err := db.AuditRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expRecords, gotRecords); diff != "" {
+		t.Fatalf("unexpected records (-want, +got):\n%s\n", diff)
+	}
+
+	// The returned records must not alias those in the database.
+	gotRecords[0].Outcome = "failure"
+	gotRecords, err = db.AuditRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, gotRecords[0].Outcome, "success", "database record was modified")
+}
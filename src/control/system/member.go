@@ -165,6 +165,9 @@ type Member struct {
 	Addr           *net.TCPAddr `json:"addr"`
 	FabricURI      string       `json:"fabric_uri"`
 	FabricContexts uint32       `json:"fabric_contexts"`
+	Restarts       uint32       `json:"restarts"`
+	ConfigHash     string       `json:"config_hash,omitempty"`
+	Config         string       `json:"config,omitempty"`
 	state          MemberState
 	Info           string       `json:"info"`
 	FaultDomain    *FaultDomain `json:"fault_domain"`
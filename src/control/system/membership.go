@@ -88,6 +88,9 @@ type JoinRequest struct {
 	FabricContexts uint32
 	FaultDomain    *FaultDomain
 	Incarnation    uint64
+	Restarts       uint32
+	ConfigHash     string
+	Config         string
 }
 
 // JoinResponse contains information returned from join membership update.
@@ -147,6 +150,9 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		curMember.FabricContexts = req.FabricContexts
 		curMember.FaultDomain = req.FaultDomain
 		curMember.Incarnation = req.Incarnation
+		curMember.Restarts = req.Restarts
+		curMember.ConfigHash = req.ConfigHash
+		curMember.Config = req.Config
 		if err := m.db.UpdateMember(curMember); err != nil {
 			return nil, err
 		}
@@ -176,6 +182,9 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		FabricURI:      req.FabricURI,
 		FabricContexts: req.FabricContexts,
 		FaultDomain:    req.FaultDomain,
+		Restarts:       req.Restarts,
+		ConfigHash:     req.ConfigHash,
+		Config:         req.Config,
 		state:          MemberStateJoined,
 	}
 	if err := m.db.AddMember(newMember); err != nil {
@@ -483,6 +492,11 @@ func (m *Membership) MarkRankDead(rank Rank, incarnation uint64) error {
 	}
 
 	ns := MemberStateExcluded
+	if member.State() == MemberStateStopping {
+		// the rank announced that it was leaving, so its loss is
+		// expected and it should not be excluded
+		ns = MemberStateStopped
+	}
 	if member.State().isTransitionIllegal(ns) {
 		msg := msgBadStateTransition(member, ns)
 		// excluded->excluded transitions expected for multiple swim
@@ -541,11 +555,41 @@ func (m *Membership) handleEngineFailure(evt *events.RASEvent) {
 	}
 }
 
+// handleEngineStopping marks the member as stopping when its control plane
+// announces a controlled shutdown so that its subsequent loss is not treated
+// as a failure.
+func (m *Membership) handleEngineStopping(evt *events.RASEvent) {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(Rank(evt.Rank))
+	if err != nil {
+		m.log.Errorf("member with rank %d not found", evt.Rank)
+		return
+	}
+
+	ns := MemberStateStopping
+	if member.State().isTransitionIllegal(ns) {
+		m.log.Debugf("skipping %s", msgBadStateTransition(member, ns))
+		return
+	}
+
+	m.log.Infof("marking rank %d as %s: %s", member.Rank, ns, evt.Msg)
+	member.state = ns
+	member.Info = evt.Msg
+
+	if err := m.db.UpdateMember(member); err != nil {
+		m.log.Errorf("updating member with rank %d: %s", member.Rank, err)
+	}
+}
+
 // OnEvent handles events on channel and updates member states accordingly.
 func (m *Membership) OnEvent(_ context.Context, evt *events.RASEvent) {
 	switch evt.ID {
 	case events.RASEngineDied:
 		m.handleEngineFailure(evt)
+	case events.RASEngineStopping:
+		m.handleEngineStopping(evt)
 	}
 }
 
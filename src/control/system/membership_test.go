@@ -731,6 +731,46 @@ func TestSystem_Membership_Join(t *testing.T) {
 				MapVersion: expMapVer,
 			},
 		},
+		"successful rejoin after automatic restarts": {
+			req: &JoinRequest{
+				Rank:        curMember.Rank,
+				UUID:        curMember.UUID,
+				ControlAddr: curMember.Addr,
+				FabricURI:   curMember.Addr.String(),
+				FaultDomain: curMember.FaultDomain,
+				Restarts:    3,
+			},
+			expResp: &JoinResponse{
+				Member: func() *Member {
+					m := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+					m.Restarts = 3
+					return m
+				}(),
+				PrevState:  curMember.state,
+				MapVersion: expMapVer,
+			},
+		},
+		"successful rejoin with changed config": {
+			req: &JoinRequest{
+				Rank:        curMember.Rank,
+				UUID:        curMember.UUID,
+				ControlAddr: curMember.Addr,
+				FabricURI:   curMember.Addr.String(),
+				FaultDomain: curMember.FaultDomain,
+				ConfigHash:  "abcd",
+				Config:      "name: daos_server\n",
+			},
+			expResp: &JoinResponse{
+				Member: func() *Member {
+					m := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+					m.ConfigHash = "abcd"
+					m.Config = "name: daos_server\n"
+					return m
+				}(),
+				PrevState:  curMember.state,
+				MapVersion: expMapVer,
+			},
+		},
 		"rejoin with existing UUID and unknown rank": {
 			req: &JoinRequest{
 				Rank:        Rank(42),
@@ -884,6 +924,22 @@ func TestSystem_Membership_OnEvent(t *testing.T) {
 				MockMember(t, 3, MemberStateExcluded),
 			},
 		},
+		"state updated on controlled stop": {
+			members: members,
+			event:   events.NewEngineStoppingEvent("foo", 0, 1, "server shutdown"),
+			expMembers: Members{
+				MockMember(t, 0, MemberStateJoined),
+				MockMember(t, 1, MemberStateStopping).WithInfo(
+					"DAOS engine 0 is stopping: server shutdown"),
+				MockMember(t, 2, MemberStateStopped),
+				MockMember(t, 3, MemberStateExcluded),
+			},
+		},
+		"controlled stop of excluded member ignored": {
+			members:    members,
+			event:      events.NewEngineStoppingEvent("foo", 0, 3, "server shutdown"),
+			expMembers: members,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -914,6 +970,7 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 		rank        Rank
 		incarnation uint64
 		expErr      error
+		expState    MemberState
 	}{
 		"unknown member": {
 			rank:   42,
@@ -931,10 +988,17 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 		"new event for joined member": {
 			rank:        0,
 			incarnation: 2,
+			expState:    MemberStateExcluded,
 		},
 		"event for stopped member": {
 			rank:        1,
 			incarnation: 2,
+			expState:    MemberStateExcluded,
+		},
+		"event for stopping member": {
+			rank:        3,
+			incarnation: 2,
+			expState:    MemberStateStopped,
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
@@ -951,10 +1015,20 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 				mock(0, 2, MemberStateJoined),
 				mock(1, 2, MemberStateStopped),
 				mock(2, 2, MemberStateExcluded),
+				mock(3, 2, MemberStateStopping),
 			)
 
 			gotErr := ms.MarkRankDead(tc.rank, tc.incarnation)
 			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil || tc.expState == MemberStateUnknown {
+				return
+			}
+
+			m, err := ms.Get(tc.rank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expState, m.State(), "unexpected member state")
 		})
 	}
 }
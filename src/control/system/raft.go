@@ -39,6 +39,7 @@ const (
 	raftOpUpdatePoolService
 	raftOpRemovePoolService
 	raftOpIncMapVer
+	raftOpAddAuditRecord
 
 	sysDBFile = "daos_system.db"
 )
@@ -72,6 +73,8 @@ func (ro raftOp) String() string {
 		"addPoolService",
 		"updatePoolService",
 		"removePoolService",
+		"incMapVer",
+		"addAuditRecord",
 	}[ro]
 }
 
@@ -340,6 +343,8 @@ func (f *fsm) Apply(l *raft.Log) interface{} {
 		f.data.applyMemberUpdate(c.Op, c.Data, f.EmergencyShutdown)
 	case raftOpAddPoolService, raftOpUpdatePoolService, raftOpRemovePoolService:
 		f.data.applyPoolUpdate(c.Op, c.Data, f.EmergencyShutdown)
+	case raftOpAddAuditRecord:
+		f.data.applyAuditRecord(c.Data, f.EmergencyShutdown)
 	default:
 		f.EmergencyShutdown(errors.Errorf("unhandled Apply operation: %d", c.Op))
 		return nil
@@ -451,6 +456,7 @@ func (f *fsm) Restore(rc io.ReadCloser) error {
 	f.data.Pools = db.data.Pools
 	f.data.NextRank = db.data.NextRank
 	f.data.MapVersion = db.data.MapVersion
+	f.data.Audit = db.data.Audit
 	f.data.Unlock()
 	f.log.Debugf("db snapshot loaded (map version %d)", db.data.MapVersion)
 	return nil
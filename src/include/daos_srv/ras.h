@@ -54,7 +54,11 @@
 	X(RAS_SWIM_RANK_ALIVE,		"swim_rank_alive")		\
 	X(RAS_SWIM_RANK_DEAD,		"swim_rank_dead")		\
 	X(RAS_SYSTEM_START_FAILED,	"system_start_failed")		\
-	X(RAS_SYSTEM_STOP_FAILED,	"system_stop_failed")
+	X(RAS_SYSTEM_STOP_FAILED,	"system_stop_failed")		\
+	X(RAS_CERT_EXPIRING,		"certificate_expiring")	\
+	X(RAS_ENGINE_CRASH_LOOP,	"engine_crash_loop")		\
+	X(RAS_ENGINE_STOPPING,		"engine_stopping")		\
+	X(RAS_AUDIT_RECORD,		"audit_record")
 
 /** Define RAS event enum */
 typedef enum {
@@ -137,10 +137,22 @@ struct  _Mgmt__JoinReq
    * rank incarnation
    */
   uint64_t incarnation;
+  /*
+   * Automatic restarts of the instance.
+   */
+  uint32_t restarts;
+  /*
+   * Hash of the normalized server config.
+   */
+  char *confighash;
+  /*
+   * Normalized server config (YAML).
+   */
+  char *config;
 };
 #define MGMT__JOIN_REQ__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__join_req__descriptor) \
-    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0 }
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0, 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string }
 
 
 struct  _Mgmt__JoinResp
@@ -328,7 +328,7 @@ const ProtobufCMessageDescriptor auth__token__descriptor =
   (ProtobufCMessageInit) auth__token__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
-static const ProtobufCFieldDescriptor auth__sys__field_descriptors[6] =
+static const ProtobufCFieldDescriptor auth__sys__field_descriptors[8] =
 {
   {
     "stamp",
@@ -402,11 +402,37 @@ static const ProtobufCFieldDescriptor auth__sys__field_descriptors[6] =
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+  {
+    "expiry",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Auth__Sys, expiry),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "nonce",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BYTES,
+    0,   /* quantifier_offset */
+    offsetof(Auth__Sys, nonce),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
 };
 static const unsigned auth__sys__field_indices_by_name[] = {
+  6,   /* field[6] = expiry */
   3,   /* field[3] = group */
   4,   /* field[4] = groups */
   1,   /* field[1] = machinename */
+  7,   /* field[7] = nonce */
   5,   /* field[5] = secctx */
   0,   /* field[0] = stamp */
   2,   /* field[2] = user */
@@ -414,7 +440,7 @@ static const unsigned auth__sys__field_indices_by_name[] = {
 static const ProtobufCIntRange auth__sys__number_ranges[1 + 1] =
 {
   { 1, 0 },
-  { 0, 6 }
+  { 0, 8 }
 };
 const ProtobufCMessageDescriptor auth__sys__descriptor =
 {
@@ -424,7 +450,7 @@ const ProtobufCMessageDescriptor auth__sys__descriptor =
   "Auth__Sys",
   "auth",
   sizeof(Auth__Sys),
-  6,
+  8,
   auth__sys__field_descriptors,
   auth__sys__field_indices_by_name,
   1,  auth__sys__number_ranges,
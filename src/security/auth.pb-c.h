@@ -60,7 +60,7 @@ struct  _Auth__Sys
 {
   ProtobufCMessage base;
   /*
-   * timestamp
+   * time the token was issued (seconds since epoch)
    */
   uint64_t stamp;
   /*
@@ -84,10 +84,18 @@ struct  _Auth__Sys
    * Additional field for MAC label
    */
   char *secctx;
+  /*
+   * time after which the token is invalid (seconds since epoch)
+   */
+  uint64_t expiry;
+  /*
+   * random value used to detect replayed tokens
+   */
+  ProtobufCBinaryData nonce;
 };
 #define AUTH__SYS__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&auth__sys__descriptor) \
-    , 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0,NULL, (char *)protobuf_c_empty_string }
+    , 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0,NULL, (char *)protobuf_c_empty_string, 0, {0,NULL} }
 
 
 /*
@@ -44,6 +44,11 @@ daos_handle_t	coh = DAOS_HDL_INVAL;
 /** force cleanup */
 int force;
 
+/** results of the steps run so far */
+#define MAX_RESULTS 64
+static struct autotest_result	results[MAX_RESULTS];
+static int			results_nr;
+
 static inline void
 new_oid(void)
 {
@@ -58,10 +63,11 @@ duration(void)
 }
 
 static inline void
-step_print(const char *status, const char *comment, va_list ap)
+step_print(const char *status, int failed, const char *comment, va_list ap)
 {
-	char	timing[8];
-	int	i;
+	struct autotest_result	*res = &results[results_nr];
+	char			timing[8];
+	int			i;
 
 	end = clock();
 	fprintf(autotest_ap->outstream, "  %s    ", status);
@@ -69,8 +75,13 @@ step_print(const char *status, const char *comment, va_list ap)
 	for (i = strlen(timing); i < 7; i++)
 		fprintf(autotest_ap->outstream, " ");
 	fprintf(autotest_ap->outstream, "%s  ", timing);
-	vfprintf(autotest_ap->outstream, comment, ap);
-	fprintf(autotest_ap->outstream, "\n");
+	vsnprintf(res->ar_comment, sizeof(res->ar_comment), comment, ap);
+	fprintf(autotest_ap->outstream, "%s\n", res->ar_comment);
+
+	res->ar_failed = failed;
+	res->ar_duration = duration();
+	if (results_nr < MAX_RESULTS - 1)
+		results_nr++;
 }
 
 static inline void
@@ -79,7 +90,7 @@ step_success(const char *comment, ...)
 	va_list	ap;
 
 	va_start(ap, comment);
-	step_print("\033[0;32mOK\033[0m",comment, ap);
+	step_print("\033[0;32mOK\033[0m", 0, comment, ap);
 	va_end(ap);
 }
 
@@ -89,7 +100,7 @@ step_fail(const char *comment, ...)
 	va_list	ap;
 
 	va_start(ap, comment);
-	step_print("\033[0;31mKO\033[0m",comment, ap);
+	step_print("\033[0;31mKO\033[0m", 1, comment, ap);
 	va_end(ap);
 }
 
@@ -98,6 +109,10 @@ step_new(int step, char *msg)
 {
 	int i;
 
+	results[results_nr].ar_step = step;
+	strncpy(results[results_nr].ar_op, msg,
+		sizeof(results[results_nr].ar_op) - 1);
+
 	fprintf(autotest_ap->outstream, "%3d  %s", step, msg);
 	for (i = strlen(msg); i < 25; i++)
 		fprintf(autotest_ap->outstream, " ");
@@ -805,6 +820,8 @@ pool_autotest_hdlr(struct cmd_args_s *ap)
 	assert(ap->p_op == POOL_AUTOTEST);
 
 	autotest_ap = ap;
+	results_nr = 0;
+	memset(results, 0, sizeof(results));
 
 	step_init();
 
@@ -831,3 +848,10 @@ pool_autotest_hdlr(struct cmd_args_s *ap)
 
 	return rc;
 }
+
+int
+pool_autotest_results(struct autotest_result **res)
+{
+	*res = results;
+	return results_nr;
+}
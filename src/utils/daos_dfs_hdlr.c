@@ -74,6 +74,9 @@ fs_dfs_hdlr(struct cmd_args_s *ap)
 		daos_oclass_id2name(info.doi_oclass_id, oclass_name);
 		fprintf(ap->outstream, "Object Class = %s\n", oclass_name);
 		fprintf(ap->outstream, "Object Chunk Size = %zu\n", info.doi_chunk_size);
+		/* also return the attributes to the caller */
+		ap->oclass = info.doi_oclass_id;
+		ap->chunk_size = info.doi_chunk_size;
 		break;
 	}
 	case FS_RESET_ATTR:
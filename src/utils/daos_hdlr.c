@@ -3467,6 +3467,8 @@ out:
 	if (rc == 0) {
 		fprintf(ap->outstream, "Successfully copied to destination "
 			"container "DF_UUIDF "\n", DP_UUID(ca.dst_c_uuid));
+		/* return the destination container to the caller */
+		uuid_copy(ap->c_uuid, ca.dst_c_uuid);
 	}
 	D_FREE(src_str);
 	D_FREE(dst_str);
@@ -186,6 +186,18 @@ int pool_del_attr_hdlr(struct cmd_args_s *ap);
 int pool_get_attr_hdlr(struct cmd_args_s *ap);
 int pool_list_attrs_hdlr(struct cmd_args_s *ap);
 int pool_autotest_hdlr(struct cmd_args_s *ap);
+
+/** Result of a step of pool_autotest_hdlr() */
+struct autotest_result {
+	int	ar_step;		/** step number */
+	char	ar_op[26];		/** string describing the operation */
+	int	ar_failed;		/** whether the step failed */
+	double	ar_duration;		/** duration of the step in seconds */
+	char	ar_comment[128];	/** details, or reason of the failure */
+};
+
+/** Return the results of the steps run by the last pool_autotest_hdlr() */
+int pool_autotest_results(struct autotest_result **results);
 /* TODO: implement these pool op functions
  * int pool_stat_hdlr(struct cmd_args_s *ap);
  */